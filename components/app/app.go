@@ -24,6 +24,7 @@ import (
 	"github.com/iotaledger/hornet/v2/components/restapi"
 	"github.com/iotaledger/hornet/v2/components/snapshot"
 	"github.com/iotaledger/hornet/v2/components/tangle"
+	"github.com/iotaledger/hornet/v2/components/txtracker"
 	"github.com/iotaledger/hornet/v2/components/urts"
 	"github.com/iotaledger/hornet/v2/components/warpsync"
 	"github.com/iotaledger/hornet/v2/pkg/toolset"
@@ -69,6 +70,7 @@ Command line flags:
 			inx.Component,
 			dashboard_metrics.Component,
 			debug.Component,
+			txtracker.Component,
 		),
 	)
 }
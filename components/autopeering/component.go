@@ -20,6 +20,7 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
 	"github.com/iotaledger/hornet/v2/pkg/p2p"
 	"github.com/iotaledger/hornet/v2/pkg/p2p/autopeering"
+	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
 )
 
 func init() {
@@ -39,17 +40,21 @@ var (
 	Component *app.Component
 	deps      dependencies
 
-	localPeerContainer *autopeering.LocalPeerContainer
+	localPeerContainer     *autopeering.LocalPeerContainer
+	handshakeFailureFilter *autopeering.HandshakeFailureFilter
+	latencyTracker         *autopeering.LatencyTracker
 )
 
 type dependencies struct {
 	dig.In
-	NodePrivateKey            crypto.PrivKey `name:"nodePrivateKey"`
-	P2PDatabasePath           string         `name:"p2pDatabasePath"`
-	P2PBindMultiAddresses     []string       `name:"p2pBindMultiAddresses"`
-	DatabaseEngine            hivedb.Engine  `name:"databaseEngine"`
-	AutopeeringRunAsEntryNode bool           `name:"autopeeringRunAsEntryNode"`
-	PeeringManager            *p2p.Manager   `optional:"true"`
+	NodePrivateKey            crypto.PrivKey  `name:"nodePrivateKey"`
+	P2PDatabasePath           string          `name:"p2pDatabasePath"`
+	P2PBindMultiAddresses     []string        `name:"p2pBindMultiAddresses"`
+	DatabaseEngine            hivedb.Engine   `name:"databaseEngine"`
+	AutopeeringRunAsEntryNode bool            `name:"autopeeringRunAsEntryNode"`
+	PruningKeepsFullHistory   bool            `name:"pruningKeepsFullHistory"`
+	PeeringManager            *p2p.Manager    `optional:"true"`
+	GossipService             *gossip.Service `optional:"true"`
 	AutopeeringManager        *autopeering.Manager
 }
 
@@ -117,6 +122,7 @@ func configure() error {
 		deps.P2PBindMultiAddresses,
 		ParamsAutopeering.BindAddress,
 		deps.AutopeeringRunAsEntryNode,
+		deps.PruningKeepsFullHistory,
 	)
 	if err != nil {
 		Component.LogPanicf("unable to initialize local peer container: %s", err)
@@ -138,9 +144,37 @@ func configure() error {
 
 	deps.AutopeeringManager.Init(localPeerContainer, initSelection)
 
+	if initSelection {
+		configureNeighborFilters()
+	}
+
 	return nil
 }
 
+// configureNeighborFilters adds the enabled neighbor filters to the peer selection.
+func configureNeighborFilters() {
+	filters := ParamsAutopeering.Filters
+
+	if filters.HandshakeFailures.Enabled {
+		handshakeFailureFilter = autopeering.NewHandshakeFailureFilter(filters.HandshakeFailures.ExclusionDuration)
+		deps.AutopeeringManager.AddNeighborFilter(handshakeFailureFilter)
+	}
+
+	if filters.Latency.Enabled {
+		latencyTracker = autopeering.NewLatencyTracker(filters.Latency.MeasurementInterval, deps.AutopeeringManager.Discovery().Ping, deps.AutopeeringManager.VerifiedPeers)
+		deps.AutopeeringManager.AddNeighborFilter(autopeering.NewLatencyFilter(filters.Latency.MaxLatency, filters.Latency.BestCandidates, latencyTracker.Latency, deps.AutopeeringManager.Neighbors, deps.AutopeeringManager.VerifiedPeers))
+	}
+
+	if filters.SubnetDiversity.Enabled {
+		deps.AutopeeringManager.AddNeighborFilter(autopeering.NewSubnetDiversityFilter(filters.SubnetDiversity.MaxPeersPerSubnet, deps.AutopeeringManager.Neighbors))
+	}
+
+	// the full history filter is a preference, so it is applied last
+	if filters.FullHistory.Enabled {
+		deps.AutopeeringManager.AddNeighborFilter(autopeering.NewFullHistoryFilter(filters.FullHistory.MinNeighbors, deps.AutopeeringManager.Neighbors, deps.AutopeeringManager.VerifiedPeers))
+	}
+}
+
 func run() error {
 	if err := Component.App().Daemon().BackgroundWorker(Component.Name, func(ctx context.Context) {
 		detach := hookEvents()
@@ -150,6 +184,14 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if latencyTracker != nil {
+		if err := Component.App().Daemon().BackgroundWorker("Autopeering latency tracker", func(ctx context.Context) {
+			latencyTracker.Run(ctx)
+		}, daemon.PriorityAutopeering); err != nil {
+			Component.LogPanicf("failed to start worker: %s", err)
+		}
+	}
+
 	return nil
 }
 
@@ -225,6 +267,27 @@ func hookEvents() (unhook func()) {
 	}
 
 	if deps.AutopeeringManager.Selection() != nil {
+		unhookCallbacks = append(unhookCallbacks,
+			deps.AutopeeringManager.Events.NeighborFiltered.Hook(func(decision *autopeering.FilterDecision) {
+				if decision.Accepted {
+					Component.LogDebugf("filter '%s' accepted %s: %s", decision.Filter, decision.Peer.Address(), decision.Reason)
+
+					return
+				}
+
+				Component.LogDebugf("filter '%s' rejected %s: %s", decision.Filter, decision.Peer.Address(), decision.Reason)
+			}).Unhook,
+		)
+
+		if handshakeFailureFilter != nil && deps.GossipService != nil {
+			unhookCallbacks = append(unhookCallbacks,
+				deps.GossipService.Events.ProtocolHandshakeFailed.Hook(func(peerID libp2p.ID, err error) {
+					Component.LogInfof("excluding %s from autopeering selection for %v, gossip handshake failed: %s", peerID.ShortString(), ParamsAutopeering.Filters.HandshakeFailures.ExclusionDuration, err)
+					handshakeFailureFilter.ReportFailure(peerID)
+				}).Unhook,
+			)
+		}
+
 		unhookCallbacks = append(unhookCallbacks,
 			// notify the selection when a connection is closed or failed.
 			deps.PeeringManager.Events.Connected.Hook(func(p *p2p.Peer, conn network.Conn) {
@@ -24,6 +24,37 @@ type ParametersAutopeering struct {
 	OutboundPeers int `default:"2" usage:"the number of outbound autopeers"`
 	// SaltLifetime lifetime of the private and public local salt.
 	SaltLifetime time.Duration `default:"2h" usage:"lifetime of the private and public local salt"`
+
+	Filters struct {
+		Latency struct {
+			// Enabled defines whether peers with a high latency are rejected.
+			Enabled bool `default:"false" usage:"whether peers with a high latency are rejected"`
+			// MaxLatency defines the maximum latency of a peer to be selected as a neighbor.
+			MaxLatency time.Duration `default:"500ms" usage:"the maximum latency of a peer to be selected as a neighbor"`
+			// BestCandidates defines the amount of candidates with the lowest latency that are accepted as neighbors (0 = no ranking).
+			BestCandidates int `default:"4" usage:"the amount of candidates with the lowest latency that are accepted as neighbors (0 = no ranking)"`
+			// MeasurementInterval defines the interval in which the latency to the peers is measured by discovery pings.
+			MeasurementInterval time.Duration `default:"30s" usage:"the interval in which the latency to the peers is measured by discovery pings"`
+		}
+		FullHistory struct {
+			// Enabled defines whether peers that keep the full history are preferred.
+			Enabled bool `default:"false" usage:"whether peers that keep the full history are preferred"`
+			// MinNeighbors defines the amount of neighbors that should keep the full history.
+			MinNeighbors int `default:"1" usage:"the amount of neighbors that should keep the full history"`
+		}
+		SubnetDiversity struct {
+			// Enabled defines whether the amount of neighbors within the same IP subnet is limited.
+			Enabled bool `default:"false" usage:"whether the amount of neighbors within the same IP subnet is limited"`
+			// MaxPeersPerSubnet defines the maximum amount of neighbors within the same /16 (IPv4) or /32 (IPv6) subnet.
+			MaxPeersPerSubnet int `default:"1" usage:"the maximum amount of neighbors within the same /16 (IPv4) or /32 (IPv6) subnet"`
+		}
+		HandshakeFailures struct {
+			// Enabled defines whether peers are excluded after a failed gossip handshake.
+			Enabled bool `default:"false" usage:"whether peers are excluded after a failed gossip handshake"`
+			// ExclusionDuration defines how long peers are excluded after a failed gossip handshake.
+			ExclusionDuration time.Duration `default:"30m" usage:"how long peers are excluded after a failed gossip handshake"`
+		}
+	}
 }
 
 var ParamsAutopeering = &ParametersAutopeering{}
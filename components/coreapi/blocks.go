@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/restapi"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag/proof"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
@@ -21,6 +22,23 @@ var (
 	blockProcessedTimeout = 1 * time.Second
 )
 
+func newConflictDetailsResponse(details *storage.ConflictDetails) *conflictDetailsResponse {
+	if details == nil {
+		return nil
+	}
+
+	response := &conflictDetailsResponse{
+		Reason:    details.Reason,
+		OutputIDs: details.OutputIDs.ToHex(),
+	}
+	if details.InputIndex != storage.ConflictDetailsNoInputIndex {
+		inputIndex := details.InputIndex
+		response.InputIndex = &inputIndex
+	}
+
+	return response
+}
+
 func blockMetadataByBlockID(blockID iotago.BlockID) (*blockMetadataResponse, error) {
 	cachedBlockMeta := deps.Storage.CachedBlockMetadataOrNil(blockID)
 	if cachedBlockMeta == nil {
@@ -61,6 +79,7 @@ func blockMetadataByBlockID(blockID iotago.BlockID) (*blockMetadataResponse, err
 		if conflict != storage.ConflictNone {
 			response.LedgerInclusionState = "conflicting"
 			response.ConflictReason = &conflict
+			response.ConflictDetails = newConflictDetailsResponse(metadata.ConflictDetails())
 		} else if metadata.IsIncludedTxInLedger() {
 			response.LedgerInclusionState = "included"
 		}
@@ -197,6 +216,9 @@ func sendBlock(c echo.Context) (*blockCreatedResponse, error) {
 		case errors.Is(err, tangle.ErrBlockAttacherPoWNotAvailable):
 			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "failed to attach block: %s", err.Error())
 
+		case errors.Is(err, tangle.ErrBlockAttacherPoWQueueFull):
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "failed to attach block: %s", err.Error())
+
 		default:
 			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to attach block: %s", err.Error())
 		}
@@ -206,3 +228,28 @@ func sendBlock(c echo.Context) (*blockCreatedResponse, error) {
 		BlockID: blockID.ToHex(),
 	}, nil
 }
+
+func blockProofByID(c echo.Context) (*proof.InclusionProof, error) {
+	blockID, err := httpserver.ParseBlockIDParam(c, restapi.ParameterBlockID)
+	if err != nil {
+		return nil, err
+	}
+
+	inclusionProof, err := deps.Tangle.BlockInclusionProof(Component.Daemon().ContextStopped(), blockID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrBlockNotFound):
+			return nil, errors.WithMessagef(echo.ErrNotFound, "block not found: %s", blockID.ToHex())
+		case errors.Is(err, tangle.ErrBlockNotReferenced):
+			return nil, errors.WithMessagef(echo.ErrNotFound, "block not referenced yet: %s", blockID.ToHex())
+		case errors.Is(err, tangle.ErrMilestoneNotFound):
+			return nil, errors.WithMessagef(echo.ErrNotFound, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		case errors.Is(err, common.ErrOperationAborted):
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		default:
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		}
+	}
+
+	return inclusionProof, nil
+}
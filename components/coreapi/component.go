@@ -9,10 +9,12 @@ import (
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hornet/v2/components/inx"
 	"github.com/iotaledger/hornet/v2/components/protocfg"
 	"github.com/iotaledger/hornet/v2/components/restapi"
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
@@ -47,6 +49,10 @@ const (
 	// GET returns block metadata (including info about "promotion/reattachment needed").
 	RouteBlockMetadata = "/blocks/:" + restapipkg.ParameterBlockID + "/metadata"
 
+	// RouteBlockProof is the route for getting a proof of inclusion of a block by its blockID.
+	// GET returns the milestone that referenced the block and the merkle audit paths of the block.
+	RouteBlockProof = "/blocks/:" + restapipkg.ParameterBlockID + "/proof"
+
 	// RouteBlocks is the route for creating new blocks.
 	// POST creates a single new block and returns the new block ID.
 	// The block is parsed based on the given type in the request "Content-Type" header.
@@ -64,6 +70,10 @@ const (
 	// GET returns block metadata (including info about "promotion/reattachment needed").
 	RouteTransactionsIncludedBlockMetadata = "/transactions/:" + restapipkg.ParameterTransactionID + "/included-block/metadata"
 
+	// RouteTransactionsSimulate is the route for validating a transaction against the current ledger state without sending it.
+	// POST returns the outputs the transaction would create, their storage deposits and the conflict reason, if any.
+	RouteTransactionsSimulate = "/transactions/simulate"
+
 	// RouteMilestoneByID is the route for getting a milestone by its ID.
 	// GET returns the milestone.
 	// MIMEApplicationJSON => json.
@@ -106,6 +116,10 @@ const (
 	// GET returns the receipts for the given migrated at index.
 	RouteReceiptsMigratedAtIndex = "/receipts/:" + restapipkg.ParameterMilestoneIndex
 
+	// RouteProtocolUpgrades is the route for getting the scheduled protocol parameters changes.
+	// GET returns the pending protocol parameters, their estimated activation time and whether they are supported by this node.
+	RouteProtocolUpgrades = "/protocol/upgrades"
+
 	// RouteComputeWhiteFlagMutations is the route to compute the white flag mutations for the cone of the given parents.
 	// POST computes the white flag mutations.
 	RouteComputeWhiteFlagMutations = "/whiteflag"
@@ -120,6 +134,15 @@ const (
 	// POST adds a new peer.
 	RoutePeers = "/peers"
 
+	// RouteFirewallRules is the route for getting the firewall rules for peer connections.
+	// GET returns the default action and the list of all rules.
+	// POST adds a new rule.
+	RouteFirewallRules = "/peers/firewall/rules"
+
+	// RouteFirewallRule is the route for firewall rules by their ID.
+	// DELETE deletes the rule.
+	RouteFirewallRule = "/peers/firewall/rules/:" + restapipkg.ParameterFirewallRuleID
+
 	// RouteControlDatabasePrune is the control route to manually prune the database.
 	// POST prunes the database.
 	RouteControlDatabasePrune = "/control/database/prune"
@@ -127,6 +150,33 @@ const (
 	// RouteControlSnapshotsCreate is the control route to manually create a snapshot files.
 	// POST creates a full snapshot.
 	RouteControlSnapshotsCreate = "/control/snapshots/create"
+
+	// RouteControlMilestoneKeyRanges is the control route for the key ranges used to verify the milestone signatures.
+	// GET returns the used verification backend and key ranges.
+	// PUT replaces the key ranges with the ones of a signed key rotation file (same as RouteControlMilestoneKeyRotation).
+	RouteControlMilestoneKeyRanges = "/control/milestones/key-ranges"
+
+	// RouteControlMilestoneKeyRotation is the control route to apply a signed key rotation file.
+	// POST verifies and persists the key rotation file and uses its key ranges.
+	RouteControlMilestoneKeyRotation = "/control/milestones/key-rotation"
+
+	// RouteControlMilestoneKeyRotationReload is the control route to reload the key rotation file from disk.
+	// POST reloads the key rotation file and uses its key ranges.
+	RouteControlMilestoneKeyRotationReload = "/control/milestones/key-rotation/reload"
+
+	// RouteControlLedgerStateHash is the control route for getting the hash of the ledger state at a milestone index.
+	// GET returns the ledger state hash, reconstructed by rolling back the milestone diffs of all newer milestones.
+	// The milestone index may be at most MaxLedgerStateHashRollback milestones older than the ledger index.
+	RouteControlLedgerStateHash = "/control/ledger/state-hash/:" + restapipkg.ParameterMilestoneIndex
+
+	// RouteControlLedgerMilestoneDiffsHash is the control route for getting the hash over the milestone diffs of a range of milestones.
+	// GET returns the hash over the milestone diff hashes from the milestone index to the optional "endIndex" query parameter.
+	// The range may contain at most MaxMilestoneDiffsHashRange milestones.
+	RouteControlLedgerMilestoneDiffsHash = "/control/ledger/milestone-diffs-hash/:" + restapipkg.ParameterMilestoneIndex
+
+	// RouteControlINXExtensions is the route for getting the connected INX extensions.
+	// GET returns the connected INX extensions with their registered routes and stream subscriptions.
+	RouteControlINXExtensions = "/control/inx/extensions"
 )
 
 func init() {
@@ -163,14 +213,17 @@ type dependencies struct {
 	PruningManager          *pruning.Manager
 	AppInfo                 *app.Info
 	PeeringConfigManager    *p2p.ConfigManager
+	Firewall                *p2p.Firewall
 	ProtocolManager         *protocol.Manager
 	BaseToken               *protocfg.BaseToken
+	SignatureVerifier       milestonemanager.SignatureVerifier
 	RestAPILimitsMaxResults int                       `name:"restAPILimitsMaxResults"`
 	SnapshotsFullPath       string                    `name:"snapshotsFullPath"`
 	SnapshotsDeltaPath      string                    `name:"snapshotsDeltaPath"`
 	TipSelector             *tipselect.TipSelector    `optional:"true"`
 	RestRouteManager        *restapi.RestRouteManager `optional:"true"`
 	RestAPIMetrics          *metrics.RestAPIMetrics
+	INXServer               *inx.Server `optional:"true"`
 }
 
 func configure() error {
@@ -206,6 +259,15 @@ func configure() error {
 		return httpserver.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteProtocolUpgrades, func(c echo.Context) error {
+		resp, err := protocolUpgrades()
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	// only handle tips api calls if the URTS plugin is enabled
 	if deps.TipSelector != nil {
 		routeGroup.GET(RouteTips, func(c echo.Context) error {
@@ -227,6 +289,15 @@ func configure() error {
 		return httpserver.JSONResponse(c, http.StatusOK, resp)
 	}, checkNodeAlmostSynced())
 
+	routeGroup.GET(RouteBlockProof, func(c echo.Context) error {
+		resp, err := blockProofByID(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	}, checkNodeAlmostSynced())
+
 	routeGroup.GET(RouteBlock, func(c echo.Context) error {
 		mimeType, err := httpserver.GetAcceptHeaderContentType(c, httpserver.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
 		if err != nil && err != httpserver.ErrNotAcceptable {
@@ -298,6 +369,15 @@ func configure() error {
 		return httpserver.JSONResponse(c, http.StatusOK, resp)
 	}, checkNodeAlmostSynced())
 
+	routeGroup.POST(RouteTransactionsSimulate, func(c echo.Context) error {
+		resp, err := simulateTransaction(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	}, checkNodeAlmostSynced())
+
 	routeGroup.GET(RouteMilestoneByID, func(c echo.Context) error {
 		mimeType, err := httpserver.GetAcceptHeaderContentType(c, httpserver.MIMEApplicationVendorIOTASerializerV1, echo.MIMEApplicationJSON)
 		if err != nil && err != httpserver.ErrNotAcceptable {
@@ -465,6 +545,35 @@ func configure() error {
 		return httpserver.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	// only handle firewall api calls if the firewall is enabled
+	if deps.Firewall != nil {
+		routeGroup.GET(RouteFirewallRules, func(c echo.Context) error {
+			resp, err := listFirewallRules(c)
+			if err != nil {
+				return err
+			}
+
+			return httpserver.JSONResponse(c, http.StatusOK, resp)
+		})
+
+		routeGroup.POST(RouteFirewallRules, func(c echo.Context) error {
+			resp, err := addFirewallRule(c)
+			if err != nil {
+				return err
+			}
+
+			return httpserver.JSONResponse(c, http.StatusCreated, resp)
+		})
+
+		routeGroup.DELETE(RouteFirewallRule, func(c echo.Context) error {
+			if err := removeFirewallRule(c); err != nil {
+				return err
+			}
+
+			return c.NoContent(http.StatusNoContent)
+		})
+	}
+
 	routeGroup.POST(RouteComputeWhiteFlagMutations, func(c echo.Context) error {
 		resp, err := computeWhiteFlagMutations(c)
 		if err != nil {
@@ -492,6 +601,66 @@ func configure() error {
 		return httpserver.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteControlMilestoneKeyRanges, func(c echo.Context) error {
+		return httpserver.JSONResponse(c, http.StatusOK, milestoneKeyRanges())
+	})
+
+	routeGroup.PUT(RouteControlMilestoneKeyRanges, func(c echo.Context) error {
+		resp, err := applyMilestoneKeyRotation(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteControlMilestoneKeyRotation, func(c echo.Context) error {
+		resp, err := applyMilestoneKeyRotation(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteControlMilestoneKeyRotationReload, func(c echo.Context) error {
+		resp, err := reloadMilestoneKeyRotation(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteControlLedgerStateHash, func(c echo.Context) error {
+		resp, err := ledgerStateHash(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteControlLedgerMilestoneDiffsHash, func(c echo.Context) error {
+		resp, err := milestoneDiffsHash(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	if deps.INXServer != nil {
+		routeGroup.GET(RouteControlINXExtensions, func(c echo.Context) error {
+			resp, err := inxExtensions()
+			if err != nil {
+				return err
+			}
+
+			return httpserver.JSONResponse(c, http.StatusOK, resp)
+		})
+	}
+
 	return nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"github.com/labstack/gommon/bytes"
 	"github.com/pkg/errors"
 
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
@@ -89,3 +90,77 @@ func createSnapshots(c echo.Context) (*createSnapshotsResponse, error) {
 		FilePath: filePath,
 	}, nil
 }
+
+func milestoneKeyRanges() *milestoneKeyRangesResponse {
+	keyRanges := deps.SignatureVerifier.KeyRanges()
+
+	response := &milestoneKeyRangesResponse{
+		Backend:   deps.SignatureVerifier.Name(),
+		KeyRanges: make([]*milestonemanager.KeyRotationFileKeyRange, 0, len(keyRanges)),
+	}
+
+	for _, keyRange := range keyRanges {
+		response.KeyRanges = append(response.KeyRanges, &milestonemanager.KeyRotationFileKeyRange{
+			Key:        iotago.EncodeHex(keyRange.PublicKey[:]),
+			StartIndex: keyRange.StartIndex,
+			EndIndex:   keyRange.EndIndex,
+		})
+	}
+
+	if verifier, ok := deps.SignatureVerifier.(*milestonemanager.KeyRotationFileVerifier); ok {
+		response.Version = verifier.Version()
+	}
+
+	return response
+}
+
+func keyRotationFileVerifier() (*milestonemanager.KeyRotationFileVerifier, error) {
+	verifier, ok := deps.SignatureVerifier.(*milestonemanager.KeyRotationFileVerifier)
+	if !ok {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "milestone key ranges can only be updated with a signed key rotation file, but the verification backend is not %s", milestonemanager.SignatureVerifierKeyRotationFile)
+	}
+
+	return verifier, nil
+}
+
+func applyMilestoneKeyRotation(c echo.Context) (*milestoneKeyRangesResponse, error) {
+
+	verifier, err := keyRotationFileVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &milestonemanager.KeyRotationFile{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if err := verifier.ApplyKeyRotationFile(request); err != nil {
+		if errors.Is(err, milestonemanager.ErrInvalidKeyRotationFile) {
+			return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "applying key rotation file failed: %s", err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "applying key rotation file failed: %s", err)
+	}
+
+	Component.LogInfof("milestone key rotation file applied via API (version %d, %d key ranges)", request.Version, len(request.KeyRanges))
+
+	return milestoneKeyRanges(), nil
+}
+
+//nolint:unparam // even if the context is never used, the structure of all routes should be the same
+func reloadMilestoneKeyRotation(_ echo.Context) (*milestoneKeyRangesResponse, error) {
+
+	verifier, err := keyRotationFileVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifier.Reload(); err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reloading key rotation file failed: %s", err)
+	}
+
+	Component.LogInfo("milestone key rotation file reloaded via API")
+
+	return milestoneKeyRanges(), nil
+}
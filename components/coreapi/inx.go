@@ -0,0 +1,50 @@
+package coreapi
+
+//nolint:unparam // even if the error is never used, the structure of all routes should be the same
+func inxExtensions() (*inxExtensionsResponse, error) {
+	confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex()
+
+	extensions := deps.INXServer.Extensions()
+
+	resp := &inxExtensionsResponse{
+		Extensions: make([]*inxExtension, 0, len(extensions)),
+	}
+
+	for _, extension := range extensions {
+		streams := make([]*inxExtensionStream, 0, len(extension.Streams))
+		for _, stream := range extension.Streams {
+			inxStream := &inxExtensionStream{
+				Method:                 stream.Method,
+				StartedAt:              stream.StartedAt.Unix(),
+				MessagesSent:           stream.MessagesSent,
+				LastSentMilestoneIndex: stream.LastSentMilestoneIndex,
+				BufferedMilestones:     stream.BufferedMilestones,
+				BufferCapacity:         stream.BufferCapacity,
+				Overflows:              stream.Overflows,
+			}
+
+			if !stream.LastSentAt.IsZero() {
+				inxStream.LastSentAt = stream.LastSentAt.Unix()
+			}
+
+			if stream.LastSentMilestoneIndex != 0 && confirmedMilestoneIndex > stream.LastSentMilestoneIndex {
+				inxStream.MilestoneLag = confirmedMilestoneIndex - stream.LastSentMilestoneIndex
+			}
+
+			streams = append(streams, inxStream)
+		}
+
+		resp.Extensions = append(resp.Extensions, &inxExtension{
+			ID:            extension.ID,
+			RemoteAddress: extension.RemoteAddress,
+			ConnectedAt:   extension.ConnectedAt.Unix(),
+			Name:          extension.Name,
+			Version:       extension.Version,
+			Capabilities:  extension.Capabilities,
+			Routes:        extension.Routes,
+			Streams:       streams,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+package coreapi
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/restapi"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MaxLedgerStateHashRollback is the maximum amount of milestones the ledger state is rolled back
+	// to compute the ledger state hash at a former milestone index.
+	MaxLedgerStateHashRollback = 1000
+
+	// MaxMilestoneDiffsHashRange is the maximum amount of milestone diffs that are hashed in a single request.
+	MaxMilestoneDiffsHashRange = 1000
+)
+
+func ledgerStateHash(c echo.Context) (*LedgerStateHashResponse, error) {
+
+	msIndex, err := httpserver.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	if ledgerIndex := deps.SyncManager.ConfirmedMilestoneIndex(); ledgerIndex > msIndex && ledgerIndex-msIndex > MaxLedgerStateHashRollback {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "milestone index %d is more than %d milestones older than the ledger index %d", msIndex, MaxLedgerStateHashRollback, ledgerIndex)
+	}
+
+	ledgerStateHash, err := deps.Storage.ComputeLedgerStateHashAtIndex(msIndex)
+	if err != nil {
+		if errors.Is(err, storage.ErrLedgerStateNotAvailable) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "ledger state at milestone index %d not available: %s", msIndex, err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "computing ledger state hash failed: %s", err)
+	}
+
+	return &LedgerStateHashResponse{
+		MilestoneIndex:    ledgerStateHash.LedgerIndex,
+		LedgerIndex:       deps.SyncManager.ConfirmedMilestoneIndex(),
+		LedgerStateHash:   iotago.EncodeHex(ledgerStateHash.Hash),
+		UTXOsCount:        ledgerStateHash.UTXOsCount,
+		LedgerTokenSupply: strconv.FormatUint(ledgerStateHash.LedgerTokenSupply, 10),
+	}, nil
+}
+
+func milestoneDiffsHash(c echo.Context) (*MilestoneDiffsHashResponse, error) {
+
+	startIndex, err := httpserver.ParseMilestoneIndexParam(c, restapi.ParameterMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	endIndex := startIndex
+	if endIndexParam := c.QueryParam(restapi.QueryParameterEndIndex); endIndexParam != "" {
+		index, err := strconv.ParseUint(endIndexParam, 10, 32)
+		if err != nil {
+			return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid end index: %s, error: %s", endIndexParam, err)
+		}
+		endIndex = iotago.MilestoneIndex(index)
+	}
+
+	if endIndex < startIndex {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "end index %d is smaller than the start index %d", endIndex, startIndex)
+	}
+
+	if endIndex-startIndex >= MaxMilestoneDiffsHashRange {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "the range from %d to %d contains more than %d milestones", startIndex, endIndex, MaxMilestoneDiffsHashRange)
+	}
+
+	if ledgerIndex := deps.SyncManager.ConfirmedMilestoneIndex(); endIndex > ledgerIndex {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "end index %d is newer than the ledger index %d", endIndex, ledgerIndex)
+	}
+
+	hash, err := deps.UTXOManager.MilestoneDiffsSHA256Sum(startIndex, endIndex)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "milestone diffs not available: %s", err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "computing milestone diffs hash failed: %s", err)
+	}
+
+	return &MilestoneDiffsHashResponse{
+		StartIndex:         startIndex,
+		EndIndex:           endIndex,
+		MilestoneDiffsHash: iotago.EncodeHex(hash),
+	}, nil
+}
@@ -69,6 +69,7 @@ func info() (*infoResponse, error) {
 				MilestoneID: confirmedMilestoneIDHex,
 			},
 			PruningIndex: pruningIndex,
+			Reachability: strings.ToLower(deps.PeeringManager.Reachability().String()),
 		},
 		SupportedProtocolVersions: deps.ProtocolManager.SupportedVersions(),
 		ProtocolParameters:        deps.ProtocolManager.Current(),
@@ -38,6 +38,9 @@ func WrapInfoSnapshot(info *p2p.PeerInfoSnapshot) *PeerResponse {
 		Alias:          alias,
 		Relation:       info.Relation,
 		Connected:      info.Connected,
+		Relayed:        info.Relayed,
+		LastTransport:  info.LastTransport,
+		Transports:     info.Transports,
 		Gossip:         gossipInfo,
 	}
 }
@@ -117,3 +120,51 @@ func addPeer(c echo.Context, logger *logger.Logger) (*PeerResponse, error) {
 
 	return WrapInfoSnapshot(info), nil
 }
+
+//nolint:unparam // even if the error is never used, the structure of all routes should be the same
+func listFirewallRules(_ echo.Context) (*firewallRulesResponse, error) {
+	return &firewallRulesResponse{
+		DefaultAction: deps.Firewall.DefaultAction(),
+		Rules:         deps.Firewall.Rules(),
+	}, nil
+}
+
+func addFirewallRule(c echo.Context) (*p2p.FirewallRule, error) {
+
+	request := &p2p.FirewallRule{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid firewall rule, error: %s", err)
+	}
+
+	rule, err := deps.Firewall.AddRule(request)
+	if err != nil {
+		switch {
+		case errors.Is(err, p2p.ErrFirewallRuleInvalid), errors.Is(err, p2p.ErrFirewallRuleExists):
+			return nil, errors.WithMessage(httpserver.ErrInvalidParameter, err.Error())
+		default:
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "adding firewall rule failed: %s", err)
+		}
+	}
+
+	// the rule only applies to new connections, so we need to close
+	// existing connections that would be denied by the updated rule set.
+	if err := deps.PeeringManager.EnforceFirewall(); err != nil {
+		Component.LogWarnf("enforcing firewall rule %s failed: %s", rule.ID, err)
+	}
+
+	return rule, nil
+}
+
+func removeFirewallRule(c echo.Context) error {
+	ruleID := c.Param(restapi.ParameterFirewallRuleID)
+
+	if err := deps.Firewall.RemoveRule(ruleID); err != nil {
+		if errors.Is(err, p2p.ErrFirewallRuleNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "firewall rule not found, ruleID: %s", ruleID)
+		}
+
+		return errors.WithMessagef(echo.ErrInternalServerError, "removing firewall rule failed: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package coreapi
+
+import (
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// milestoneIntervalSampleSize is the amount of recent milestones used to estimate the milestone interval.
+	milestoneIntervalSampleSize = 100
+)
+
+// milestoneInterval estimates the average duration between milestones in seconds
+// based on the timestamps of the recent confirmed milestones.
+// It returns 0 if the interval can't be estimated.
+func milestoneInterval(confirmedMilestoneIndex iotago.MilestoneIndex) float64 {
+	var startIndex iotago.MilestoneIndex = 1
+	if snapshotInfo := deps.Storage.SnapshotInfo(); snapshotInfo != nil {
+		startIndex = snapshotInfo.PruningIndex() + 1
+	}
+
+	if confirmedMilestoneIndex > milestoneIntervalSampleSize && confirmedMilestoneIndex-milestoneIntervalSampleSize > startIndex {
+		startIndex = confirmedMilestoneIndex - milestoneIntervalSampleSize
+	}
+
+	if startIndex >= confirmedMilestoneIndex {
+		return 0
+	}
+
+	startTimestamp, err := deps.Storage.MilestoneTimestampUnixByIndex(startIndex)
+	if err != nil {
+		return 0
+	}
+
+	endTimestamp, err := deps.Storage.MilestoneTimestampUnixByIndex(confirmedMilestoneIndex)
+	if err != nil || endTimestamp < startTimestamp {
+		return 0
+	}
+
+	return float64(endTimestamp-startTimestamp) / float64(confirmedMilestoneIndex-startIndex)
+}
+
+//nolint:unparam // even if the error is never used, the structure of all routes should be the same
+func protocolUpgrades() (*protocolUpgradesResponse, error) {
+	confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex()
+	interval := milestoneInterval(confirmedMilestoneIndex)
+
+	var confirmedMilestoneTimestamp uint32
+	if interval > 0 {
+		timestamp, err := deps.Storage.MilestoneTimestampUnixByIndex(confirmedMilestoneIndex)
+		if err == nil {
+			confirmedMilestoneTimestamp = timestamp
+		}
+	}
+
+	upgrades := deps.ProtocolManager.Upgrades()
+
+	resp := &protocolUpgradesResponse{
+		ConfirmedMilestoneIndex: confirmedMilestoneIndex,
+		MilestoneInterval:       interval,
+		Upgrades:                make([]*protocolUpgrade, 0, len(upgrades)),
+	}
+
+	for _, upgrade := range upgrades {
+		var milestonesUntilActivation iotago.MilestoneIndex
+		if upgrade.TargetMilestoneIndex > confirmedMilestoneIndex {
+			milestonesUntilActivation = upgrade.TargetMilestoneIndex - confirmedMilestoneIndex
+		}
+
+		var estimatedActivationTimestamp uint32
+		if confirmedMilestoneTimestamp != 0 {
+			estimatedActivationTimestamp = confirmedMilestoneTimestamp + uint32(float64(milestonesUntilActivation)*interval)
+		}
+
+		changes := make([]*protocolParameterChange, 0, len(upgrade.Changes))
+		for _, change := range upgrade.Changes {
+			changes = append(changes, &protocolParameterChange{
+				Name:    change.Name,
+				Current: change.Current,
+				Pending: change.Pending,
+			})
+		}
+
+		var decodeError string
+		if upgrade.DecodeError != nil {
+			decodeError = upgrade.DecodeError.Error()
+		}
+
+		resp.Upgrades = append(resp.Upgrades, &protocolUpgrade{
+			TargetMilestoneIndex:         upgrade.TargetMilestoneIndex,
+			MilestonesUntilActivation:    milestonesUntilActivation,
+			EstimatedActivationTimestamp: estimatedActivationTimestamp,
+			ProtocolVersion:              upgrade.ProtocolVersion,
+			Supported:                    upgrade.Supported,
+			Params:                       upgrade.Params,
+			Error:                        decodeError,
+			Changes:                      changes,
+		})
+	}
+
+	return resp, nil
+}
@@ -1,11 +1,17 @@
 package coreapi
 
 import (
+	"encoding/json"
+	"strconv"
+
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/restapi"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
@@ -69,3 +75,63 @@ func blockMetadataByTransactionID(c echo.Context) (*blockMetadataResponse, error
 
 	return blockMetadataByBlockID(blockID)
 }
+
+func simulateTransaction(c echo.Context) (*transactionSimulationResponse, error) {
+	request := &simulateTransactionRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if request.Transaction == nil {
+		return nil, errors.WithMessage(httpserver.ErrInvalidParameter, "invalid request, error: transaction missing")
+	}
+
+	simulation, err := deps.Tangle.SimulateTransaction(request.Transaction, request.SkipSignatureChecks)
+	if err != nil {
+		switch {
+		case errors.Is(err, whiteflag.ErrInvalidTransaction):
+			return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "failed to simulate transaction: %s", err)
+		case errors.Is(err, tangle.ErrMilestoneNotFound):
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "failed to simulate transaction: %s", err)
+		default:
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to simulate transaction: %s", err)
+		}
+	}
+
+	response := &transactionSimulationResponse{
+		LedgerIndex:          simulation.LedgerIndex,
+		LedgerInclusionState: "included",
+		Outputs:              make([]*simulatedOutputResponse, 0, len(simulation.Outputs)),
+	}
+
+	if simulation.TransactionID != nil {
+		response.TransactionID = simulation.TransactionID.ToHex()
+	}
+
+	if simulation.Conflict != storage.ConflictNone {
+		conflict := simulation.Conflict
+		response.LedgerInclusionState = "conflicting"
+		response.ConflictReason = &conflict
+		response.ConflictDetails = newConflictDetailsResponse(simulation.ConflictDetails)
+	}
+
+	for _, output := range simulation.Outputs {
+		outputJSON, err := output.Output.MarshalJSON()
+		if err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to marshal output: %s", err)
+		}
+		rawOutputJSON := json.RawMessage(outputJSON)
+
+		outputResponse := &simulatedOutputResponse{
+			RawOutput:         &rawOutputJSON,
+			MinStorageDeposit: strconv.FormatUint(output.MinStorageDeposit, 10),
+		}
+		if output.OutputID != nil {
+			outputResponse.OutputID = output.OutputID.ToHex()
+		}
+
+		response.Outputs = append(response.Outputs, outputResponse)
+	}
+
+	return response, nil
+}
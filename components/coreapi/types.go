@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 
 	"github.com/iotaledger/hornet/v2/components/protocfg"
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
 	"github.com/iotaledger/hornet/v2/pkg/protocol"
 	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -34,6 +36,8 @@ type nodeStatus struct {
 	ConfirmedMilestone milestoneInfoResponse `json:"confirmedMilestone"`
 	// The milestone index at which the last pruning commenced.
 	PruningIndex iotago.MilestoneIndex `json:"pruningIndex"`
+	// The reachability of the node from the internet as determined by AutoNAT (unknown, public, private).
+	Reachability string `json:"reachability,omitempty"`
 }
 
 type nodeMetrics struct {
@@ -94,6 +98,8 @@ type blockMetadataResponse struct {
 	LedgerInclusionState string `json:"ledgerInclusionState,omitempty"`
 	// The reason why this block is marked as conflicting.
 	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The details why this block is marked as conflicting.
+	ConflictDetails *conflictDetailsResponse `json:"conflictDetails,omitempty"`
 	// Whether the block should be promoted.
 	ShouldPromote *bool `json:"shouldPromote,omitempty"`
 	// Whether the block should be reattached.
@@ -102,6 +108,50 @@ type blockMetadataResponse struct {
 	WhiteFlagIndex *uint32 `json:"whiteFlagIndex,omitempty"`
 }
 
+// conflictDetailsResponse defines the details why a block is marked as conflicting.
+type conflictDetailsResponse struct {
+	// The index of the input that caused the conflict.
+	InputIndex *int `json:"inputIndex,omitempty"`
+	// The violated condition.
+	Reason string `json:"reason"`
+	// The hex encoded IDs of the outputs referenced by the inputs of the transaction.
+	OutputIDs []string `json:"outputIds"`
+}
+
+// simulateTransactionRequest defines the request of a POST transactions simulate REST API call.
+type simulateTransactionRequest struct {
+	// The transaction payload to simulate.
+	Transaction *iotago.Transaction `json:"transaction"`
+	// Whether the transaction is unsigned and checks depending on its unlocks should be skipped.
+	SkipSignatureChecks bool `json:"skipSignatureChecks"`
+}
+
+// simulatedOutputResponse defines an output that would be created by a simulated transaction.
+type simulatedOutputResponse struct {
+	// The hex encoded output ID. It is only known if the signatures were checked.
+	OutputID string `json:"outputId,omitempty"`
+	// The output in its serialized form.
+	RawOutput *json.RawMessage `json:"output"`
+	// The minimum storage deposit the output needs to cover.
+	MinStorageDeposit string `json:"minStorageDeposit"`
+}
+
+// transactionSimulationResponse defines the response of a POST transactions simulate REST API call.
+type transactionSimulationResponse struct {
+	// The ledger index the transaction was simulated at.
+	LedgerIndex iotago.MilestoneIndex `json:"ledgerIndex"`
+	// The hex encoded transaction ID. It is only known if the signatures were checked.
+	TransactionID string `json:"transactionId,omitempty"`
+	// The ledger inclusion state the transaction would have.
+	LedgerInclusionState string `json:"ledgerInclusionState"`
+	// The reason why the transaction would be marked as conflicting.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The details why the transaction would be marked as conflicting.
+	ConflictDetails *conflictDetailsResponse `json:"conflictDetails,omitempty"`
+	// The outputs the transaction would create.
+	Outputs []*simulatedOutputResponse `json:"outputs"`
+}
+
 // blockCreatedResponse defines the response of a POST blocks REST API call.
 type blockCreatedResponse struct {
 	// The hex encoded block ID of the block.
@@ -169,10 +219,24 @@ type PeerResponse struct {
 	Relation string `json:"relation"`
 	// Whether the peer is connected.
 	Connected bool `json:"connected"`
+	// Whether the peer is only connected via a circuit relay.
+	Relayed bool `json:"relayed,omitempty"`
+	// The transport of the last established connection.
+	LastTransport p2p.Transport `json:"lastTransport,omitempty"`
+	// The connection statistics per transport.
+	Transports map[p2p.Transport]*p2p.TransportStats `json:"transports,omitempty"`
 	// The gossip protocol information of the peer.
 	Gossip *gossip.Info `json:"gossip,omitempty"`
 }
 
+// firewallRulesResponse defines the response of a GET firewall rules REST API call.
+type firewallRulesResponse struct {
+	// The action applied to connections that don't match any rule.
+	DefaultAction p2p.FirewallAction `json:"defaultAction"`
+	// The rules in order of evaluation.
+	Rules []*p2p.FirewallRule `json:"rules"`
+}
+
 // pruneDatabaseRequest defines the request of a prune database REST API call.
 type pruneDatabaseRequest struct {
 	// The pruning target index.
@@ -203,6 +267,128 @@ type createSnapshotsResponse struct {
 	FilePath string `json:"filePath"`
 }
 
+// milestoneKeyRangesResponse defines the response of a milestone key ranges REST API call.
+type milestoneKeyRangesResponse struct {
+	// The name of the milestone signature verification backend.
+	Backend string `json:"backend"`
+	// The version of the applied key rotation file (only for the keyRotationFile backend).
+	Version uint32 `json:"version,omitempty"`
+	// The key ranges used to verify the milestone signatures.
+	KeyRanges []*milestonemanager.KeyRotationFileKeyRange `json:"keyRanges"`
+}
+
+// LedgerStateHashResponse defines the response of a GET control ledger state hash REST API call.
+type LedgerStateHashResponse struct {
+	// The milestone index of the ledger state.
+	MilestoneIndex iotago.MilestoneIndex `json:"milestoneIndex"`
+	// The current ledger index of the node.
+	LedgerIndex iotago.MilestoneIndex `json:"ledgerIndex"`
+	// The hex encoded sha256 hash of the ledger state (without solid entry points).
+	LedgerStateHash string `json:"ledgerStateHash"`
+	// The amount of unspent outputs.
+	UTXOsCount int `json:"utxosCount"`
+	// The sum of the deposits of all unspent outputs and the treasury.
+	LedgerTokenSupply string `json:"ledgerTokenSupply"`
+}
+
+// MilestoneDiffsHashResponse defines the response of a GET control milestone diffs hash REST API call.
+type MilestoneDiffsHashResponse struct {
+	// The first milestone index of the range.
+	StartIndex iotago.MilestoneIndex `json:"startIndex"`
+	// The last milestone index of the range.
+	EndIndex iotago.MilestoneIndex `json:"endIndex"`
+	// The hex encoded sha256 hash over the hashes of all milestone diffs in the range.
+	MilestoneDiffsHash string `json:"milestoneDiffsHash"`
+}
+
+// protocolParameterChange defines a changed protocol parameter of a scheduled protocol upgrade.
+type protocolParameterChange struct {
+	// The name of the changed parameter.
+	Name string `json:"name"`
+	// The value of the parameter under the current protocol parameters.
+	Current string `json:"current"`
+	// The value of the parameter after the activation of the protocol upgrade.
+	Pending string `json:"pending"`
+}
+
+// protocolUpgrade defines a scheduled protocol parameters change.
+type protocolUpgrade struct {
+	// The milestone index at which the protocol parameters get activated.
+	TargetMilestoneIndex iotago.MilestoneIndex `json:"targetMilestoneIndex"`
+	// The amount of milestones until the protocol parameters get activated.
+	MilestonesUntilActivation iotago.MilestoneIndex `json:"milestonesUntilActivation"`
+	// The estimated unix timestamp of the activation, based on the recent milestone rate.
+	EstimatedActivationTimestamp uint32 `json:"estimatedActivationTimestamp,omitempty"`
+	// The protocol version of the pending protocol parameters.
+	ProtocolVersion byte `json:"protocolVersion"`
+	// Whether the protocol version is supported by this node.
+	Supported bool `json:"supported"`
+	// The pending protocol parameters, if they could be decoded by this node.
+	Params *iotago.ProtocolParameters `json:"params,omitempty"`
+	// The error that occurred while decoding the pending protocol parameters.
+	Error string `json:"error,omitempty"`
+	// The changes of the pending protocol parameters compared to the current ones.
+	Changes []*protocolParameterChange `json:"changes"`
+}
+
+// protocolUpgradesResponse defines the response of a GET protocol upgrades REST API call.
+type protocolUpgradesResponse struct {
+	// The index of the confirmed milestone the estimations are based on.
+	ConfirmedMilestoneIndex iotago.MilestoneIndex `json:"confirmedMilestoneIndex"`
+	// The average duration between milestones in seconds, or 0 if it is unknown.
+	MilestoneInterval float64 `json:"milestoneInterval"`
+	// The scheduled protocol parameters changes.
+	Upgrades []*protocolUpgrade `json:"upgrades"`
+}
+
+// inxExtensionStream defines an active stream subscription of an INX extension.
+type inxExtensionStream struct {
+	// The full gRPC method name of the stream.
+	Method string `json:"method"`
+	// The unix timestamp the stream was started.
+	StartedAt int64 `json:"startedAt"`
+	// The amount of messages sent to the extension.
+	MessagesSent uint64 `json:"messagesSent"`
+	// The unix timestamp the last message was sent to the extension.
+	LastSentAt int64 `json:"lastSentAt,omitempty"`
+	// The milestone index of the last milestone related message sent to the extension.
+	LastSentMilestoneIndex iotago.MilestoneIndex `json:"lastSentMilestoneIndex,omitempty"`
+	// The amount of confirmed milestones the extension did not receive yet.
+	MilestoneLag iotago.MilestoneIndex `json:"milestoneLag,omitempty"`
+	// The amount of milestones that are buffered and were not sent to the extension yet.
+	BufferedMilestones int `json:"bufferedMilestones"`
+	// The maximum amount of milestones that can be buffered for the stream.
+	BufferCapacity int `json:"bufferCapacity,omitempty"`
+	// The amount of milestones that didn't fit into the buffer.
+	Overflows uint64 `json:"overflows"`
+}
+
+// inxExtension defines a connected INX extension.
+type inxExtension struct {
+	// The ID of the connection of the extension.
+	ID uint64 `json:"id"`
+	// The remote address of the extension.
+	RemoteAddress string `json:"remoteAddress"`
+	// The unix timestamp the extension connected.
+	ConnectedAt int64 `json:"connectedAt"`
+	// The name the extension announced.
+	Name string `json:"name,omitempty"`
+	// The version the extension announced.
+	Version string `json:"version,omitempty"`
+	// The capabilities the extension announced.
+	Capabilities []string `json:"capabilities"`
+	// The REST API routes the extension registered.
+	Routes []string `json:"routes"`
+	// The active stream subscriptions of the extension.
+	Streams []*inxExtensionStream `json:"streams"`
+}
+
+// inxExtensionsResponse defines the response of a GET control INX extensions REST API call.
+type inxExtensionsResponse struct {
+	// The connected INX extensions.
+	Extensions []*inxExtension `json:"extensions"`
+}
+
 // ComputeWhiteFlagMutationsRequest defines the request for a POST debugComputeWhiteFlagMutations REST API call.
 type ComputeWhiteFlagMutationsRequest struct {
 	// The index of the milestone.
@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"path/filepath"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
 	"github.com/iotaledger/hornet/v2/pkg/database"
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
@@ -61,6 +63,7 @@ type dependencies struct {
 	UTXODatabase   *database.Database `name:"utxoDatabase"`
 	Storage        *storage.Storage
 	StorageMetrics *metrics.StorageMetrics
+	Verifier       *integrity.Verifier `optional:"true"`
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -243,6 +246,24 @@ func provide(c *dig.Container) error {
 		Component.LogPanic(err)
 	}
 
+	if ParamsDatabase.IntegrityCheck.Enabled {
+		if err := c.Provide(func(storage *storage.Storage) *integrity.Verifier {
+			var referenceSources []integrity.ReferenceSource
+			if ParamsDatabase.IntegrityCheck.ReferenceFile != "" {
+				referenceSources = append(referenceSources, integrity.NewFileReferenceSource(ParamsDatabase.IntegrityCheck.ReferenceFile))
+			}
+
+			client := &http.Client{Timeout: ParamsDatabase.IntegrityCheck.ReferenceRequestTimeout}
+			for _, url := range ParamsDatabase.IntegrityCheck.ReferenceURLs {
+				referenceSources = append(referenceSources, integrity.NewHTTPReferenceSource(url, client))
+			}
+
+			return integrity.New(Component.Logger(), storage, referenceSources, ParamsDatabase.IntegrityCheck.MilestoneDiffSampleSize)
+		}); err != nil {
+			Component.LogPanic(err)
+		}
+	}
+
 	return nil
 }
 
@@ -306,5 +327,45 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if deps.Verifier != nil {
+		if err := Component.Daemon().BackgroundWorker("Database[IntegrityCheck]", func(ctx context.Context) {
+			Component.LogInfof("Starting ledger integrity check with interval %v ... done", ParamsDatabase.IntegrityCheck.Interval)
+
+			ticker := time.NewTicker(ParamsDatabase.IntegrityCheck.Interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					Component.LogInfo("Stopping ledger integrity check ... done")
+
+					return
+
+				case <-ticker.C:
+					runIntegrityCheck(ctx)
+				}
+			}
+		}, daemon.PriorityIntegrityCheck); err != nil {
+			Component.LogPanicf("failed to start worker: %s", err)
+		}
+	}
+
 	return nil
 }
+
+func runIntegrityCheck(ctx context.Context) {
+	result, err := deps.Verifier.Check(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			Component.LogWarnf("ledger integrity check failed: %s", err)
+		}
+
+		return
+	}
+
+	for _, divergence := range result.Divergences {
+		Component.LogErrorf("ledger integrity check detected a divergence: %s", divergence)
+	}
+
+	Component.LogDebugf("ledger integrity check at ledger index %d done, compared %d hashes, found %d divergences, took %v", result.LedgerIndex, result.HashesCompared, len(result.Divergences), result.Duration.Truncate(time.Millisecond))
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
@@ -16,6 +18,21 @@ type ParametersDatabase struct {
 	Debug bool `default:"false" usage:"ignore the check for corrupted databases (should only be used for debug reasons)"`
 	// CheckLedgerStateOnStartup defines whether to check if the ledger state matches the total supply on startup
 	CheckLedgerStateOnStartup bool `default:"false" usage:"whether to check if the ledger state matches the total supply on startup"`
+
+	IntegrityCheck struct {
+		// Enabled defines whether to periodically check the integrity of the ledger state in the background.
+		Enabled bool `default:"false" usage:"whether to periodically check the integrity of the ledger state in the background"`
+		// Interval defines the interval in which the integrity of the ledger state is checked.
+		Interval time.Duration `default:"1h" usage:"the interval in which the integrity of the ledger state is checked"`
+		// MilestoneDiffSampleSize defines the amount of milestone diffs whose hashes are checked per run.
+		MilestoneDiffSampleSize int `default:"10" usage:"the amount of milestone diffs whose hashes are checked per run"`
+		// ReferenceFile defines the path to a file with reference hashes.
+		ReferenceFile string `default:"" usage:"the path to a file with reference hashes (optional)"`
+		// ReferenceURLs defines the URLs of the hashes published by peers.
+		ReferenceURLs []string `name:"referenceURLs" default:"" usage:"the URLs of the hashes published by peers (optional)"`
+		// ReferenceRequestTimeout defines the timeout for fetching the hashes published by peers.
+		ReferenceRequestTimeout time.Duration `default:"10s" usage:"the timeout for fetching the hashes published by peers"`
+	}
 }
 
 var ParamsDatabase = &ParametersDatabase{}
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hornet/v2/components/restapi"
 	"github.com/iotaledger/hornet/v2/pkg/components"
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
@@ -23,6 +24,14 @@ const (
 	// POST triggers the solidifier.
 	RouteDebugSolidifier = "/solidifier"
 
+	// RouteDebugSolidifierDiagnostics is the debug route for getting diagnostics about the next milestone to solidify.
+	// GET returns the missing blocks, their requests and the referenced solid entry points of the milestone cone.
+	RouteDebugSolidifierDiagnostics = "/solidifier/diagnostics"
+
+	// RouteDebugLedgerIntegrity is the debug route for getting the state of the ledger integrity check.
+	// GET returns the result of the last check and the hashes computed by this node, which can be used as a reference by other nodes.
+	RouteDebugLedgerIntegrity = "/ledger/integrity"
+
 	// RouteDebugOutputs is the debug route for getting all output IDs.
 	// GET returns the outputIDs for all outputs.
 	RouteDebugOutputs = "/outputs"
@@ -76,6 +85,7 @@ type dependencies struct {
 	RequestQueue     gossip.RequestQueue
 	UTXOManager      *utxo.Manager
 	RestRouteManager *restapi.RestRouteManager `optional:"true"`
+	Verifier         *integrity.Verifier       `optional:"true"`
 }
 
 func configure() error {
@@ -92,6 +102,24 @@ func configure() error {
 		return c.NoContent(http.StatusNoContent)
 	})
 
+	routeGroup.GET(RouteDebugSolidifierDiagnostics, func(c echo.Context) error {
+		resp, err := solidifierDiagnostics(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteDebugLedgerIntegrity, func(c echo.Context) error {
+		resp, err := ledgerIntegrity(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteDebugOutputs, func(c echo.Context) error {
 		resp, err := outputsIDs(c)
 		if err != nil {
@@ -1,6 +1,7 @@
 package debug
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -194,6 +195,106 @@ func milestoneDiff(c echo.Context) (*milestoneDiffResponse, error) {
 	}, nil
 }
 
+func solidifierDiagnostics(_ echo.Context) (*solidifierDiagnosticsResponse, error) {
+
+	diagnostics := deps.Tangle.SolidificationDiagnostics()
+	if diagnostics == nil {
+		return nil, errors.WithMessage(echo.ErrNotFound, "no failed solidification attempt for the next milestone")
+	}
+
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+
+		return t.Format(time.RFC3339)
+	}
+
+	missingBlocks := make([]*missingBlock, 0, len(diagnostics.MissingBlocks))
+	for _, block := range diagnostics.MissingBlocks {
+		requestedFromPeers := make(map[string]int, len(block.RequestedFromPeers))
+		for peerID, count := range block.RequestedFromPeers {
+			requestedFromPeers[peerID.String()] = count
+		}
+
+		missingBlocks = append(missingBlocks, &missingBlock{
+			BlockID:              block.BlockID.ToHex(),
+			RequestState:         string(block.RequestState),
+			EnqueueTimestamp:     formatTime(block.EnqueueTime),
+			RequestAttempts:      block.RequestAttempts,
+			RequestedFromPeers:   requestedFromPeers,
+			LastRequestTimestamp: formatTime(block.LastRequestTime),
+		})
+	}
+
+	solidEntryPoints := make([]string, 0, len(diagnostics.SolidEntryPoints))
+	for _, blockID := range diagnostics.SolidEntryPoints {
+		solidEntryPoints = append(solidEntryPoints, blockID.ToHex())
+	}
+
+	return &solidifierDiagnosticsResponse{
+		ConfirmedMilestoneIndex: deps.SyncManager.ConfirmedMilestoneIndex(),
+		MilestoneIndex:          diagnostics.MilestoneIndex,
+		MilestoneID:             iotago.EncodeHex(diagnostics.MilestoneID[:]),
+		WaitingSinceTimestamp:   formatTime(diagnostics.WaitingSince),
+		WaitingSeconds:          diagnostics.WaitingDuration().Truncate(time.Millisecond).Seconds(),
+		LastAttemptTimestamp:    formatTime(diagnostics.LastAttemptTime),
+		Attempts:                diagnostics.Attempts,
+		BlocksChecked:           diagnostics.BlocksChecked,
+		MissingBlocks:           missingBlocks,
+		SolidEntryPoints:        solidEntryPoints,
+	}, nil
+}
+
+func ledgerIntegrity(_ echo.Context) (*ledgerIntegrityResponse, error) {
+
+	if deps.Verifier == nil {
+		return nil, errors.WithMessage(echo.ErrNotFound, "ledger integrity check is disabled")
+	}
+
+	publishedHashes := deps.Verifier.PublishedHashes()
+
+	resp := &ledgerIntegrityResponse{
+		Healthy:             deps.Verifier.Healthy(),
+		ChecksTotal:         deps.Verifier.ChecksTotal(),
+		DivergencesTotal:    deps.Verifier.DivergencesTotal(),
+		LedgerStateHashes:   publishedHashes.LedgerStateHashes,
+		MilestoneDiffHashes: publishedHashes.MilestoneDiffHashes,
+	}
+
+	if result := deps.Verifier.LastCheckResult(); result != nil {
+		sourceErrors := make([]string, 0, len(result.SourceErrors))
+		for _, sourceError := range result.SourceErrors {
+			sourceErrors = append(sourceErrors, fmt.Sprintf("%s: %s", sourceError.Source, sourceError.Err))
+		}
+
+		divergences := make([]*ledgerIntegrityDivergence, 0, len(result.Divergences))
+		for _, divergence := range result.Divergences {
+			divergences = append(divergences, &ledgerIntegrityDivergence{
+				Kind:           string(divergence.Kind),
+				MilestoneIndex: divergence.MilestoneIndex,
+				Source:         divergence.Source,
+				Expected:       divergence.Expected,
+				Actual:         divergence.Actual,
+			})
+		}
+
+		resp.LastCheck = &ledgerIntegrityCheck{
+			Timestamp:             result.Timestamp.Format(time.RFC3339),
+			DurationSeconds:       result.Duration.Truncate(time.Millisecond).Seconds(),
+			LedgerIndex:           result.LedgerIndex,
+			LedgerStateHash:       result.LedgerStateHash,
+			LedgerTokenSupply:     strconv.FormatUint(result.LedgerTokenSupply, 10),
+			MilestoneDiffsChecked: result.MilestoneDiffsChecked,
+			HashesCompared:        result.HashesCompared,
+			SourceErrors:          sourceErrors,
+			Divergences:           divergences,
+		}
+	}
+
+	return resp, nil
+}
+
 //nolint:unparam // even if the error is never used, the structure of all routes should be the same
 func requests(_ echo.Context) (*requestsResponse, error) {
 
@@ -43,6 +43,100 @@ type requestsResponse struct {
 	Requests []*request `json:"requests"`
 }
 
+// missingBlock defines a block that is missing in the cone of a milestone.
+type missingBlock struct {
+	// The hex encoded block ID of the missing block.
+	BlockID string `json:"blockId"`
+	// The state of the request for the missing block.
+	RequestState string `json:"requestState"`
+	// The time the request was enqueued.
+	EnqueueTimestamp string `json:"enqueueTimestamp,omitempty"`
+	// The amount of times the request was taken from the queue to be sent.
+	RequestAttempts int `json:"requestAttempts"`
+	// The amount of times the request was sent to each peer.
+	RequestedFromPeers map[string]int `json:"requestedFromPeers"`
+	// The time the request was sent the last time.
+	LastRequestTimestamp string `json:"lastRequestTimestamp,omitempty"`
+}
+
+// solidifierDiagnosticsResponse defines the response of a GET debug solidifier diagnostics REST API call.
+type solidifierDiagnosticsResponse struct {
+	// The index of the last confirmed milestone.
+	ConfirmedMilestoneIndex iotago.MilestoneIndex `json:"confirmedMilestoneIndex"`
+	// The index of the milestone that is being solidified.
+	MilestoneIndex iotago.MilestoneIndex `json:"milestoneIndex"`
+	// The hex encoded ID of the milestone that is being solidified.
+	MilestoneID string `json:"milestoneId"`
+	// The time the first failed solidification attempt for the milestone was started.
+	WaitingSinceTimestamp string `json:"waitingSinceTimestamp"`
+	// The duration in seconds the node has been waiting for the milestone to become solid.
+	WaitingSeconds float64 `json:"waitingSeconds"`
+	// The time of the last solidification attempt.
+	LastAttemptTimestamp string `json:"lastAttemptTimestamp"`
+	// The amount of solidification attempts for the milestone.
+	Attempts int `json:"attempts"`
+	// The amount of non-solid blocks that were found during the last attempt.
+	BlocksChecked int `json:"blocksChecked"`
+	// The blocks that were missing during the last attempt.
+	MissingBlocks []*missingBlock `json:"missingBlocks"`
+	// The hex encoded block IDs of the solid entry points referenced by the non-solid part of the milestone cone.
+	SolidEntryPoints []string `json:"solidEntryPoints"`
+}
+
+// ledgerIntegrityDivergence defines a divergence detected by the ledger integrity check.
+type ledgerIntegrityDivergence struct {
+	// The kind of check that detected the divergence.
+	Kind string `json:"kind"`
+	// The milestone index the divergence was detected at.
+	MilestoneIndex iotago.MilestoneIndex `json:"milestoneIndex"`
+	// The source of the expected value.
+	Source string `json:"source"`
+	// The expected value.
+	Expected string `json:"expected"`
+	// The locally computed value.
+	Actual string `json:"actual"`
+}
+
+// ledgerIntegrityCheck defines the result of a ledger integrity check.
+type ledgerIntegrityCheck struct {
+	// The time the check was started.
+	Timestamp string `json:"timestamp"`
+	// The duration of the check in seconds.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// The ledger index the check was performed at.
+	LedgerIndex iotago.MilestoneIndex `json:"ledgerIndex"`
+	// The hex encoded ledger state hash (without solid entry points).
+	LedgerStateHash string `json:"ledgerStateHash"`
+	// The sum of the deposits of all unspent outputs and the treasury.
+	LedgerTokenSupply string `json:"ledgerTokenSupply"`
+	// The amount of milestone diffs whose hashes were computed.
+	MilestoneDiffsChecked int `json:"milestoneDiffsChecked"`
+	// The amount of hashes that were compared against a reference.
+	HashesCompared int `json:"hashesCompared"`
+	// The errors of reference sources that could not be fetched.
+	SourceErrors []string `json:"sourceErrors,omitempty"`
+	// The detected divergences.
+	Divergences []*ledgerIntegrityDivergence `json:"divergences"`
+}
+
+// ledgerIntegrityResponse defines the response of a GET debug ledger integrity REST API call.
+// The hashes use the same format as the reference hashes of the ledger integrity check,
+// so the response can be used as a reference by other nodes.
+type ledgerIntegrityResponse struct {
+	// Whether the last check found no divergences.
+	Healthy bool `json:"healthy"`
+	// The amount of finished checks.
+	ChecksTotal uint64 `json:"checksTotal"`
+	// The amount of detected divergences.
+	DivergencesTotal uint64 `json:"divergencesTotal"`
+	// The result of the last check.
+	LastCheck *ledgerIntegrityCheck `json:"lastCheck,omitempty"`
+	// The hex encoded ledger state hashes computed by this node by ledger index.
+	LedgerStateHashes map[iotago.MilestoneIndex]string `json:"ledgerStateHashes"`
+	// The hex encoded milestone diff hashes computed by this node by milestone index.
+	MilestoneDiffHashes map[iotago.MilestoneIndex]string `json:"milestoneDiffHashes"`
+}
+
 // entryPoint defines an entryPoint with information about the milestone index of the cone it references.
 type entryPoint struct {
 	// The hex encoded block ID of the block.
@@ -26,6 +26,7 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/pruning"
 	"github.com/iotaledger/hornet/v2/pkg/snapshot"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	iotago "github.com/iotaledger/iota.go/v3"
 )
 
 const (
@@ -223,6 +224,9 @@ func run() error {
 
 	if err := Component.Daemon().BackgroundWorker("MessageProcessor", func(ctx context.Context) {
 		Component.LogInfo("Running MessageProcessor")
+		unhook := hookEventsMessageProcessor()
+		defer unhook()
+
 		deps.MessageProcessor.Run(ctx)
 		Component.LogInfo("Stopped MessageProcessor")
 	}, daemon.PriorityMessageProcessor); err != nil {
@@ -380,6 +384,12 @@ func hookEventsGossipService() (unhook func()) {
 	)
 }
 
+func hookEventsMessageProcessor() (unhook func()) {
+	return deps.MessageProcessor.Events.ValidationDropped.Hook(func(blockID iotago.BlockID, proto *gossip.Protocol) {
+		Component.LogDebugf("dropped block %s received from peer %s, it could not be submitted to the block validator", blockID.ToHex(), proto.PeerID.ShortString())
+	}).Unhook
+}
+
 func hookEventsBroadcastQueue() (unhook func()) {
 	return deps.MessageProcessor.Events.BroadcastBlock.Hook(deps.Broadcaster.Broadcast).Unhook
 }
@@ -2,6 +2,8 @@ package inx
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -13,16 +15,18 @@ import (
 	"github.com/iotaledger/hornet/v2/components/restapi"
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
+	"github.com/iotaledger/hornet/v2/pkg/health"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
 	"github.com/iotaledger/hornet/v2/pkg/pow"
 	"github.com/iotaledger/hornet/v2/pkg/protocol"
+	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
 	"github.com/iotaledger/hornet/v2/pkg/pruning"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
 	"github.com/iotaledger/hornet/v2/pkg/tipselect"
-	"github.com/iotaledger/iota.go/v3/keymanager"
 )
 
 func init() {
@@ -55,17 +59,19 @@ type dependencies struct {
 	Tangle                  *tangle.Tangle
 	TipScoreCalculator      *tangle.TipScoreCalculator
 	Storage                 *storage.Storage
-	KeyManager              *keymanager.KeyManager
+	SignatureVerifier       milestonemanager.SignatureVerifier
 	TipSelector             *tipselect.TipSelector `optional:"true"`
 	MilestonePublicKeyCount int                    `name:"milestonePublicKeyCount"`
 	ProtocolManager         *protocol.Manager
 	PruningManager          *pruning.Manager
 	BaseToken               *protocfg.BaseToken
 	PoWHandler              *pow.Handler
+	MessageProcessor        *gossip.MessageProcessor
 	INXServer               *Server
 	INXMetrics              *metrics.INXMetrics
 	Echo                    *echo.Echo                `optional:"true"`
 	RestRouteManager        *restapi.RestRouteManager `optional:"true"`
+	HealthChecker           *health.Checker           `optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -73,7 +79,8 @@ func provide(c *dig.Container) error {
 	if err := c.Provide(func() *metrics.INXMetrics {
 		return &metrics.INXMetrics{
 			Events: &metrics.INXEvents{
-				PoWCompleted: event.New2[int, time.Duration](),
+				PoWCompleted:   event.New2[int, time.Duration](),
+				BlockValidated: event.New1[time.Duration](),
 			},
 		}
 	}); err != nil {
@@ -91,6 +98,12 @@ func provide(c *dig.Container) error {
 
 func configure() error {
 
+	switch ParamsINX.Streams.OverflowPolicy {
+	case OverflowPolicyDrop, OverflowPolicyReplay:
+	default:
+		Component.LogPanicf("unknown INX stream overflow policy: %s", ParamsINX.Streams.OverflowPolicy)
+	}
+
 	attacherOpts := []tangle.BlockAttacherOption{
 		tangle.WithTimeout(blockProcessedTimeout),
 		tangle.WithPoW(deps.PoWHandler, ParamsINX.PoW.WorkerCount),
@@ -102,6 +115,41 @@ func configure() error {
 
 	attacher = deps.Tangle.BlockAttacher(attacherOpts...)
 
+	if ParamsINX.Validator.Enabled {
+		deps.MessageProcessor.SetBlockValidator(deps.INXServer.validateBlock)
+	}
+
+	if deps.HealthChecker != nil {
+		deps.HealthChecker.Register(restapi.HealthCheckINXExtensions, func() error {
+			minExtensions := restapi.ParamsRestAPI.Health.MinINXExtensions
+			if connected := deps.INXServer.ConnectedExtensions(); connected < minExtensions {
+				return fmt.Errorf("not enough connected INX extensions: %d/%d", connected, minExtensions)
+			}
+
+			return nil
+		})
+
+		if len(ParamsINX.RequiredExtensions) > 0 {
+			deps.HealthChecker.RegisterReadiness(restapi.HealthCheckINXRequiredExtensions, func() error {
+				if missing := deps.INXServer.MissingExtensions(ParamsINX.RequiredExtensions); len(missing) > 0 {
+					return fmt.Errorf("required INX extensions not connected: %s", strings.Join(missing, ", "))
+				}
+
+				return nil
+			})
+		}
+	}
+
+	if deps.RestRouteManager != nil {
+		// remove the REST API routes of extensions that disconnected without unregistering them
+		deps.INXServer.extensions.onRoutesOrphaned = func(extension *ExtensionInfo, routes []string) {
+			for _, route := range routes {
+				deps.RestRouteManager.RemoveRoute(route)
+				Component.LogInfof("Removed proxy %s of disconnected INX extension %s", route, extension.DisplayName())
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -117,5 +165,19 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if err := Component.Daemon().BackgroundWorker("INX block submissions", func(ctx context.Context) {
+		// the state of attached blocks only changes if a milestone gets confirmed
+		unhook := deps.Tangle.Events.ConfirmedMilestoneChanged.Hook(func(cachedMilestone *storage.CachedMilestone) {
+			cachedMilestone.Release(true) // milestone -1
+
+			deps.INXServer.submissions.checkAttached()
+		}).Unhook
+		defer unhook()
+
+		<-ctx.Done()
+	}, daemon.PriorityIndexer); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
 	return nil
 }
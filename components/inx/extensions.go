@@ -0,0 +1,436 @@
+package inx
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MetadataExtensionName is the gRPC metadata key an extension uses to announce its name.
+	MetadataExtensionName = "inx-extension-name"
+	// MetadataExtensionVersion is the gRPC metadata key an extension uses to announce its version.
+	MetadataExtensionVersion = "inx-extension-version"
+	// MetadataExtensionCapabilities is the gRPC metadata key an extension uses to announce its capabilities (comma separated).
+	MetadataExtensionCapabilities = "inx-extension-capabilities"
+)
+
+type extensionContextKey struct{}
+
+type streamContextKey struct{}
+
+// ExtensionStreamInfo contains information about an active stream subscription of an INX extension.
+type ExtensionStreamInfo struct {
+	// The full gRPC method name of the stream.
+	Method string
+	// The time the stream was started.
+	StartedAt time.Time
+	// The amount of messages sent to the extension.
+	MessagesSent uint64
+	// The time the last message was sent to the extension.
+	LastSentAt time.Time
+	// The milestone index of the last milestone related message sent to the extension.
+	LastSentMilestoneIndex iotago.MilestoneIndex
+	// The amount of milestones that are buffered and not sent to the extension yet.
+	BufferedMilestones int
+	// The maximum amount of milestones that can be buffered for the extension, or 0 if the stream is not buffered.
+	BufferCapacity int
+	// The amount of milestones that did not fit into the buffer.
+	Overflows uint64
+}
+
+// ExtensionInfo contains information about a connected INX extension.
+type ExtensionInfo struct {
+	// The ID of the connection of the extension.
+	ID uint64
+	// The remote address of the extension.
+	RemoteAddress string
+	// The time the extension connected.
+	ConnectedAt time.Time
+	// The name the extension announced, or empty if it didn't announce itself.
+	Name string
+	// The version the extension announced.
+	Version string
+	// The capabilities the extension announced.
+	Capabilities []string
+	// The REST API routes the extension registered.
+	Routes []string
+	// The active stream subscriptions of the extension.
+	Streams []*ExtensionStreamInfo
+}
+
+// DisplayName returns the announced name of the extension, or its remote address if it didn't announce itself.
+func (e *ExtensionInfo) DisplayName() string {
+	if e.Name != "" {
+		return e.Name
+	}
+
+	return e.RemoteAddress
+}
+
+// extensionStream tracks an active stream subscription of an INX extension.
+type extensionStream struct {
+	method                 string
+	startedAt              time.Time
+	messagesSent           atomic.Uint64
+	lastSentAt             atomic.Int64
+	lastSentMilestoneIndex atomic.Uint32
+	buffered               atomic.Int64
+	bufferCapacity         atomic.Int64
+	overflows              atomic.Uint64
+}
+
+// milestoneSent stores the milestone index of the last milestone related message sent on the stream.
+func (s *extensionStream) milestoneSent(index iotago.MilestoneIndex) {
+	s.lastSentMilestoneIndex.Store(index)
+}
+
+func (s *extensionStream) info() *ExtensionStreamInfo {
+	var lastSentAt time.Time
+	if lastSent := s.lastSentAt.Load(); lastSent != 0 {
+		lastSentAt = time.Unix(0, lastSent)
+	}
+
+	return &ExtensionStreamInfo{
+		Method:                 s.method,
+		StartedAt:              s.startedAt,
+		MessagesSent:           s.messagesSent.Load(),
+		LastSentAt:             lastSentAt,
+		LastSentMilestoneIndex: s.lastSentMilestoneIndex.Load(),
+		BufferedMilestones:     int(s.buffered.Load()),
+		BufferCapacity:         int(s.bufferCapacity.Load()),
+		Overflows:              s.overflows.Load(),
+	}
+}
+
+// extension tracks a connected INX extension.
+type extension struct {
+	sync.RWMutex
+	registry      *extensionRegistry
+	id            uint64
+	remoteAddress string
+	connectedAt   time.Time
+	name          string
+	version       string
+	capabilities  []string
+	routes        map[string]struct{}
+	streams       map[*extensionStream]struct{}
+}
+
+func (e *extension) info() *ExtensionInfo {
+	e.RLock()
+	defer e.RUnlock()
+
+	routes := make([]string, 0, len(e.routes))
+	for route := range e.routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+
+	streams := make([]*ExtensionStreamInfo, 0, len(e.streams))
+	for stream := range e.streams {
+		streams = append(streams, stream.info())
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		return streams[i].StartedAt.Before(streams[j].StartedAt)
+	})
+
+	return &ExtensionInfo{
+		ID:            e.id,
+		RemoteAddress: e.remoteAddress,
+		ConnectedAt:   e.connectedAt,
+		Name:          e.name,
+		Version:       e.version,
+		Capabilities:  append([]string{}, e.capabilities...),
+		Routes:        routes,
+		Streams:       streams,
+	}
+}
+
+// displayName returns the announced name of the extension, or its remote address if it didn't announce itself.
+func (e *extension) displayName() string {
+	e.RLock()
+	defer e.RUnlock()
+
+	if e.name != "" {
+		return e.name
+	}
+
+	return e.remoteAddress
+}
+
+// cursor returns the index of the last milestone that was acknowledged on the given method
+// by an extension with the same name, or 0 if there is none.
+func (e *extension) cursor(method string) iotago.MilestoneIndex {
+	e.RLock()
+	name := e.name
+	e.RUnlock()
+
+	if name == "" {
+		return 0
+	}
+
+	return e.registry.cursor(name, method)
+}
+
+// applyMetadata updates the announced name, version and capabilities of the extension.
+func (e *extension) applyMetadata(md metadata.MD) {
+	e.Lock()
+	defer e.Unlock()
+
+	if values := md.Get(MetadataExtensionName); len(values) > 0 {
+		e.name = values[0]
+	}
+	if values := md.Get(MetadataExtensionVersion); len(values) > 0 {
+		e.version = values[0]
+	}
+	if values := md.Get(MetadataExtensionCapabilities); len(values) > 0 {
+		capabilities := make([]string, 0)
+		for _, value := range values {
+			for _, capability := range strings.Split(value, ",") {
+				if capability = strings.TrimSpace(capability); capability != "" {
+					capabilities = append(capabilities, capability)
+				}
+			}
+		}
+		e.capabilities = capabilities
+	}
+}
+
+// extensionRegistry is a grpc stats handler that keeps track of the connected INX extensions,
+// their announced identity, their active streams and their registered REST API routes.
+type extensionRegistry struct {
+	sync.RWMutex
+	nextID     uint64
+	extensions map[uint64]*extension
+	// routeOwners maps the registered REST API routes to the extension that registered them.
+	routeOwners map[string]*extension
+	// onRoutesOrphaned is called with the routes of an extension that disconnected.
+	onRoutesOrphaned func(extension *ExtensionInfo, routes []string)
+	// cursors holds the index of the last milestone that was acknowledged per extension name and method,
+	// so that reconnecting extensions are able to resume their streams.
+	cursorsLock sync.RWMutex
+	cursors     map[string]iotago.MilestoneIndex
+}
+
+func newExtensionRegistry() *extensionRegistry {
+	return &extensionRegistry{
+		extensions:  make(map[uint64]*extension),
+		routeOwners: make(map[string]*extension),
+		cursors:     make(map[string]iotago.MilestoneIndex),
+	}
+}
+
+// extensionFromContext returns the extension the context of an RPC belongs to.
+func extensionFromContext(ctx context.Context) *extension {
+	ext, _ := ctx.Value(extensionContextKey{}).(*extension)
+
+	return ext
+}
+
+// streamFromContext returns the tracked stream the context of a streaming RPC belongs to.
+func streamFromContext(ctx context.Context) *extensionStream {
+	stream, _ := ctx.Value(streamContextKey{}).(*extensionStream)
+
+	return stream
+}
+
+// trackMilestoneSent stores the milestone index of the last milestone related message sent on the stream of the given context.
+// The resume cursor of the extension is not affected, it only advances if the extension acknowledges the milestone.
+func trackMilestoneSent(ctx context.Context, index iotago.MilestoneIndex) {
+	stream := streamFromContext(ctx)
+	if stream == nil {
+		return
+	}
+	stream.milestoneSent(index)
+}
+
+func cursorKey(name string, method string) string {
+	return name + method
+}
+
+// cursor returns the index of the last milestone that was acknowledged on the given method by an extension with the given name.
+func (r *extensionRegistry) cursor(name string, method string) iotago.MilestoneIndex {
+	r.cursorsLock.RLock()
+	defer r.cursorsLock.RUnlock()
+
+	return r.cursors[cursorKey(name, method)]
+}
+
+// storeCursor stores the index of the last milestone that was acknowledged on the given method by an extension with the given name.
+// The cursor never moves backwards, so acknowledgements that arrive out of order are ignored.
+func (r *extensionRegistry) storeCursor(name string, method string, index iotago.MilestoneIndex) {
+	r.cursorsLock.Lock()
+	defer r.cursorsLock.Unlock()
+
+	key := cursorKey(name, method)
+	if index <= r.cursors[key] {
+		return
+	}
+	r.cursors[key] = index
+}
+
+func (r *extensionRegistry) TagConn(ctx context.Context, info *stats.ConnTagInfo) context.Context {
+	r.Lock()
+	defer r.Unlock()
+
+	r.nextID++
+	ext := &extension{
+		registry:    r,
+		id:          r.nextID,
+		connectedAt: time.Now(),
+		routes:      make(map[string]struct{}),
+		streams:     make(map[*extensionStream]struct{}),
+	}
+	if info.RemoteAddr != nil {
+		ext.remoteAddress = info.RemoteAddr.String()
+	}
+
+	return context.WithValue(ctx, extensionContextKey{}, ext)
+}
+
+func (r *extensionRegistry) HandleConn(ctx context.Context, connStats stats.ConnStats) {
+	ext := extensionFromContext(ctx)
+	if ext == nil {
+		return
+	}
+
+	switch connStats.(type) {
+	case *stats.ConnBegin:
+		r.Lock()
+		r.extensions[ext.id] = ext
+		r.Unlock()
+
+	case *stats.ConnEnd:
+		r.Lock()
+		delete(r.extensions, ext.id)
+
+		// only remove the routes that were not taken over by another extension in the meantime
+		orphanedRoutes := make([]string, 0)
+		for route, owner := range r.routeOwners {
+			if owner == ext {
+				delete(r.routeOwners, route)
+				orphanedRoutes = append(orphanedRoutes, route)
+			}
+		}
+		onRoutesOrphaned := r.onRoutesOrphaned
+		r.Unlock()
+
+		if len(orphanedRoutes) > 0 && onRoutesOrphaned != nil {
+			sort.Strings(orphanedRoutes)
+			onRoutesOrphaned(ext.info(), orphanedRoutes)
+		}
+	}
+}
+
+func (r *extensionRegistry) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	// the stream is only tracked if the RPC turns out to be a server stream
+	return context.WithValue(ctx, streamContextKey{}, &extensionStream{method: info.FullMethodName})
+}
+
+func (r *extensionRegistry) HandleRPC(ctx context.Context, rpcStats stats.RPCStats) {
+	ext := extensionFromContext(ctx)
+	stream := streamFromContext(ctx)
+	if ext == nil || stream == nil {
+		return
+	}
+
+	switch s := rpcStats.(type) {
+	case *stats.InHeader:
+		ext.applyMetadata(s.Header)
+
+	case *stats.Begin:
+		if !s.IsServerStream {
+			return
+		}
+
+		stream.startedAt = s.BeginTime
+
+		ext.Lock()
+		ext.streams[stream] = struct{}{}
+		ext.Unlock()
+
+	case *stats.OutPayload:
+		stream.messagesSent.Inc()
+		stream.lastSentAt.Store(s.SentTime.UnixNano())
+
+	case *stats.End:
+		ext.Lock()
+		delete(ext.streams, stream)
+		ext.Unlock()
+	}
+}
+
+// addRoute stores that the extension of the given context registered the given REST API route.
+func (r *extensionRegistry) addRoute(ctx context.Context, route string) {
+	ext := extensionFromContext(ctx)
+	if ext == nil {
+		return
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	if previousOwner, exists := r.routeOwners[route]; exists && previousOwner != ext {
+		previousOwner.Lock()
+		delete(previousOwner.routes, route)
+		previousOwner.Unlock()
+	}
+	r.routeOwners[route] = ext
+
+	ext.Lock()
+	ext.routes[route] = struct{}{}
+	ext.Unlock()
+}
+
+// removeRoute removes the given REST API route from the extension that registered it.
+func (r *extensionRegistry) removeRoute(route string) {
+	r.Lock()
+	defer r.Unlock()
+
+	owner, exists := r.routeOwners[route]
+	if !exists {
+		return
+	}
+	delete(r.routeOwners, route)
+
+	owner.Lock()
+	delete(owner.routes, route)
+	owner.Unlock()
+}
+
+// count returns the amount of connected extensions.
+func (r *extensionRegistry) count() int {
+	r.RLock()
+	defer r.RUnlock()
+
+	return len(r.extensions)
+}
+
+// infos returns information about all connected extensions, ordered by their connection time.
+func (r *extensionRegistry) infos() []*ExtensionInfo {
+	r.RLock()
+	extensions := make([]*extension, 0, len(r.extensions))
+	for _, ext := range r.extensions {
+		extensions = append(extensions, ext)
+	}
+	r.RUnlock()
+
+	infos := make([]*ExtensionInfo, 0, len(extensions))
+	for _, ext := range extensions {
+		infos = append(infos, ext.info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+
+	return infos
+}
@@ -0,0 +1,50 @@
+package inx
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/filter"
+)
+
+// filterFromRequest parses the subscription filter of a filtered stream request.
+// The returned filter matches everything if no filter was given.
+func filterFromRequest(req *SubscriptionFilter) (*filter.Filter, error) {
+	f := &filter.Filter{}
+
+	if err := parseFilterValues(req.GetPayloadTypes(), filter.ParsePayloadType, &f.PayloadTypes); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetTagPrefixes(), filter.ParseTagPrefix, &f.TagPrefixes); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetOutputTypes(), filter.ParseOutputType, &f.OutputTypes); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetAddresses(), filter.ParseAddress, &f.Addresses); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetNativeTokenIds(), filter.ParseNativeTokenID, &f.NativeTokenIDs); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetAliasIds(), filter.ParseAliasID, &f.AliasIDs); err != nil {
+		return nil, err
+	}
+	if err := parseFilterValues(req.GetNftIds(), filter.ParseNFTID, &f.NFTIDs); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func parseFilterValues[T any](values []string, parse func(string) (T, error), target *[]T) error {
+	for _, value := range values {
+		parsed, err := parse(value)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		*target = append(*target, parsed)
+	}
+
+	return nil
+}
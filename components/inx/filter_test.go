@@ -0,0 +1,50 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package inx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestFilterFromRequest(t *testing.T) {
+	// no filter matches everything
+	f, err := filterFromRequest(nil)
+	require.NoError(t, err)
+	require.True(t, f.IsEmpty())
+
+	f, err = filterFromRequest(&SubscriptionFilter{})
+	require.NoError(t, err)
+	require.True(t, f.IsEmpty())
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+
+	f, err = filterFromRequest(&SubscriptionFilter{
+		PayloadTypes: []string{"taggedData", "6"},
+		TagPrefixes:  []string{"0x6869"},
+		OutputTypes:  []string{"basic"},
+		Addresses:    []string{address.Bech32(iotago.PrefixTestnet)},
+		AliasIds:     []string{aliasID.ToHex()},
+	})
+	require.NoError(t, err)
+	require.False(t, f.IsEmpty())
+	require.Equal(t, []iotago.PayloadType{iotago.PayloadTaggedData, iotago.PayloadTransaction}, f.PayloadTypes)
+	require.Equal(t, [][]byte{[]byte("hi")}, f.TagPrefixes)
+	require.Equal(t, []iotago.OutputType{iotago.OutputBasic}, f.OutputTypes)
+	require.Len(t, f.Addresses, 1)
+	require.True(t, address.Equal(f.Addresses[0]))
+	require.Equal(t, []iotago.AliasID{aliasID}, f.AliasIDs)
+
+	// invalid values are rejected
+	_, err = filterFromRequest(&SubscriptionFilter{OutputTypes: []string{"unknown"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = filterFromRequest(&SubscriptionFilter{NftIds: []string{"0x1234"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,1135 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: hornet_inx.proto
+
+package inx
+
+import (
+	_go "github.com/iotaledger/inx/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BlockInclusionProof is the proof of inclusion of a block.
+type BlockInclusionProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON encoding of the proof, as it is returned by the REST API, so it can be verified with the proof package.
+	Proof []byte `protobuf:"bytes,1,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (x *BlockInclusionProof) Reset() {
+	*x = BlockInclusionProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockInclusionProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockInclusionProof) ProtoMessage() {}
+
+func (x *BlockInclusionProof) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockInclusionProof.ProtoReflect.Descriptor instead.
+func (*BlockInclusionProof) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockInclusionProof) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// BlockConflictDetails explains why a transaction was marked as conflicting.
+type BlockConflictDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The index of the input that caused the conflict, or -1 if the conflict can't be attributed to a single input.
+	InputIndex int32           `protobuf:"zigzag32,1,opt,name=input_index,json=inputIndex,proto3" json:"input_index,omitempty"`
+	Reason     string          `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	OutputIds  []*_go.OutputId `protobuf:"bytes,3,rep,name=output_ids,json=outputIds,proto3" json:"output_ids,omitempty"`
+}
+
+func (x *BlockConflictDetails) Reset() {
+	*x = BlockConflictDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockConflictDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockConflictDetails) ProtoMessage() {}
+
+func (x *BlockConflictDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockConflictDetails.ProtoReflect.Descriptor instead.
+func (*BlockConflictDetails) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockConflictDetails) GetInputIndex() int32 {
+	if x != nil {
+		return x.InputIndex
+	}
+	return 0
+}
+
+func (x *BlockConflictDetails) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BlockConflictDetails) GetOutputIds() []*_go.OutputId {
+	if x != nil {
+		return x.OutputIds
+	}
+	return nil
+}
+
+// BlockMetadataWithConflictDetails is the metadata of a block including the details of its conflict.
+type BlockMetadataWithConflictDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *_go.BlockMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Only set if the block contains a conflicting transaction.
+	ConflictDetails *BlockConflictDetails `protobuf:"bytes,2,opt,name=conflict_details,json=conflictDetails,proto3" json:"conflict_details,omitempty"`
+}
+
+func (x *BlockMetadataWithConflictDetails) Reset() {
+	*x = BlockMetadataWithConflictDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockMetadataWithConflictDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockMetadataWithConflictDetails) ProtoMessage() {}
+
+func (x *BlockMetadataWithConflictDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockMetadataWithConflictDetails.ProtoReflect.Descriptor instead.
+func (*BlockMetadataWithConflictDetails) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BlockMetadataWithConflictDetails) GetMetadata() *_go.BlockMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *BlockMetadataWithConflictDetails) GetConflictDetails() *BlockConflictDetails {
+	if x != nil {
+		return x.ConflictDetails
+	}
+	return nil
+}
+
+// MilestoneConesRangeRequest selects the range and the content of a milestone cones stream.
+// The fields of the range are compatible with the INX MilestoneRangeRequest.
+type MilestoneConesRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMilestoneIndex uint32 `protobuf:"varint,1,opt,name=start_milestone_index,json=startMilestoneIndex,proto3" json:"start_milestone_index,omitempty"`
+	EndMilestoneIndex   uint32 `protobuf:"varint,2,opt,name=end_milestone_index,json=endMilestoneIndex,proto3" json:"end_milestone_index,omitempty"`
+	// The content of the stream, one of "all", "metadata" or "blocks". Empty selects "all".
+	Content string `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *MilestoneConesRangeRequest) Reset() {
+	*x = MilestoneConesRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MilestoneConesRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MilestoneConesRangeRequest) ProtoMessage() {}
+
+func (x *MilestoneConesRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MilestoneConesRangeRequest.ProtoReflect.Descriptor instead.
+func (*MilestoneConesRangeRequest) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MilestoneConesRangeRequest) GetStartMilestoneIndex() uint32 {
+	if x != nil {
+		return x.StartMilestoneIndex
+	}
+	return 0
+}
+
+func (x *MilestoneConesRangeRequest) GetEndMilestoneIndex() uint32 {
+	if x != nil {
+		return x.EndMilestoneIndex
+	}
+	return 0
+}
+
+func (x *MilestoneConesRangeRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// BlockValidationResult is the decision of a validator extension about a candidate block.
+type BlockValidationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockId *_go.BlockId `protobuf:"bytes,1,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Accept  bool         `protobuf:"varint,2,opt,name=accept,proto3" json:"accept,omitempty"`
+	Reason  string       `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *BlockValidationResult) Reset() {
+	*x = BlockValidationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockValidationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockValidationResult) ProtoMessage() {}
+
+func (x *BlockValidationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockValidationResult.ProtoReflect.Descriptor instead.
+func (*BlockValidationResult) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BlockValidationResult) GetBlockId() *_go.BlockId {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *BlockValidationResult) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+func (x *BlockValidationResult) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// BlockSubmissionRequest is a block that is submitted asynchronously.
+type BlockSubmissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block *_go.RawBlock `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	// The priority of the PoW of the block, one of "low", "normal" or "high". Empty selects "normal".
+	PowPriority string `protobuf:"bytes,2,opt,name=pow_priority,json=powPriority,proto3" json:"pow_priority,omitempty"`
+}
+
+func (x *BlockSubmissionRequest) Reset() {
+	*x = BlockSubmissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSubmissionRequest) ProtoMessage() {}
+
+func (x *BlockSubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSubmissionRequest.ProtoReflect.Descriptor instead.
+func (*BlockSubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BlockSubmissionRequest) GetBlock() *_go.RawBlock {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+func (x *BlockSubmissionRequest) GetPowPriority() string {
+	if x != nil {
+		return x.PowPriority
+	}
+	return ""
+}
+
+// BlockSubmissionTicket identifies an asynchronous block submission.
+type BlockSubmissionTicket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TicketId string `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+}
+
+func (x *BlockSubmissionTicket) Reset() {
+	*x = BlockSubmissionTicket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSubmissionTicket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSubmissionTicket) ProtoMessage() {}
+
+func (x *BlockSubmissionTicket) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSubmissionTicket.ProtoReflect.Descriptor instead.
+func (*BlockSubmissionTicket) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BlockSubmissionTicket) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+// BlockSubmissionStatus is the status of an asynchronous block submission.
+type BlockSubmissionStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TicketId                   string       `protobuf:"bytes,1,opt,name=ticket_id,json=ticketId,proto3" json:"ticket_id,omitempty"`
+	State                      string       `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	BlockId                    *_go.BlockId `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	ReferencedByMilestoneIndex uint32       `protobuf:"varint,4,opt,name=referenced_by_milestone_index,json=referencedByMilestoneIndex,proto3" json:"referenced_by_milestone_index,omitempty"`
+	Error                      string       `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BlockSubmissionStatus) Reset() {
+	*x = BlockSubmissionStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlockSubmissionStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockSubmissionStatus) ProtoMessage() {}
+
+func (x *BlockSubmissionStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockSubmissionStatus.ProtoReflect.Descriptor instead.
+func (*BlockSubmissionStatus) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BlockSubmissionStatus) GetTicketId() string {
+	if x != nil {
+		return x.TicketId
+	}
+	return ""
+}
+
+func (x *BlockSubmissionStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *BlockSubmissionStatus) GetBlockId() *_go.BlockId {
+	if x != nil {
+		return x.BlockId
+	}
+	return nil
+}
+
+func (x *BlockSubmissionStatus) GetReferencedByMilestoneIndex() uint32 {
+	if x != nil {
+		return x.ReferencedByMilestoneIndex
+	}
+	return 0
+}
+
+func (x *BlockSubmissionStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// MilestoneAck acknowledges that all milestones up to the given index received on a stream were processed.
+type MilestoneAck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The full gRPC method name of the stream, e.g. "/inx.INX/ListenToConfirmedMilestones".
+	Method         string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	MilestoneIndex uint32 `protobuf:"varint,2,opt,name=milestone_index,json=milestoneIndex,proto3" json:"milestone_index,omitempty"`
+}
+
+func (x *MilestoneAck) Reset() {
+	*x = MilestoneAck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MilestoneAck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MilestoneAck) ProtoMessage() {}
+
+func (x *MilestoneAck) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MilestoneAck.ProtoReflect.Descriptor instead.
+func (*MilestoneAck) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MilestoneAck) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MilestoneAck) GetMilestoneIndex() uint32 {
+	if x != nil {
+		return x.MilestoneIndex
+	}
+	return 0
+}
+
+// SubscriptionFilter selects the blocks and outputs an extension is interested in.
+// Different fields are combined with AND, the values of a single field are combined with OR.
+// Fields without values are ignored, so an empty filter matches everything.
+type SubscriptionFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The types of the payloads of the blocks, given by name (e.g. "taggedData") or number.
+	PayloadTypes []string `protobuf:"bytes,1,rep,name=payload_types,json=payloadTypes,proto3" json:"payload_types,omitempty"`
+	// The hex encoded prefixes of the tagged data tag or of the tag feature of the outputs.
+	TagPrefixes []string `protobuf:"bytes,2,rep,name=tag_prefixes,json=tagPrefixes,proto3" json:"tag_prefixes,omitempty"`
+	// The types of the outputs, given by name (e.g. "basic") or number.
+	OutputTypes []string `protobuf:"bytes,3,rep,name=output_types,json=outputTypes,proto3" json:"output_types,omitempty"`
+	// The bech32 encoded addresses that are able to unlock the outputs.
+	Addresses []string `protobuf:"bytes,4,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// The hex encoded IDs of the native tokens the outputs hold.
+	NativeTokenIds []string `protobuf:"bytes,5,rep,name=native_token_ids,json=nativeTokenIds,proto3" json:"native_token_ids,omitempty"`
+	// The hex encoded IDs of aliases that are either the outputs or are able to unlock them.
+	AliasIds []string `protobuf:"bytes,6,rep,name=alias_ids,json=aliasIds,proto3" json:"alias_ids,omitempty"`
+	// The hex encoded IDs of NFTs that are either the outputs or are able to unlock them.
+	NftIds []string `protobuf:"bytes,7,rep,name=nft_ids,json=nftIds,proto3" json:"nft_ids,omitempty"`
+}
+
+func (x *SubscriptionFilter) Reset() {
+	*x = SubscriptionFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionFilter) ProtoMessage() {}
+
+func (x *SubscriptionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionFilter.ProtoReflect.Descriptor instead.
+func (*SubscriptionFilter) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SubscriptionFilter) GetPayloadTypes() []string {
+	if x != nil {
+		return x.PayloadTypes
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetTagPrefixes() []string {
+	if x != nil {
+		return x.TagPrefixes
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetOutputTypes() []string {
+	if x != nil {
+		return x.OutputTypes
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetNativeTokenIds() []string {
+	if x != nil {
+		return x.NativeTokenIds
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetAliasIds() []string {
+	if x != nil {
+		return x.AliasIds
+	}
+	return nil
+}
+
+func (x *SubscriptionFilter) GetNftIds() []string {
+	if x != nil {
+		return x.NftIds
+	}
+	return nil
+}
+
+// FilteredLedgerUpdatesRequest selects the range and the outputs of a filtered ledger updates stream.
+// The fields of the range are compatible with the INX MilestoneRangeRequest.
+type FilteredLedgerUpdatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StartMilestoneIndex uint32              `protobuf:"varint,1,opt,name=start_milestone_index,json=startMilestoneIndex,proto3" json:"start_milestone_index,omitempty"`
+	EndMilestoneIndex   uint32              `protobuf:"varint,2,opt,name=end_milestone_index,json=endMilestoneIndex,proto3" json:"end_milestone_index,omitempty"`
+	Filter              *SubscriptionFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *FilteredLedgerUpdatesRequest) Reset() {
+	*x = FilteredLedgerUpdatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hornet_inx_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilteredLedgerUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilteredLedgerUpdatesRequest) ProtoMessage() {}
+
+func (x *FilteredLedgerUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hornet_inx_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilteredLedgerUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*FilteredLedgerUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_hornet_inx_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FilteredLedgerUpdatesRequest) GetStartMilestoneIndex() uint32 {
+	if x != nil {
+		return x.StartMilestoneIndex
+	}
+	return 0
+}
+
+func (x *FilteredLedgerUpdatesRequest) GetEndMilestoneIndex() uint32 {
+	if x != nil {
+		return x.EndMilestoneIndex
+	}
+	return 0
+}
+
+func (x *FilteredLedgerUpdatesRequest) GetFilter() *SubscriptionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+var File_hornet_inx_proto protoreflect.FileDescriptor
+
+var file_hornet_inx_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x6e, 0x78, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0a, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x1a, 0x09,
+	0x69, 0x6e, 0x78, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x2b, 0x0a, 0x13, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x7d, 0x0a, 0x14, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43,
+	0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x11, 0x52, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x0a, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x69, 0x6e,
+	0x78, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x64, 0x52, 0x09, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x49, 0x64, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x20, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d,
+	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x6c,
+	0x69, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x69,
+	0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4b, 0x0a, 0x10, 0x63, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x5f, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e,
+	0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x0f, 0x63, 0x6f, 0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x1a, 0x4d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x65, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x6e,
+	0x64, 0x5f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x22, 0x70, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x56, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x27, 0x0a,
+	0x08, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x52, 0x07, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x16, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x23, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x52, 0x61, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x05,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x5f, 0x70, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6f, 0x77,
+	0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x22, 0x34, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x49, 0x64, 0x22, 0xcc,
+	0x01, 0x0a, 0x15, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b,
+	0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x63,
+	0x6b, 0x65, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x08, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x52, 0x07, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x49, 0x64, 0x12, 0x41, 0x0a, 0x1d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63,
+	0x65, 0x64, 0x5f, 0x62, 0x79, 0x5f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1a, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x64, 0x42, 0x79, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x4f, 0x0a,
+	0x0c, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x41, 0x63, 0x6b, 0x12, 0x16, 0x0a,
+	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e,
+	0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xfd,
+	0x01, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61,
+	0x67, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x74, 0x61, 0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x65, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x49, 0x64, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x66, 0x74, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x66, 0x74, 0x49, 0x64, 0x73, 0x22, 0xba,
+	0x01, 0x0a, 0x1c, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x4c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x32, 0x0a, 0x15, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f,
+	0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x2e, 0x0a, 0x13, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x69, 0x6c, 0x65, 0x73,
+	0x74, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x11, 0x65, 0x6e, 0x64, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x36, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x32, 0x56, 0x0a, 0x0a, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x48, 0x0a, 0x17, 0x52, 0x65, 0x61,
+	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x0c, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x49, 0x64, 0x1a, 0x1f, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x32, 0x74, 0x0a, 0x0e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x6e, 0x66,
+	0x6c, 0x69, 0x63, 0x74, 0x73, 0x12, 0x62, 0x0a, 0x24, 0x52, 0x65, 0x61, 0x64, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f,
+	0x6e, 0x66, 0x6c, 0x69, 0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x0c, 0x2e,
+	0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x1a, 0x2c, 0x2e, 0x68, 0x6f,
+	0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x57, 0x69, 0x74, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x6c, 0x69,
+	0x63, 0x74, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x32, 0x6d, 0x0a, 0x0e, 0x4d, 0x69, 0x6c,
+	0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x5b, 0x0a, 0x17, 0x52,
+	0x65, 0x61, 0x64, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x43, 0x6f, 0x6e, 0x65,
+	0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x26, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e,
+	0x69, 0x6e, 0x78, 0x2e, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x43, 0x6f, 0x6e,
+	0x65, 0x73, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x57, 0x69, 0x74, 0x68, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x30, 0x01, 0x32, 0x56, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x43, 0x0a, 0x0e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x2e,
+	0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x1a, 0x0a, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x28, 0x01, 0x30, 0x01,
+	0x32, 0x9a, 0x02, 0x0a, 0x0f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x59, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x41, 0x73, 0x79, 0x6e, 0x63, 0x12, 0x22, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65,
+	0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x68,
+	0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x49, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65,
+	0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0d, 0x2e, 0x69, 0x6e,
+	0x78, 0x2e, 0x4e, 0x6f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x61, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x54, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69,
+	0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x21, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65,
+	0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x30, 0x01, 0x32, 0x48, 0x0a,
+	0x0d, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x41, 0x63, 0x6b, 0x73, 0x12, 0x37,
+	0x0a, 0x0c, 0x41, 0x63, 0x6b, 0x4d, 0x69, 0x6c, 0x65, 0x73, 0x74, 0x6f, 0x6e, 0x65, 0x12, 0x18,
+	0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x4d, 0x69, 0x6c, 0x65,
+	0x73, 0x74, 0x6f, 0x6e, 0x65, 0x41, 0x63, 0x6b, 0x1a, 0x0d, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x4e,
+	0x6f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x32, 0x8e, 0x02, 0x0a, 0x0f, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x46, 0x0a, 0x16, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x1e, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69,
+	0x6e, 0x78, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x1a, 0x0a, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x30, 0x01, 0x12, 0x53, 0x0a, 0x1b, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x54, 0x6f, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x53, 0x6f, 0x6c, 0x69, 0x64, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x12, 0x1e, 0x2e, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e,
+	0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x1a, 0x12, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x30, 0x01, 0x12, 0x5e, 0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x54, 0x6f, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x4c, 0x65, 0x64, 0x67,
+	0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x68, 0x6f, 0x72, 0x6e,
+	0x65, 0x74, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x65, 0x64, 0x4c,
+	0x65, 0x64, 0x67, 0x65, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x69, 0x6e, 0x78, 0x2e, 0x4c, 0x65, 0x64, 0x67, 0x65, 0x72,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x30, 0x01, 0x42, 0x34, 0x5a, 0x32, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x2f, 0x68, 0x6f, 0x72, 0x6e, 0x65, 0x74, 0x2f, 0x76, 0x32, 0x2f, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x69, 0x6e, 0x78, 0x3b, 0x69, 0x6e, 0x78, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hornet_inx_proto_rawDescOnce sync.Once
+	file_hornet_inx_proto_rawDescData = file_hornet_inx_proto_rawDesc
+)
+
+func file_hornet_inx_proto_rawDescGZIP() []byte {
+	file_hornet_inx_proto_rawDescOnce.Do(func() {
+		file_hornet_inx_proto_rawDescData = protoimpl.X.CompressGZIP(file_hornet_inx_proto_rawDescData)
+	})
+	return file_hornet_inx_proto_rawDescData
+}
+
+var file_hornet_inx_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hornet_inx_proto_goTypes = []interface{}{
+	(*BlockInclusionProof)(nil),              // 0: hornet.inx.BlockInclusionProof
+	(*BlockConflictDetails)(nil),             // 1: hornet.inx.BlockConflictDetails
+	(*BlockMetadataWithConflictDetails)(nil), // 2: hornet.inx.BlockMetadataWithConflictDetails
+	(*MilestoneConesRangeRequest)(nil),       // 3: hornet.inx.MilestoneConesRangeRequest
+	(*BlockValidationResult)(nil),            // 4: hornet.inx.BlockValidationResult
+	(*BlockSubmissionRequest)(nil),           // 5: hornet.inx.BlockSubmissionRequest
+	(*BlockSubmissionTicket)(nil),            // 6: hornet.inx.BlockSubmissionTicket
+	(*BlockSubmissionStatus)(nil),            // 7: hornet.inx.BlockSubmissionStatus
+	(*MilestoneAck)(nil),                     // 8: hornet.inx.MilestoneAck
+	(*SubscriptionFilter)(nil),               // 9: hornet.inx.SubscriptionFilter
+	(*FilteredLedgerUpdatesRequest)(nil),     // 10: hornet.inx.FilteredLedgerUpdatesRequest
+	(*_go.OutputId)(nil),                     // 11: inx.OutputId
+	(*_go.BlockMetadata)(nil),                // 12: inx.BlockMetadata
+	(*_go.BlockId)(nil),                      // 13: inx.BlockId
+	(*_go.RawBlock)(nil),                     // 14: inx.RawBlock
+	(*_go.BlockWithMetadata)(nil),            // 15: inx.BlockWithMetadata
+	(*_go.Block)(nil),                        // 16: inx.Block
+	(*_go.NoParams)(nil),                     // 17: inx.NoParams
+	(*_go.LedgerUpdate)(nil),                 // 18: inx.LedgerUpdate
+}
+var file_hornet_inx_proto_depIdxs = []int32{
+	11, // 0: hornet.inx.BlockConflictDetails.output_ids:type_name -> inx.OutputId
+	12, // 1: hornet.inx.BlockMetadataWithConflictDetails.metadata:type_name -> inx.BlockMetadata
+	1,  // 2: hornet.inx.BlockMetadataWithConflictDetails.conflict_details:type_name -> hornet.inx.BlockConflictDetails
+	13, // 3: hornet.inx.BlockValidationResult.block_id:type_name -> inx.BlockId
+	14, // 4: hornet.inx.BlockSubmissionRequest.block:type_name -> inx.RawBlock
+	13, // 5: hornet.inx.BlockSubmissionStatus.block_id:type_name -> inx.BlockId
+	9,  // 6: hornet.inx.FilteredLedgerUpdatesRequest.filter:type_name -> hornet.inx.SubscriptionFilter
+	13, // 7: hornet.inx.BlockProof.ReadBlockInclusionProof:input_type -> inx.BlockId
+	13, // 8: hornet.inx.BlockConflicts.ReadBlockMetadataWithConflictDetails:input_type -> inx.BlockId
+	3,  // 9: hornet.inx.MilestoneCones.ReadMilestoneConesRange:input_type -> hornet.inx.MilestoneConesRangeRequest
+	4,  // 10: hornet.inx.BlockValidation.ValidateBlocks:input_type -> hornet.inx.BlockValidationResult
+	5,  // 11: hornet.inx.BlockSubmission.SubmitBlockAsync:input_type -> hornet.inx.BlockSubmissionRequest
+	6,  // 12: hornet.inx.BlockSubmission.CancelBlockSubmission:input_type -> hornet.inx.BlockSubmissionTicket
+	6,  // 13: hornet.inx.BlockSubmission.ListenToBlockSubmission:input_type -> hornet.inx.BlockSubmissionTicket
+	8,  // 14: hornet.inx.MilestoneAcks.AckMilestone:input_type -> hornet.inx.MilestoneAck
+	9,  // 15: hornet.inx.FilteredStreams.ListenToFilteredBlocks:input_type -> hornet.inx.SubscriptionFilter
+	9,  // 16: hornet.inx.FilteredStreams.ListenToFilteredSolidBlocks:input_type -> hornet.inx.SubscriptionFilter
+	10, // 17: hornet.inx.FilteredStreams.ListenToFilteredLedgerUpdates:input_type -> hornet.inx.FilteredLedgerUpdatesRequest
+	0,  // 18: hornet.inx.BlockProof.ReadBlockInclusionProof:output_type -> hornet.inx.BlockInclusionProof
+	2,  // 19: hornet.inx.BlockConflicts.ReadBlockMetadataWithConflictDetails:output_type -> hornet.inx.BlockMetadataWithConflictDetails
+	15, // 20: hornet.inx.MilestoneCones.ReadMilestoneConesRange:output_type -> inx.BlockWithMetadata
+	16, // 21: hornet.inx.BlockValidation.ValidateBlocks:output_type -> inx.Block
+	6,  // 22: hornet.inx.BlockSubmission.SubmitBlockAsync:output_type -> hornet.inx.BlockSubmissionTicket
+	17, // 23: hornet.inx.BlockSubmission.CancelBlockSubmission:output_type -> inx.NoParams
+	7,  // 24: hornet.inx.BlockSubmission.ListenToBlockSubmission:output_type -> hornet.inx.BlockSubmissionStatus
+	17, // 25: hornet.inx.MilestoneAcks.AckMilestone:output_type -> inx.NoParams
+	16, // 26: hornet.inx.FilteredStreams.ListenToFilteredBlocks:output_type -> inx.Block
+	12, // 27: hornet.inx.FilteredStreams.ListenToFilteredSolidBlocks:output_type -> inx.BlockMetadata
+	18, // 28: hornet.inx.FilteredStreams.ListenToFilteredLedgerUpdates:output_type -> inx.LedgerUpdate
+	18, // [18:29] is the sub-list for method output_type
+	7,  // [7:18] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_hornet_inx_proto_init() }
+func file_hornet_inx_proto_init() {
+	if File_hornet_inx_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hornet_inx_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockInclusionProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockConflictDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockMetadataWithConflictDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MilestoneConesRangeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockValidationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockSubmissionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockSubmissionTicket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlockSubmissionStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MilestoneAck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hornet_inx_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FilteredLedgerUpdatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hornet_inx_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   7,
+		},
+		GoTypes:           file_hornet_inx_proto_goTypes,
+		DependencyIndexes: file_hornet_inx_proto_depIdxs,
+		MessageInfos:      file_hornet_inx_proto_msgTypes,
+	}.Build()
+	File_hornet_inx_proto = out.File
+	file_hornet_inx_proto_rawDesc = nil
+	file_hornet_inx_proto_goTypes = nil
+	file_hornet_inx_proto_depIdxs = nil
+}
@@ -1,6 +1,8 @@
 package inx
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
@@ -11,6 +13,32 @@ type ParametersINX struct {
 	// the bind address on which the INX can be accessed from
 	BindAddress string `default:"localhost:9029" usage:"the bind address on which the INX can be accessed from"`
 
+	// the names of the INX extensions that need to be connected for the node to be ready
+	RequiredExtensions []string `usage:"the names of the INX extensions that need to be connected for the node to be ready"`
+
+	Keepalive struct {
+		// the interval of keepalive pings to detect dead INX extensions
+		Time time.Duration `default:"20s" usage:"the interval of keepalive pings to detect dead INX extensions"`
+		// the time to wait for a keepalive response before the INX extension is considered dead
+		Timeout time.Duration `default:"5s" usage:"the time to wait for a keepalive response before the INX extension is considered dead"`
+	}
+
+	Streams struct {
+		// the maximum amount of milestones that are buffered per subscriber of ledger updates and confirmed milestones
+		BufferSize int `default:"100" usage:"the maximum amount of milestones that are buffered per subscriber of ledger updates and confirmed milestones"`
+		// the policy that is applied if the buffer of a subscriber overflows (drop, replay)
+		OverflowPolicy string `default:"replay" usage:"the policy that is applied if the buffer of a subscriber overflows (drop: disconnect the subscriber, replay: replay the missed milestones from the storage)"`
+	}
+
+	Validator struct {
+		// whether received and issued blocks are validated by a connected INX extension
+		Enabled bool `default:"false" usage:"whether received and issued blocks are validated by a connected INX extension"`
+		// the maximum time to wait for the decision of the validator
+		Timeout time.Duration `default:"500ms" usage:"the maximum time to wait for the decision of the validator"`
+		// whether blocks are rejected if the validator is not connected or didn't decide in time
+		FailClosed bool `default:"false" usage:"whether blocks are rejected if the validator is not connected or didn't decide in time"`
+	}
+
 	PoW struct {
 		// the amount of workers used for calculating PoW when issuing blocks via INX
 		WorkerCount int `default:"0" usage:"the amount of workers used for calculating PoW when issuing blocks via INX. (use 0 to use the maximum possible)"`
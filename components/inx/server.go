@@ -3,6 +3,7 @@ package inx
 import (
 	"context"
 	"net"
+	"sync"
 	"time"
 
 	grpcprometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -23,19 +24,37 @@ const (
 	workerCount = 1
 )
 
+// The features of the node that are not part of the INX protocol definition are served as additional
+// "hornet.inx" gRPC services on the same server, so extensions use the same connection for both.
+// Their messages are defined in proto/hornet_inx.proto, the service descriptions and client helpers
+// are kept next to the server implementation of each service (server_*.go).
+//
+// Generating the messages requires the proto directory of github.com/iotaledger/inx as INX_PROTO_PATH.
+//go:generate sh -c "protoc -I proto -I $INX_PROTO_PATH --go_out=. --go_opt=paths=source_relative proto/hornet_inx.proto"
+
 func newServer() *Server {
+	extensions := newExtensionRegistry()
+
 	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(extensions),
 		grpc.StreamInterceptor(grpcprometheus.StreamServerInterceptor),
 		grpc.UnaryInterceptor(grpcprometheus.UnaryServerInterceptor),
 		grpc.KeepaliveParams(keepalive.ServerParameters{
-			Time:    20 * time.Second,
-			Timeout: 5 * time.Second,
+			Time:    ParamsINX.Keepalive.Time,
+			Timeout: ParamsINX.Keepalive.Timeout,
 		}),
 		grpc.MaxConcurrentStreams(10),
 	)
 
-	s := &Server{grpcServer: grpcServer}
+	s := &Server{grpcServer: grpcServer, extensions: extensions, submissions: newSubmissionTickets()}
 	inx.RegisterINXServer(grpcServer, s)
+	grpcServer.RegisterService(&milestoneConesServiceDesc, s)
+	grpcServer.RegisterService(&blockValidationServiceDesc, s)
+	grpcServer.RegisterService(&blockSubmissionServiceDesc, s)
+	grpcServer.RegisterService(&blockProofServiceDesc, s)
+	grpcServer.RegisterService(&blockConflictsServiceDesc, s)
+	grpcServer.RegisterService(&milestoneAcksServiceDesc, s)
+	grpcServer.RegisterService(&filteredStreamsServiceDesc, s)
 
 	return s
 }
@@ -43,6 +62,41 @@ func newServer() *Server {
 type Server struct {
 	inx.UnimplementedINXServer
 	grpcServer *grpc.Server
+	extensions *extensionRegistry
+
+	validatorLock sync.RWMutex
+	// validator is the extension that is registered as block validator.
+	validator *blockValidator
+
+	// submissions holds the tickets of the asynchronous block submissions.
+	submissions *submissionTickets
+}
+
+// ConnectedExtensions returns the amount of INX extensions that are currently connected to the server.
+func (s *Server) ConnectedExtensions() int {
+	return s.extensions.count()
+}
+
+// Extensions returns information about the INX extensions that are currently connected to the server.
+func (s *Server) Extensions() []*ExtensionInfo {
+	return s.extensions.infos()
+}
+
+// MissingExtensions returns the names of the given extensions that are currently not connected to the server.
+func (s *Server) MissingExtensions(names []string) []string {
+	connected := make(map[string]struct{})
+	for _, extension := range s.extensions.infos() {
+		connected[extension.Name] = struct{}{}
+	}
+
+	missing := make([]string, 0)
+	for _, name := range names {
+		if _, exists := connected[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
 }
 
 func (s *Server) ConfigurePrometheus() {
@@ -201,7 +255,7 @@ func (s *Server) ListenToNodeStatus(req *inx.NodeStatusRequest, srv inx.INX_List
 }
 
 func (s *Server) ReadNodeConfiguration(context.Context, *inx.NoParams) (*inx.NodeConfiguration, error) {
-	keyRanges := deps.KeyManager.KeyRanges()
+	keyRanges := deps.SignatureVerifier.KeyRanges()
 	inxKeyRanges := make([]*inx.MilestoneKeyRange, len(keyRanges))
 	for i, r := range keyRanges {
 		inxKeyRanges[i] = &inx.MilestoneKeyRange{
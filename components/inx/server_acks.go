@@ -0,0 +1,92 @@
+package inx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MilestoneAcksServiceName is the name of the gRPC service that lets extensions acknowledge processed milestones.
+	MilestoneAcksServiceName = "hornet.inx.MilestoneAcks"
+	// AckMilestoneMethod is the full gRPC method name to acknowledge a processed milestone.
+	AckMilestoneMethod = "/" + MilestoneAcksServiceName + "/AckMilestone"
+)
+
+// milestoneAcksServer is the interface of the gRPC service that lets extensions acknowledge processed milestones.
+type milestoneAcksServer interface {
+	AckMilestone(ctx context.Context, ack *MilestoneAck) (*inx.NoParams, error)
+}
+
+func ackMilestoneHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	ack := &MilestoneAck{}
+	if err := dec(ack); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(milestoneAcksServer).AckMilestone(ctx, ack)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AckMilestoneMethod,
+	}
+
+	return interceptor(ctx, ack, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(milestoneAcksServer).AckMilestone(ctx, req.(*MilestoneAck))
+	})
+}
+
+// milestoneAcksServiceDesc describes the gRPC service that lets extensions acknowledge processed milestones.
+var milestoneAcksServiceDesc = grpc.ServiceDesc{
+	ServiceName: MilestoneAcksServiceName,
+	HandlerType: (*milestoneAcksServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AckMilestone",
+			Handler:    ackMilestoneHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// AckMilestone acknowledges that all milestones up to the given index received on the stream of the given method were processed.
+// The extension needs to announce its name, so that a stream that is resumed by an extension with the same name
+// continues after the acknowledged milestone.
+func AckMilestone(ctx context.Context, conn grpc.ClientConnInterface, method string, index iotago.MilestoneIndex, opts ...grpc.CallOption) error {
+	return conn.Invoke(ctx, AckMilestoneMethod, &MilestoneAck{Method: method, MilestoneIndex: index}, &inx.NoParams{}, opts...)
+}
+
+func (s *Server) AckMilestone(ctx context.Context, req *MilestoneAck) (*inx.NoParams, error) {
+	if req.GetMethod() == "" {
+		return nil, status.Error(codes.InvalidArgument, "the method of the acknowledged stream is missing")
+	}
+	if req.GetMilestoneIndex() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "the acknowledged milestone index is missing")
+	}
+
+	ext := extensionFromContext(ctx)
+	if ext == nil {
+		return nil, status.Error(codes.Internal, "the connection of the extension is not tracked")
+	}
+
+	ext.RLock()
+	name := ext.name
+	ext.RUnlock()
+
+	if name == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "the extension needs to announce its name with the %s metadata to acknowledge milestones", MetadataExtensionName)
+	}
+
+	ext.registry.storeCursor(name, req.GetMethod(), req.GetMilestoneIndex())
+
+	return &inx.NoParams{}, nil
+}
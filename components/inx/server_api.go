@@ -12,7 +12,7 @@ import (
 	inx "github.com/iotaledger/inx/go"
 )
 
-func (s *Server) RegisterAPIRoute(_ context.Context, req *inx.APIRouteRequest) (*inx.NoParams, error) {
+func (s *Server) RegisterAPIRoute(ctx context.Context, req *inx.APIRouteRequest) (*inx.NoParams, error) {
 	if !Component.App().IsComponentEnabled(restapi.Component.Identifier()) {
 		return nil, status.Error(codes.Unavailable, "RestAPI plugin is not enabled")
 	}
@@ -31,6 +31,7 @@ func (s *Server) RegisterAPIRoute(_ context.Context, req *inx.APIRouteRequest) (
 
 		return nil, status.Errorf(codes.Internal, "error adding route to proxy: %s", err.Error())
 	}
+	s.extensions.addRoute(ctx, req.GetRoute())
 	Component.LogInfof("Registered proxy %s => %s:%d", req.GetRoute(), req.GetHost(), req.GetPort())
 
 	return &inx.NoParams{}, nil
@@ -45,6 +46,7 @@ func (s *Server) UnregisterAPIRoute(_ context.Context, req *inx.APIRouteRequest)
 		return nil, status.Error(codes.InvalidArgument, "route can not be empty")
 	}
 	deps.RestRouteManager.RemoveRoute(req.GetRoute())
+	s.extensions.removeRoute(req.GetRoute())
 	Component.LogInfof("Removed proxy %s", req.GetRoute())
 
 	return &inx.NoParams{}, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 	"github.com/iotaledger/hive.go/serializer/v2"
 	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/filter"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
 	"github.com/iotaledger/hornet/v2/pkg/tipselect"
@@ -139,7 +140,23 @@ func (s *Server) ReadBlockMetadata(_ context.Context, blockID *inx.BlockId) (*in
 	return NewINXBlockMetadata(Component.Daemon().ContextStopped(), cachedBlockMeta.Metadata().BlockID(), cachedBlockMeta.Metadata())
 }
 
+// matchBlockByID loads the block with the given ID from the storage and returns whether it matches the filter.
+func matchBlockByID(blockFilter *filter.Filter, blockID iotago.BlockID) bool {
+	cachedBlock := deps.Storage.CachedBlockOrNil(blockID) // block +1
+	if cachedBlock == nil {
+		return false
+	}
+	defer cachedBlock.Release(true) // block -1
+
+	return blockFilter.MatchBlock(cachedBlock.Block().Block())
+}
+
 func (s *Server) ListenToBlocks(_ *inx.NoParams, srv inx.INX_ListenToBlocksServer) error {
+	return s.listenToBlocks(&filter.Filter{}, srv)
+}
+
+// listenToBlocks streams the new blocks that match the given filter.
+func (s *Server) listenToBlocks(blockFilter *filter.Filter, srv inx.INX_ListenToBlocksServer) error {
 	ctx, cancel := context.WithCancel(Component.Daemon().ContextStopped())
 
 	wp := workerpool.New("ListenToBlocks", workerCount).Start()
@@ -147,6 +164,10 @@ func (s *Server) ListenToBlocks(_ *inx.NoParams, srv inx.INX_ListenToBlocksServe
 	unhook := deps.Tangle.Events.ReceivedNewBlock.Hook(func(cachedBlock *storage.CachedBlock, latestMilestoneIndex iotago.MilestoneIndex, confirmedMilestoneIndex iotago.MilestoneIndex) {
 		defer cachedBlock.Release(true) // block -1
 
+		if !blockFilter.MatchBlock(cachedBlock.Block().Block()) {
+			return
+		}
+
 		payload := inx.NewBlockWithBytes(cachedBlock.Block().BlockID(), cachedBlock.Block().Data())
 		if err := srv.Send(payload); err != nil {
 			Component.LogErrorf("send error: %v", err)
@@ -167,6 +188,11 @@ func (s *Server) ListenToBlocks(_ *inx.NoParams, srv inx.INX_ListenToBlocksServe
 }
 
 func (s *Server) ListenToSolidBlocks(_ *inx.NoParams, srv inx.INX_ListenToSolidBlocksServer) error {
+	return s.listenToSolidBlocks(&filter.Filter{}, srv)
+}
+
+// listenToSolidBlocks streams the metadata of the solid blocks that match the given filter.
+func (s *Server) listenToSolidBlocks(blockFilter *filter.Filter, srv inx.INX_ListenToSolidBlocksServer) error {
 	ctx, cancel := context.WithCancel(Component.Daemon().ContextStopped())
 
 	wp := workerpool.New("ListenToSolidBlocks", workerCount).Start()
@@ -174,6 +200,10 @@ func (s *Server) ListenToSolidBlocks(_ *inx.NoParams, srv inx.INX_ListenToSolidB
 	unhook := deps.Tangle.Events.BlockSolid.Hook(func(blockMeta *storage.CachedMetadata) {
 		defer blockMeta.Release(true) // meta -1
 
+		if !blockFilter.IsEmpty() && !matchBlockByID(blockFilter, blockMeta.Metadata().BlockID()) {
+			return
+		}
+
 		payload, err := NewINXBlockMetadata(ctx, blockMeta.Metadata().BlockID(), blockMeta.Metadata())
 		if err != nil {
 			Component.LogErrorf("serialize error: %v", err)
@@ -288,19 +318,7 @@ func (s *Server) SubmitBlock(ctx context.Context, rawBlock *inx.RawBlock) (*inx.
 
 	blockID, err := attacher.AttachBlock(mergedCtx, block)
 	if err != nil {
-		switch {
-		case errors.Is(err, tangle.ErrBlockAttacherInvalidBlock):
-			return nil, status.Errorf(codes.InvalidArgument, "failed to attach block: %s", err.Error())
-
-		case errors.Is(err, tangle.ErrBlockAttacherAttachingNotPossible):
-			return nil, status.Errorf(codes.Internal, "failed to attach block: %s", err.Error())
-
-		case errors.Is(err, tangle.ErrBlockAttacherPoWNotAvailable):
-			return nil, status.Errorf(codes.Unavailable, "failed to attach block: %s", err.Error())
-
-		default:
-			return nil, status.Errorf(codes.Internal, "failed to attach block: %s", err.Error())
-		}
+		return nil, attachBlockError(err)
 	}
 
 	return inx.NewBlockId(blockID), nil
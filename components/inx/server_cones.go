@@ -0,0 +1,300 @@
+package inx
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MilestoneConesServiceName is the name of the gRPC service that streams the cones of a range of milestones.
+	MilestoneConesServiceName = "hornet.inx.MilestoneCones"
+	// MilestoneConesRangeMethod is the full gRPC method name of the ranged milestone cone stream.
+	MilestoneConesRangeMethod = "/" + MilestoneConesServiceName + "/ReadMilestoneConesRange"
+
+	// ConeContentAll streams the blocks and their metadata.
+	ConeContentAll = "all"
+	// ConeContentMetadata only streams the metadata of the blocks.
+	ConeContentMetadata = "metadata"
+	// ConeContentBlocks only streams the blocks, together with their ID, referencing milestone index and white flag index,
+	// so the extension is still able to assign the blocks to the milestones.
+	ConeContentBlocks = "blocks"
+)
+
+// milestoneConesServer is the interface of the gRPC service that streams the cones of a range of milestones.
+type milestoneConesServer interface {
+	ReadMilestoneConesRange(req *MilestoneConesRangeRequest, srv MilestoneConesRangeServer) error
+}
+
+// MilestoneConesRangeServer is the server side of the ranged milestone cone stream.
+type MilestoneConesRangeServer interface {
+	Send(payload *inx.BlockWithMetadata) error
+	grpc.ServerStream
+}
+
+type milestoneConesRangeServer struct {
+	grpc.ServerStream
+}
+
+func (s *milestoneConesRangeServer) Send(payload *inx.BlockWithMetadata) error {
+	return s.ServerStream.SendMsg(payload)
+}
+
+func readMilestoneConesRangeHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &MilestoneConesRangeRequest{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(milestoneConesServer).ReadMilestoneConesRange(req, &milestoneConesRangeServer{stream})
+}
+
+// milestoneConesServiceDesc describes the gRPC service that streams the cones of a range of milestones.
+var milestoneConesServiceDesc = grpc.ServiceDesc{
+	ServiceName: MilestoneConesServiceName,
+	HandlerType: (*milestoneConesServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadMilestoneConesRange",
+			Handler:       readMilestoneConesRangeHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// MilestoneConesRangeClient is the client side of the ranged milestone cone stream.
+type MilestoneConesRangeClient interface {
+	Recv() (*inx.BlockWithMetadata, error)
+	grpc.ClientStream
+}
+
+type milestoneConesRangeClient struct {
+	grpc.ClientStream
+}
+
+func (c *milestoneConesRangeClient) Recv() (*inx.BlockWithMetadata, error) {
+	payload := &inx.BlockWithMetadata{}
+	if err := c.ClientStream.RecvMsg(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// ReadMilestoneConesRange streams the blocks of the cones of all milestones in the requested range in white flag order.
+// It can be used by extensions that are connected to the INX server of the node.
+func ReadMilestoneConesRange(ctx context.Context, conn grpc.ClientConnInterface, req *MilestoneConesRangeRequest, opts ...grpc.CallOption) (MilestoneConesRangeClient, error) {
+	stream, err := conn.NewStream(ctx, &milestoneConesServiceDesc.Streams[0], MilestoneConesRangeMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &milestoneConesRangeClient{stream}
+	if err := client.ClientStream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := client.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// coneContent returns the requested content of the ranged milestone cone stream.
+func coneContent(req *MilestoneConesRangeRequest) (string, error) {
+	switch content := req.GetContent(); content {
+	case "":
+		return ConeContentAll, nil
+	case ConeContentAll, ConeContentMetadata, ConeContentBlocks:
+		return content, nil
+	default:
+		return "", status.Errorf(codes.InvalidArgument, "unknown cone content: %s", content)
+	}
+}
+
+func (s *Server) ReadMilestoneConesRange(req *MilestoneConesRangeRequest, srv MilestoneConesRangeServer) error {
+	snapshotInfo := deps.Storage.SnapshotInfo()
+	if snapshotInfo == nil {
+		return common.ErrSnapshotInfoNotFound
+	}
+
+	content, err := coneContent(req)
+	if err != nil {
+		return err
+	}
+
+	createConePayloadAndSend := func(blockMetadata *storage.BlockMetadata) error {
+		payload := &inx.BlockWithMetadata{}
+
+		if content != ConeContentBlocks {
+			meta, err := NewINXBlockMetadata(Component.Daemon().ContextStopped(), blockMetadata.BlockID(), blockMetadata)
+			if err != nil {
+				return err
+			}
+			payload.Metadata = meta
+		} else {
+			_, referencedIndex, whiteFlagIndex := blockMetadata.ReferencedWithIndexAndWhiteFlagIndex()
+			payload.Metadata = &inx.BlockMetadata{
+				BlockId:                    inx.NewBlockId(blockMetadata.BlockID()),
+				ReferencedByMilestoneIndex: referencedIndex,
+				WhiteFlagIndex:             whiteFlagIndex,
+			}
+		}
+
+		if content != ConeContentMetadata {
+			cachedBlock := deps.Storage.CachedBlockOrNil(blockMetadata.BlockID()) // block +1
+			if cachedBlock == nil {
+				return status.Errorf(codes.Internal, "block %s not found", blockMetadata.BlockID().ToHex())
+			}
+			defer cachedBlock.Release(true) // block -1
+
+			payload.Block = &inx.RawBlock{
+				Data: cachedBlock.Block().Data(),
+			}
+		}
+
+		if err := srv.Send(payload); err != nil {
+			return fmt.Errorf("send error: %w", err)
+		}
+
+		return nil
+	}
+
+	sendMilestoneCone := func(msIndex iotago.MilestoneIndex, parents iotago.BlockIDs) error {
+		if err := milestoneCone(msIndex, parents, createConePayloadAndSend); err != nil {
+			return err
+		}
+		trackMilestoneSent(srv.Context(), msIndex)
+
+		return nil
+	}
+
+	sendMilestoneConesRange := func(startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) error {
+		for currentIndex := startIndex; currentIndex <= endIndex; currentIndex++ {
+			cachedMilestone := deps.Storage.CachedMilestoneByIndexOrNil(currentIndex) // milestone +1
+			if cachedMilestone == nil {
+				return status.Errorf(codes.NotFound, "milestone %d not found", currentIndex)
+			}
+			parents := cachedMilestone.Milestone().Parents()
+			cachedMilestone.Release(true) // milestone -1
+
+			if err := sendMilestoneCone(currentIndex, parents); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	// if a startIndex is given, we send the cones of all available milestones including the start index.
+	// if an endIndex is given, we send the cones of all available milestones up to and including min(confirmedMilestoneIndex, endIndex).
+	// if no startIndex is given, but an endIndex, we don't send the cones of previous milestones.
+	sendPreviousMilestoneCones := func(startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) (iotago.MilestoneIndex, error) {
+		if startIndex == 0 {
+			// no need to send the cones of previous milestones
+			return 0, nil
+		}
+
+		cmi := deps.SyncManager.ConfirmedMilestoneIndex()
+
+		if startIndex > cmi {
+			// no need to send the cones of previous milestones
+			return 0, nil
+		}
+
+		// the cones of pruned milestones are not available anymore
+		pruningIndex := snapshotInfo.PruningIndex()
+		if startIndex <= pruningIndex {
+			return 0, status.Errorf(codes.InvalidArgument, "given startMilestoneIndex %d is older than the current pruningIndex %d", startIndex, pruningIndex)
+		}
+
+		if endIndex == 0 || endIndex > cmi {
+			endIndex = cmi
+		}
+
+		if err := sendMilestoneConesRange(startIndex, endIndex); err != nil {
+			return 0, err
+		}
+
+		return endIndex, nil
+	}
+
+	stream := &streamRange{
+		start: resumeStartIndex(srv.Context(), req.GetStartMilestoneIndex()),
+		end:   req.GetEndMilestoneIndex(),
+	}
+
+	stream.lastSent, err = sendPreviousMilestoneCones(stream.start, stream.end)
+	if err != nil {
+		return err
+	}
+
+	if stream.isBounded() && stream.lastSent >= stream.end {
+		// We are done sending, so close the stream
+		return nil
+	}
+
+	catchUpFunc := func(start iotago.MilestoneIndex, end iotago.MilestoneIndex) error {
+		if err := sendMilestoneConesRange(start, end); err != nil {
+			Component.LogErrorf("sendMilestoneConesRange error: %v", err)
+
+			return err
+		}
+
+		return nil
+	}
+
+	sendFunc := func(index iotago.MilestoneIndex, parents iotago.BlockIDs) error {
+		if err := sendMilestoneCone(index, parents); err != nil {
+			Component.LogErrorf("send error: %v", err)
+
+			return err
+		}
+
+		return nil
+	}
+
+	// the events are buffered, so that a slow subscriber doesn't block the node.
+	buffer := newMilestoneBuffer[iotago.BlockIDs](srv.Context())
+
+	unhook := deps.Tangle.Events.ConfirmedMilestoneChanged.Hook(func(cachedMilestone *storage.CachedMilestone) {
+		defer cachedMilestone.Release(true) // milestone -1
+
+		buffer.push(cachedMilestone.Milestone().Index(), cachedMilestone.Milestone().Parents())
+	}).Unhook
+	defer unhook()
+
+	for {
+		select {
+		case <-Component.Daemon().ContextStopped().Done():
+			return nil
+
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+
+		case <-buffer.failed:
+			return buffer.err
+
+		case item := <-buffer.items:
+			prepareReplay(stream, buffer.pop(item.index))
+
+			done, err := handleRangedSend1(item.index, item.payload, stream, catchUpFunc, sendFunc)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package inx
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// testMilestoneConesServer streams one block per milestone in the requested range,
+// with the content selected by the request.
+type testMilestoneConesServer struct {
+	blockIDs map[iotago.MilestoneIndex]iotago.BlockID
+}
+
+func (s *testMilestoneConesServer) ReadMilestoneConesRange(req *MilestoneConesRangeRequest, srv MilestoneConesRangeServer) error {
+	content, err := coneContent(req)
+	if err != nil {
+		return err
+	}
+
+	for index := req.GetStartMilestoneIndex(); index <= req.GetEndMilestoneIndex(); index++ {
+		payload := &inx.BlockWithMetadata{
+			Metadata: &inx.BlockMetadata{
+				BlockId:                    inx.NewBlockId(s.blockIDs[index]),
+				ReferencedByMilestoneIndex: index,
+			},
+		}
+		if content != ConeContentMetadata {
+			payload.Block = &inx.RawBlock{Data: []byte{byte(index)}}
+		}
+
+		if err := srv.Send(payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newTestMilestoneConesConn(t *testing.T, server *testMilestoneConesServer) *grpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	grpcServer.RegisterService(&milestoneConesServiceDesc, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func readAllMilestoneCones(t *testing.T, client MilestoneConesRangeClient) []*inx.BlockWithMetadata {
+	var payloads []*inx.BlockWithMetadata
+	for {
+		payload, err := client.Recv()
+		if err == io.EOF {
+			return payloads
+		}
+		require.NoError(t, err)
+
+		payloads = append(payloads, payload)
+	}
+}
+
+func TestConeContent(t *testing.T) {
+	tests := []struct {
+		content  string
+		expected string
+	}{
+		{"", ConeContentAll},
+		{ConeContentAll, ConeContentAll},
+		{ConeContentMetadata, ConeContentMetadata},
+		{ConeContentBlocks, ConeContentBlocks},
+	}
+
+	for _, test := range tests {
+		content, err := coneContent(&MilestoneConesRangeRequest{Content: test.content})
+		require.NoError(t, err)
+		require.Equal(t, test.expected, content)
+	}
+
+	// a request without content streams everything
+	content, err := coneContent(nil)
+	require.NoError(t, err)
+	require.Equal(t, ConeContentAll, content)
+
+	_, err = coneContent(&MilestoneConesRangeRequest{Content: "parents"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMilestoneConesRangeRequestCompatibility(t *testing.T) {
+	codec := encoding.GetCodec(proto.Name)
+
+	// a request of an extension that doesn't know the content field streams everything
+	data, err := codec.Marshal(&inx.MilestoneRangeRequest{StartMilestoneIndex: 5, EndMilestoneIndex: 10})
+	require.NoError(t, err)
+
+	req := &MilestoneConesRangeRequest{}
+	require.NoError(t, codec.Unmarshal(data, req))
+	require.True(t, protobuf.Equal(&MilestoneConesRangeRequest{StartMilestoneIndex: 5, EndMilestoneIndex: 10}, req))
+
+	// the content field is sent along with the range
+	data, err = codec.Marshal(&MilestoneConesRangeRequest{StartMilestoneIndex: 5, EndMilestoneIndex: 10, Content: ConeContentBlocks})
+	require.NoError(t, err)
+
+	req = &MilestoneConesRangeRequest{}
+	require.NoError(t, codec.Unmarshal(data, req))
+	require.True(t, protobuf.Equal(&MilestoneConesRangeRequest{StartMilestoneIndex: 5, EndMilestoneIndex: 10, Content: ConeContentBlocks}, req))
+
+	rangeReq := &inx.MilestoneRangeRequest{}
+	require.NoError(t, codec.Unmarshal(data, rangeReq))
+	require.Equal(t, uint32(5), rangeReq.GetStartMilestoneIndex())
+	require.Equal(t, uint32(10), rangeReq.GetEndMilestoneIndex())
+}
+
+func TestReadMilestoneConesRange(t *testing.T) {
+	server := &testMilestoneConesServer{
+		blockIDs: map[iotago.MilestoneIndex]iotago.BlockID{
+			1: tpkg.RandBlockID(),
+			2: tpkg.RandBlockID(),
+			3: tpkg.RandBlockID(),
+		},
+	}
+	conn := newTestMilestoneConesConn(t, server)
+
+	// all content
+	client, err := ReadMilestoneConesRange(context.Background(), conn, &MilestoneConesRangeRequest{StartMilestoneIndex: 1, EndMilestoneIndex: 3})
+	require.NoError(t, err)
+
+	payloads := readAllMilestoneCones(t, client)
+	require.Len(t, payloads, 3)
+	for i, payload := range payloads {
+		index := iotago.MilestoneIndex(i + 1)
+		require.Equal(t, server.blockIDs[index], payload.GetMetadata().GetBlockId().Unwrap())
+		require.Equal(t, index, payload.GetMetadata().GetReferencedByMilestoneIndex())
+		require.Equal(t, []byte{byte(index)}, payload.GetBlock().GetData())
+	}
+
+	// only the metadata
+	client, err = ReadMilestoneConesRange(context.Background(), conn, &MilestoneConesRangeRequest{StartMilestoneIndex: 2, EndMilestoneIndex: 3, Content: ConeContentMetadata})
+	require.NoError(t, err)
+
+	payloads = readAllMilestoneCones(t, client)
+	require.Len(t, payloads, 2)
+	for i, payload := range payloads {
+		require.Equal(t, server.blockIDs[iotago.MilestoneIndex(i+2)], payload.GetMetadata().GetBlockId().Unwrap())
+		require.Nil(t, payload.GetBlock())
+	}
+
+	// unknown content
+	client, err = ReadMilestoneConesRange(context.Background(), conn, &MilestoneConesRangeRequest{StartMilestoneIndex: 1, EndMilestoneIndex: 3, Content: "parents"})
+	require.NoError(t, err)
+
+	_, err = client.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,139 @@
+package inx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// BlockConflictsServiceName is the name of the gRPC service that returns the metadata of blocks including the details of their conflict.
+	BlockConflictsServiceName = "hornet.inx.BlockConflicts"
+	// ReadBlockMetadataWithConflictDetailsMethod is the full gRPC method name to read the metadata of a block including the details of its conflict.
+	ReadBlockMetadataWithConflictDetailsMethod = "/" + BlockConflictsServiceName + "/ReadBlockMetadataWithConflictDetails"
+)
+
+// NewBlockConflictDetails creates the BlockConflictDetails message for the given conflict details.
+func NewBlockConflictDetails(details *storage.ConflictDetails) *BlockConflictDetails {
+	if details == nil {
+		return nil
+	}
+
+	outputIDs := make([]*inx.OutputId, len(details.OutputIDs))
+	for i, outputID := range details.OutputIDs {
+		outputIDs[i] = inx.NewOutputId(outputID)
+	}
+
+	return &BlockConflictDetails{
+		//nolint:gosec // the input index is bounded by the maximum amount of inputs
+		InputIndex: int32(details.InputIndex),
+		Reason:     details.Reason,
+		OutputIds:  outputIDs,
+	}
+}
+
+// Unwrap returns the conflict details.
+func (d *BlockConflictDetails) Unwrap() *storage.ConflictDetails {
+	if d == nil {
+		return nil
+	}
+
+	outputIDs := make(iotago.OutputIDs, len(d.GetOutputIds()))
+	for i, outputID := range d.GetOutputIds() {
+		outputIDs[i] = outputID.Unwrap()
+	}
+
+	return &storage.ConflictDetails{
+		InputIndex: int(d.GetInputIndex()),
+		Reason:     d.GetReason(),
+		OutputIDs:  outputIDs,
+	}
+}
+
+// blockConflictsServer is the interface of the gRPC service that returns the metadata of blocks including the details of their conflict.
+type blockConflictsServer interface {
+	ReadBlockMetadataWithConflictDetails(ctx context.Context, blockID *inx.BlockId) (*BlockMetadataWithConflictDetails, error)
+}
+
+func readBlockMetadataWithConflictDetailsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	blockID := &inx.BlockId{}
+	if err := dec(blockID); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockConflictsServer).ReadBlockMetadataWithConflictDetails(ctx, blockID)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReadBlockMetadataWithConflictDetailsMethod,
+	}
+
+	return interceptor(ctx, blockID, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockConflictsServer).ReadBlockMetadataWithConflictDetails(ctx, req.(*inx.BlockId))
+	})
+}
+
+// blockConflictsServiceDesc describes the gRPC service that returns the metadata of blocks including the details of their conflict.
+var blockConflictsServiceDesc = grpc.ServiceDesc{
+	ServiceName: BlockConflictsServiceName,
+	HandlerType: (*blockConflictsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadBlockMetadataWithConflictDetails",
+			Handler:    readBlockMetadataWithConflictDetailsHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// ReadBlockMetadataWithConflictDetails returns the metadata of the block including the details of its conflict.
+func ReadBlockMetadataWithConflictDetails(ctx context.Context, conn grpc.ClientConnInterface, blockID *inx.BlockId, opts ...grpc.CallOption) (*BlockMetadataWithConflictDetails, error) {
+	metadata := &BlockMetadataWithConflictDetails{}
+	if err := conn.Invoke(ctx, ReadBlockMetadataWithConflictDetailsMethod, blockID, metadata, opts...); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func (s *Server) ReadBlockMetadataWithConflictDetails(_ context.Context, blockID *inx.BlockId) (*BlockMetadataWithConflictDetails, error) {
+	blkID := blockID.Unwrap()
+	cachedBlockMeta := deps.Storage.CachedBlockMetadataOrNil(blkID) // meta +1
+	if cachedBlockMeta == nil {
+		isSolidEntryPoint, err := deps.Storage.SolidEntryPointsContain(blkID)
+		if err == nil && isSolidEntryPoint {
+			return &BlockMetadataWithConflictDetails{
+				Metadata: &inx.BlockMetadata{
+					BlockId: blockID,
+					Solid:   true,
+				},
+			}, nil
+		}
+
+		return nil, status.Errorf(codes.NotFound, "block metadata %s not found", blkID.ToHex())
+	}
+	defer cachedBlockMeta.Release(true) // meta -1
+
+	metadata := cachedBlockMeta.Metadata()
+
+	//nolint:contextcheck // we don't care if the client context has ended already (merging contexts would be too expensive here)
+	inxMetadata, err := NewINXBlockMetadata(Component.Daemon().ContextStopped(), metadata.BlockID(), metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockMetadataWithConflictDetails{
+		Metadata:        inxMetadata,
+		ConflictDetails: NewBlockConflictDetails(metadata.ConflictDetails()),
+	}, nil
+}
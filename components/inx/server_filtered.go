@@ -0,0 +1,227 @@
+package inx
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// FilteredStreamsServiceName is the name of the gRPC service that streams the blocks and ledger updates that match a filter.
+	FilteredStreamsServiceName = "hornet.inx.FilteredStreams"
+	// ListenToFilteredBlocksMethod is the full gRPC method name of the filtered block stream.
+	ListenToFilteredBlocksMethod = "/" + FilteredStreamsServiceName + "/ListenToFilteredBlocks"
+	// ListenToFilteredSolidBlocksMethod is the full gRPC method name of the filtered solid block stream.
+	ListenToFilteredSolidBlocksMethod = "/" + FilteredStreamsServiceName + "/ListenToFilteredSolidBlocks"
+	// ListenToFilteredLedgerUpdatesMethod is the full gRPC method name of the filtered ledger updates stream.
+	ListenToFilteredLedgerUpdatesMethod = "/" + FilteredStreamsServiceName + "/ListenToFilteredLedgerUpdates"
+)
+
+// filteredStreamsServer is the interface of the gRPC service that streams the blocks and ledger updates that match a filter.
+// The streams use the same server and client stream interfaces as their unfiltered INX counterparts.
+type filteredStreamsServer interface {
+	ListenToFilteredBlocks(req *SubscriptionFilter, srv inx.INX_ListenToBlocksServer) error
+	ListenToFilteredSolidBlocks(req *SubscriptionFilter, srv inx.INX_ListenToSolidBlocksServer) error
+	ListenToFilteredLedgerUpdates(req *FilteredLedgerUpdatesRequest, srv inx.INX_ListenToLedgerUpdatesServer) error
+}
+
+type filteredBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (s *filteredBlocksServer) Send(payload *inx.Block) error {
+	return s.ServerStream.SendMsg(payload)
+}
+
+type filteredSolidBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (s *filteredSolidBlocksServer) Send(payload *inx.BlockMetadata) error {
+	return s.ServerStream.SendMsg(payload)
+}
+
+type filteredLedgerUpdatesServer struct {
+	grpc.ServerStream
+}
+
+func (s *filteredLedgerUpdatesServer) Send(payload *inx.LedgerUpdate) error {
+	return s.ServerStream.SendMsg(payload)
+}
+
+func listenToFilteredBlocksHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &SubscriptionFilter{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(filteredStreamsServer).ListenToFilteredBlocks(req, &filteredBlocksServer{stream})
+}
+
+func listenToFilteredSolidBlocksHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &SubscriptionFilter{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(filteredStreamsServer).ListenToFilteredSolidBlocks(req, &filteredSolidBlocksServer{stream})
+}
+
+func listenToFilteredLedgerUpdatesHandler(srv interface{}, stream grpc.ServerStream) error {
+	req := &FilteredLedgerUpdatesRequest{}
+	if err := stream.RecvMsg(req); err != nil {
+		return err
+	}
+
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(filteredStreamsServer).ListenToFilteredLedgerUpdates(req, &filteredLedgerUpdatesServer{stream})
+}
+
+// filteredStreamsServiceDesc describes the gRPC service that streams the blocks and ledger updates that match a filter.
+var filteredStreamsServiceDesc = grpc.ServiceDesc{
+	ServiceName: FilteredStreamsServiceName,
+	HandlerType: (*filteredStreamsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListenToFilteredBlocks",
+			Handler:       listenToFilteredBlocksHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListenToFilteredSolidBlocks",
+			Handler:       listenToFilteredSolidBlocksHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ListenToFilteredLedgerUpdates",
+			Handler:       listenToFilteredLedgerUpdatesHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+type filteredBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (c *filteredBlocksClient) Recv() (*inx.Block, error) {
+	payload := &inx.Block{}
+	if err := c.ClientStream.RecvMsg(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+type filteredSolidBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (c *filteredSolidBlocksClient) Recv() (*inx.BlockMetadata, error) {
+	payload := &inx.BlockMetadata{}
+	if err := c.ClientStream.RecvMsg(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+type filteredLedgerUpdatesClient struct {
+	grpc.ClientStream
+}
+
+func (c *filteredLedgerUpdatesClient) Recv() (*inx.LedgerUpdate, error) {
+	payload := &inx.LedgerUpdate{}
+	if err := c.ClientStream.RecvMsg(payload); err != nil {
+		return nil, err
+	}
+
+	return payload, nil
+}
+
+// openFilteredStream opens the filtered stream with the given index in the service description and sends the request.
+func openFilteredStream(ctx context.Context, conn grpc.ClientConnInterface, streamIndex int, method string, req interface{}, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := conn.NewStream(ctx, &filteredStreamsServiceDesc.Streams[streamIndex], method, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := stream.SendMsg(req); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// ListenToFilteredBlocks streams the new blocks that match the given filter.
+// It can be used by extensions that are connected to the INX server of the node.
+func ListenToFilteredBlocks(ctx context.Context, conn grpc.ClientConnInterface, req *SubscriptionFilter, opts ...grpc.CallOption) (inx.INX_ListenToBlocksClient, error) {
+	stream, err := openFilteredStream(ctx, conn, 0, ListenToFilteredBlocksMethod, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filteredBlocksClient{stream}, nil
+}
+
+// ListenToFilteredSolidBlocks streams the metadata of the solid blocks that match the given filter.
+// It can be used by extensions that are connected to the INX server of the node.
+func ListenToFilteredSolidBlocks(ctx context.Context, conn grpc.ClientConnInterface, req *SubscriptionFilter, opts ...grpc.CallOption) (inx.INX_ListenToSolidBlocksClient, error) {
+	stream, err := openFilteredStream(ctx, conn, 1, ListenToFilteredSolidBlocksMethod, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filteredSolidBlocksClient{stream}, nil
+}
+
+// ListenToFilteredLedgerUpdates streams the ledger updates in the requested range.
+// Only the created and consumed outputs that match the filter are sent, but the batch markers
+// of every milestone are sent, so the extension still knows about every milestone.
+// It can be used by extensions that are connected to the INX server of the node.
+func ListenToFilteredLedgerUpdates(ctx context.Context, conn grpc.ClientConnInterface, req *FilteredLedgerUpdatesRequest, opts ...grpc.CallOption) (inx.INX_ListenToLedgerUpdatesClient, error) {
+	stream, err := openFilteredStream(ctx, conn, 2, ListenToFilteredLedgerUpdatesMethod, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filteredLedgerUpdatesClient{stream}, nil
+}
+
+func (s *Server) ListenToFilteredBlocks(req *SubscriptionFilter, srv inx.INX_ListenToBlocksServer) error {
+	blockFilter, err := filterFromRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return s.listenToBlocks(blockFilter, srv)
+}
+
+func (s *Server) ListenToFilteredSolidBlocks(req *SubscriptionFilter, srv inx.INX_ListenToSolidBlocksServer) error {
+	blockFilter, err := filterFromRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return s.listenToSolidBlocks(blockFilter, srv)
+}
+
+func (s *Server) ListenToFilteredLedgerUpdates(req *FilteredLedgerUpdatesRequest, srv inx.INX_ListenToLedgerUpdatesServer) error {
+	outputFilter, err := filterFromRequest(req.GetFilter())
+	if err != nil {
+		return err
+	}
+
+	return s.listenToLedgerUpdates(&inx.MilestoneRangeRequest{
+		StartMilestoneIndex: req.GetStartMilestoneIndex(),
+		EndMilestoneIndex:   req.GetEndMilestoneIndex(),
+	}, outputFilter, srv)
+}
@@ -137,6 +137,7 @@ func (s *Server) ListenToConfirmedMilestones(req *inx.MilestoneRangeRequest, srv
 		if err := srv.Send(payload); err != nil {
 			return fmt.Errorf("send error: %w", err)
 		}
+		trackMilestoneSent(srv.Context(), msIndex)
 
 		return nil
 	}
@@ -197,7 +198,7 @@ func (s *Server) ListenToConfirmedMilestones(req *inx.MilestoneRangeRequest, srv
 	}
 
 	stream := &streamRange{
-		start: req.GetStartMilestoneIndex(),
+		start: resumeStartIndex(srv.Context(), req.GetStartMilestoneIndex()),
 		end:   req.GetEndMilestoneIndex(),
 	}
 
@@ -224,21 +225,20 @@ func (s *Server) ListenToConfirmedMilestones(req *inx.MilestoneRangeRequest, srv
 		return nil
 	}
 
-	sendFunc := func(_ iotago.MilestoneIndex, payload *inx.MilestoneAndProtocolParameters) error {
+	sendFunc := func(index iotago.MilestoneIndex, payload *inx.MilestoneAndProtocolParameters) error {
 		if err := srv.Send(payload); err != nil {
 			err := fmt.Errorf("send error: %w", err)
 			Component.LogError(err.Error())
 
 			return err
 		}
+		trackMilestoneSent(srv.Context(), index)
 
 		return nil
 	}
 
-	var innerErr error
-	ctx, cancel := context.WithCancel(Component.Daemon().ContextStopped())
-
-	wp := workerpool.New("ListenToConfirmedMilestones", 1).Start()
+	// the events are buffered, so that a slow subscriber doesn't block the node.
+	buffer := newMilestoneBuffer[*inx.MilestoneAndProtocolParameters](srv.Context())
 
 	unhook := deps.Tangle.Events.ConfirmedMilestoneChanged.Hook(func(cachedMilestone *storage.CachedMilestone) {
 		defer cachedMilestone.Release(true) // milestone -1
@@ -246,32 +246,38 @@ func (s *Server) ListenToConfirmedMilestones(req *inx.MilestoneRangeRequest, srv
 		payload, err := createMilestoneAndProtocolParametersPayloadForMilestone(cachedMilestone.Milestone())
 		if err != nil {
 			Component.LogErrorf("serialize error: %v", err)
-			cancel()
+			buffer.fail(err)
 
 			return
 		}
 
-		done, err := handleRangedSend1(cachedMilestone.Milestone().Index(), payload, stream, catchUpFunc, sendFunc)
-		switch {
-		case err != nil:
-			innerErr = err
-			cancel()
-
-		case done:
-			cancel()
-		}
+		buffer.push(cachedMilestone.Milestone().Index(), payload)
 	}).Unhook
+	defer unhook()
 
-	<-ctx.Done()
-	unhook()
+	for {
+		select {
+		case <-Component.Daemon().ContextStopped().Done():
+			return nil
 
-	// We need to wait until all tasks are done, otherwise we might call
-	// "SendMsg" and "CloseSend" in parallel on the grpc stream, which is
-	// not safe according to the grpc docs.
-	wp.Shutdown()
-	wp.ShutdownComplete.Wait()
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+
+		case <-buffer.failed:
+			return buffer.err
+
+		case item := <-buffer.items:
+			prepareReplay(stream, buffer.pop(item.index))
 
-	return innerErr
+			done, err := handleRangedSend1(item.index, item.payload, stream, catchUpFunc, sendFunc)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+	}
 }
 
 func (s *Server) ComputeWhiteFlag(ctx context.Context, req *inx.WhiteFlagRequest) (*inx.WhiteFlagResponse, error) {
@@ -0,0 +1,114 @@
+package inx
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag/proof"
+	inx "github.com/iotaledger/inx/go"
+)
+
+const (
+	// BlockProofServiceName is the name of the gRPC service that creates proofs of inclusion of blocks.
+	BlockProofServiceName = "hornet.inx.BlockProof"
+	// ReadBlockInclusionProofMethod is the full gRPC method name to read the proof of inclusion of a block.
+	ReadBlockInclusionProofMethod = "/" + BlockProofServiceName + "/ReadBlockInclusionProof"
+)
+
+// Unwrap decodes the proof of inclusion.
+func (p *BlockInclusionProof) Unwrap() (*proof.InclusionProof, error) {
+	inclusionProof := &proof.InclusionProof{}
+	if err := json.Unmarshal(p.GetProof(), inclusionProof); err != nil {
+		return nil, err
+	}
+
+	return inclusionProof, nil
+}
+
+// blockProofServer is the interface of the gRPC service that creates proofs of inclusion of blocks.
+type blockProofServer interface {
+	ReadBlockInclusionProof(ctx context.Context, blockID *inx.BlockId) (*BlockInclusionProof, error)
+}
+
+func readBlockInclusionProofHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	blockID := &inx.BlockId{}
+	if err := dec(blockID); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockProofServer).ReadBlockInclusionProof(ctx, blockID)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ReadBlockInclusionProofMethod,
+	}
+
+	return interceptor(ctx, blockID, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockProofServer).ReadBlockInclusionProof(ctx, req.(*inx.BlockId))
+	})
+}
+
+// blockProofServiceDesc describes the gRPC service that creates proofs of inclusion of blocks.
+var blockProofServiceDesc = grpc.ServiceDesc{
+	ServiceName: BlockProofServiceName,
+	HandlerType: (*blockProofServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadBlockInclusionProof",
+			Handler:    readBlockInclusionProofHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// ReadBlockInclusionProof returns the proof that the block is part of the merkle trees committed to by the milestone that referenced it.
+func ReadBlockInclusionProof(ctx context.Context, conn grpc.ClientConnInterface, blockID *inx.BlockId, opts ...grpc.CallOption) (*proof.InclusionProof, error) {
+	inclusionProof := &BlockInclusionProof{}
+	if err := conn.Invoke(ctx, ReadBlockInclusionProofMethod, blockID, inclusionProof, opts...); err != nil {
+		return nil, err
+	}
+
+	return inclusionProof.Unwrap()
+}
+
+func (s *Server) ReadBlockInclusionProof(ctx context.Context, req *inx.BlockId) (*BlockInclusionProof, error) {
+	if !deps.SyncManager.IsNodeAlmostSynced() {
+		return nil, status.Error(codes.Unavailable, "node is not synced")
+	}
+
+	blockID := req.Unwrap()
+
+	inclusionProof, err := deps.Tangle.BlockInclusionProof(ctx, blockID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrBlockNotFound):
+			return nil, status.Errorf(codes.NotFound, "block %s not found", blockID.ToHex())
+		case errors.Is(err, tangle.ErrBlockNotReferenced):
+			return nil, status.Errorf(codes.NotFound, "block %s not referenced yet", blockID.ToHex())
+		case errors.Is(err, tangle.ErrMilestoneNotFound):
+			return nil, status.Errorf(codes.NotFound, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		case errors.Is(err, common.ErrOperationAborted):
+			return nil, status.Errorf(codes.Unavailable, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		default:
+			return nil, status.Errorf(codes.Internal, "failed to create proof for block %s: %s", blockID.ToHex(), err)
+		}
+	}
+
+	proofBytes, err := json.Marshal(inclusionProof)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode proof for block %s: %s", blockID.ToHex(), err)
+	}
+
+	return &BlockInclusionProof{Proof: proofBytes}, nil
+}
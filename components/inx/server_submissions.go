@@ -0,0 +1,587 @@
+package inx
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/pow"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// BlockSubmissionServiceName is the name of the gRPC service that lets an extension submit blocks asynchronously.
+	BlockSubmissionServiceName = "hornet.inx.BlockSubmission"
+	// SubmitBlockAsyncMethod is the full gRPC method name to submit a block asynchronously.
+	SubmitBlockAsyncMethod = "/" + BlockSubmissionServiceName + "/SubmitBlockAsync"
+	// CancelBlockSubmissionMethod is the full gRPC method name to cancel a block submission.
+	CancelBlockSubmissionMethod = "/" + BlockSubmissionServiceName + "/CancelBlockSubmission"
+	// ListenToBlockSubmissionMethod is the full gRPC method name of the block submission status stream.
+	ListenToBlockSubmissionMethod = "/" + BlockSubmissionServiceName + "/ListenToBlockSubmission"
+
+	// PoWPriorityLow is used for blocks that are not time critical.
+	PoWPriorityLow = "low"
+	// PoWPriorityNormal is the default priority.
+	PoWPriorityNormal = "normal"
+	// PoWPriorityHigh is used for blocks that should be attached as fast as possible.
+	PoWPriorityHigh = "high"
+
+	// SubmissionStateQueued means the PoW of the block is waiting in the queue.
+	SubmissionStateQueued = "queued"
+	// SubmissionStateDoingPoW means the PoW of the block is running.
+	SubmissionStateDoingPoW = "doingPoW"
+	// SubmissionStateAttached means the block was attached to the tangle.
+	SubmissionStateAttached = "attached"
+	// SubmissionStateReferenced means the block was referenced by a milestone.
+	SubmissionStateReferenced = "referenced"
+	// SubmissionStateConflicting means the block was referenced by a milestone, but the transaction is conflicting.
+	SubmissionStateConflicting = "conflicting"
+	// SubmissionStateFailed means the block couldn't be attached, or it wasn't referenced in time.
+	SubmissionStateFailed = "failed"
+	// SubmissionStateCancelled means the submission was cancelled before the block was attached.
+	SubmissionStateCancelled = "cancelled"
+
+	// submissionTicketRetention is the time a ticket is kept after it reached a final state.
+	// It is also the maximum time an attached block may take to get referenced by a milestone.
+	submissionTicketRetention = 10 * time.Minute
+	// maxSubmissionTickets is the maximum amount of tickets that are kept at the same time.
+	// If the limit is reached, the oldest finished tickets are removed before their retention time is over.
+	maxSubmissionTickets = 1000
+)
+
+var (
+	// ErrSubmissionTicketNotFound is returned if the ticket of a block submission is unknown.
+	ErrSubmissionTicketNotFound = status.Error(codes.NotFound, "block submission ticket not found")
+	// ErrSubmissionAlreadyAttached is returned if a block submission can't be cancelled anymore.
+	ErrSubmissionAlreadyAttached = status.Error(codes.FailedPrecondition, "block submission is already attached or finished")
+	// ErrSubmissionTicketsExhausted is returned if there are too many block submissions that are not finished yet.
+	ErrSubmissionTicketsExhausted = status.Error(codes.ResourceExhausted, "too many outstanding block submissions")
+
+	errSubmissionNotReferenced = errors.New("block was not referenced in time")
+)
+
+// IsFinal returns whether the status won't change anymore.
+func (s *BlockSubmissionStatus) IsFinal() bool {
+	switch s.GetState() {
+	case SubmissionStateReferenced, SubmissionStateConflicting, SubmissionStateFailed, SubmissionStateCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// blockSubmissionServer is the interface of the gRPC service that lets an extension submit blocks asynchronously.
+type blockSubmissionServer interface {
+	SubmitBlockAsync(ctx context.Context, req *BlockSubmissionRequest) (*BlockSubmissionTicket, error)
+	CancelBlockSubmission(ctx context.Context, ticket *BlockSubmissionTicket) (*inx.NoParams, error)
+	ListenToBlockSubmission(ticket *BlockSubmissionTicket, srv BlockSubmissionStatusServer) error
+}
+
+// BlockSubmissionStatusServer is the server side of the block submission status stream.
+type BlockSubmissionStatusServer interface {
+	Send(status *BlockSubmissionStatus) error
+	grpc.ServerStream
+}
+
+type blockSubmissionStatusServer struct {
+	grpc.ServerStream
+}
+
+func (s *blockSubmissionStatusServer) Send(status *BlockSubmissionStatus) error {
+	return s.ServerStream.SendMsg(status)
+}
+
+func submitBlockAsyncHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	submissionRequest := &BlockSubmissionRequest{}
+	if err := dec(submissionRequest); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockSubmissionServer).SubmitBlockAsync(ctx, submissionRequest)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SubmitBlockAsyncMethod,
+	}
+
+	return interceptor(ctx, submissionRequest, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockSubmissionServer).SubmitBlockAsync(ctx, req.(*BlockSubmissionRequest))
+	})
+}
+
+func cancelBlockSubmissionHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	ticket := &BlockSubmissionTicket{}
+	if err := dec(ticket); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockSubmissionServer).CancelBlockSubmission(ctx, ticket)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CancelBlockSubmissionMethod,
+	}
+
+	return interceptor(ctx, ticket, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		//nolint:forcetypeassert // the type is ensured by the service description
+		return srv.(blockSubmissionServer).CancelBlockSubmission(ctx, req.(*BlockSubmissionTicket))
+	})
+}
+
+func listenToBlockSubmissionHandler(srv interface{}, stream grpc.ServerStream) error {
+	ticket := &BlockSubmissionTicket{}
+	if err := stream.RecvMsg(ticket); err != nil {
+		return err
+	}
+
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(blockSubmissionServer).ListenToBlockSubmission(ticket, &blockSubmissionStatusServer{stream})
+}
+
+// blockSubmissionServiceDesc describes the gRPC service that lets an extension submit blocks asynchronously.
+var blockSubmissionServiceDesc = grpc.ServiceDesc{
+	ServiceName: BlockSubmissionServiceName,
+	HandlerType: (*blockSubmissionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitBlockAsync",
+			Handler:    submitBlockAsyncHandler,
+		},
+		{
+			MethodName: "CancelBlockSubmission",
+			Handler:    cancelBlockSubmissionHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListenToBlockSubmission",
+			Handler:       listenToBlockSubmissionHandler,
+			ServerStreams: true,
+		},
+	},
+}
+
+// SubmitBlockAsync queues the block for attachment with the requested PoW priority and returns a ticket to follow the submission.
+func SubmitBlockAsync(ctx context.Context, conn grpc.ClientConnInterface, req *BlockSubmissionRequest, opts ...grpc.CallOption) (*BlockSubmissionTicket, error) {
+	ticket := &BlockSubmissionTicket{}
+	if err := conn.Invoke(ctx, SubmitBlockAsyncMethod, req, ticket, opts...); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// CancelBlockSubmission cancels the block submission if the block was not attached yet.
+func CancelBlockSubmission(ctx context.Context, conn grpc.ClientConnInterface, ticket *BlockSubmissionTicket, opts ...grpc.CallOption) error {
+	return conn.Invoke(ctx, CancelBlockSubmissionMethod, ticket, &inx.NoParams{}, opts...)
+}
+
+// BlockSubmissionStatusClient is the client side of the block submission status stream.
+type BlockSubmissionStatusClient interface {
+	Recv() (*BlockSubmissionStatus, error)
+	grpc.ClientStream
+}
+
+type blockSubmissionStatusClient struct {
+	grpc.ClientStream
+}
+
+func (c *blockSubmissionStatusClient) Recv() (*BlockSubmissionStatus, error) {
+	status := &BlockSubmissionStatus{}
+	if err := c.ClientStream.RecvMsg(status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// ListenToBlockSubmission streams the current status of the block submission and every change of it.
+// The stream is closed by the node after a final status was sent.
+func ListenToBlockSubmission(ctx context.Context, conn grpc.ClientConnInterface, ticket *BlockSubmissionTicket, opts ...grpc.CallOption) (BlockSubmissionStatusClient, error) {
+	stream, err := conn.NewStream(ctx, &blockSubmissionServiceDesc.Streams[0], ListenToBlockSubmissionMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &blockSubmissionStatusClient{stream}
+	if err := client.ClientStream.SendMsg(ticket); err != nil {
+		return nil, err
+	}
+	if err := client.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// powPriority returns the requested priority of the PoW of a submitted block.
+func powPriority(req *BlockSubmissionRequest) (pow.Priority, error) {
+	switch req.GetPowPriority() {
+	case "", PoWPriorityNormal:
+		return pow.PriorityNormal, nil
+	case PoWPriorityLow:
+		return pow.PriorityLow, nil
+	case PoWPriorityHigh:
+		return pow.PriorityHigh, nil
+	default:
+		return pow.PriorityNormal, status.Errorf(codes.InvalidArgument, "unknown PoW priority: %s", req.GetPowPriority())
+	}
+}
+
+// attachBlockError converts an error of the block attacher to a gRPC status error.
+func attachBlockError(err error) error {
+	switch {
+	case errors.Is(err, tangle.ErrBlockAttacherInvalidBlock):
+		return status.Errorf(codes.InvalidArgument, "failed to attach block: %s", err.Error())
+
+	case errors.Is(err, tangle.ErrBlockAttacherAttachingNotPossible):
+		return status.Errorf(codes.Internal, "failed to attach block: %s", err.Error())
+
+	case errors.Is(err, tangle.ErrBlockAttacherPoWNotAvailable):
+		return status.Errorf(codes.Unavailable, "failed to attach block: %s", err.Error())
+
+	case errors.Is(err, tangle.ErrBlockAttacherPoWQueueFull):
+		return status.Errorf(codes.ResourceExhausted, "failed to attach block: %s", err.Error())
+
+	default:
+		return status.Errorf(codes.Internal, "failed to attach block: %s", err.Error())
+	}
+}
+
+// submissionTicket tracks the state of an asynchronous block submission.
+type submissionTicket struct {
+	id string
+
+	// cancel cancels the attachment of the block.
+	cancel context.CancelFunc
+
+	lock            sync.Mutex
+	status          *BlockSubmissionStatus
+	cancelRequested bool
+	// updatedAt is the time of the last status change.
+	updatedAt time.Time
+	// changed is closed and replaced on every status change.
+	changed chan struct{}
+}
+
+// current returns a copy of the current status and a channel that is closed if the status changes.
+func (t *submissionTicket) current() (*BlockSubmissionStatus, <-chan struct{}) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	//nolint:forcetypeassert // the type is ensured by the clone
+	return proto.Clone(t.status).(*BlockSubmissionStatus), t.changed
+}
+
+// update applies the given change to the status and notifies the listeners.
+// Changes of tickets in a final state are ignored.
+func (t *submissionTicket) update(change func(status *BlockSubmissionStatus)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.status.IsFinal() {
+		return
+	}
+
+	change(t.status)
+	t.updatedAt = time.Now()
+
+	close(t.changed)
+	t.changed = make(chan struct{})
+}
+
+func (t *submissionTicket) setState(state string) {
+	t.update(func(status *BlockSubmissionStatus) {
+		status.State = state
+	})
+}
+
+// submissionTickets holds the tickets of all asynchronous block submissions.
+type submissionTickets struct {
+	lock    sync.Mutex
+	tickets map[string]*submissionTicket
+}
+
+func newSubmissionTickets() *submissionTickets {
+	return &submissionTickets{
+		tickets: make(map[string]*submissionTicket),
+	}
+}
+
+func (t *submissionTickets) add(cancel context.CancelFunc) (*submissionTicket, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, err
+	}
+
+	ticket := &submissionTicket{
+		id:     hex.EncodeToString(idBytes),
+		cancel: cancel,
+		status: &BlockSubmissionStatus{
+			State: SubmissionStateQueued,
+		},
+		updatedAt: time.Now(),
+		changed:   make(chan struct{}),
+	}
+	ticket.status.TicketId = ticket.id
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.tickets) >= maxSubmissionTickets && !t.removeOldestFinishedWithoutLocking() {
+		return nil, ErrSubmissionTicketsExhausted
+	}
+
+	t.tickets[ticket.id] = ticket
+
+	return ticket, nil
+}
+
+// removeOldestFinishedWithoutLocking removes the ticket that reached a final state first.
+// It returns false if there is no finished ticket.
+func (t *submissionTickets) removeOldestFinishedWithoutLocking() bool {
+	var oldest *submissionTicket
+	var oldestUpdatedAt time.Time
+
+	for _, ticket := range t.tickets {
+		ticket.lock.Lock()
+		finished := ticket.status.IsFinal()
+		updatedAt := ticket.updatedAt
+		ticket.lock.Unlock()
+
+		if finished && (oldest == nil || updatedAt.Before(oldestUpdatedAt)) {
+			oldest = ticket
+			oldestUpdatedAt = updatedAt
+		}
+	}
+
+	if oldest == nil {
+		return false
+	}
+
+	delete(t.tickets, oldest.id)
+
+	return true
+}
+
+func (t *submissionTickets) get(id string) *submissionTicket {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.tickets[id]
+}
+
+func (t *submissionTickets) all() []*submissionTicket {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	tickets := make([]*submissionTicket, 0, len(t.tickets))
+	for _, ticket := range t.tickets {
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets
+}
+
+func (t *submissionTickets) remove(id string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	delete(t.tickets, id)
+}
+
+// checkAttached updates the state of the attached blocks after a milestone was confirmed
+// and removes the tickets that are finished for longer than the retention time.
+func (t *submissionTickets) checkAttached() {
+	for _, ticket := range t.all() {
+		status, _ := ticket.current()
+
+		switch {
+		case status.IsFinal():
+			ticket.lock.Lock()
+			expired := time.Since(ticket.updatedAt) > submissionTicketRetention
+			ticket.lock.Unlock()
+
+			if expired {
+				t.remove(ticket.id)
+			}
+
+		case status.GetState() == SubmissionStateAttached:
+			checkSubmissionReferenced(ticket, status.GetBlockId().Unwrap())
+		}
+	}
+}
+
+func checkSubmissionReferenced(ticket *submissionTicket, blockID iotago.BlockID) {
+	cachedBlockMeta := deps.Storage.CachedBlockMetadataOrNil(blockID) // meta +1
+	if cachedBlockMeta == nil {
+		ticket.update(func(status *BlockSubmissionStatus) {
+			status.State = SubmissionStateFailed
+			status.Error = fmt.Sprintf("block %s not found", blockID.ToHex())
+		})
+
+		return
+	}
+	defer cachedBlockMeta.Release(true) // meta -1
+
+	metadata := cachedBlockMeta.Metadata()
+
+	referenced, msIndex := metadata.ReferencedWithIndex()
+	if !referenced {
+		ticket.lock.Lock()
+		expired := time.Since(ticket.updatedAt) > submissionTicketRetention
+		ticket.lock.Unlock()
+
+		if expired {
+			ticket.update(func(status *BlockSubmissionStatus) {
+				status.State = SubmissionStateFailed
+				status.Error = errSubmissionNotReferenced.Error()
+			})
+		}
+
+		return
+	}
+
+	ticket.update(func(status *BlockSubmissionStatus) {
+		status.State = SubmissionStateReferenced
+		if metadata.IsConflictingTx() {
+			status.State = SubmissionStateConflicting
+		}
+		status.ReferencedByMilestoneIndex = msIndex
+	})
+}
+
+func (s *Server) SubmitBlockAsync(_ context.Context, req *BlockSubmissionRequest) (*BlockSubmissionTicket, error) {
+	if req.GetBlock() == nil {
+		return nil, status.Error(codes.InvalidArgument, "the block is missing")
+	}
+
+	block, err := req.GetBlock().UnwrapBlock(serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block: %s", err.Error())
+	}
+
+	priority, err := powPriority(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// the attachment is not bound to the context of the request, only to the lifetime of the node.
+	attachCtx, attachCancel := context.WithCancel(Component.Daemon().ContextStopped())
+
+	ticket, err := s.submissions.add(attachCancel)
+	if err != nil {
+		attachCancel()
+
+		if errors.Is(err, ErrSubmissionTicketsExhausted) {
+			return nil, err
+		}
+
+		return nil, status.Errorf(codes.Internal, "failed to create ticket: %s", err.Error())
+	}
+
+	go func() {
+		defer attachCancel()
+
+		blockID, err := attacher.AttachBlock(attachCtx, block,
+			pow.WithPriority(priority),
+			pow.WithStartedCallback(func() {
+				ticket.setState(SubmissionStateDoingPoW)
+			}),
+		)
+		if err != nil {
+			ticket.update(func(status *BlockSubmissionStatus) {
+				if ticket.cancelRequested {
+					status.State = SubmissionStateCancelled
+
+					return
+				}
+
+				status.State = SubmissionStateFailed
+				status.Error = err.Error()
+			})
+
+			return
+		}
+
+		ticket.update(func(status *BlockSubmissionStatus) {
+			status.State = SubmissionStateAttached
+			status.BlockId = inx.NewBlockId(blockID)
+		})
+	}()
+
+	return &BlockSubmissionTicket{TicketId: ticket.id}, nil
+}
+
+func (s *Server) CancelBlockSubmission(_ context.Context, req *BlockSubmissionTicket) (*inx.NoParams, error) {
+	ticket := s.submissions.get(req.GetTicketId())
+	if ticket == nil {
+		return nil, ErrSubmissionTicketNotFound
+	}
+
+	ticket.lock.Lock()
+	defer ticket.lock.Unlock()
+
+	switch ticket.status.GetState() {
+	case SubmissionStateQueued, SubmissionStateDoingPoW:
+		// the state is set to cancelled as soon as the attachment stopped.
+		ticket.cancelRequested = true
+		ticket.cancel()
+
+		return &inx.NoParams{}, nil
+
+	case SubmissionStateCancelled:
+		return &inx.NoParams{}, nil
+
+	default:
+		return nil, ErrSubmissionAlreadyAttached
+	}
+}
+
+func (s *Server) ListenToBlockSubmission(req *BlockSubmissionTicket, srv BlockSubmissionStatusServer) error {
+	ticket := s.submissions.get(req.GetTicketId())
+	if ticket == nil {
+		return ErrSubmissionTicketNotFound
+	}
+
+	for {
+		status, changed := ticket.current()
+
+		if err := srv.Send(status); err != nil {
+			return fmt.Errorf("send error: %w", err)
+		}
+
+		if status.IsFinal() {
+			return nil
+		}
+
+		select {
+		case <-Component.Daemon().ContextStopped().Done():
+			return nil
+
+		case <-srv.Context().Done():
+			return srv.Context().Err()
+
+		case <-changed:
+		}
+	}
+}
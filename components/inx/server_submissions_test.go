@@ -0,0 +1,28 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package inx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/pow"
+)
+
+func TestPoWPriority(t *testing.T) {
+	for name, expected := range map[string]pow.Priority{
+		"":                pow.PriorityNormal,
+		PoWPriorityLow:    pow.PriorityLow,
+		PoWPriorityNormal: pow.PriorityNormal,
+		PoWPriorityHigh:   pow.PriorityHigh,
+	} {
+		priority, err := powPriority(&BlockSubmissionRequest{PowPriority: name})
+		require.NoError(t, err)
+		require.Equal(t, expected, priority)
+	}
+
+	_, err := powPriority(&BlockSubmissionRequest{PowPriority: "urgent"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/hive.go/runtime/event"
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/filter"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
 	inx "github.com/iotaledger/inx/go"
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -207,13 +208,51 @@ func (s *Server) ReadUnspentOutputs(_ *inx.NoParams, srv inx.INX_ReadUnspentOutp
 	return err
 }
 
+// filterLedgerUpdate returns the created and consumed outputs that match the filter.
+func filterLedgerUpdate(outputFilter *filter.Filter, outputs utxo.Outputs, spents utxo.Spents) (utxo.Outputs, utxo.Spents) {
+	if outputFilter.IsEmpty() {
+		return outputs, spents
+	}
+
+	filteredOutputs := make(utxo.Outputs, 0, len(outputs))
+	for _, output := range outputs {
+		if outputFilter.MatchOutput(output.OutputID(), output.Output()) {
+			filteredOutputs = append(filteredOutputs, output)
+		}
+	}
+
+	filteredSpents := make(utxo.Spents, 0, len(spents))
+	for _, spent := range spents {
+		if outputFilter.MatchOutput(spent.OutputID(), spent.Output().Output()) {
+			filteredSpents = append(filteredSpents, spent)
+		}
+	}
+
+	return filteredOutputs, filteredSpents
+}
+
+// ledgerUpdate holds the changes of the ledger by a milestone until they are sent to a subscriber.
+type ledgerUpdate struct {
+	outputs utxo.Outputs
+	spents  utxo.Spents
+}
+
 func (s *Server) ListenToLedgerUpdates(req *inx.MilestoneRangeRequest, srv inx.INX_ListenToLedgerUpdatesServer) error {
+	return s.listenToLedgerUpdates(req, &filter.Filter{}, srv)
+}
+
+// listenToLedgerUpdates streams the ledger updates in the requested range,
+// only the created and consumed outputs that match the given filter are sent.
+func (s *Server) listenToLedgerUpdates(req *inx.MilestoneRangeRequest, outputFilter *filter.Filter, srv inx.INX_ListenToLedgerUpdatesServer) error {
 	snapshotInfo := deps.Storage.SnapshotInfo()
 	if snapshotInfo == nil {
 		return common.ErrSnapshotInfoNotFound
 	}
 
 	createLedgerUpdatePayloadAndSend := func(msIndex iotago.MilestoneIndex, outputs utxo.Outputs, spents utxo.Spents) error {
+		// the batch markers are sent even if nothing matches the filter, so the extension knows about the milestone
+		outputs, spents = filterLedgerUpdate(outputFilter, outputs, spents)
+
 		// Send Begin
 		if err := srv.Send(NewLedgerUpdateBatchBegin(msIndex, len(outputs), len(spents))); err != nil {
 			return fmt.Errorf("send error: %w", err)
@@ -247,6 +286,7 @@ func (s *Server) ListenToLedgerUpdates(req *inx.MilestoneRangeRequest, srv inx.I
 		if err := srv.Send(NewLedgerUpdateBatchEnd(msIndex, len(outputs), len(spents))); err != nil {
 			return fmt.Errorf("send error: %w", err)
 		}
+		trackMilestoneSent(srv.Context(), msIndex)
 
 		return nil
 	}
@@ -303,7 +343,7 @@ func (s *Server) ListenToLedgerUpdates(req *inx.MilestoneRangeRequest, srv inx.I
 	}
 
 	stream := &streamRange{
-		start: req.GetStartMilestoneIndex(),
+		start: resumeStartIndex(srv.Context(), req.GetStartMilestoneIndex()),
 		end:   req.GetEndMilestoneIndex(),
 	}
 
@@ -338,33 +378,37 @@ func (s *Server) ListenToLedgerUpdates(req *inx.MilestoneRangeRequest, srv inx.I
 		return nil
 	}
 
-	var innerErr error
-	ctx, cancel := context.WithCancel(Component.Daemon().ContextStopped())
-
-	wp := workerpool.New("ListenToLedgerUpdates", workerCount).Start()
+	// the events are buffered, so that a slow subscriber doesn't block the node.
+	buffer := newMilestoneBuffer[*ledgerUpdate](srv.Context())
 
 	unhook := deps.Tangle.Events.LedgerUpdated.Hook(func(index iotago.MilestoneIndex, newOutputs utxo.Outputs, newSpents utxo.Spents) {
-		done, err := handleRangedSend2(index, newOutputs, newSpents, stream, catchUpFunc, sendFunc)
-		switch {
-		case err != nil:
-			innerErr = err
-			cancel()
-
-		case done:
-			cancel()
-		}
+		buffer.push(index, &ledgerUpdate{outputs: newOutputs, spents: newSpents})
 	}).Unhook
+	defer unhook()
 
-	<-ctx.Done()
-	unhook()
+	for {
+		select {
+		case <-Component.Daemon().ContextStopped().Done():
+			return nil
 
-	// We need to wait until all tasks are done, otherwise we might call
-	// "SendMsg" and "CloseSend" in parallel on the grpc stream, which is
-	// not safe according to the grpc docs.
-	wp.Shutdown()
-	wp.ShutdownComplete.Wait()
+		case <-srv.Context().Done():
+			return srv.Context().Err()
 
-	return innerErr
+		case <-buffer.failed:
+			return buffer.err
+
+		case item := <-buffer.items:
+			prepareReplay(stream, buffer.pop(item.index))
+
+			done, err := handleRangedSend2(item.index, item.payload.outputs, item.payload.spents, stream, catchUpFunc, sendFunc)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+	}
 }
 
 func (s *Server) ListenToTreasuryUpdates(req *inx.MilestoneRangeRequest, srv inx.INX_ListenToTreasuryUpdatesServer) error {
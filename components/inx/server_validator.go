@@ -0,0 +1,303 @@
+package inx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	inx "github.com/iotaledger/inx/go"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// BlockValidationServiceName is the name of the gRPC service that lets an extension validate blocks.
+	BlockValidationServiceName = "hornet.inx.BlockValidation"
+	// BlockValidationMethod is the full gRPC method name of the block validation stream.
+	BlockValidationMethod = "/" + BlockValidationServiceName + "/ValidateBlocks"
+)
+
+var (
+	// ErrValidationDisabled is returned if an extension tries to register as block validator, but block validation is disabled.
+	ErrValidationDisabled = status.Error(codes.FailedPrecondition, "block validation is disabled")
+	// ErrValidatorAlreadyRegistered is returned if an extension tries to register as block validator, but another extension is already registered.
+	ErrValidatorAlreadyRegistered = status.Error(codes.AlreadyExists, "a block validator is already registered")
+
+	errValidatorNotConnected     = errors.New("no block validator connected")
+	errValidatorDisconnected     = errors.New("block validator disconnected")
+	errValidatorTimeout          = errors.New("block validator didn't decide in time")
+	errValidatorRejectedNoReason = errors.New("no reason given")
+)
+
+// blockValidationServer is the interface of the gRPC service that lets an extension validate blocks.
+type blockValidationServer interface {
+	ValidateBlocks(srv BlockValidationServer) error
+}
+
+// BlockValidationServer is the server side of the block validation stream.
+type BlockValidationServer interface {
+	Send(candidate *inx.Block) error
+	Recv() (*BlockValidationResult, error)
+	grpc.ServerStream
+}
+
+type blockValidationServerStream struct {
+	grpc.ServerStream
+}
+
+func (s *blockValidationServerStream) Send(candidate *inx.Block) error {
+	return s.ServerStream.SendMsg(candidate)
+}
+
+func (s *blockValidationServerStream) Recv() (*BlockValidationResult, error) {
+	result := &BlockValidationResult{}
+	if err := s.ServerStream.RecvMsg(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func validateBlocksHandler(srv interface{}, stream grpc.ServerStream) error {
+	//nolint:forcetypeassert // the type is ensured by the service description
+	return srv.(blockValidationServer).ValidateBlocks(&blockValidationServerStream{stream})
+}
+
+// blockValidationServiceDesc describes the gRPC service that lets an extension validate blocks.
+var blockValidationServiceDesc = grpc.ServiceDesc{
+	ServiceName: BlockValidationServiceName,
+	HandlerType: (*blockValidationServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ValidateBlocks",
+			Handler:       validateBlocksHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
+// BlockValidationClient is the client side of the block validation stream.
+type BlockValidationClient interface {
+	Send(result *BlockValidationResult) error
+	Recv() (*inx.Block, error)
+	grpc.ClientStream
+}
+
+type blockValidationClientStream struct {
+	grpc.ClientStream
+}
+
+func (c *blockValidationClientStream) Send(result *BlockValidationResult) error {
+	return c.ClientStream.SendMsg(result)
+}
+
+func (c *blockValidationClientStream) Recv() (*inx.Block, error) {
+	candidate := &inx.Block{}
+	if err := c.ClientStream.RecvMsg(candidate); err != nil {
+		return nil, err
+	}
+
+	return candidate, nil
+}
+
+// ValidateBlocks registers the extension as block validator of the node.
+// The node sends the candidate blocks, and the extension has to answer every candidate with a BlockValidationResult.
+func ValidateBlocks(ctx context.Context, conn grpc.ClientConnInterface, opts ...grpc.CallOption) (BlockValidationClient, error) {
+	stream, err := conn.NewStream(ctx, &blockValidationServiceDesc.Streams[0], BlockValidationMethod, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockValidationClientStream{stream}, nil
+}
+
+// blockValidator is a connected extension that validates blocks.
+type blockValidator struct {
+	srv BlockValidationServer
+	// closed is closed if the validator disconnected.
+	closed chan struct{}
+
+	sendLock sync.Mutex
+
+	pendingLock sync.Mutex
+	// pending holds the channels of the callers that wait for the decision about a block.
+	pending map[iotago.BlockID][]chan *BlockValidationResult
+}
+
+func newBlockValidator(srv BlockValidationServer) *blockValidator {
+	return &blockValidator{
+		srv:     srv,
+		closed:  make(chan struct{}),
+		pending: make(map[iotago.BlockID][]chan *BlockValidationResult),
+	}
+}
+
+// validate sends the block to the validator and waits for its decision.
+func (v *blockValidator) validate(ctx context.Context, block *storage.Block) (*BlockValidationResult, error) {
+	blockID := block.BlockID()
+	decision := make(chan *BlockValidationResult, 1)
+
+	v.pendingLock.Lock()
+	waiting, alreadyRequested := v.pending[blockID]
+	v.pending[blockID] = append(waiting, decision)
+	v.pendingLock.Unlock()
+	defer v.removePending(blockID, decision)
+
+	if !alreadyRequested {
+		if err := v.send(inx.NewBlockWithBytes(blockID, block.Data())); err != nil {
+			return nil, err
+		}
+	}
+
+	select {
+	case result := <-decision:
+		return result, nil
+	case <-ctx.Done():
+		return nil, errValidatorTimeout
+	case <-v.closed:
+		return nil, errValidatorDisconnected
+	}
+}
+
+func (v *blockValidator) send(candidate *inx.Block) error {
+	v.sendLock.Lock()
+	defer v.sendLock.Unlock()
+
+	if err := v.srv.Send(candidate); err != nil {
+		return fmt.Errorf("send error: %w", err)
+	}
+
+	return nil
+}
+
+// decide passes the decision of the validator to the callers that wait for it.
+func (v *blockValidator) decide(result *BlockValidationResult) {
+	blockID := result.GetBlockId().Unwrap()
+
+	v.pendingLock.Lock()
+	defer v.pendingLock.Unlock()
+
+	for _, decision := range v.pending[blockID] {
+		decision <- result
+	}
+	delete(v.pending, blockID)
+}
+
+func (v *blockValidator) removePending(blockID iotago.BlockID, decision chan *BlockValidationResult) {
+	v.pendingLock.Lock()
+	defer v.pendingLock.Unlock()
+
+	waiting := v.pending[blockID]
+	for i, c := range waiting {
+		if c == decision {
+			waiting = append(waiting[:i], waiting[i+1:]...)
+
+			break
+		}
+	}
+
+	if len(waiting) == 0 {
+		delete(v.pending, blockID)
+
+		return
+	}
+	v.pending[blockID] = waiting
+}
+
+func (s *Server) ValidateBlocks(srv BlockValidationServer) error {
+	if !ParamsINX.Validator.Enabled {
+		return ErrValidationDisabled
+	}
+
+	validator := newBlockValidator(srv)
+
+	s.validatorLock.Lock()
+	if s.validator != nil {
+		s.validatorLock.Unlock()
+
+		return ErrValidatorAlreadyRegistered
+	}
+	s.validator = validator
+	s.validatorLock.Unlock()
+
+	name := "unknown"
+	if ext := extensionFromContext(srv.Context()); ext != nil {
+		name = ext.displayName()
+	}
+	Component.LogInfof("INX extension %s registered as block validator", name)
+
+	defer func() {
+		s.validatorLock.Lock()
+		s.validator = nil
+		s.validatorLock.Unlock()
+		close(validator.closed)
+
+		Component.LogInfof("INX extension %s unregistered as block validator", name)
+	}()
+
+	for {
+		result, err := srv.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+
+			return err
+		}
+
+		validator.decide(result)
+	}
+}
+
+// validateBlock asks the registered validator extension whether the block is accepted.
+// If the validator didn't decide, the block is only rejected if the validation is configured to fail closed.
+func (s *Server) validateBlock(block *storage.Block) error {
+	start := time.Now()
+
+	result, err := s.requestBlockValidation(block)
+	if err != nil {
+		deps.INXMetrics.BlockValidationFailed()
+
+		if ParamsINX.Validator.FailClosed {
+			return err
+		}
+
+		return nil
+	}
+
+	deps.INXMetrics.BlockValidated(result.GetAccept(), time.Since(start))
+
+	if !result.GetAccept() {
+		if result.GetReason() == "" {
+			return errValidatorRejectedNoReason
+		}
+
+		return errors.New(result.GetReason())
+	}
+
+	return nil
+}
+
+func (s *Server) requestBlockValidation(block *storage.Block) (*BlockValidationResult, error) {
+	s.validatorLock.RLock()
+	validator := s.validator
+	s.validatorLock.RUnlock()
+
+	if validator == nil {
+		return nil, errValidatorNotConnected
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ParamsINX.Validator.Timeout)
+	defer cancel()
+
+	return validator.validate(ctx, block)
+}
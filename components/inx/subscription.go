@@ -0,0 +1,189 @@
+package inx
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// MetadataResume is the gRPC metadata key an extension sets to "true" to resume a milestone based stream
+	// after the last milestone that was acknowledged by an extension with the same name (see AckMilestone).
+	MetadataResume = "inx-resume"
+
+	// OverflowPolicyDrop disconnects subscribers that can't keep up with the node.
+	OverflowPolicyDrop = "drop"
+	// OverflowPolicyReplay drops new milestones for subscribers that can't keep up with the node
+	// and replays the missed milestones from the storage as soon as the subscriber caught up.
+	OverflowPolicyReplay = "replay"
+)
+
+var (
+	// ErrSubscriberTooSlow is returned if a subscriber couldn't keep up with the node and the overflow policy is "drop".
+	ErrSubscriberTooSlow = status.Error(codes.ResourceExhausted, "subscriber can't keep up with the node, the stream buffer overflowed")
+)
+
+type bufferedMilestone[T any] struct {
+	index   iotago.MilestoneIndex
+	payload T
+}
+
+// milestoneBuffer decouples the event hooks of the node from sending milestone based data to a slow subscriber.
+type milestoneBuffer[T any] struct {
+	items  chan *bufferedMilestone[T]
+	stream *extensionStream
+	policy string
+
+	failOnce sync.Once
+	// failed is closed if the subscription failed, e.g. because the buffer overflowed and the policy is "drop".
+	failed chan struct{}
+	err    error
+
+	firstDroppedLock sync.Mutex
+	// firstDropped is the first milestone index that was dropped and was not replayed yet.
+	firstDropped iotago.MilestoneIndex
+}
+
+func newMilestoneBuffer[T any](ctx context.Context) *milestoneBuffer[T] {
+	size := ParamsINX.Streams.BufferSize
+	if size < 1 {
+		size = 1
+	}
+
+	stream := streamFromContext(ctx)
+	if stream != nil {
+		stream.bufferCapacity.Store(int64(size))
+	}
+
+	return &milestoneBuffer[T]{
+		items:  make(chan *bufferedMilestone[T], size),
+		stream: stream,
+		policy: ParamsINX.Streams.OverflowPolicy,
+		failed: make(chan struct{}),
+	}
+}
+
+// push adds the data of a milestone to the buffer without blocking.
+// If the buffer is full, the overflow policy is applied.
+func (b *milestoneBuffer[T]) push(index iotago.MilestoneIndex, payload T) {
+	select {
+	case b.items <- &bufferedMilestone[T]{index: index, payload: payload}:
+		if b.stream != nil {
+			b.stream.buffered.Inc()
+		}
+
+		return
+	default:
+	}
+
+	if b.stream != nil {
+		b.stream.overflows.Inc()
+	}
+
+	if b.policy == OverflowPolicyDrop {
+		b.fail(ErrSubscriberTooSlow)
+
+		return
+	}
+
+	b.firstDroppedLock.Lock()
+	defer b.firstDroppedLock.Unlock()
+
+	if b.firstDropped == 0 {
+		b.firstDropped = index
+	}
+}
+
+// fail marks the subscription as failed with the given error.
+// Only the first error is kept.
+func (b *milestoneBuffer[T]) fail(err error) {
+	b.failOnce.Do(func() {
+		b.err = err
+		close(b.failed)
+	})
+}
+
+// pop marks the milestone with the given index as taken from the buffer and returns the first dropped
+// milestone index that needs to be replayed before this milestone, or 0 if no milestones were dropped.
+func (b *milestoneBuffer[T]) pop(index iotago.MilestoneIndex) iotago.MilestoneIndex {
+	if b.stream != nil {
+		b.stream.buffered.Dec()
+	}
+
+	b.firstDroppedLock.Lock()
+	defer b.firstDroppedLock.Unlock()
+
+	// milestones that were buffered before the first dropped milestone are sent first
+	if b.firstDropped == 0 || index < b.firstDropped {
+		return 0
+	}
+
+	firstDropped := b.firstDropped
+	b.firstDropped = 0
+
+	return firstDropped
+}
+
+// prepareReplay makes sure that the dropped milestones starting at firstDropped are replayed from the storage
+// by the catch up function of the ranged send before the next milestone is sent.
+func prepareReplay(stream *streamRange, firstDropped iotago.MilestoneIndex) {
+	if firstDropped == 0 || stream.rangeRequested() {
+		// the catch up of the ranged send already covers all missing milestones
+		return
+	}
+
+	stream.start = firstDropped
+}
+
+// resumeStartIndex returns the milestone index a stream should start at.
+// If the request has no start index, but the extension requested to resume the stream,
+// the stream starts after the last milestone that was acknowledged on the same method by an extension with the same name.
+func resumeStartIndex(ctx context.Context, startIndex iotago.MilestoneIndex) iotago.MilestoneIndex {
+	ext, resumeIndex := resumeIndexFromCursor(ctx, startIndex)
+	if ext == nil {
+		return startIndex
+	}
+
+	Component.LogInfof("Resuming %s of INX extension %s at milestone %d", streamFromContext(ctx).method, ext.displayName(), resumeIndex)
+
+	return resumeIndex
+}
+
+// resumeIndexFromCursor returns the extension and the milestone index the stream of the given context resumes at,
+// or nil if the stream is not resumed.
+func resumeIndexFromCursor(ctx context.Context, startIndex iotago.MilestoneIndex) (*extension, iotago.MilestoneIndex) {
+	if startIndex != 0 {
+		return nil, 0
+	}
+
+	stream := streamFromContext(ctx)
+	if stream == nil {
+		return nil, 0
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, 0
+	}
+
+	if values := md.Get(MetadataResume); len(values) == 0 || values[0] != "true" {
+		return nil, 0
+	}
+
+	ext := extensionFromContext(ctx)
+	if ext == nil {
+		return nil, 0
+	}
+
+	cursor := ext.cursor(stream.method)
+	if cursor == 0 {
+		return nil, 0
+	}
+
+	return ext, cursor + 1
+}
@@ -0,0 +1,220 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package inx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/stats"
+	"google.golang.org/grpc/status"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const testStreamMethod = "/inx.INX/ListenToConfirmedMilestones"
+
+func setTestStreamParams(t *testing.T, bufferSize int, overflowPolicy string) {
+	bufferSizeBefore := ParamsINX.Streams.BufferSize
+	overflowPolicyBefore := ParamsINX.Streams.OverflowPolicy
+	t.Cleanup(func() {
+		ParamsINX.Streams.BufferSize = bufferSizeBefore
+		ParamsINX.Streams.OverflowPolicy = overflowPolicyBefore
+	})
+
+	ParamsINX.Streams.BufferSize = bufferSize
+	ParamsINX.Streams.OverflowPolicy = overflowPolicy
+}
+
+// newTestRPCContext returns the context of an RPC on the given method of a new connection of an extension with the given name,
+// as it is created by the stats handler of the server.
+func newTestRPCContext(registry *extensionRegistry, name string, method string, md metadata.MD) context.Context {
+	ctx := registry.TagConn(context.Background(), &stats.ConnTagInfo{})
+	ctx = registry.TagRPC(ctx, &stats.RPCTagInfo{FullMethodName: method})
+
+	if name != "" {
+		md = metadata.Join(md, metadata.Pairs(MetadataExtensionName, name))
+	}
+	registry.HandleRPC(ctx, &stats.InHeader{Header: md})
+
+	return metadata.NewIncomingContext(ctx, md)
+}
+
+func TestMilestoneBufferDropPolicy(t *testing.T) {
+	setTestStreamParams(t, 2, OverflowPolicyDrop)
+
+	ctx := newTestRPCContext(newExtensionRegistry(), "", testStreamMethod, metadata.MD{})
+	buffer := newMilestoneBuffer[int](ctx)
+
+	buffer.push(1, 1)
+	buffer.push(2, 2)
+
+	select {
+	case <-buffer.failed:
+		require.FailNow(t, "subscription failed before the buffer overflowed")
+	default:
+	}
+
+	// the third milestone overflows the buffer and fails the subscription
+	buffer.push(3, 3)
+
+	select {
+	case <-buffer.failed:
+	default:
+		require.FailNow(t, "subscription didn't fail after the buffer overflowed")
+	}
+	require.ErrorIs(t, buffer.err, ErrSubscriberTooSlow)
+	require.Equal(t, codes.ResourceExhausted, status.Code(buffer.err))
+
+	// later overflows don't fail the subscription again
+	buffer.push(4, 4)
+	require.ErrorIs(t, buffer.err, ErrSubscriberTooSlow)
+
+	stream := streamFromContext(ctx)
+	require.Equal(t, int64(2), stream.buffered.Load())
+	require.Equal(t, uint64(2), stream.overflows.Load())
+
+	// nothing is replayed with the drop policy
+	require.Equal(t, iotago.MilestoneIndex(0), buffer.pop(1))
+	require.Equal(t, iotago.MilestoneIndex(0), buffer.pop(2))
+}
+
+func TestMilestoneBufferReplayPolicy(t *testing.T) {
+	setTestStreamParams(t, 2, OverflowPolicyReplay)
+
+	ctx := newTestRPCContext(newExtensionRegistry(), "", testStreamMethod, metadata.MD{})
+	buffer := newMilestoneBuffer[int](ctx)
+
+	buffer.push(10, 10)
+	buffer.push(11, 11)
+	// the buffer is full, the following milestones are dropped
+	buffer.push(12, 12)
+	buffer.push(13, 13)
+
+	select {
+	case <-buffer.failed:
+		require.FailNow(t, "subscription failed with the replay policy")
+	default:
+	}
+	require.Equal(t, uint64(2), streamFromContext(ctx).overflows.Load())
+
+	// the buffered milestones before the first dropped milestone are sent without a replay
+	require.Equal(t, 10, (<-buffer.items).payload)
+	require.Equal(t, iotago.MilestoneIndex(0), buffer.pop(10))
+
+	buffer.push(14, 14)
+	require.Equal(t, 11, (<-buffer.items).payload)
+	require.Equal(t, iotago.MilestoneIndex(0), buffer.pop(11))
+
+	// the next buffered milestone is after the dropped milestones, so the replay starts at the first dropped milestone
+	require.Equal(t, 14, (<-buffer.items).payload)
+	require.Equal(t, iotago.MilestoneIndex(12), buffer.pop(14))
+
+	// the dropped milestones are only replayed once
+	buffer.push(15, 15)
+	require.Equal(t, 15, (<-buffer.items).payload)
+	require.Equal(t, iotago.MilestoneIndex(0), buffer.pop(15))
+}
+
+func TestPrepareReplay(t *testing.T) {
+	// without a requested range, the stream starts the catch up at the first dropped milestone
+	stream := &streamRange{lastSent: 11}
+	prepareReplay(stream, 12)
+	require.Equal(t, iotago.MilestoneIndex(12), stream.start)
+	require.True(t, stream.rangeRequested())
+
+	// with a requested range, the catch up of the range already replays all milestones after the last sent one
+	stream = &streamRange{start: 5, end: 100, lastSent: 11}
+	prepareReplay(stream, 12)
+	require.Equal(t, iotago.MilestoneIndex(5), stream.start)
+	require.Equal(t, iotago.MilestoneIndex(11), stream.lastSent)
+
+	// nothing to replay
+	stream = &streamRange{lastSent: 11}
+	prepareReplay(stream, 0)
+	require.Equal(t, iotago.MilestoneIndex(0), stream.start)
+	require.False(t, stream.rangeRequested())
+}
+
+func TestResumeIndexFromCursor(t *testing.T) {
+	registry := newExtensionRegistry()
+	resume := metadata.Pairs(MetadataResume, "true")
+
+	// sending a milestone doesn't advance the cursor
+	ctx := newTestRPCContext(registry, "indexer", testStreamMethod, resume)
+	trackMilestoneSent(ctx, 20)
+	require.Equal(t, iotago.MilestoneIndex(20), streamFromContext(ctx).lastSentMilestoneIndex.Load())
+	ext, _ := resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+
+	_, err := (&Server{}).AckMilestone(newTestRPCContext(registry, "indexer", AckMilestoneMethod, metadata.MD{}), &MilestoneAck{Method: testStreamMethod, MilestoneIndex: 18})
+	require.NoError(t, err)
+
+	// with inx-resume, the stream starts after the acknowledged milestone
+	ctx = newTestRPCContext(registry, "indexer", testStreamMethod, resume)
+	ext, index := resumeIndexFromCursor(ctx, 0)
+	require.NotNil(t, ext)
+	require.Equal(t, iotago.MilestoneIndex(19), index)
+
+	// without inx-resume, the stream starts at the latest milestone
+	ctx = newTestRPCContext(registry, "indexer", testStreamMethod, metadata.MD{})
+	ext, _ = resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+
+	ctx = newTestRPCContext(registry, "indexer", testStreamMethod, metadata.Pairs(MetadataResume, "false"))
+	ext, _ = resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+
+	// a requested start index has priority over the cursor
+	ctx = newTestRPCContext(registry, "indexer", testStreamMethod, resume)
+	ext, _ = resumeIndexFromCursor(ctx, 5)
+	require.Nil(t, ext)
+	require.Equal(t, iotago.MilestoneIndex(5), resumeStartIndex(ctx, 5))
+
+	// the cursor is kept per method and per extension name
+	ctx = newTestRPCContext(registry, "indexer", "/inx.INX/ListenToLedgerUpdates", resume)
+	ext, _ = resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+
+	ctx = newTestRPCContext(registry, "participation", testStreamMethod, resume)
+	ext, _ = resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+
+	ctx = newTestRPCContext(registry, "", testStreamMethod, resume)
+	ext, _ = resumeIndexFromCursor(ctx, 0)
+	require.Nil(t, ext)
+}
+
+func TestAckMilestone(t *testing.T) {
+	registry := newExtensionRegistry()
+	server := &Server{}
+
+	ackCtx := newTestRPCContext(registry, "indexer", AckMilestoneMethod, metadata.MD{})
+
+	_, err := server.AckMilestone(ackCtx, &MilestoneAck{Method: testStreamMethod, MilestoneIndex: 10})
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(10), registry.cursor("indexer", testStreamMethod))
+
+	_, err = server.AckMilestone(ackCtx, &MilestoneAck{Method: testStreamMethod, MilestoneIndex: 12})
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(12), registry.cursor("indexer", testStreamMethod))
+
+	// the cursor doesn't move backwards
+	_, err = server.AckMilestone(ackCtx, &MilestoneAck{Method: testStreamMethod, MilestoneIndex: 11})
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(12), registry.cursor("indexer", testStreamMethod))
+
+	// invalid acknowledgements
+	_, err = server.AckMilestone(ackCtx, &MilestoneAck{MilestoneIndex: 13})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = server.AckMilestone(ackCtx, &MilestoneAck{Method: testStreamMethod})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// an extension without a name can't be resumed, so it can't acknowledge milestones
+	_, err = server.AckMilestone(newTestRPCContext(registry, "", AckMilestoneMethod, metadata.MD{}), &MilestoneAck{Method: testStreamMethod, MilestoneIndex: 13})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Equal(t, iotago.MilestoneIndex(12), registry.cursor("indexer", testStreamMethod))
+}
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	libp2pquic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 	"github.com/multiformats/go-multiaddr"
 	"go.uber.org/dig"
 
@@ -18,6 +21,7 @@ import (
 	"github.com/iotaledger/hive.go/app/configuration"
 	hivep2p "github.com/iotaledger/hive.go/crypto/p2p"
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
 	"github.com/iotaledger/hornet/v2/pkg/p2p"
 )
@@ -41,11 +45,13 @@ var (
 
 type dependencies struct {
 	dig.In
-	PeeringManager       *p2p.Manager
-	Host                 host.Host
-	PeerStoreContainer   *p2p.PeerStoreContainer
-	PeeringConfig        *configuration.Configuration `name:"peeringConfig"`
-	PeeringConfigManager *p2p.ConfigManager
+	PeeringManager        *p2p.Manager
+	Host                  host.Host
+	PeerStoreContainer    *p2p.PeerStoreContainer
+	PeeringConfig         *configuration.Configuration `name:"peeringConfig"`
+	PeeringConfigManager  *p2p.ConfigManager
+	PeeringConfigFilePath *string `name:"peeringConfigFilePath"`
+	Firewall              *p2p.Firewall
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -75,13 +81,15 @@ func provide(c *dig.Container) error {
 		DatabaseEngine        hivedb.Engine `name:"databaseEngine"`
 		P2PDatabasePath       string        `name:"p2pDatabasePath"`
 		P2PBindMultiAddresses []string      `name:"p2pBindMultiAddresses"`
+		ConnectionGater       *p2p.ConnectionGater
 	}
 
 	type p2presult struct {
 		dig.Out
-		PeerStoreContainer *p2p.PeerStoreContainer
-		NodePrivateKey     crypto.PrivKey `name:"nodePrivateKey"`
-		Host               host.Host
+		PeerStoreContainer   *p2p.PeerStoreContainer
+		NodePrivateKey       crypto.PrivKey `name:"nodePrivateKey"`
+		Host                 host.Host
+		TransportPreferences *p2p.TransportPreferences
 	}
 
 	if err := c.Provide(func(deps hostDeps) p2presult {
@@ -121,13 +129,43 @@ func provide(c *dig.Container) error {
 			Component.LogPanicf("unable to initialize connection manager: %s", err)
 		}
 
-		createdHost, err := libp2p.New(libp2p.Identity(privKey),
+		natTraversalConfig := &p2p.NATTraversalConfig{
+			AutoNATServiceEnabled: ParamsP2P.AutoNAT.ServiceEnabled,
+			ForceReachability:     ParamsP2P.AutoNAT.ForceReachability,
+			RelayClientEnabled:    ParamsP2P.Relay.ClientEnabled,
+			StaticRelays:          ParamsP2P.Relay.StaticRelays,
+			RelayServiceEnabled:   ParamsP2P.Relay.ServiceEnabled,
+			HolePunchingEnabled:   ParamsP2P.HolePunching.Enabled,
+		}
+
+		natTraversalOpts, err := natTraversalConfig.LibP2POptions()
+		if err != nil {
+			Component.LogPanicf("invalid NAT traversal config: %s", err)
+		}
+
+		transportOpts, err := transportOptions(deps.P2PBindMultiAddresses)
+		if err != nil {
+			Component.LogPanicf("invalid bind addresses: %s", err)
+		}
+
+		res.TransportPreferences = p2p.NewTransportPreferences()
+
+		hostOpts := []libp2p.Option{
+			libp2p.Identity(privKey),
 			libp2p.ListenAddrStrings(deps.P2PBindMultiAddresses...),
 			libp2p.Peerstore(peerStoreContainer.Peerstore()),
-			libp2p.Transport(tcp.NewTCPTransport),
 			libp2p.ConnectionManager(connManager),
 			libp2p.NATPortMap(),
-		)
+			libp2p.DialRanker(res.TransportPreferences.DialRanker),
+		}
+		hostOpts = append(hostOpts, transportOpts...)
+		hostOpts = append(hostOpts, natTraversalOpts...)
+		if deps.ConnectionGater != nil {
+			// the firewall rules are applied before connections are upgraded, so denied peers can't open any stream
+			hostOpts = append(hostOpts, libp2p.ConnectionGater(deps.ConnectionGater))
+		}
+
+		createdHost, err := libp2p.New(hostOpts...)
 		if err != nil {
 			Component.LogPanicf("unable to initialize peer: %s", err)
 		}
@@ -138,9 +176,58 @@ func provide(c *dig.Container) error {
 		Component.LogPanic(err)
 	}
 
+	type firewallDeps struct {
+		dig.In
+		PeeringConfig         *configuration.Configuration `name:"peeringConfig"`
+		PeeringConfigFilePath *string                      `name:"peeringConfigFilePath"`
+	}
+
+	if err := c.Provide(func(deps firewallDeps) *p2p.Firewall {
+		if !ParamsP2P.Firewall.Enabled {
+			return nil
+		}
+
+		firewall, err := p2p.NewFirewall(p2p.FirewallAction(ParamsP2P.Firewall.DefaultAction), func(rules []*p2p.FirewallRule) error {
+			if err := deps.PeeringConfig.Set(CfgFirewallRules, rules); err != nil {
+				return err
+			}
+
+			return deps.PeeringConfig.StoreFile(*deps.PeeringConfigFilePath, 0o600, []string{"p2p"})
+		})
+		if err != nil {
+			Component.LogPanic(err)
+		}
+
+		// firewall rules from peering config
+		var rules []*p2p.FirewallRule
+		if err := deps.PeeringConfig.Unmarshal(CfgFirewallRules, &rules); err != nil {
+			Component.LogPanicf("invalid firewall rules config: %s", err)
+		}
+
+		if err := firewall.LoadRules(rules); err != nil {
+			Component.LogPanicf("invalid firewall rules config: %s", err)
+		}
+
+		return firewall
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
+	if err := c.Provide(func(firewall *p2p.Firewall) *p2p.ConnectionGater {
+		if firewall == nil {
+			return nil
+		}
+
+		return p2p.NewConnectionGater(firewall)
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
 	type mngDeps struct {
 		dig.In
 		Host                      host.Host
+		ConnectionGater           *p2p.ConnectionGater
+		TransportPreferences      *p2p.TransportPreferences
 		AutopeeringRunAsEntryNode bool `name:"autopeeringRunAsEntryNode"`
 	}
 
@@ -149,6 +236,8 @@ func provide(c *dig.Container) error {
 			return p2p.NewManager(deps.Host,
 				p2p.WithManagerLogger(Component.App().NewLogger("P2P-Manager")),
 				p2p.WithManagerReconnectInterval(ParamsP2P.ReconnectInterval, 1*time.Second),
+				p2p.WithManagerConnectionGater(deps.ConnectionGater),
+				p2p.WithManagerTransportPreferences(deps.TransportPreferences),
 			)
 		}
 
@@ -281,9 +370,62 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if ParamsP2P.WatchPeeringConfig {
+		if err := Component.Daemon().BackgroundWorker("PeeringConfigWatcher", func(ctx context.Context) {
+			unhook := lo.Batch(
+				deps.PeeringConfigManager.Events.Reloaded.Hook(func(diff *p2p.PeerConfigDiff) {
+					Component.LogInfof("reloaded peering config: %d added, %d removed, %d alias updates", len(diff.Added), len(diff.Removed), len(diff.AliasUpdated))
+				}).Unhook,
+				deps.PeeringConfigManager.Events.ReloadFailed.Hook(func(err error) {
+					Component.LogWarnf("reloading peering config failed: %s", err)
+				}).Unhook,
+			)
+			defer unhook()
+
+			watchPeeringConfig(ctx, *deps.PeeringConfigFilePath)
+		}, daemon.PriorityP2PManager); err != nil {
+			Component.LogPanicf("failed to start worker: %s", err)
+		}
+	}
+
 	return nil
 }
 
+// returns the libp2p transport options for the transports used in the given bind addresses.
+// TCP is always enabled, QUIC and WebTransport only if there is a bind address using them.
+func transportOptions(bindMultiAddresses []string) ([]libp2p.Option, error) {
+	transports := map[p2p.Transport]struct{}{
+		p2p.TransportTCP: {},
+	}
+
+	for _, bindMultiAddress := range bindMultiAddresses {
+		multiAddr, err := multiaddr.NewMultiaddr(bindMultiAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		transport := p2p.TransportOf(multiAddr)
+		switch transport {
+		case p2p.TransportTCP, p2p.TransportQUIC, p2p.TransportWebTransport:
+			transports[transport] = struct{}{}
+		default:
+			return nil, fmt.Errorf("unsupported transport in bind address %s", bindMultiAddress)
+		}
+	}
+
+	opts := []libp2p.Option{
+		libp2p.Transport(tcp.NewTCPTransport),
+	}
+	if _, has := transports[p2p.TransportQUIC]; has {
+		opts = append(opts, libp2p.Transport(libp2pquic.NewTransport))
+	}
+	if _, has := transports[p2p.TransportWebTransport]; has {
+		opts = append(opts, libp2p.Transport(webtransport.New))
+	}
+
+	return opts, nil
+}
+
 // connects to the peers defined in the config.
 func connectConfigKnownPeers() {
 	for _, p := range deps.PeeringConfigManager.Peers() {
@@ -9,12 +9,14 @@ import (
 const (
 	// CfgPeers defines the static peers this node should retain a connection to (CLI).
 	CfgPeers = "peers"
+	// CfgFirewallRules defines the firewall rules stored in the peering config.
+	CfgFirewallRules = "firewallRules"
 )
 
 // ParametersP2P contains the definition of the parameters used by p2p.
 type ParametersP2P struct {
 	// Defines the bind addresses of this node.
-	BindMultiAddresses []string `default:"/ip4/0.0.0.0/tcp/15600,/ip6/::/tcp/15600" usage:"the bind addresses for this node"`
+	BindMultiAddresses []string `default:"/ip4/0.0.0.0/tcp/15600,/ip6/::/tcp/15600" usage:"the bind addresses for this node (TCP, QUIC and WebTransport addresses are supported)"`
 
 	ConnectionManager struct {
 		// Defines the high watermark to use within the connection manager.
@@ -33,6 +35,37 @@ type ParametersP2P struct {
 
 	// Defines the time to wait before trying to reconnect to a disconnected peer.
 	ReconnectInterval time.Duration `default:"30s" usage:"the time to wait before trying to reconnect to a disconnected peer"`
+
+	// Defines whether changes to the peering config file are applied without a restart.
+	WatchPeeringConfig bool `default:"true" usage:"whether changes to the peering config file are applied without a restart"`
+
+	AutoNAT struct {
+		// ServiceEnabled defines whether to help other peers to determine their reachability.
+		ServiceEnabled bool `default:"false" usage:"whether to help other peers to determine their reachability (AutoNAT service)"`
+		// ForceReachability forces the reachability of the node instead of detecting it via AutoNAT.
+		ForceReachability string `default:"" usage:"forces the reachability of the node instead of detecting it via AutoNAT (public, private)"`
+	} `name:"autoNAT"`
+
+	Relay struct {
+		// ClientEnabled defines whether to reserve slots on the static relays if the node is not publicly reachable.
+		ClientEnabled bool `default:"false" usage:"whether to reserve slots on the static relays if the node is not publicly reachable (circuit relay v2)"`
+		// StaticRelays defines the multiaddresses of the relays used by the relay client.
+		StaticRelays []string `default:"" usage:"the multiaddresses of the relays used by the relay client"`
+		// ServiceEnabled defines whether to act as a relay for other peers.
+		ServiceEnabled bool `default:"false" usage:"whether to act as a relay for other peers if the node is publicly reachable (circuit relay v2)"`
+	}
+
+	HolePunching struct {
+		// Enabled defines whether to upgrade relayed connections to direct connections.
+		Enabled bool `default:"false" usage:"whether to upgrade relayed connections to direct connections via hole punching (DCUtR)"`
+	}
+
+	Firewall struct {
+		// Enabled defines whether the firewall is enabled.
+		Enabled bool `default:"false" usage:"whether the firewall for peer connections is enabled"`
+		// DefaultAction defines the action applied to connections that don't match any rule.
+		DefaultAction string `default:"allow" usage:"the action applied to connections that don't match any firewall rule (allow, deny)"`
+	}
 }
 
 // ParametersPeers contains the definition of the parameters used by peers.
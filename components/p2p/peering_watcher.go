@@ -0,0 +1,180 @@
+package p2p
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+const (
+	// editors often write files in several steps, so we wait
+	// for the file to settle before reloading it.
+	peeringConfigReloadDelay = 1 * time.Second
+)
+
+// watches the peering config file for changes and reconciles the peers of the Manager.
+// this method blocks until the given context is done.
+func watchPeeringConfig(ctx context.Context, filePath string) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		Component.LogWarnf("unable to watch peering config: %s", err)
+
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	// we watch the directory instead of the file itself,
+	// because editors often replace the file instead of modifying it.
+	if err := watcher.Add(filepath.Dir(filePath)); err != nil {
+		Component.LogWarnf("unable to watch peering config: %s", err)
+
+		return
+	}
+
+	Component.LogInfof("watching peering config for changes: %s", filePath)
+
+	reloadTimer := time.NewTimer(0)
+	if !reloadTimer.Stop() {
+		<-reloadTimer.C
+	}
+	defer reloadTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(ev.Name) != filepath.Clean(filePath) {
+				continue
+			}
+
+			if !ev.Has(fsnotify.Write) && !ev.Has(fsnotify.Create) && !ev.Has(fsnotify.Rename) {
+				continue
+			}
+
+			reloadTimer.Reset(peeringConfigReloadDelay)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			Component.LogWarnf("error while watching peering config: %s", err)
+
+		case <-reloadTimer.C:
+			reloadPeeringConfig(filePath)
+		}
+	}
+}
+
+// reloads the peering config file and applies the changes to the Manager.
+func reloadPeeringConfig(filePath string) {
+	peeringConfig := configuration.New()
+	if err := peeringConfig.LoadFile(filePath); err != nil {
+		deps.PeeringConfigManager.Events.ReloadFailed.Trigger(errors.Wrap(err, "unable to load peering config"))
+
+		return
+	}
+
+	var peers []*p2p.PeerConfig
+	if err := peeringConfig.Unmarshal(CfgPeers, &peers); err != nil {
+		deps.PeeringConfigManager.Events.ReloadFailed.Trigger(errors.Wrap(err, "invalid peer config"))
+
+		return
+	}
+
+	var rules []*p2p.FirewallRule
+	if deps.Firewall != nil {
+		if err := peeringConfig.Unmarshal(CfgFirewallRules, &rules); err != nil {
+			deps.PeeringConfigManager.Events.ReloadFailed.Trigger(errors.Wrap(err, "invalid firewall rules config"))
+
+			return
+		}
+	}
+
+	diff, err := deps.PeeringConfigManager.Reload(peers)
+	if err != nil {
+		// the error was already reported by the ReloadFailed event
+		return
+	}
+
+	if deps.Firewall != nil {
+		if err := deps.Firewall.LoadRules(rules); err != nil {
+			deps.PeeringConfigManager.Events.ReloadFailed.Trigger(errors.Wrap(err, "invalid firewall rules config"))
+		} else {
+			if err := deps.PeeringConfig.Set(CfgFirewallRules, deps.Firewall.Rules()); err != nil {
+				Component.LogWarnf("unable to update firewall rules in peering config: %s", err)
+			}
+
+			// the rules only apply to new connections, so existing connections that are denied by the reloaded rules are closed.
+			if err := deps.PeeringManager.EnforceFirewall(); err != nil {
+				Component.LogWarnf("enforcing reloaded firewall rules failed: %s", err)
+			}
+		}
+	}
+
+	// keep the in-memory config in sync, otherwise the next store would overwrite the changes
+	if err := deps.PeeringConfig.Set(CfgPeers, deps.PeeringConfigManager.Peers()); err != nil {
+		Component.LogWarnf("unable to update peers in peering config: %s", err)
+	}
+
+	applyPeerConfigDiff(diff)
+}
+
+// applies the changes of the peering config to the Manager.
+func applyPeerConfigDiff(diff *p2p.PeerConfigDiff) {
+	for _, p := range diff.Removed {
+		addrInfo, err := addrInfoFromPeerConfig(p)
+		if err != nil {
+			continue
+		}
+
+		if err := deps.PeeringManager.DisconnectPeer(addrInfo.ID, errors.New("peer was removed from peering config")); err != nil {
+			Component.LogWarnf("unable to disconnect peer (%s): %s", p.MultiAddress, err)
+		}
+	}
+
+	for _, p := range diff.AliasUpdated {
+		addrInfo, err := addrInfoFromPeerConfig(p)
+		if err != nil {
+			continue
+		}
+
+		alias := p.Alias
+		deps.PeeringManager.Call(addrInfo.ID, func(peer *p2p.Peer) {
+			peer.Alias = alias
+		})
+	}
+
+	for _, p := range diff.Added {
+		addrInfo, err := addrInfoFromPeerConfig(p)
+		if err != nil {
+			continue
+		}
+
+		if err := deps.PeeringManager.ConnectPeer(addrInfo, p2p.PeerRelationKnown, p.Alias); err != nil {
+			Component.LogInfof("can't connect to peer (%s): %s", p.MultiAddress, err)
+		}
+	}
+}
+
+func addrInfoFromPeerConfig(p *p2p.PeerConfig) (*peer.AddrInfo, error) {
+	multiAddr, err := multiaddr.NewMultiaddr(p.MultiAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return peer.AddrInfoFromP2pAddr(multiAddr)
+}
@@ -35,7 +35,11 @@ func provide(c *dig.Container) error {
 
 	if err := c.Provide(func(deps handlerDeps) *pow.Handler {
 		// init the pow handler with all possible settings
-		return pow.New(ParamsPoW.RefreshTipsInterval)
+		return pow.New(
+			ParamsPoW.RefreshTipsInterval,
+			pow.WithQueueSize(ParamsPoW.QueueSize),
+			pow.WithParallelJobs(ParamsPoW.ParallelJobs),
+		)
 	}); err != nil {
 		Component.LogPanic(err)
 	}
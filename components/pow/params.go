@@ -10,6 +10,10 @@ import (
 type ParametersPoW struct {
 	// Defines the interval for refreshing tips during PoW for blocks passed without parents via API.
 	RefreshTipsInterval time.Duration `default:"5s" usage:"interval for refreshing tips during PoW for blocks passed without parents via API"`
+	// Defines the maximum amount of PoW jobs waiting in the queue.
+	QueueSize int `default:"100" usage:"the maximum amount of PoW jobs waiting in the queue"`
+	// Defines the maximum amount of PoW jobs running at the same time (0 = number of CPU cores).
+	ParallelJobs int `default:"0" usage:"the maximum amount of PoW jobs running at the same time (0 = number of CPU cores)"`
 }
 
 var ParamsPoW = &ParametersPoW{}
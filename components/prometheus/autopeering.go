@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p/autopeering"
+)
+
+var (
+	autopeeringFilterDecisions *prometheus.CounterVec
+
+	// unhookAutopeeringEvents detaches the autopeering metrics from the events on shutdown.
+	unhookAutopeeringEvents func()
+)
+
+func configureAutopeering() {
+
+	autopeeringFilterDecisions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "autopeering",
+			Name:      "filter_decisions",
+			Help:      "Number of decisions of the autopeering neighbor filters.",
+		},
+		[]string{"filter", "decision"},
+	)
+
+	registry.MustRegister(autopeeringFilterDecisions)
+
+	unhookAutopeeringEvents = deps.AutopeeringManager.Events.NeighborFiltered.Hook(func(decision *autopeering.FilterDecision) {
+		result := "rejected"
+		if decision.Accepted {
+			result = "accepted"
+		}
+
+		autopeeringFilterDecisions.With(prometheus.Labels{
+			"filter":   decision.Filter,
+			"decision": result,
+		}).Inc()
+	}).Unhook
+}
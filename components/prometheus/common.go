@@ -23,4 +23,6 @@ var (
 		32286,
 	}
 	powDurationBuckets = []float64{.1, .2, .5, 1, 2, 5, 10, 20, 50, 100, 200, 500}
+
+	validatorDecisionDurationBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5}
 )
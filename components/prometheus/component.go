@@ -22,11 +22,15 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
 	"github.com/iotaledger/hornet/v2/pkg/database"
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
 	"github.com/iotaledger/hornet/v2/pkg/model/migrator"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/p2p"
+	"github.com/iotaledger/hornet/v2/pkg/p2p/autopeering"
+	"github.com/iotaledger/hornet/v2/pkg/pow"
+	"github.com/iotaledger/hornet/v2/pkg/protocol"
 	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
 	"github.com/iotaledger/hornet/v2/pkg/pruning"
 	"github.com/iotaledger/hornet/v2/pkg/snapshot"
@@ -65,27 +69,31 @@ var (
 
 type dependencies struct {
 	dig.In
-	AppInfo          *app.Info
-	SyncManager      *syncmanager.SyncManager
-	ServerMetrics    *metrics.ServerMetrics
-	Storage          *storage.Storage
-	StorageMetrics   *metrics.StorageMetrics
-	TangleDatabase   *database.Database      `name:"tangleDatabase"`
-	UTXODatabase     *database.Database      `name:"utxoDatabase"`
-	RestAPIMetrics   *metrics.RestAPIMetrics `optional:"true"`
-	INXMetrics       *metrics.INXMetrics     `optional:"true"`
-	GossipService    *gossip.Service
-	ReceiptService   *migrator.ReceiptService `optional:"true"`
-	Tangle           *tangle.Tangle
-	PeeringManager   *p2p.Manager
-	RequestQueue     gossip.RequestQueue
-	MessageProcessor *gossip.MessageProcessor
-	TipSelector      *tipselect.TipSelector `optional:"true"`
-	SnapshotManager  *snapshot.Manager
-	PruningManager   *pruning.Manager
-	Echo             *echo.Echo  `optional:"true"`
-	PrometheusEcho   *echo.Echo  `name:"prometheusEcho"`
-	INXServer        *inx.Server `optional:"true"`
+	AppInfo            *app.Info
+	SyncManager        *syncmanager.SyncManager
+	ServerMetrics      *metrics.ServerMetrics
+	Storage            *storage.Storage
+	StorageMetrics     *metrics.StorageMetrics
+	TangleDatabase     *database.Database      `name:"tangleDatabase"`
+	UTXODatabase       *database.Database      `name:"utxoDatabase"`
+	RestAPIMetrics     *metrics.RestAPIMetrics `optional:"true"`
+	INXMetrics         *metrics.INXMetrics     `optional:"true"`
+	GossipService      *gossip.Service
+	ReceiptService     *migrator.ReceiptService `optional:"true"`
+	Tangle             *tangle.Tangle
+	ProtocolManager    *protocol.Manager
+	PeeringManager     *p2p.Manager
+	AutopeeringManager *autopeering.Manager `optional:"true"`
+	RequestQueue       gossip.RequestQueue
+	MessageProcessor   *gossip.MessageProcessor
+	PoWHandler         *pow.Handler           `optional:"true"`
+	TipSelector        *tipselect.TipSelector `optional:"true"`
+	SnapshotManager    *snapshot.Manager
+	PruningManager     *pruning.Manager
+	Echo               *echo.Echo          `optional:"true"`
+	PrometheusEcho     *echo.Echo          `name:"prometheusEcho"`
+	INXServer          *inx.Server         `optional:"true"`
+	Verifier           *integrity.Verifier `optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -115,14 +123,24 @@ func configure() error {
 		configureDatabase(coreDatabase.TangleDatabaseDirectoryName, deps.TangleDatabase)
 		configureDatabase(coreDatabase.UTXODatabaseDirectoryName, deps.UTXODatabase)
 		configureStorage(deps.Storage, deps.StorageMetrics)
+		if deps.Verifier != nil {
+			configureLedgerIntegrity(deps.Verifier)
+		}
 	}
 	if ParamsPrometheus.NodeMetrics {
 		configureNode()
+		configureProtocol()
+		if deps.PoWHandler != nil {
+			configurePoW()
+		}
 	}
 	if ParamsPrometheus.GossipMetrics {
 		configureGossipPeers()
 		configureGossipNode()
 	}
+	if ParamsPrometheus.AutopeeringMetrics && deps.AutopeeringManager != nil {
+		configureAutopeering()
+	}
 	if ParamsPrometheus.CachesMetrics {
 		configureCaches()
 	}
@@ -228,6 +246,10 @@ func run() error {
 		<-ctx.Done()
 		Component.LogInfo("Stopping Prometheus exporter ...")
 
+		if unhookAutopeeringEvents != nil {
+			unhookAutopeeringEvents()
+		}
+
 		shutdownCtx, shutdownCtxCancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer shutdownCtxCancel()
 
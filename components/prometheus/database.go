@@ -6,6 +6,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/iotaledger/hornet/v2/pkg/database"
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 )
@@ -70,6 +71,92 @@ func (m *storageMetrics) collect() {
 	}
 }
 
+type ledgerIntegrityMetrics struct {
+	verifier *integrity.Verifier
+
+	healthy                  prometheus.Gauge
+	checksCount              prometheus.Counter
+	divergencesCount         *prometheus.CounterVec
+	lastCheckDurationSeconds prometheus.Gauge
+	lastCheckTimestamp       prometheus.Gauge
+}
+
+func configureLedgerIntegrity(verifier *integrity.Verifier) {
+
+	m := &ledgerIntegrityMetrics{
+		verifier: verifier,
+	}
+
+	m.healthy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "iota",
+		Subsystem: "database",
+		Name:      "ledger_integrity_healthy",
+		Help:      "Whether the last ledger integrity check found no divergences.",
+	})
+
+	m.checksCount = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "database",
+			Name:      "ledger_integrity_checks_total",
+			Help:      "The total amount of ledger integrity checks.",
+		},
+	)
+
+	m.divergencesCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "iota",
+			Subsystem: "database",
+			Name:      "ledger_integrity_divergences_total",
+			Help:      "The total amount of divergences detected by the ledger integrity check.",
+		},
+		[]string{"kind"},
+	)
+
+	m.lastCheckDurationSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "iota",
+		Subsystem: "database",
+		Name:      "ledger_integrity_last_check_duration_seconds",
+		Help:      "The duration of the last ledger integrity check in seconds.",
+	})
+
+	m.lastCheckTimestamp = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "iota",
+		Subsystem: "database",
+		Name:      "ledger_integrity_last_check_timestamp_seconds",
+		Help:      "The unix timestamp of the last ledger integrity check.",
+	})
+
+	verifier.Events.CheckDone.Hook(func(_ *integrity.CheckResult) {
+		m.checksCount.Inc()
+	})
+
+	verifier.Events.DivergenceDetected.Hook(func(divergence *integrity.Divergence) {
+		m.divergencesCount.WithLabelValues(string(divergence.Kind)).Inc()
+	})
+
+	registry.MustRegister(m.healthy)
+	registry.MustRegister(m.checksCount)
+	registry.MustRegister(m.divergencesCount)
+	registry.MustRegister(m.lastCheckDurationSeconds)
+	registry.MustRegister(m.lastCheckTimestamp)
+
+	addCollect(m.collect)
+}
+
+func (m *ledgerIntegrityMetrics) collect() {
+
+	m.healthy.Set(0)
+	if m.verifier.Healthy() {
+		m.healthy.Set(1)
+	}
+
+	if result := m.verifier.LastCheckResult(); result != nil {
+		m.lastCheckDurationSeconds.Set(result.Duration.Seconds())
+		m.lastCheckTimestamp.Set(float64(result.Timestamp.Unix()))
+	}
+}
+
 func configureDatabase(name string, db *database.Database) {
 
 	m := &databaseMetrics{
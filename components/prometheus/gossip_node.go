@@ -66,6 +66,8 @@ func collectServer() {
 	gossipBlocks.WithLabelValues("known").Set(float64(deps.ServerMetrics.KnownBlocks.Load()))
 	gossipBlocks.WithLabelValues("referenced").Set(float64(deps.ServerMetrics.ReferencedBlocks.Load()))
 	gossipBlocks.WithLabelValues("invalid").Set(float64(deps.ServerMetrics.InvalidBlocks.Load()))
+	gossipBlocks.WithLabelValues("rejected").Set(float64(deps.ServerMetrics.RejectedBlocks.Load()))
+	gossipBlocks.WithLabelValues("validation_dropped").Set(float64(deps.ServerMetrics.ValidationDroppedBlocks.Load()))
 	gossipBlocks.WithLabelValues("sent").Set(float64(deps.ServerMetrics.SentBlocks.Load()))
 	gossipBlocks.WithLabelValues("sent_spam").Set(float64(deps.ServerMetrics.SentSpamBlocks.Load()))
 
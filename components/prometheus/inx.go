@@ -10,6 +10,9 @@ var (
 	inxPoWCompletedCount prometheus.Gauge
 	inxPoWBlockSizes     prometheus.Histogram
 	inxPoWDurations      prometheus.Histogram
+
+	inxValidatorBlocks    *prometheus.GaugeVec
+	inxValidatorDurations prometheus.Histogram
 )
 
 func configureINX() {
@@ -41,18 +44,46 @@ func configureINX() {
 			Buckets:   powDurationBuckets,
 		})
 
+	inxValidatorBlocks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "inx",
+			Name:      "validator_blocks",
+			Help:      "The amount of blocks validated by the INX validator.",
+		},
+		[]string{"type"},
+	)
+
+	inxValidatorDurations = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "iota",
+			Subsystem: "inx",
+			Name:      "validator_decision_durations",
+			Help:      "The duration until the INX validator decided about a block [s].",
+			Buckets:   validatorDecisionDurationBuckets,
+		})
+
 	registry.MustRegister(inxPoWCompletedCount)
 	registry.MustRegister(inxPoWBlockSizes)
 	registry.MustRegister(inxPoWDurations)
+	registry.MustRegister(inxValidatorBlocks)
+	registry.MustRegister(inxValidatorDurations)
 
 	deps.INXMetrics.Events.PoWCompleted.Hook(func(blockSize int, duration time.Duration) {
 		inxPoWBlockSizes.Observe(float64(blockSize))
 		inxPoWDurations.Observe(duration.Seconds())
 	})
 
+	deps.INXMetrics.Events.BlockValidated.Hook(func(duration time.Duration) {
+		inxValidatorDurations.Observe(duration.Seconds())
+	})
+
 	addCollect(collectINX)
 }
 
 func collectINX() {
 	inxPoWCompletedCount.Set(float64(deps.INXMetrics.PoWCompletedCounter.Load()))
+	inxValidatorBlocks.WithLabelValues("accepted").Set(float64(deps.INXMetrics.ValidatorAcceptedCounter.Load()))
+	inxValidatorBlocks.WithLabelValues("rejected").Set(float64(deps.INXMetrics.ValidatorRejectedCounter.Load()))
+	inxValidatorBlocks.WithLabelValues("failed").Set(float64(deps.INXMetrics.ValidatorFailedCounter.Load()))
 }
@@ -26,6 +26,8 @@ type ParametersPrometheus struct {
 	NodeMetrics bool `default:"true" usage:"whether to include node metrics"`
 	// GossipMetrics defines whether to include gossip metrics.
 	GossipMetrics bool `default:"true" usage:"whether to include gossip metrics"`
+	// AutopeeringMetrics defines whether to include autopeering metrics.
+	AutopeeringMetrics bool `default:"true" usage:"whether to include autopeering metrics"`
 	// CachesMetrics defines whether to include caches metrics.
 	CachesMetrics bool `default:"true" usage:"whether to include caches metrics"`
 	// RestAPIMetrics include restAPI metrics.
@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	powQueuedJobs  prometheus.Gauge
+	powRunningJobs prometheus.Gauge
+)
+
+func configurePoW() {
+
+	powQueuedJobs = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "pow",
+			Name:      "queued_jobs",
+			Help:      "The amount of PoW jobs waiting in the queue.",
+		},
+	)
+
+	powRunningJobs = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "pow",
+			Name:      "running_jobs",
+			Help:      "The amount of PoW jobs that are currently running.",
+		},
+	)
+
+	registry.MustRegister(powQueuedJobs)
+	registry.MustRegister(powRunningJobs)
+
+	addCollect(collectPoW)
+}
+
+func collectPoW() {
+	powQueuedJobs.Set(float64(deps.PoWHandler.QueuedJobs()))
+	powRunningJobs.Set(float64(deps.PoWHandler.RunningJobs()))
+}
@@ -0,0 +1,68 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/hornet/v2/components/protocfg"
+)
+
+var (
+	protocolPendingUpgrades                 prometheus.Gauge
+	protocolUnsupportedUpgradeMilestones    prometheus.Gauge
+	protocolUnsupportedUpgradeWarningActive prometheus.Gauge
+)
+
+func configureProtocol() {
+
+	protocolPendingUpgrades = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "protocol",
+			Name:      "pending_upgrades",
+			Help:      "Number of scheduled protocol parameters changes.",
+		})
+
+	protocolUnsupportedUpgradeMilestones = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "protocol",
+			Name:      "unsupported_upgrade_milestones",
+			Help:      "Number of milestones until the activation of an unsupported protocol version (-1 if none is scheduled).",
+		})
+
+	protocolUnsupportedUpgradeWarningActive = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "iota",
+			Subsystem: "protocol",
+			Name:      "unsupported_upgrade_warning",
+			Help:      "Whether an unsupported protocol version gets activated within the configured warning milestones.",
+		})
+
+	registry.MustRegister(protocolPendingUpgrades)
+	registry.MustRegister(protocolUnsupportedUpgradeMilestones)
+	registry.MustRegister(protocolUnsupportedUpgradeWarningActive)
+
+	addCollect(collectProtocol)
+}
+
+func collectProtocol() {
+	protocolPendingUpgrades.Set(float64(len(deps.ProtocolManager.Pending())))
+
+	protocolUnsupportedUpgradeMilestones.Set(-1)
+	protocolUnsupportedUpgradeWarningActive.Set(0)
+
+	unsupportedProtoParamsMsOption := deps.ProtocolManager.NextUnsupported()
+	if unsupportedProtoParamsMsOption == nil {
+		return
+	}
+
+	var milestonesUntilActivation uint32
+	if confirmedMilestoneIndex := deps.SyncManager.ConfirmedMilestoneIndex(); unsupportedProtoParamsMsOption.TargetMilestoneIndex > confirmedMilestoneIndex {
+		milestonesUntilActivation = unsupportedProtoParamsMsOption.TargetMilestoneIndex - confirmedMilestoneIndex
+	}
+	protocolUnsupportedUpgradeMilestones.Set(float64(milestonesUntilActivation))
+
+	if warningMilestones := protocfg.ParamsProtocol.Upgrades.WarningMilestones; warningMilestones > 0 && milestonesUntilActivation <= warningMilestones {
+		protocolUnsupportedUpgradeWarningActive.Set(1)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hive.go/app/shutdown"
 	"github.com/iotaledger/hornet/v2/pkg/components"
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
@@ -34,10 +35,16 @@ func init() {
 	}
 }
 
+const (
+	// upgradeWarningIntervalMilestones is the amount of milestones between warnings about an upcoming unsupported protocol version.
+	upgradeWarningIntervalMilestones = 360
+)
+
 var (
-	Component           *app.Component
-	deps                dependencies
-	cooPubKeyRangesFlag = flag.String(CfgProtocolPublicKeyRangesJSON, "", "overwrite public key ranges (JSON)")
+	Component               *app.Component
+	lastUpgradeWarningIndex iotago.MilestoneIndex
+	deps                    dependencies
+	cooPubKeyRangesFlag     = flag.String(CfgProtocolPublicKeyRangesJSON, "", "overwrite public key ranges (JSON)")
 )
 
 type dependencies struct {
@@ -63,6 +70,7 @@ func initConfigParams(c *dig.Container) error {
 	type cfgResult struct {
 		dig.Out
 		KeyManager              *keymanager.KeyManager
+		SignatureVerifier       milestonemanager.SignatureVerifier
 		MilestonePublicKeyCount int `name:"milestonePublicKeyCount"`
 		BaseToken               *BaseToken
 	}
@@ -96,6 +104,12 @@ func initConfigParams(c *dig.Container) error {
 		}
 		res.KeyManager = keyManager
 
+		signatureVerifier, err := SignatureVerifierWithConfig(keyManager, ParamsProtocol.MilestonePublicKeyCount, iotago.NetworkIDFromString(ParamsProtocol.TargetNetworkName))
+		if err != nil {
+			Component.LogPanicf("can't initialize milestone signature verification: %s", err)
+		}
+		res.SignatureVerifier = signatureVerifier
+
 		return res
 	}); err != nil {
 		Component.LogPanic(err)
@@ -139,6 +153,7 @@ func configure() error {
 		defer cachedMilestone.Release(true) // milestone -1
 
 		deps.ProtocolManager.HandleConfirmedMilestone(cachedMilestone.Milestone().Milestone())
+		warnUpcomingUnsupportedProtocolVersion(cachedMilestone.Milestone().Index())
 	})
 
 	deps.ProtocolManager.Events.NextMilestoneUnsupported.Hook(func(unsupportedProtoParamsMsOption *iotago.ProtocolParamsMilestoneOpt) {
@@ -152,3 +167,29 @@ func configure() error {
 
 	return nil
 }
+
+// warnUpcomingUnsupportedProtocolVersion logs a warning if protocol parameters with an unsupported
+// protocol version get activated within the configured amount of milestones.
+func warnUpcomingUnsupportedProtocolVersion(confirmedMilestoneIndex iotago.MilestoneIndex) {
+	if ParamsProtocol.Upgrades.WarningMilestones == 0 {
+		return
+	}
+
+	unsupportedProtoParamsMsOption := deps.ProtocolManager.NextUnsupported()
+	if unsupportedProtoParamsMsOption == nil || unsupportedProtoParamsMsOption.TargetMilestoneIndex <= confirmedMilestoneIndex {
+		return
+	}
+
+	milestonesUntilActivation := unsupportedProtoParamsMsOption.TargetMilestoneIndex - confirmedMilestoneIndex
+	if milestonesUntilActivation > ParamsProtocol.Upgrades.WarningMilestones {
+		return
+	}
+
+	// do not flood the log, but warn again after some milestones
+	if lastUpgradeWarningIndex != 0 && confirmedMilestoneIndex < lastUpgradeWarningIndex+upgradeWarningIntervalMilestones {
+		return
+	}
+	lastUpgradeWarningIndex = confirmedMilestoneIndex
+
+	Component.LogWarnf("unsupported protocol version %d will be activated at milestone %d (in %d milestones), please update your node!", unsupportedProtoParamsMsOption.ProtocolVersion, unsupportedProtoParamsMsOption.TargetMilestoneIndex, milestonesUntilActivation)
+}
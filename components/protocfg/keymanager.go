@@ -1,7 +1,12 @@
 package protocfg
 
 import (
+	"crypto/ed25519"
+	"fmt"
+
 	"github.com/iotaledger/hive.go/crypto"
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/iota.go/v3/keymanager"
 )
 
@@ -18,3 +23,46 @@ func KeyManagerWithConfigPublicKeyRanges(coordinatorPublicKeyRanges ConfigPublic
 
 	return keyManager, nil
 }
+
+// SignatureVerifierWithConfig creates the milestone signature verification backend that is configured in the protocol parameters.
+func SignatureVerifierWithConfig(keyManager *keymanager.KeyManager, milestonePublicKeyCount int, networkID iotago.NetworkID) (milestonemanager.SignatureVerifier, error) {
+	verificationParams := ParamsProtocol.MilestoneVerification
+
+	switch verificationParams.Backend {
+	case milestonemanager.SignatureVerifierKeyManager:
+		return milestonemanager.NewKeyManagerVerifier(keyManager, milestonePublicKeyCount), nil
+
+	case milestonemanager.SignatureVerifierThreshold:
+		threshold := verificationParams.Threshold
+		if threshold == 0 {
+			threshold = milestonePublicKeyCount
+		}
+
+		return milestonemanager.NewThresholdVerifier(keyManager, threshold)
+
+	case milestonemanager.SignatureVerifierKeyRotationFile:
+		trustedPublicKeys := make([]ed25519.PublicKey, 0, len(verificationParams.KeyRotationFile.TrustedPublicKeys))
+		for _, trustedPublicKey := range verificationParams.KeyRotationFile.TrustedPublicKeys {
+			pubKey, err := crypto.ParseEd25519PublicKeyFromString(trustedPublicKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted public key of the key rotation file: %w", err)
+			}
+			trustedPublicKeys = append(trustedPublicKeys, pubKey)
+		}
+
+		return milestonemanager.NewKeyRotationFileVerifier(
+			verificationParams.KeyRotationFile.Path,
+			networkID,
+			trustedPublicKeys,
+			verificationParams.KeyRotationFile.MinSignatures,
+			milestonePublicKeyCount,
+		)
+
+	case milestonemanager.SignatureVerifierHSM:
+		// the local HSM stub holds all keys of the current key ranges
+		return milestonemanager.NewLocalHSMVerifier(keyManager, milestonePublicKeyCount), nil
+
+	default:
+		return nil, fmt.Errorf("unknown milestone signature verification backend: %s", verificationParams.Backend)
+	}
+}
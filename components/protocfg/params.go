@@ -42,6 +42,27 @@ type ParametersProtocol struct {
 	// the ed25519 public key of the coordinator in hex representation.
 	PublicKeyRanges ConfigPublicKeyRanges `noflag:"true"`
 
+	MilestoneVerification struct {
+		// the backend that is used to verify the signatures of milestones.
+		Backend string `default:"keyManager" usage:"the backend that is used to verify the signatures of milestones (keyManager, threshold, keyRotationFile, hsm)"`
+		// the minimum amount of valid signatures of applicable keys in a milestone for the threshold backend.
+		Threshold int `default:"0" usage:"the minimum amount of valid signatures of applicable keys in a milestone for the threshold backend (0 = milestonePublicKeyCount)"`
+
+		KeyRotationFile struct {
+			// the path to the signed key rotation file.
+			Path string `default:"keyrotation.json" usage:"the path to the signed key rotation file"`
+			// the hex encoded ed25519 public keys that are trusted to sign the key rotation file.
+			TrustedPublicKeys []string `default:"" usage:"the hex encoded ed25519 public keys that are trusted to sign the key rotation file"`
+			// the minimum amount of signatures of trusted keys in the key rotation file.
+			MinSignatures int `default:"1" usage:"the minimum amount of signatures of trusted keys in the key rotation file"`
+		} `name:"keyRotationFile"`
+	} `name:"milestoneVerification"`
+
+	Upgrades struct {
+		// the amount of milestones before the activation of unsupported protocol parameters at which the node starts to warn.
+		WarningMilestones uint32 `default:"8640" usage:"the amount of milestones before the activation of unsupported protocol parameters at which the node starts to warn (0 = disabled)"`
+	}
+
 	BaseToken BaseToken `usage:"the network base token properties"`
 }
 
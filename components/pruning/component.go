@@ -44,12 +44,15 @@ func initConfigParams(c *dig.Container) error {
 
 	type cfgResult struct {
 		dig.Out
-		PruningPruneReceipts bool `name:"pruneReceipts"`
+		PruningPruneReceipts    bool `name:"pruneReceipts"`
+		PruningKeepsFullHistory bool `name:"pruningKeepsFullHistory"`
 	}
 
 	return c.Provide(func() cfgResult {
 		return cfgResult{
 			PruningPruneReceipts: ParamsPruning.PruneReceipts,
+			// the node keeps the full history of the tangle if pruning is disabled
+			PruningKeepsFullHistory: !ParamsPruning.Milestones.Enabled && !ParamsPruning.Size.Enabled,
 		}
 	})
 }
@@ -16,8 +16,11 @@ import (
 	"github.com/iotaledger/hive.go/runtime/event"
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
+	"github.com/iotaledger/hornet/v2/pkg/health"
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
 	"github.com/iotaledger/hornet/v2/pkg/jwt"
 	"github.com/iotaledger/hornet/v2/pkg/metrics"
+	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 )
@@ -46,13 +49,16 @@ var (
 
 type dependencies struct {
 	dig.In
-	Tangle             *tangle.Tangle `optional:"true"`
+	Tangle             *tangle.Tangle           `optional:"true"`
+	SyncManager        *syncmanager.SyncManager `optional:"true"`
 	Echo               *echo.Echo
 	RestAPIMetrics     *metrics.RestAPIMetrics
 	Host               host.Host
 	RestAPIBindAddress string         `name:"restAPIBindAddress"`
 	NodePrivateKey     crypto.PrivKey `name:"nodePrivateKey"`
 	RestRouteManager   *RestRouteManager
+	Verifier           *integrity.Verifier `optional:"true"`
+	HealthChecker      *health.Checker
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -107,6 +113,10 @@ func provide(c *dig.Container) error {
 		Component.LogPanic(err)
 	}
 
+	if err := c.Provide(health.NewChecker); err != nil {
+		Component.LogPanic(err)
+	}
+
 	type proxyDeps struct {
 		dig.In
 		Echo *echo.Echo
@@ -123,12 +133,15 @@ func provide(c *dig.Container) error {
 
 func configure() error {
 	deps.Echo.Use(apiMiddleware())
+	registerHealthChecks()
 	setupRoutes()
 
 	return nil
 }
 
 func run() error {
+	// all components registered their health checks during configure
+	validateHealthChecks()
 
 	Component.LogInfo("Starting REST-API server ...")
 
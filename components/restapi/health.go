@@ -0,0 +1,61 @@
+package restapi
+
+import (
+	"github.com/pkg/errors"
+)
+
+// registerHealthChecks registers the health checks that are provided by the node itself.
+// Other components may register additional health checks at the health checker.
+func registerHealthChecks() {
+	deps.HealthChecker.Register(HealthCheckResponsive, func() error {
+		return nil
+	})
+
+	deps.HealthChecker.Register(HealthCheckLedgerIntegrity, func() error {
+		// the ledger integrity check is disabled
+		if deps.Verifier == nil {
+			return nil
+		}
+
+		if !deps.Verifier.Healthy() {
+			return errors.New("the ledger integrity check detected a divergence")
+		}
+
+		return nil
+	})
+
+	// node mode
+	if deps.Tangle == nil {
+		return
+	}
+
+	deps.HealthChecker.Register(HealthCheckAlmostSynced, func() error {
+		return deps.Tangle.CheckNodeAlmostSynced(deps.SyncManager.SyncState())
+	})
+
+	deps.HealthChecker.Register(HealthCheckSynced, func() error {
+		return deps.Tangle.CheckNodeSynced(deps.SyncManager.SyncState())
+	})
+
+	deps.HealthChecker.Register(HealthCheckGossipStreams, func() error {
+		return deps.Tangle.CheckGossipStreams(ParamsRestAPI.Health.MinGossipStreams)
+	})
+
+	deps.HealthChecker.Register(HealthCheckProtocolSupported, deps.Tangle.CheckProtocolSupported)
+
+	deps.HealthChecker.Register(HealthCheckMilestoneAge, func() error {
+		return deps.Tangle.CheckLatestMilestoneAge(deps.SyncManager.SyncState(), ParamsRestAPI.Health.MaxMilestoneAge)
+	})
+}
+
+// validateHealthChecks warns about configured health checks that are not registered by any component.
+// Those checks always fail.
+func validateHealthChecks() {
+	for _, names := range [][]string{ParamsRestAPI.Health.Live, ParamsRestAPI.Health.Ready} {
+		for _, name := range names {
+			if !deps.HealthChecker.Registered(name) {
+				Component.LogWarnf("health check %s is not available and will always fail, available checks: %v", name, deps.HealthChecker.Names())
+			}
+		}
+	}
+}
@@ -1,9 +1,47 @@
 package restapi
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 )
 
+const (
+	// HealthCheckResponsive is the name of the health check that passes as long as the node is responsive.
+	HealthCheckResponsive = "responsive"
+	// HealthCheckAlmostSynced is the name of the health check that passes if the node is almost synced.
+	HealthCheckAlmostSynced = "almostSynced"
+	// HealthCheckSynced is the name of the health check that passes if the node is synced.
+	HealthCheckSynced = "synced"
+	// HealthCheckGossipStreams is the name of the health check that passes if the node has enough ongoing gossip streams.
+	HealthCheckGossipStreams = "gossipStreams"
+	// HealthCheckProtocolSupported is the name of the health check that passes if the next pending protocol version is supported.
+	HealthCheckProtocolSupported = "protocolSupported"
+	// HealthCheckMilestoneAge is the name of the health check that passes if the latest milestone is not too old.
+	HealthCheckMilestoneAge = "milestoneAge"
+	// HealthCheckLedgerIntegrity is the name of the health check that passes if the ledger integrity check detected no divergence.
+	HealthCheckLedgerIntegrity = "ledgerIntegrity"
+	// HealthCheckINXExtensions is the name of the health check that passes if enough INX extensions are connected.
+	HealthCheckINXExtensions = "inxExtensions"
+	// HealthCheckINXRequiredExtensions is the name of the health check that passes if all required INX extensions are connected.
+	// It is part of the readiness as soon as required INX extensions are configured.
+	HealthCheckINXRequiredExtensions = "inxRequiredExtensions"
+)
+
+// ParametersHealth contains the definition of the health check parameters used by REST API.
+type ParametersHealth struct {
+	// the health checks that need to pass for the node to be considered live
+	Live []string `usage:"the health checks that need to pass for the node to be considered live (responsive, almostSynced, synced, gossipStreams, protocolSupported, milestoneAge, ledgerIntegrity, inxExtensions)"`
+	// the health checks that need to pass for the node to be considered ready
+	Ready []string `usage:"the health checks that need to pass for the node to be considered ready (responsive, almostSynced, synced, gossipStreams, protocolSupported, milestoneAge, ledgerIntegrity, inxExtensions)"`
+	// the maximum age of the latest milestone for the milestoneAge check
+	MaxMilestoneAge time.Duration `default:"5m" usage:"the maximum age of the latest milestone for the milestoneAge check"`
+	// the minimum amount of ongoing gossip streams for the gossipStreams check
+	MinGossipStreams int `default:"1" usage:"the minimum amount of ongoing gossip streams for the gossipStreams check"`
+	// the minimum amount of connected INX extensions for the inxExtensions check
+	MinINXExtensions int `name:"minINXExtensions" default:"1" usage:"the minimum amount of connected INX extensions for the inxExtensions check"`
+}
+
 // ParametersRestAPI contains the definition of the parameters used by REST API.
 type ParametersRestAPI struct {
 	// Enabled defines whether the REST API plugin is enabled.
@@ -31,6 +69,8 @@ type ParametersRestAPI struct {
 		WorkerCount int `default:"1" usage:"the amount of workers used for calculating PoW when issuing blocks via API"`
 	} `name:"pow"`
 
+	Health ParametersHealth
+
 	Limits struct {
 		// the maximum number of characters that the body of an API call may contain
 		MaxBodyLength string `default:"1M" usage:"the maximum number of characters that the body of an API call may contain"`
@@ -42,6 +82,8 @@ type ParametersRestAPI struct {
 var ParamsRestAPI = &ParametersRestAPI{
 	PublicRoutes: []string{
 		"/health",
+		"/health/live",
+		"/health/ready",
 		"/api/routes",
 		"/api/core/v2/info",
 		"/api/core/v2/tips",
@@ -51,18 +93,32 @@ var ParamsRestAPI = &ParametersRestAPI{
 		"/api/core/v2/outputs*",
 		"/api/core/v2/treasury",
 		"/api/core/v2/receipts*",
+		"/api/core/v2/protocol/upgrades",
 		"/api/debug/v1/*",
 		"/api/indexer/v1/*",
 		"/api/mqtt/v1",
 		"/api/participation/v1/events*",
 		"/api/participation/v1/outputs*",
 		"/api/participation/v1/addresses*",
+		"/api/snapshots/v1/*",
 		"/api/core/v0/*",
 		"/api/core/v1/*",
 	},
 	ProtectedRoutes: []string{
 		"/api/*",
 	},
+	Health: ParametersHealth{
+		Live: []string{
+			HealthCheckResponsive,
+		},
+		Ready: []string{
+			HealthCheckAlmostSynced,
+			HealthCheckGossipStreams,
+			HealthCheckProtocolSupported,
+			HealthCheckMilestoneAge,
+			HealthCheckLedgerIntegrity,
+		},
+	},
 }
 
 var params = &app.ComponentParams{
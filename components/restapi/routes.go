@@ -5,12 +5,17 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/iotaledger/hornet/v2/pkg/health"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 )
 
 const (
 	nodeAPIHealthRoute = "/health"
 
+	nodeAPIHealthLiveRoute = "/health/live"
+
+	nodeAPIHealthReadyRoute = "/health/ready"
+
 	nodeAPIRoutesRoute = "/api/routes"
 )
 
@@ -21,14 +26,21 @@ type RoutesResponse struct {
 func setupRoutes() {
 
 	deps.Echo.GET(nodeAPIHealthRoute, func(c echo.Context) error {
-		// node mode
-		if deps.Tangle != nil && !deps.Tangle.IsNodeHealthy() {
+		if !deps.HealthChecker.Evaluate(deps.HealthChecker.WithReadinessChecks(ParamsRestAPI.Health.Ready)).Healthy {
 			return c.NoContent(http.StatusServiceUnavailable)
 		}
 
 		return c.NoContent(http.StatusOK)
 	})
 
+	deps.Echo.GET(nodeAPIHealthLiveRoute, func(c echo.Context) error {
+		return healthReportResponse(c, deps.HealthChecker.Evaluate(ParamsRestAPI.Health.Live))
+	})
+
+	deps.Echo.GET(nodeAPIHealthReadyRoute, func(c echo.Context) error {
+		return healthReportResponse(c, deps.HealthChecker.Evaluate(deps.HealthChecker.WithReadinessChecks(ParamsRestAPI.Health.Ready)))
+	})
+
 	// node mode
 	if deps.Tangle != nil {
 		deps.Echo.GET(nodeAPIRoutesRoute, func(c echo.Context) error {
@@ -40,3 +52,13 @@ func setupRoutes() {
 		})
 	}
 }
+
+// healthReportResponse returns the breakdown of the health checks,
+// with status code 503 if any of the checks failed.
+func healthReportResponse(c echo.Context, report *health.Report) error {
+	if !report.Healthy {
+		return httpserver.JSONResponse(c, http.StatusServiceUnavailable, report)
+	}
+
+	return httpserver.JSONResponse(c, http.StatusOK, report)
+}
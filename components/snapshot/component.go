@@ -4,11 +4,13 @@ import (
 	"context"
 	"os"
 
+	"github.com/labstack/echo/v4"
 	"github.com/labstack/gommon/bytes"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hornet/v2/components/restapi"
 	"github.com/iotaledger/hornet/v2/pkg/components"
 	"github.com/iotaledger/hornet/v2/pkg/daemon"
 	"github.com/iotaledger/hornet/v2/pkg/database"
@@ -45,6 +47,7 @@ func init() {
 		InitConfigParams: initConfigParams,
 		IsEnabled:        components.IsAutopeeringEntryNodeDisabled, // do not enable in "autopeering entry node" mode
 		Provide:          provide,
+		Configure:        configure,
 		Run:              run,
 	}
 }
@@ -54,6 +57,8 @@ var (
 	deps      dependencies
 
 	forceLoadingSnapshot = flag.Bool(CfgSnapshotsForceLoadingSnapshot, false, "force loading of a snapshot, even if a database already exists")
+
+	fileServer *snapshot.FileServer
 )
 
 type dependencies struct {
@@ -66,6 +71,8 @@ type dependencies struct {
 	SnapshotsFullPath  string `name:"snapshotsFullPath"`
 	SnapshotsDeltaPath string `name:"snapshotsDeltaPath"`
 	StorageMetrics     *metrics.StorageMetrics
+	Echo               *echo.Echo                `optional:"true"`
+	RestRouteManager   *restapi.RestRouteManager `optional:"true"`
 }
 
 func initConfigParams(c *dig.Container) error {
@@ -155,6 +162,14 @@ func provide(c *dig.Container) error {
 		solidEntryPointCheckThresholdPast := syncmanager.MilestoneIndexDelta(deps.ProtocolManager.Current().BelowMaxDepth + SolidEntryPointCheckAdditionalThresholdPast)
 		solidEntryPointCheckThresholdFuture := syncmanager.MilestoneIndexDelta(deps.ProtocolManager.Current().BelowMaxDepth + SolidEntryPointCheckAdditionalThresholdFuture)
 
+		var snapshotSchedule *snapshot.Schedule
+		if ParamsSnapshots.Schedule.Interval > 0 {
+			snapshotSchedule, err = snapshot.NewSchedule(ParamsSnapshots.Schedule.Interval, ParamsSnapshots.Schedule.Offset)
+			if err != nil {
+				Component.LogPanicf("parameters %s and %s invalid: %s", Component.App().Config().GetParameterPath(&(ParamsSnapshots.Schedule.Interval)), Component.App().Config().GetParameterPath(&(ParamsSnapshots.Schedule.Offset)), err)
+			}
+		}
+
 		snapshotDepth := syncmanager.MilestoneIndexDelta(ParamsSnapshots.Depth)
 		if snapshotDepth < solidEntryPointCheckThresholdFuture {
 			Component.LogWarnf("parameter '%s' is too small (%d). value was changed to %d", Component.App().Config().GetParameterPath(&(ParamsSnapshots.Depth)), snapshotDepth, solidEntryPointCheckThresholdFuture)
@@ -175,10 +190,20 @@ func provide(c *dig.Container) error {
 			solidEntryPointCheckThresholdFuture,
 			snapshotDepth,
 			syncmanager.MilestoneIndexDelta(ParamsSnapshots.Interval),
+			snapshotSchedule,
+			ParamsSnapshots.FullRetention,
 		)
 	})
 }
 
+func configure() error {
+	if ParamsSnapshots.Serve.Enabled {
+		configureSnapshotFileServer()
+	}
+
+	return nil
+}
+
 func run() error {
 	if err := Component.Daemon().BackgroundWorker("Snapshots", func(ctx context.Context) {
 		Component.LogInfo("Starting snapshot background worker ... done")
@@ -208,5 +233,13 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	if ParamsSnapshots.Hooks.Command != "" || ParamsSnapshots.Hooks.WebhookURL != "" {
+		runPostSnapshotHooksWorker()
+	}
+
+	if ParamsSnapshots.Serve.Enabled {
+		runSnapshotFileServerWorker()
+	}
+
 	return nil
 }
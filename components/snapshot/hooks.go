@@ -0,0 +1,37 @@
+package snapshot
+
+import (
+	"context"
+
+	"github.com/iotaledger/hornet/v2/pkg/daemon"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+)
+
+const (
+	// postSnapshotHooksQueueSize is the amount of created snapshot files that are queued for the post-snapshot hooks.
+	postSnapshotHooksQueueSize = 10
+)
+
+func runPostSnapshotHooksWorker() {
+	hooks := snapshot.NewPostSnapshotHooks(ParamsSnapshots.Hooks.Command, ParamsSnapshots.Hooks.WebhookURL, ParamsSnapshots.Hooks.Timeout, postSnapshotHooksQueueSize)
+
+	if err := Component.Daemon().BackgroundWorker("Snapshot hooks", func(ctx context.Context) {
+		Component.LogInfo("Starting snapshot hooks ... done")
+
+		unhook := deps.SnapshotManager.Events.SnapshotFileCreated.Hook(func(createdFile *snapshot.CreatedSnapshotFile) {
+			if !hooks.Enqueue(createdFile) {
+				Component.LogWarnf("skipping post-snapshot hooks for %s snapshot at index %d, queue is full", createdFile.Type, createdFile.TargetMilestoneIndex)
+			}
+		}).Unhook
+		defer unhook()
+
+		hooks.Run(ctx, func(err error) {
+			Component.LogWarn(err)
+		})
+
+		Component.LogInfo("Stopping snapshot hooks ...")
+		Component.LogInfo("Stopping snapshot hooks ... done")
+	}, daemon.PrioritySnapshots); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+}
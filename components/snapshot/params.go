@@ -1,6 +1,8 @@
 package snapshot
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/hornet/v2/pkg/snapshot"
 )
@@ -23,6 +25,32 @@ type ParametersSnapshots struct {
 	// DeltaSizeThresholdMinSize defines the minimum size of the delta snapshot file before the threshold percentage condition is checked
 	// (below that size the delta snapshot is always created)
 	DeltaSizeThresholdMinSize string `default:"50M" usage:"the minimum size of the delta snapshot file before the threshold percentage condition is checked (below that size the delta snapshot is always created)"`
+
+	Schedule struct {
+		// Interval defines the interval at which snapshot files are created based on the milestone timestamps, starting at 00:00 UTC.
+		// The interval must divide 24h without remainder (0 = snapshots are created based on the milestone interval)
+		Interval time.Duration `default:"0s" usage:"the interval at which snapshot files are created based on the milestone timestamps, starting at 00:00 UTC, it must divide 24h (0 = use the milestone interval)"`
+		// Offset defines the offset of the scheduled snapshots from 00:00 UTC, it must be smaller than the interval
+		Offset time.Duration `default:"0s" usage:"the offset of the scheduled snapshots from 00:00 UTC, it must be smaller than the interval"`
+	}
+
+	// FullRetention defines the amount of timestamped copies of the full snapshot file that are kept next to it
+	FullRetention int `default:"0" usage:"the amount of timestamped copies of the full snapshot file that are kept next to it (0 = disabled)"`
+
+	Hooks struct {
+		// Command defines the shell command that is executed after a snapshot file was created
+		Command string `default:"" usage:"the shell command that is executed after a snapshot file was created"`
+		// WebhookURL defines the URL that is notified with a HTTP POST request after a snapshot file was created
+		WebhookURL string `name:"webhookURL" default:"" usage:"the URL that is notified with a HTTP POST request after a snapshot file was created"`
+		// Timeout defines the timeout for the post-snapshot hooks
+		Timeout time.Duration `default:"1m" usage:"the timeout for the post-snapshot hooks"`
+	}
+
+	Serve struct {
+		// Enabled defines whether the latest snapshot files are served via the REST API
+		Enabled bool `default:"false" usage:"whether the latest snapshot files are served via the REST API"`
+	}
+
 	// DownloadURLs defines the URLs to load the snapshot files from.
 	DownloadURLs []*snapshot.DownloadTarget `noflag:"true" usage:"URLs to load the snapshot files from"`
 }
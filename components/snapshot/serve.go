@@ -0,0 +1,81 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hornet/v2/components/restapi"
+	"github.com/iotaledger/hornet/v2/pkg/daemon"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+)
+
+const (
+	// RouteSnapshots is the route group of the snapshot files.
+	// The routes of the snapshot files are defined by the snapshot.FileServer.
+	RouteSnapshots = "snapshots/v1"
+)
+
+func configureSnapshotFileServer() {
+	// check if RestAPI plugin is disabled
+	if !Component.App().IsComponentEnabled(restapi.Component.Identifier()) {
+		Component.LogPanic("RestAPI plugin needs to be enabled to serve the snapshot files")
+	}
+
+	fileServer = snapshot.NewFileServer(deps.SnapshotsFullPath, deps.SnapshotsDeltaPath)
+
+	// the snapshot files are served as they are, so range requests refer to the bytes of the file.
+	// this is not the case if the gzip middleware of the REST API compresses the response.
+	routePrefix := fmt.Sprintf("/api/%s/", RouteSnapshots)
+	deps.Echo.Pre(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if strings.HasPrefix(c.Request().URL.Path, routePrefix) {
+				c.Request().Header.Del(echo.HeaderAcceptEncoding)
+			}
+
+			return next(c)
+		}
+	})
+
+	fileServer.RegisterRoutes(deps.RestRouteManager.AddRoute(RouteSnapshots))
+}
+
+func runSnapshotFileServerWorker() {
+	if err := Component.Daemon().BackgroundWorker("Snapshot file server", func(ctx context.Context) {
+		Component.LogInfo("Starting snapshot file server ... done")
+
+		// the event is triggered while the snapshot lock is held, so the snapshot files are not modified during the update
+		unhook := deps.SnapshotManager.Events.SnapshotFileCreated.Hook(func(_ *snapshot.CreatedSnapshotFile) {
+			if err := fileServer.UpdateServedFiles(); err != nil {
+				Component.LogWarn(err)
+			}
+		}).Unhook
+		defer unhook()
+
+		// serve the existing snapshot files
+		deps.SnapshotManager.ExecuteWithSnapshotLock(func() {
+			if err := fileServer.UpdateServedFiles(); err != nil {
+				Component.LogWarn(err)
+			}
+		})
+
+		for {
+			select {
+			case <-ctx.Done():
+				Component.LogInfo("Stopping snapshot file server ...")
+				Component.LogInfo("Stopping snapshot file server ... done")
+
+				return
+
+			case snapshotType := <-fileServer.ETagsQueue():
+				if err := fileServer.CalculateETag(snapshotType); err != nil {
+					Component.LogDebugf("unable to calculate the ETag of the %s snapshot file: %s", snapshotType, err)
+				}
+			}
+		}
+	}, daemon.PrioritySnapshots); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/iotaledger/hornet/v2/pkg/snapshot"
 	"github.com/iotaledger/hornet/v2/pkg/tangle"
 	iotago "github.com/iotaledger/iota.go/v3"
-	"github.com/iotaledger/iota.go/v3/keymanager"
 )
 
 const (
@@ -86,18 +85,16 @@ func provide(c *dig.Container) error {
 
 	type milestoneManagerDeps struct {
 		dig.In
-		Storage                 *storage.Storage
-		SyncManager             *syncmanager.SyncManager
-		CoordinatorKeyManager   *keymanager.KeyManager
-		MilestonePublicKeyCount int `name:"milestonePublicKeyCount"`
+		Storage           *storage.Storage
+		SyncManager       *syncmanager.SyncManager
+		SignatureVerifier milestonemanager.SignatureVerifier
 	}
 
 	if err := c.Provide(func(deps milestoneManagerDeps) *milestonemanager.MilestoneManager {
-		return milestonemanager.New(
+		return milestonemanager.NewWithSignatureVerifier(
 			deps.Storage,
 			deps.SyncManager,
-			deps.CoordinatorKeyManager,
-			deps.MilestonePublicKeyCount)
+			deps.SignatureVerifier)
 	}); err != nil {
 		Component.LogPanic(err)
 	}
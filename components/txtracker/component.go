@@ -0,0 +1,207 @@
+package txtracker
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/hornet/v2/components/restapi"
+	"github.com/iotaledger/hornet/v2/pkg/components"
+	"github.com/iotaledger/hornet/v2/pkg/daemon"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/pow"
+	"github.com/iotaledger/hornet/v2/pkg/protocol"
+	restapipkg "github.com/iotaledger/hornet/v2/pkg/restapi"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	"github.com/iotaledger/hornet/v2/pkg/tipselect"
+	"github.com/iotaledger/hornet/v2/pkg/txtracker"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// RouteTransactions is the route for tracked transactions.
+	// GET returns all tracked transactions.
+	// POST registers a new transaction to be tracked.
+	RouteTransactions = "/transactions"
+
+	// RouteTransaction is the route for a tracked transaction by its ID.
+	// GET returns the state of the transaction.
+	// DELETE stops tracking the transaction.
+	RouteTransaction = "/transactions/:" + restapipkg.ParameterTransactionID
+
+	// the timeout for promotions and reattachments to be processed.
+	blockProcessedTimeout = 1 * time.Minute
+)
+
+func init() {
+	Component = &app.Component{
+		Name:     "TransactionTracker",
+		DepsFunc: func(cDeps dependencies) { deps = cDeps },
+		Params:   params,
+		IsEnabled: func(c *dig.Container) bool {
+			// do not enable in "autopeering entry node" mode
+			return components.IsAutopeeringEntryNodeDisabled(c) && ParamsTransactionTracker.Enabled
+		},
+		Provide:   provide,
+		Configure: configure,
+		Run:       run,
+	}
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+type dependencies struct {
+	dig.In
+	Tracker          *txtracker.Tracker
+	Tangle           *tangle.Tangle
+	SyncManager      *syncmanager.SyncManager
+	RestRouteManager *restapi.RestRouteManager `optional:"true"`
+}
+
+func provide(c *dig.Container) error {
+
+	type trackerDeps struct {
+		dig.In
+		Storage            *storage.Storage
+		UTXOManager        *utxo.Manager
+		SyncManager        *syncmanager.SyncManager
+		Tangle             *tangle.Tangle
+		TipScoreCalculator *tangle.TipScoreCalculator
+		PoWHandler         *pow.Handler
+		ProtocolManager    *protocol.Manager
+		TipSelector        *tipselect.TipSelector `optional:"true"`
+	}
+
+	if err := c.Provide(func(deps trackerDeps) *txtracker.Tracker {
+		if deps.TipSelector == nil {
+			Component.LogPanic("Tipselection plugin needs to be enabled to use the TransactionTracker plugin")
+		}
+
+		attacher := deps.Tangle.BlockAttacher(
+			tangle.WithTimeout(blockProcessedTimeout),
+			tangle.WithTipSel(deps.TipSelector.SelectNonLazyTips),
+			tangle.WithPoW(deps.PoWHandler, ParamsTransactionTracker.PoW.WorkerCount),
+		)
+
+		return txtracker.New(
+			Component.Logger(),
+			deps.Storage,
+			deps.UTXOManager,
+			func(ctx context.Context, blockID iotago.BlockID) (tangle.TipScore, error) {
+				return deps.TipScoreCalculator.TipScore(ctx, blockID, deps.SyncManager.ConfirmedMilestoneIndex())
+			},
+			func(ctx context.Context, block *iotago.Block) (iotago.BlockID, error) {
+				// promotions and reattachments shouldn't delay the PoW of blocks submitted by clients
+				return attacher.AttachBlock(ctx, block, pow.WithPriority(pow.PriorityLow))
+			},
+			deps.TipSelector.SelectNonLazyTips,
+			func() byte {
+				return deps.ProtocolManager.Current().Version
+			},
+			ParamsTransactionTracker.MaxTrackedTransactions,
+			ParamsTransactionTracker.MaxReattachments,
+			ParamsTransactionTracker.RetentionTime,
+		)
+	}); err != nil {
+		Component.LogPanic(err)
+	}
+
+	return nil
+}
+
+func configure() error {
+	// check if RestAPI plugin is disabled
+	if !Component.App().IsComponentEnabled(restapi.Component.Identifier()) {
+		Component.LogPanic("RestAPI plugin needs to be enabled to use the TransactionTracker plugin")
+	}
+
+	routeGroup := deps.RestRouteManager.AddRoute("txtracker/v1")
+
+	routeGroup.GET(RouteTransactions, func(c echo.Context) error {
+		return httpserver.JSONResponse(c, http.StatusOK, trackedTransactions())
+	})
+
+	routeGroup.POST(RouteTransactions, func(c echo.Context) error {
+		resp, err := registerTransaction(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusCreated, resp)
+	})
+
+	routeGroup.GET(RouteTransaction, func(c echo.Context) error {
+		resp, err := trackedTransaction(c)
+		if err != nil {
+			return err
+		}
+
+		return httpserver.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.DELETE(RouteTransaction, func(c echo.Context) error {
+		if err := unregisterTransaction(c); err != nil {
+			return err
+		}
+
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	deps.Tracker.Events.StateChanged.Hook(func(stateChange *txtracker.StateChange) {
+		Component.LogDebugf("transaction %s changed state from %s to %s, block: %s", stateChange.TransactionID.ToHex(), stateChange.PreviousState, stateChange.State, stateChange.BlockID.ToHex())
+	})
+
+	return nil
+}
+
+func run() error {
+	if err := Component.Daemon().BackgroundWorker("TransactionTracker", func(ctx context.Context) {
+		Component.LogInfo("Starting transaction tracker ... done")
+
+		// checks are triggered by confirmed milestones, but they must not block the confirmation
+		checkTrigger := make(chan struct{}, 1)
+
+		unhook := deps.Tangle.Events.ConfirmedMilestoneChanged.Hook(func(cachedMilestone *storage.CachedMilestone) {
+			cachedMilestone.Release(true) // milestone -1
+
+			select {
+			case checkTrigger <- struct{}{}:
+			default:
+				// a check is already pending
+			}
+		}).Unhook
+		defer unhook()
+
+		for {
+			select {
+			case <-ctx.Done():
+				Component.LogInfo("Stopping transaction tracker ...")
+				Component.LogInfo("Stopping transaction tracker ... done")
+
+				return
+
+			case <-checkTrigger:
+				if !deps.SyncManager.IsNodeAlmostSynced() {
+					// promotions and reattachments are useless if the node is not synced
+					continue
+				}
+
+				deps.Tracker.Check(ctx)
+			}
+		}
+	}, daemon.PriorityTransactionTracker); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package txtracker
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersTransactionTracker contains the definition of the parameters used by the transaction tracker plugin.
+type ParametersTransactionTracker struct {
+	// Enabled defines whether the transaction tracker plugin is enabled.
+	Enabled bool `default:"false" usage:"whether the transaction tracker plugin is enabled"`
+	// MaxTrackedTransactions defines the maximum amount of tracked transactions.
+	MaxTrackedTransactions int `default:"1000" usage:"the maximum amount of tracked transactions"`
+	// MaxReattachments defines the maximum amount of reattachments of a transaction before the tracker gives up.
+	MaxReattachments int `default:"5" usage:"the maximum amount of reattachments of a transaction before the tracker gives up"`
+	// RetentionTime defines how long transactions that were included, conflicted or failed are kept.
+	RetentionTime time.Duration `default:"1h" usage:"how long transactions that were included, conflicted or failed are kept"`
+
+	PoW struct {
+		// the amount of workers used for calculating PoW of promotions and reattachments
+		WorkerCount int `default:"1" usage:"the amount of workers used for calculating PoW of promotions and reattachments. (use 0 to use the maximum possible)"`
+	} `name:"pow"`
+}
+
+var ParamsTransactionTracker = &ParametersTransactionTracker{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"txTracker": ParamsTransactionTracker,
+	},
+	Masked: nil,
+}
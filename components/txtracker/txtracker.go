@@ -0,0 +1,131 @@
+package txtracker
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hornet/v2/pkg/common"
+	restapipkg "github.com/iotaledger/hornet/v2/pkg/restapi"
+	"github.com/iotaledger/hornet/v2/pkg/txtracker"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func newTrackedTransactionResponse(tracked *txtracker.TrackedTransaction) *trackedTransactionResponse {
+	response := &trackedTransactionResponse{
+		TransactionID: tracked.TransactionID.ToHex(),
+		State:         tracked.State,
+		Attachments:   tracked.Attachments.ToHex(),
+		Promotions:    tracked.Promotions,
+		History:       make([]*stateChangeResponse, len(tracked.History)),
+		RegisteredAt:  tracked.RegisteredAt.Unix(),
+	}
+
+	switch tracked.State {
+	case txtracker.StateIncluded:
+		response.ReferencedBlockID = tracked.ReferencedBlockID.ToHex()
+	case txtracker.StateConflicting:
+		conflict := tracked.Conflict
+		response.ReferencedBlockID = tracked.ReferencedBlockID.ToHex()
+		response.ConflictReason = &conflict
+	}
+
+	for i, stateChange := range tracked.History {
+		response.History[i] = &stateChangeResponse{
+			PreviousState: stateChange.PreviousState,
+			State:         stateChange.State,
+			BlockID:       stateChange.BlockID.ToHex(),
+			Timestamp:     stateChange.Timestamp.Unix(),
+		}
+	}
+
+	return response
+}
+
+func trackedTransactions() *trackedTransactionsResponse {
+	transactions := deps.Tracker.Transactions()
+
+	response := &trackedTransactionsResponse{
+		Transactions: make([]*trackedTransactionResponse, len(transactions)),
+	}
+	for i, tracked := range transactions {
+		response.Transactions[i] = newTrackedTransactionResponse(tracked)
+	}
+
+	return response
+}
+
+func trackedTransaction(c echo.Context) (*trackedTransactionResponse, error) {
+	transactionID, err := httpserver.ParseTransactionIDParam(c, restapipkg.ParameterTransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	tracked, err := deps.Tracker.Transaction(transactionID)
+	if err != nil {
+		if errors.Is(err, txtracker.ErrTransactionNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "transaction not tracked: %s", transactionID.ToHex())
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to load transaction: %s", err)
+	}
+
+	return newTrackedTransactionResponse(tracked), nil
+}
+
+func registerTransaction(c echo.Context) (*trackedTransactionResponse, error) {
+	request := &registerTransactionRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	transactionIDBytes, err := iotago.DecodeHex(request.TransactionID)
+	if err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid transaction ID: %s, error: %s", request.TransactionID, err)
+	}
+
+	if len(transactionIDBytes) != iotago.TransactionIDLength {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid transaction ID: %s, invalid length: %d", request.TransactionID, len(transactionIDBytes))
+	}
+
+	var transactionID iotago.TransactionID
+	copy(transactionID[:], transactionIDBytes)
+
+	blockID, err := iotago.BlockIDFromHexString(request.BlockID)
+	if err != nil {
+		return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "invalid block ID: %s, error: %s", request.BlockID, err)
+	}
+
+	tracked, err := deps.Tracker.Register(transactionID, blockID)
+	if err != nil {
+		switch {
+		case errors.Is(err, common.ErrBlockNotFound):
+			return nil, errors.WithMessagef(echo.ErrNotFound, "failed to register transaction: %s", err)
+		case errors.Is(err, txtracker.ErrNoTransaction):
+			return nil, errors.WithMessagef(httpserver.ErrInvalidParameter, "failed to register transaction: %s", err)
+		case errors.Is(err, txtracker.ErrTrackerFull):
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "failed to register transaction: %s", err)
+		default:
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to register transaction: %s", err)
+		}
+	}
+
+	return newTrackedTransactionResponse(tracked), nil
+}
+
+func unregisterTransaction(c echo.Context) error {
+	transactionID, err := httpserver.ParseTransactionIDParam(c, restapipkg.ParameterTransactionID)
+	if err != nil {
+		return err
+	}
+
+	if err := deps.Tracker.Unregister(transactionID); err != nil {
+		if errors.Is(err, txtracker.ErrTransactionNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "transaction not tracked: %s", transactionID.ToHex())
+		}
+
+		return errors.WithMessagef(echo.ErrInternalServerError, "failed to unregister transaction: %s", err)
+	}
+
+	return nil
+}
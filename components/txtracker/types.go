@@ -0,0 +1,52 @@
+package txtracker
+
+import (
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/txtracker"
+)
+
+// registerTransactionRequest defines the request of a POST transactions REST API call.
+type registerTransactionRequest struct {
+	// The hex encoded transaction ID.
+	TransactionID string `json:"transactionId"`
+	// The hex encoded block ID of the block that contains the transaction.
+	BlockID string `json:"blockId"`
+}
+
+// stateChangeResponse defines a state transition of a tracked transaction.
+type stateChangeResponse struct {
+	// The state before the transition.
+	PreviousState txtracker.State `json:"previousState,omitempty"`
+	// The state after the transition.
+	State txtracker.State `json:"state"`
+	// The hex encoded block ID of the block that caused the transition.
+	BlockID string `json:"blockId"`
+	// The unix timestamp of the transition.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// trackedTransactionResponse defines the response of a tracked transaction.
+type trackedTransactionResponse struct {
+	// The hex encoded transaction ID.
+	TransactionID string `json:"transactionId"`
+	// The current state of the transaction.
+	State txtracker.State `json:"state"`
+	// The hex encoded block IDs of the blocks that contain the transaction.
+	Attachments []string `json:"attachments"`
+	// The amount of promotions of the transaction.
+	Promotions int `json:"promotions"`
+	// The hex encoded block ID of the block that was included or conflicted.
+	ReferencedBlockID string `json:"referencedBlockId,omitempty"`
+	// The reason why the transaction conflicted.
+	ConflictReason *storage.Conflict `json:"conflictReason,omitempty"`
+	// The state transitions of the transaction.
+	History []*stateChangeResponse `json:"history"`
+	// The unix timestamp the transaction was registered at.
+	RegisteredAt int64 `json:"registeredAt"`
+}
+
+// trackedTransactionsResponse defines the response of a GET transactions REST API call.
+type trackedTransactionsResponse struct {
+	// The tracked transactions.
+	Transactions []*trackedTransactionResponse `json:"transactions"`
+}
@@ -25,8 +25,10 @@ const (
 	PrioritySnapshots
 	PriorityPruning
 	PriorityMetricsUpdater
+	PriorityIntegrityCheck
 	PriorityPoWHandler
-	PriorityRestAPI // depends on PriorityPoWHandler
+	PriorityRestAPI            // depends on PriorityPoWHandler
+	PriorityTransactionTracker // depends on PriorityPoWHandler
 	PriorityIndexer
 	PriorityStatusReport
 	PriorityPrometheus
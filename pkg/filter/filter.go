@@ -0,0 +1,280 @@
+package filter
+
+import (
+	"bytes"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// Filter selects the blocks and outputs a subscriber is interested in.
+// Different criteria are combined with AND, the values of a single criterion are combined with OR.
+// Criteria without values are ignored.
+type Filter struct {
+	// PayloadTypes are the types of the block payload.
+	PayloadTypes []iotago.PayloadType
+	// TagPrefixes are the prefixes of the tag of the tagged data payload of a block,
+	// or of the tag feature of an output.
+	TagPrefixes [][]byte
+	// OutputTypes are the types of an output.
+	OutputTypes []iotago.OutputType
+	// Addresses are the addresses that are able to unlock an output.
+	Addresses []iotago.Address
+	// NativeTokenIDs are the IDs of the native tokens an output holds.
+	NativeTokenIDs []iotago.NativeTokenID
+	// AliasIDs are the IDs of aliases that are either the output itself or are able to unlock it.
+	AliasIDs []iotago.AliasID
+	// NFTIDs are the IDs of NFTs that are either the output itself or are able to unlock it.
+	NFTIDs []iotago.NFTID
+}
+
+// IsEmpty returns whether the filter has no criteria and therefore matches everything.
+func (f *Filter) IsEmpty() bool {
+	return f == nil || (len(f.PayloadTypes) == 0 && len(f.TagPrefixes) == 0 && !f.hasOutputCriteria())
+}
+
+// hasOutputCriteria returns whether the filter has criteria that only apply to outputs.
+func (f *Filter) hasOutputCriteria() bool {
+	return len(f.OutputTypes) > 0 ||
+		len(f.Addresses) > 0 ||
+		len(f.NativeTokenIDs) > 0 ||
+		len(f.AliasIDs) > 0 ||
+		len(f.NFTIDs) > 0
+}
+
+// MatchBlock returns whether the block matches the filter.
+// The tag prefixes are matched against the tagged data payload of the block or of its transaction.
+// The output criteria are matched against the outputs created by the transaction of the block.
+func (f *Filter) MatchBlock(block *iotago.Block) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	var payloadType iotago.PayloadType
+	var hasPayload bool
+	if block.Payload != nil {
+		payloadType = block.Payload.PayloadType()
+		hasPayload = true
+	}
+
+	if len(f.PayloadTypes) > 0 && (!hasPayload || !containsPayloadType(f.PayloadTypes, payloadType)) {
+		return false
+	}
+
+	if len(f.TagPrefixes) > 0 && !f.matchTag(blockTag(block)) {
+		return false
+	}
+
+	if !f.hasOutputCriteria() {
+		return true
+	}
+
+	transaction, ok := block.Payload.(*iotago.Transaction)
+	if !ok || transaction.Essence == nil {
+		return false
+	}
+
+	transactionID, err := transaction.ID()
+	if err != nil {
+		return false
+	}
+
+	for index, output := range transaction.Essence.Outputs {
+		if f.matchOutputCriteria(iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(index)), output) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MatchOutput returns whether the output with the given ID matches the filter.
+// The payload types are ignored, and the tag prefixes are matched against the tag feature of the output.
+func (f *Filter) MatchOutput(outputID iotago.OutputID, output iotago.Output) bool {
+	if f.IsEmpty() {
+		return true
+	}
+
+	if len(f.TagPrefixes) > 0 && !f.matchTag(outputTag(output)) {
+		return false
+	}
+
+	return f.matchOutputCriteria(outputID, output)
+}
+
+func (f *Filter) matchOutputCriteria(outputID iotago.OutputID, output iotago.Output) bool {
+	if len(f.OutputTypes) > 0 && !containsOutputType(f.OutputTypes, output.Type()) {
+		return false
+	}
+
+	unlockAddresses := outputUnlockAddresses(output)
+
+	if len(f.Addresses) > 0 && !containsAnyAddress(unlockAddresses, f.Addresses) {
+		return false
+	}
+
+	if len(f.NativeTokenIDs) > 0 && !f.matchNativeTokens(output) {
+		return false
+	}
+
+	if len(f.AliasIDs) > 0 && !f.matchAlias(outputID, output, unlockAddresses) {
+		return false
+	}
+
+	if len(f.NFTIDs) > 0 && !f.matchNFT(outputID, output, unlockAddresses) {
+		return false
+	}
+
+	return true
+}
+
+func (f *Filter) matchTag(tag []byte) bool {
+	if tag == nil {
+		return false
+	}
+
+	for _, prefix := range f.TagPrefixes {
+		if bytes.HasPrefix(tag, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Filter) matchNativeTokens(output iotago.Output) bool {
+	for _, nativeToken := range output.NativeTokenList() {
+		for _, id := range f.NativeTokenIDs {
+			if nativeToken.ID == id {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *Filter) matchAlias(outputID iotago.OutputID, output iotago.Output, unlockAddresses []iotago.Address) bool {
+	var aliasID iotago.AliasID
+	if aliasOutput, ok := output.(*iotago.AliasOutput); ok {
+		aliasID = aliasOutput.AliasID
+		if aliasID.Empty() {
+			aliasID = iotago.AliasIDFromOutputID(outputID)
+		}
+	}
+
+	for _, id := range f.AliasIDs {
+		if aliasID == id || containsAddress(unlockAddresses, id.ToAddress()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *Filter) matchNFT(outputID iotago.OutputID, output iotago.Output, unlockAddresses []iotago.Address) bool {
+	var nftID iotago.NFTID
+	if nftOutput, ok := output.(*iotago.NFTOutput); ok {
+		nftID = nftOutput.NFTID
+		if nftID.Empty() {
+			nftID = iotago.NFTIDFromOutputID(outputID)
+		}
+	}
+
+	for _, id := range f.NFTIDs {
+		if nftID == id || containsAddress(unlockAddresses, id.ToAddress()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// blockTag returns the tag of the tagged data payload of the block or of its transaction.
+func blockTag(block *iotago.Block) []byte {
+	switch payload := block.Payload.(type) {
+	case *iotago.TaggedData:
+		return payload.Tag
+	case *iotago.Transaction:
+		if payload.Essence == nil {
+			return nil
+		}
+		if taggedData, ok := payload.Essence.Payload.(*iotago.TaggedData); ok {
+			return taggedData.Tag
+		}
+	}
+
+	return nil
+}
+
+// outputTag returns the tag of the tag feature of the output.
+func outputTag(output iotago.Output) []byte {
+	if tagFeature := output.FeatureSet().TagFeature(); tagFeature != nil {
+		return tagFeature.Tag
+	}
+
+	return nil
+}
+
+// outputUnlockAddresses returns all addresses that are able to unlock the output.
+func outputUnlockAddresses(output iotago.Output) []iotago.Address {
+	conditions := output.UnlockConditionSet()
+
+	addresses := make([]iotago.Address, 0, 2)
+	if condition := conditions.Address(); condition != nil {
+		addresses = append(addresses, condition.Address)
+	}
+	if condition := conditions.StateControllerAddress(); condition != nil {
+		addresses = append(addresses, condition.Address)
+	}
+	if condition := conditions.GovernorAddress(); condition != nil {
+		addresses = append(addresses, condition.Address)
+	}
+	if condition := conditions.ImmutableAlias(); condition != nil {
+		addresses = append(addresses, condition.Address)
+	}
+	if condition := conditions.Expiration(); condition != nil {
+		addresses = append(addresses, condition.ReturnAddress)
+	}
+
+	return addresses
+}
+
+func containsPayloadType(payloadTypes []iotago.PayloadType, payloadType iotago.PayloadType) bool {
+	for _, t := range payloadTypes {
+		if t == payloadType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsOutputType(outputTypes []iotago.OutputType, outputType iotago.OutputType) bool {
+	for _, t := range outputTypes {
+		if t == outputType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAddress(addresses []iotago.Address, address iotago.Address) bool {
+	for _, addr := range addresses {
+		if addr.Equal(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsAnyAddress(addresses []iotago.Address, candidates []iotago.Address) bool {
+	for _, candidate := range candidates {
+		if containsAddress(addresses, candidate) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,164 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package filter_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/filter"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestFilterMatchOutput(t *testing.T) {
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	otherAddress := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+	nftID := tpkg.RandNFTID()
+
+	var nativeTokenID iotago.NativeTokenID
+	copy(nativeTokenID[:], tpkg.RandBytes(iotago.NativeTokenIDLength))
+
+	basicOutput := &iotago.BasicOutput{
+		Amount:       1_000_000,
+		NativeTokens: iotago.NativeTokens{{ID: nativeTokenID, Amount: big.NewInt(1)}},
+		Conditions: iotago.UnlockConditions{
+			&iotago.AddressUnlockCondition{Address: address},
+			&iotago.ExpirationUnlockCondition{ReturnAddress: aliasID.ToAddress(), UnixTime: 1},
+		},
+		Features: iotago.Features{
+			&iotago.TagFeature{Tag: []byte("hornet-filter")},
+		},
+	}
+
+	nftOutputID := tpkg.RandOutputID()
+	nftOutput := &iotago.NFTOutput{
+		Amount: 1_000_000,
+		Conditions: iotago.UnlockConditions{
+			&iotago.AddressUnlockCondition{Address: otherAddress},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   *filter.Filter
+		outputID iotago.OutputID
+		output   iotago.Output
+		match    bool
+	}{
+		{"nil filter", nil, tpkg.RandOutputID(), basicOutput, true},
+		{"empty filter", &filter.Filter{}, tpkg.RandOutputID(), basicOutput, true},
+		{"payload types are ignored", &filter.Filter{PayloadTypes: []iotago.PayloadType{iotago.PayloadMilestone}}, tpkg.RandOutputID(), basicOutput, true},
+		{"output type", &filter.Filter{OutputTypes: []iotago.OutputType{iotago.OutputAlias, iotago.OutputBasic}}, tpkg.RandOutputID(), basicOutput, true},
+		{"wrong output type", &filter.Filter{OutputTypes: []iotago.OutputType{iotago.OutputNFT}}, tpkg.RandOutputID(), basicOutput, false},
+		{"address", &filter.Filter{Addresses: []iotago.Address{address}}, tpkg.RandOutputID(), basicOutput, true},
+		{"wrong address", &filter.Filter{Addresses: []iotago.Address{otherAddress}}, tpkg.RandOutputID(), basicOutput, false},
+		{"expiration return address", &filter.Filter{Addresses: []iotago.Address{aliasID.ToAddress()}}, tpkg.RandOutputID(), basicOutput, true},
+		{"tag prefix", &filter.Filter{TagPrefixes: [][]byte{[]byte("hornet")}}, tpkg.RandOutputID(), basicOutput, true},
+		{"wrong tag prefix", &filter.Filter{TagPrefixes: [][]byte{[]byte("bee")}}, tpkg.RandOutputID(), basicOutput, false},
+		{"native token", &filter.Filter{NativeTokenIDs: []iotago.NativeTokenID{nativeTokenID}}, tpkg.RandOutputID(), basicOutput, true},
+		{"wrong native token", &filter.Filter{NativeTokenIDs: []iotago.NativeTokenID{{}}}, tpkg.RandOutputID(), basicOutput, false},
+		{"unlockable by alias", &filter.Filter{AliasIDs: []iotago.AliasID{aliasID}}, tpkg.RandOutputID(), basicOutput, true},
+		{"criteria are combined", &filter.Filter{Addresses: []iotago.Address{address}, OutputTypes: []iotago.OutputType{iotago.OutputNFT}}, tpkg.RandOutputID(), basicOutput, false},
+		{"NFT", &filter.Filter{NFTIDs: []iotago.NFTID{nftID}}, tpkg.RandOutputID(), &iotago.NFTOutput{NFTID: nftID}, true},
+		{"new NFT", &filter.Filter{NFTIDs: []iotago.NFTID{iotago.NFTIDFromOutputID(nftOutputID)}}, nftOutputID, nftOutput, true},
+		{"wrong NFT", &filter.Filter{NFTIDs: []iotago.NFTID{nftID}}, nftOutputID, nftOutput, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.match, test.filter.MatchOutput(test.outputID, test.output))
+		})
+	}
+}
+
+func TestFilterMatchBlock(t *testing.T) {
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+
+	taggedDataBlock := &iotago.Block{
+		Payload: &iotago.TaggedData{Tag: []byte("hornet-filter")},
+	}
+
+	transactionBlock := &iotago.Block{
+		Payload: &iotago.Transaction{
+			Essence: &iotago.TransactionEssence{
+				Outputs: iotago.Outputs{
+					&iotago.BasicOutput{
+						Amount:     1_000_000,
+						Conditions: iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: address}},
+					},
+				},
+				Payload: &iotago.TaggedData{Tag: []byte("hornet-tx")},
+			},
+		},
+	}
+
+	emptyBlock := &iotago.Block{}
+
+	// payload type
+	f := &filter.Filter{PayloadTypes: []iotago.PayloadType{iotago.PayloadTaggedData}}
+	require.True(t, f.MatchBlock(taggedDataBlock))
+	require.False(t, f.MatchBlock(transactionBlock))
+	require.False(t, f.MatchBlock(emptyBlock))
+
+	// tag prefix of the payload or the transaction payload
+	f = &filter.Filter{TagPrefixes: [][]byte{[]byte("hornet")}}
+	require.True(t, f.MatchBlock(taggedDataBlock))
+	require.True(t, f.MatchBlock(transactionBlock))
+	require.False(t, f.MatchBlock(emptyBlock))
+
+	f = &filter.Filter{TagPrefixes: [][]byte{[]byte("hornet-tx")}}
+	require.False(t, f.MatchBlock(taggedDataBlock))
+	require.True(t, f.MatchBlock(transactionBlock))
+
+	// created outputs of the transaction
+	f = &filter.Filter{Addresses: []iotago.Address{address}}
+	require.False(t, f.MatchBlock(taggedDataBlock))
+	require.True(t, f.MatchBlock(transactionBlock))
+
+	f = &filter.Filter{Addresses: []iotago.Address{tpkg.RandAddress(iotago.AddressEd25519)}}
+	require.False(t, f.MatchBlock(transactionBlock))
+
+	require.True(t, (&filter.Filter{}).MatchBlock(emptyBlock))
+}
+
+func TestParse(t *testing.T) {
+	payloadType, err := filter.ParsePayloadType("taggedData")
+	require.NoError(t, err)
+	require.Equal(t, iotago.PayloadTaggedData, payloadType)
+
+	payloadType, err = filter.ParsePayloadType("6")
+	require.NoError(t, err)
+	require.Equal(t, iotago.PayloadTransaction, payloadType)
+
+	_, err = filter.ParsePayloadType("unknown")
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+
+	outputType, err := filter.ParseOutputType("nft")
+	require.NoError(t, err)
+	require.Equal(t, iotago.OutputNFT, outputType)
+
+	_, err = filter.ParseOutputType("256")
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+
+	prefix, err := filter.ParseTagPrefix("0x686f726e6574")
+	require.NoError(t, err)
+	require.Equal(t, []byte("hornet"), prefix)
+
+	nftID := tpkg.RandNFTID()
+	parsedNFTID, err := filter.ParseNFTID(nftID.ToHex())
+	require.NoError(t, err)
+	require.Equal(t, nftID, parsedNFTID)
+
+	_, err = filter.ParseAliasID("0x1234")
+	require.ErrorIs(t, err, filter.ErrInvalidFilter)
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	parsedAddress, err := filter.ParseAddress(address.Bech32(iotago.PrefixTestnet))
+	require.NoError(t, err)
+	require.True(t, address.Equal(parsedAddress))
+
+	require.Equal(t, []string{"basic", "nft", "alias"}, filter.SplitValues([]string{"basic, nft", "", "alias,"}))
+}
@@ -0,0 +1,139 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrInvalidFilter is returned if a filter criterion can't be parsed.
+	ErrInvalidFilter = errors.New("invalid filter")
+)
+
+var (
+	payloadTypesByName = map[string]iotago.PayloadType{
+		"treasuryTransaction": iotago.PayloadTreasuryTransaction,
+		"taggedData":          iotago.PayloadTaggedData,
+		"transaction":         iotago.PayloadTransaction,
+		"milestone":           iotago.PayloadMilestone,
+	}
+
+	outputTypesByName = map[string]iotago.OutputType{
+		"treasury": iotago.OutputTreasury,
+		"basic":    iotago.OutputBasic,
+		"alias":    iotago.OutputAlias,
+		"foundry":  iotago.OutputFoundry,
+		"nft":      iotago.OutputNFT,
+	}
+)
+
+// ParsePayloadType parses a payload type given by its name (e.g. "taggedData") or its number.
+func ParsePayloadType(s string) (iotago.PayloadType, error) {
+	if payloadType, exists := payloadTypesByName[s]; exists {
+		return payloadType, nil
+	}
+
+	value, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, errors.WithMessagef(ErrInvalidFilter, "unknown payload type: %s", s)
+	}
+
+	return iotago.PayloadType(value), nil
+}
+
+// ParseOutputType parses an output type given by its name (e.g. "basic") or its number.
+func ParseOutputType(s string) (iotago.OutputType, error) {
+	if outputType, exists := outputTypesByName[s]; exists {
+		return outputType, nil
+	}
+
+	value, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, errors.WithMessagef(ErrInvalidFilter, "unknown output type: %s", s)
+	}
+
+	return iotago.OutputType(value), nil
+}
+
+// ParseTagPrefix parses a hex encoded tag prefix.
+func ParseTagPrefix(s string) ([]byte, error) {
+	prefix, err := iotago.DecodeHex(s)
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidFilter, "invalid tag prefix %s: %s", s, err)
+	}
+
+	return prefix, nil
+}
+
+// ParseAddress parses a bech32 encoded address.
+func ParseAddress(s string) (iotago.Address, error) {
+	_, address, err := iotago.ParseBech32(s)
+	if err != nil {
+		return nil, errors.WithMessagef(ErrInvalidFilter, "invalid address %s: %s", s, err)
+	}
+
+	return address, nil
+}
+
+// ParseNativeTokenID parses a hex encoded native token ID.
+func ParseNativeTokenID(s string) (iotago.NativeTokenID, error) {
+	var id iotago.NativeTokenID
+	if err := decodeFixedHex(s, id[:]); err != nil {
+		return id, errors.WithMessagef(ErrInvalidFilter, "invalid native token ID %s: %s", s, err)
+	}
+
+	return id, nil
+}
+
+// ParseAliasID parses a hex encoded alias ID.
+func ParseAliasID(s string) (iotago.AliasID, error) {
+	var id iotago.AliasID
+	if err := decodeFixedHex(s, id[:]); err != nil {
+		return id, errors.WithMessagef(ErrInvalidFilter, "invalid alias ID %s: %s", s, err)
+	}
+
+	return id, nil
+}
+
+// ParseNFTID parses a hex encoded NFT ID.
+func ParseNFTID(s string) (iotago.NFTID, error) {
+	var id iotago.NFTID
+	if err := decodeFixedHex(s, id[:]); err != nil {
+		return id, errors.WithMessagef(ErrInvalidFilter, "invalid NFT ID %s: %s", s, err)
+	}
+
+	return id, nil
+}
+
+// SplitValues splits comma separated values and drops empty ones.
+func SplitValues(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+
+	return result
+}
+
+func decodeFixedHex(s string, target []byte) error {
+	data, err := iotago.DecodeHex(s)
+	if err != nil {
+		return err
+	}
+
+	if len(data) != len(target) {
+		return fmt.Errorf("expected %d bytes, got %d", len(target), len(data))
+	}
+	copy(target, data)
+
+	return nil
+}
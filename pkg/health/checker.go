@@ -0,0 +1,152 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CheckFunc is a health check. It returns an error describing the problem if the check failed.
+type CheckFunc func() error
+
+// CheckResult is the result of a single health check.
+type CheckResult struct {
+	// The name of the health check.
+	Name string `json:"name"`
+	// Whether the health check passed.
+	Healthy bool `json:"healthy"`
+	// The reason why the health check failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Report is the result of the evaluation of several health checks.
+type Report struct {
+	// Whether all health checks passed.
+	Healthy bool `json:"healthy"`
+	// The results of the single health checks.
+	Checks []*CheckResult `json:"checks"`
+}
+
+// Checker holds named health checks that can be registered by different components.
+type Checker struct {
+	checksLock sync.RWMutex
+	checks     map[string]CheckFunc
+	// readinessChecks are the names of the checks that are always part of the readiness of the node.
+	readinessChecks []string
+}
+
+// NewChecker creates a new Checker.
+func NewChecker() *Checker {
+	return &Checker{
+		checks: make(map[string]CheckFunc),
+	}
+}
+
+// Register registers a health check with the given name.
+// An already registered check with the same name is replaced.
+func (c *Checker) Register(name string, check CheckFunc) {
+	c.checksLock.Lock()
+	defer c.checksLock.Unlock()
+
+	c.checks[name] = check
+}
+
+// RegisterReadiness registers a health check with the given name,
+// that is always part of the readiness of the node, independent of the configured checks.
+func (c *Checker) RegisterReadiness(name string, check CheckFunc) {
+	c.checksLock.Lock()
+	defer c.checksLock.Unlock()
+
+	c.checks[name] = check
+	for _, readinessCheck := range c.readinessChecks {
+		if readinessCheck == name {
+			return
+		}
+	}
+	c.readinessChecks = append(c.readinessChecks, name)
+}
+
+// WithReadinessChecks returns the given names extended by the names of the checks
+// that were registered with RegisterReadiness and are not part of the given names yet.
+func (c *Checker) WithReadinessChecks(names []string) []string {
+	c.checksLock.RLock()
+	defer c.checksLock.RUnlock()
+
+	result := append(make([]string, 0, len(names)+len(c.readinessChecks)), names...)
+	for _, readinessCheck := range c.readinessChecks {
+		found := false
+		for _, name := range names {
+			if name == readinessCheck {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			result = append(result, readinessCheck)
+		}
+	}
+
+	return result
+}
+
+// Registered returns whether a health check with the given name is registered.
+func (c *Checker) Registered(name string) bool {
+	c.checksLock.RLock()
+	defer c.checksLock.RUnlock()
+
+	_, exists := c.checks[name]
+
+	return exists
+}
+
+// Names returns the sorted names of all registered health checks.
+func (c *Checker) Names() []string {
+	c.checksLock.RLock()
+	defer c.checksLock.RUnlock()
+
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Evaluate runs the health checks with the given names.
+// Checks that are not registered are reported as failed.
+func (c *Checker) Evaluate(names []string) *Report {
+	c.checksLock.RLock()
+	defer c.checksLock.RUnlock()
+
+	report := &Report{
+		Healthy: true,
+		Checks:  make([]*CheckResult, 0, len(names)),
+	}
+
+	for _, name := range names {
+		result := &CheckResult{
+			Name:    name,
+			Healthy: true,
+		}
+
+		check, exists := c.checks[name]
+		if !exists {
+			result.Healthy = false
+			result.Error = fmt.Sprintf("health check %s is not registered", name)
+		} else if err := check(); err != nil {
+			result.Healthy = false
+			result.Error = err.Error()
+		}
+
+		if !result.Healthy {
+			report.Healthy = false
+		}
+
+		report.Checks = append(report.Checks, result)
+	}
+
+	return report
+}
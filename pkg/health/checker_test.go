@@ -0,0 +1,67 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package health_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/health"
+)
+
+func TestChecker(t *testing.T) {
+	checker := health.NewChecker()
+
+	synced := false
+	checker.Register("responsive", func() error { return nil })
+	checker.Register("synced", func() error {
+		if !synced {
+			return errors.New("node is not synced")
+		}
+
+		return nil
+	})
+
+	require.True(t, checker.Registered("synced"))
+	require.False(t, checker.Registered("unknown"))
+	require.Equal(t, []string{"responsive", "synced"}, checker.Names())
+
+	report := checker.Evaluate([]string{"responsive"})
+	require.True(t, report.Healthy)
+	require.Len(t, report.Checks, 1)
+
+	report = checker.Evaluate([]string{"responsive", "synced"})
+	require.False(t, report.Healthy)
+	require.Len(t, report.Checks, 2)
+	require.True(t, report.Checks[0].Healthy)
+	require.False(t, report.Checks[1].Healthy)
+	require.Equal(t, "node is not synced", report.Checks[1].Error)
+
+	synced = true
+	report = checker.Evaluate([]string{"responsive", "synced"})
+	require.True(t, report.Healthy)
+
+	// unknown checks fail
+	report = checker.Evaluate([]string{"unknown"})
+	require.False(t, report.Healthy)
+	require.NotEmpty(t, report.Checks[0].Error)
+
+	// no checks means healthy
+	require.True(t, checker.Evaluate(nil).Healthy)
+}
+
+func TestCheckerReadiness(t *testing.T) {
+	checker := health.NewChecker()
+
+	checker.Register("synced", func() error { return nil })
+	require.Equal(t, []string{"synced"}, checker.WithReadinessChecks([]string{"synced"}))
+
+	checker.RegisterReadiness("extensions", func() error { return errors.New("extension missing") })
+	require.Equal(t, []string{"synced", "extensions"}, checker.WithReadinessChecks([]string{"synced"}))
+	require.Equal(t, []string{"extensions", "synced"}, checker.WithReadinessChecks([]string{"extensions", "synced"}))
+
+	report := checker.Evaluate(checker.WithReadinessChecks([]string{"synced"}))
+	require.False(t, report.Healthy)
+	require.Equal(t, "extension missing", report.Checks[1].Error)
+}
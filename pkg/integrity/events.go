@@ -0,0 +1,24 @@
+package integrity
+
+import (
+	"github.com/iotaledger/hive.go/runtime/event"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+type Events struct {
+	// CheckStarted is triggered with the ledger index the integrity check is performed at,
+	// before the ledger state is computed.
+	CheckStarted *event.Event1[iotago.MilestoneIndex]
+	// CheckDone is triggered after every finished integrity check.
+	CheckDone *event.Event1[*CheckResult]
+	// DivergenceDetected is triggered for every divergence found during an integrity check.
+	DivergenceDetected *event.Event1[*Divergence]
+}
+
+func newEvents() *Events {
+	return &Events{
+		CheckStarted:       event.New1[iotago.MilestoneIndex](),
+		CheckDone:          event.New1[*CheckResult](),
+		DivergenceDetected: event.New1[*Divergence](),
+	}
+}
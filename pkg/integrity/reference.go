@@ -0,0 +1,130 @@
+package integrity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// ReferenceHashes contains hex encoded ledger state hashes and milestone diff hashes by milestone index.
+// The same format is used for reference files, for the hashes published by peers and for the hashes published by this node.
+type ReferenceHashes struct {
+	// The ledger state hashes (without solid entry points) by ledger index.
+	LedgerStateHashes map[iotago.MilestoneIndex]string `json:"ledgerStateHashes"`
+	// The milestone diff hashes by milestone index.
+	MilestoneDiffHashes map[iotago.MilestoneIndex]string `json:"milestoneDiffHashes"`
+}
+
+// NewReferenceHashes creates a new empty ReferenceHashes.
+func NewReferenceHashes() *ReferenceHashes {
+	return &ReferenceHashes{
+		LedgerStateHashes:   make(map[iotago.MilestoneIndex]string),
+		MilestoneDiffHashes: make(map[iotago.MilestoneIndex]string),
+	}
+}
+
+func (r *ReferenceHashes) copy() *ReferenceHashes {
+	c := NewReferenceHashes()
+	for index, hash := range r.LedgerStateHashes {
+		c.LedgerStateHashes[index] = hash
+	}
+	for index, hash := range r.MilestoneDiffHashes {
+		c.MilestoneDiffHashes[index] = hash
+	}
+
+	return c
+}
+
+// ReferenceSource provides hashes the locally computed hashes are compared against.
+type ReferenceSource interface {
+	// Name returns a human readable name of the source.
+	Name() string
+	// ReferenceHashes returns the hashes of the source.
+	ReferenceHashes(ctx context.Context) (*ReferenceHashes, error)
+}
+
+// FileReferenceSource reads the reference hashes from a JSON file.
+type FileReferenceSource struct {
+	filePath string
+}
+
+// NewFileReferenceSource creates a new FileReferenceSource.
+func NewFileReferenceSource(filePath string) *FileReferenceSource {
+	return &FileReferenceSource{filePath: filePath}
+}
+
+// Name returns a human readable name of the source.
+func (s *FileReferenceSource) Name() string {
+	return fmt.Sprintf("file %s", s.filePath)
+}
+
+// ReferenceHashes reads the hashes from the file.
+// The file is read on every call, so it can be updated while the node is running.
+func (s *FileReferenceSource) ReferenceHashes(_ context.Context) (*ReferenceHashes, error) {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read reference file: %w", err)
+	}
+
+	hashes := NewReferenceHashes()
+	if err := json.Unmarshal(data, hashes); err != nil {
+		return nil, fmt.Errorf("unable to parse reference file: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// HTTPReferenceSource fetches the reference hashes published by a peer via HTTP.
+type HTTPReferenceSource struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPReferenceSource creates a new HTTPReferenceSource.
+func NewHTTPReferenceSource(url string, client *http.Client) *HTTPReferenceSource {
+	return &HTTPReferenceSource{
+		url:    url,
+		client: client,
+	}
+}
+
+// Name returns a human readable name of the source.
+func (s *HTTPReferenceSource) Name() string {
+	return s.url
+}
+
+// ReferenceHashes fetches the hashes from the peer.
+func (s *HTTPReferenceSource) ReferenceHashes(ctx context.Context) (*ReferenceHashes, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reference hashes: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch reference hashes, server returned status code %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read reference hashes: %w", err)
+	}
+
+	hashes := NewReferenceHashes()
+	if err := json.Unmarshal(data, hashes); err != nil {
+		return nil, fmt.Errorf("unable to parse reference hashes: %w", err)
+	}
+
+	return hashes, nil
+}
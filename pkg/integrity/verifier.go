@@ -0,0 +1,422 @@
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/atomic"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// the maximum amount of own hashes per kind that are kept to be published to peers.
+	maxPublishedHashes = 100
+)
+
+// DivergenceKind is the kind of check that detected a divergence.
+type DivergenceKind string
+
+const (
+	// DivergenceTokenSupply means the sum of the ledger doesn't match the token supply.
+	DivergenceTokenSupply DivergenceKind = "tokenSupply"
+	// DivergenceLedgerStateHash means the ledger state hash doesn't match the reference.
+	DivergenceLedgerStateHash DivergenceKind = "ledgerStateHash"
+	// DivergenceMilestoneDiffHash means the hash of a milestone diff doesn't match the reference.
+	DivergenceMilestoneDiffHash DivergenceKind = "milestoneDiffHash"
+)
+
+// Divergence is a mismatch between the local ledger and the expected state.
+type Divergence struct {
+	// The kind of check that detected the divergence.
+	Kind DivergenceKind
+	// The milestone index the divergence was detected at.
+	MilestoneIndex iotago.MilestoneIndex
+	// The source of the expected value.
+	Source string
+	// The expected value.
+	Expected string
+	// The locally computed value.
+	Actual string
+}
+
+func (d *Divergence) String() string {
+	return fmt.Sprintf("%s divergence at milestone %d: expected %s (%s), got %s", d.Kind, d.MilestoneIndex, d.Expected, d.Source, d.Actual)
+}
+
+// SourceError is an error that occurred while fetching the hashes of a reference source.
+type SourceError struct {
+	// The name of the reference source.
+	Source string
+	// The error that occurred.
+	Err error
+}
+
+// CheckResult is the result of an integrity check.
+type CheckResult struct {
+	// The time the check was started.
+	Timestamp time.Time
+	// The duration of the check.
+	Duration time.Duration
+	// The ledger index the check was performed at.
+	LedgerIndex iotago.MilestoneIndex
+	// The ledger state hash (without solid entry points).
+	LedgerStateHash string
+	// The sum of the deposits of all unspent outputs and the treasury.
+	LedgerTokenSupply uint64
+	// The amount of milestone diffs whose hashes were computed.
+	MilestoneDiffsChecked int
+	// The amount of hashes that were compared against a reference.
+	HashesCompared int
+	// The errors of reference sources that could not be fetched.
+	SourceErrors []*SourceError
+	// The detected divergences.
+	Divergences []*Divergence
+}
+
+// Healthy tells whether no divergences were detected.
+func (r *CheckResult) Healthy() bool {
+	return len(r.Divergences) == 0
+}
+
+// Verifier periodically checks the integrity of the ledger state.
+// It recomputes the ledger state hash, checks the ledger balance against the token supply
+// and computes the hashes of a sample of milestone diffs. The hashes are compared
+// against the hashes of the reference sources, e.g. a file or hashes published by peers.
+type Verifier struct {
+	// the logger used to log events.
+	*logger.WrappedLogger
+
+	storage            *storage.Storage
+	utxoManager        *utxo.Manager
+	referenceSources   []ReferenceSource
+	diffSampleSize     int
+	lastCheckResultPtr atomic.Pointer[CheckResult]
+	healthy            atomic.Bool
+	checks             atomic.Uint64
+	divergences        atomic.Uint64
+
+	publishedHashesLock syncutils.RWMutex
+	publishedHashes     *ReferenceHashes
+
+	Events *Events
+}
+
+// New creates a new Verifier.
+func New(log *logger.Logger, storage *storage.Storage, referenceSources []ReferenceSource, diffSampleSize int) *Verifier {
+	v := &Verifier{
+		WrappedLogger:    logger.NewWrappedLogger(log),
+		storage:          storage,
+		utxoManager:      storage.UTXOManager(),
+		referenceSources: referenceSources,
+		diffSampleSize:   diffSampleSize,
+		publishedHashes:  NewReferenceHashes(),
+		Events:           newEvents(),
+	}
+	v.healthy.Store(true)
+
+	return v
+}
+
+// Healthy tells whether the last integrity check found no divergences.
+// The verifier is considered healthy as long as no check was performed.
+func (v *Verifier) Healthy() bool {
+	return v.healthy.Load()
+}
+
+// LastCheckResult returns the result of the last finished integrity check, or nil if no check was finished yet.
+func (v *Verifier) LastCheckResult() *CheckResult {
+	return v.lastCheckResultPtr.Load()
+}
+
+// ChecksTotal returns the amount of finished integrity checks.
+func (v *Verifier) ChecksTotal() uint64 {
+	return v.checks.Load()
+}
+
+// DivergencesTotal returns the amount of detected divergences.
+func (v *Verifier) DivergencesTotal() uint64 {
+	return v.divergences.Load()
+}
+
+// PublishedHashes returns the hashes computed by this node, so peers can use them as a reference.
+func (v *Verifier) PublishedHashes() *ReferenceHashes {
+	v.publishedHashesLock.RLock()
+	defer v.publishedHashesLock.RUnlock()
+
+	return v.publishedHashes.copy()
+}
+
+func (v *Verifier) publishHash(hashes map[iotago.MilestoneIndex]string, index iotago.MilestoneIndex, hash string) {
+	hashes[index] = hash
+
+	if len(hashes) <= maxPublishedHashes {
+		return
+	}
+
+	// remove the oldest hashes
+	indexes := make([]iotago.MilestoneIndex, 0, len(hashes))
+	for index := range hashes {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	for _, index := range indexes[:len(indexes)-maxPublishedHashes] {
+		delete(hashes, index)
+	}
+}
+
+// computeLedgerState computes the ledger state hash at the given ledger index.
+// The ledger is not locked during the computation, so milestones can be confirmed in the meantime.
+func (v *Verifier) computeLedgerState(ledgerIndex iotago.MilestoneIndex) (*storage.LedgerStateHash, error) {
+	ledgerStateHash, err := v.storage.ComputeLedgerStateHashAtIndex(ledgerIndex)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute ledger state hash: %w", err)
+	}
+
+	return ledgerStateHash, nil
+}
+
+// computeLedgerBalance computes the sum of the deposits of all unspent outputs and the treasury.
+// The ledger is not locked during the computation. If a milestone was confirmed in the meantime,
+// the balance may contain a partially applied milestone, so false is returned and the balance is not checked.
+func (v *Verifier) computeLedgerBalance() (uint64, iotago.MilestoneIndex, bool, error) {
+	ledgerIndexBefore, err := v.utxoManager.ReadLedgerIndex()
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	balance, _, err := v.utxoManager.ComputeLedgerBalance(utxo.ReadLockLedger(false))
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("unable to compute ledger balance: %w", err)
+	}
+
+	treasuryOutput, err := v.utxoManager.UnspentTreasuryOutputWithoutLocking()
+	if err != nil {
+		return 0, 0, false, fmt.Errorf("unable to get unspent treasury output: %w", err)
+	}
+	if treasuryOutput != nil {
+		balance += treasuryOutput.Amount
+	}
+
+	ledgerIndexAfter, err := v.utxoManager.ReadLedgerIndex()
+	if err != nil {
+		return 0, 0, false, err
+	}
+
+	return balance, ledgerIndexBefore, ledgerIndexBefore == ledgerIndexAfter, nil
+}
+
+// fetchReferenceHashes fetches the hashes of all reference sources.
+func (v *Verifier) fetchReferenceHashes(ctx context.Context, result *CheckResult) map[string]*ReferenceHashes {
+	references := make(map[string]*ReferenceHashes, len(v.referenceSources))
+	for _, source := range v.referenceSources {
+		hashes, err := source.ReferenceHashes(ctx)
+		if err != nil {
+			v.LogWarnf("fetching reference hashes from %s failed: %s", source.Name(), err)
+			result.SourceErrors = append(result.SourceErrors, &SourceError{Source: source.Name(), Err: err})
+
+			continue
+		}
+		references[source.Name()] = hashes
+	}
+
+	return references
+}
+
+// sampleMilestoneDiffIndexes selects the milestone diffs whose hashes are computed.
+// Indexes that are known by the reference sources are preferred, the rest is chosen randomly.
+func (v *Verifier) sampleMilestoneDiffIndexes(startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex, references map[string]*ReferenceHashes) []iotago.MilestoneIndex {
+	if v.diffSampleSize <= 0 || endIndex < startIndex {
+		return nil
+	}
+
+	selected := make(map[iotago.MilestoneIndex]struct{})
+
+	var referenceIndexes []iotago.MilestoneIndex
+	for _, hashes := range references {
+		for index := range hashes.MilestoneDiffHashes {
+			if index >= startIndex && index <= endIndex {
+				referenceIndexes = append(referenceIndexes, index)
+			}
+		}
+	}
+
+	//nolint:gosec // we don't care about weak random numbers here
+	rand.Shuffle(len(referenceIndexes), func(i, j int) {
+		referenceIndexes[i], referenceIndexes[j] = referenceIndexes[j], referenceIndexes[i]
+	})
+
+	for _, index := range referenceIndexes {
+		if len(selected) >= v.diffSampleSize {
+			break
+		}
+		selected[index] = struct{}{}
+	}
+
+	rangeSize := int(endIndex-startIndex) + 1
+	for len(selected) < v.diffSampleSize && len(selected) < rangeSize {
+		//nolint:gosec // we don't care about weak random numbers here
+		selected[startIndex+iotago.MilestoneIndex(rand.Intn(rangeSize))] = struct{}{}
+	}
+
+	indexes := make([]iotago.MilestoneIndex, 0, len(selected))
+	for index := range selected {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+
+	return indexes
+}
+
+// Check performs an integrity check of the ledger state.
+// An error is returned if the check could not be performed, detected divergences are part of the result.
+func (v *Verifier) Check(ctx context.Context) (*CheckResult, error) {
+	result := &CheckResult{
+		Timestamp: time.Now(),
+	}
+
+	addDivergence := func(kind DivergenceKind, index iotago.MilestoneIndex, source string, expected string, actual string) {
+		result.Divergences = append(result.Divergences, &Divergence{
+			Kind:           kind,
+			MilestoneIndex: index,
+			Source:         source,
+			Expected:       expected,
+			Actual:         actual,
+		})
+	}
+
+	ledgerIndex, err := v.utxoManager.ReadLedgerIndex()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ledger index: %w", err)
+	}
+	v.Events.CheckStarted.Trigger(ledgerIndex)
+
+	ledgerStateHash, err := v.computeLedgerState(ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	result.LedgerIndex = ledgerStateHash.LedgerIndex
+	result.LedgerStateHash = iotago.EncodeHex(ledgerStateHash.Hash)
+	result.LedgerTokenSupply = ledgerStateHash.LedgerTokenSupply
+
+	protoParams, err := v.storage.ProtocolParameters(ledgerStateHash.LedgerIndex)
+	if err != nil {
+		return nil, fmt.Errorf("loading protocol parameters failed: %w", err)
+	}
+
+	if ledgerStateHash.LedgerTokenSupply != protoParams.TokenSupply {
+		addDivergence(DivergenceTokenSupply, ledgerStateHash.LedgerIndex, "protocol parameters", fmt.Sprintf("%d", protoParams.TokenSupply), fmt.Sprintf("%d", ledgerStateHash.LedgerTokenSupply))
+	}
+
+	ledgerBalance, ledgerBalanceIndex, balanceConsistent, err := v.computeLedgerBalance()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case !balanceConsistent:
+		v.LogDebug("skipping ledger balance check, a milestone was confirmed during the computation")
+	case ledgerBalance != protoParams.TokenSupply:
+		addDivergence(DivergenceTokenSupply, ledgerBalanceIndex, "ledger balance", fmt.Sprintf("%d", protoParams.TokenSupply), fmt.Sprintf("%d", ledgerBalance))
+	}
+
+	v.publishedHashesLock.Lock()
+	v.publishHash(v.publishedHashes.LedgerStateHashes, ledgerStateHash.LedgerIndex, result.LedgerStateHash)
+	v.publishedHashesLock.Unlock()
+
+	references := v.fetchReferenceHashes(ctx, result)
+
+	// compute the hashes of a sample of the milestone diffs that were not pruned yet
+	diffHashes := make(map[iotago.MilestoneIndex]string)
+	for _, index := range v.sampleMilestoneDiffIndexes(v.storage.SnapshotInfo().PruningIndex()+1, ledgerStateHash.LedgerIndex, references) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		msDiff, err := v.utxoManager.MilestoneDiff(index)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the diff may have been pruned in the meantime
+				continue
+			}
+
+			return nil, fmt.Errorf("loading milestone diff %d failed: %w", index, err)
+		}
+
+		hash, err := msDiff.SHA256Sum()
+		if err != nil {
+			return nil, fmt.Errorf("computing hash of milestone diff %d failed: %w", index, err)
+		}
+		diffHashes[index] = iotago.EncodeHex(hash)
+	}
+	result.MilestoneDiffsChecked = len(diffHashes)
+
+	v.publishedHashesLock.Lock()
+	for index, hash := range diffHashes {
+		v.publishHash(v.publishedHashes.MilestoneDiffHashes, index, hash)
+	}
+	ownLedgerStateHashes := v.publishedHashes.copy().LedgerStateHashes
+	v.publishedHashesLock.Unlock()
+
+	// compare the hashes against the references
+	sourceNames := make([]string, 0, len(references))
+	for name := range references {
+		sourceNames = append(sourceNames, name)
+	}
+	sort.Strings(sourceNames)
+
+	for _, name := range sourceNames {
+		hashes := references[name]
+
+		// the ledger state hash can only be compared at the same ledger index,
+		// so the hashes of former checks are compared as well.
+		for index, hash := range ownLedgerStateHashes {
+			expected, has := hashes.LedgerStateHashes[index]
+			if !has {
+				continue
+			}
+			result.HashesCompared++
+
+			if expected != hash {
+				addDivergence(DivergenceLedgerStateHash, index, name, expected, hash)
+			}
+		}
+
+		for index, hash := range diffHashes {
+			expected, has := hashes.MilestoneDiffHashes[index]
+			if !has {
+				continue
+			}
+			result.HashesCompared++
+
+			if expected != hash {
+				addDivergence(DivergenceMilestoneDiffHash, index, name, expected, hash)
+			}
+		}
+	}
+
+	result.Duration = time.Since(result.Timestamp)
+
+	v.lastCheckResultPtr.Store(result)
+	v.healthy.Store(result.Healthy())
+	v.checks.Inc()
+	v.divergences.Add(uint64(len(result.Divergences)))
+
+	for _, divergence := range result.Divergences {
+		v.Events.DivergenceDetected.Trigger(divergence)
+	}
+	v.Events.CheckDone.Trigger(result)
+
+	return result, nil
+}
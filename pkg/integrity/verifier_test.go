@@ -0,0 +1,176 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package integrity_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/integrity"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	ProtocolVersion = 2
+	MinPoWScore     = 1
+	BelowMaxDepth   = 15
+)
+
+var (
+	seed1, _ = hex.DecodeString("96d9ff7a79e4b0a5f3e5848ae7867064402da92a62eabb4ebbe463f12d1f3b1aace1775488f51cb1e3a80732a03ef60b111d6833ab605aa9f8faebeb33bbe3d9")
+	seed2, _ = hex.DecodeString("b15209ddc93cbdb600137ea6a8f88cdd7c5d480d5815c9352a0fb5c4e4b86f7151dcb44c2ba635657a2df5a8fd48cb9bab674a9eceea527dbbb254ef8c9f9cd7")
+)
+
+func writeReferenceFile(t *testing.T, filePath string, hashes *integrity.ReferenceHashes) {
+	data, err := json.Marshal(hashes)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filePath, data, 0o600))
+}
+
+func TestVerifier(t *testing.T) {
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(3_000_000).
+		BuildTransactionToWallet(seed2Wallet).
+		Store().
+		BookOnWallets()
+
+	te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockA.StoredBlockID()}, false)
+
+	referenceFilePath := filepath.Join(t.TempDir(), "reference.json")
+	verifier := integrity.New(nil, te.Storage(), []integrity.ReferenceSource{integrity.NewFileReferenceSource(referenceFilePath)}, 10)
+
+	var divergences []*integrity.Divergence
+	verifier.Events.DivergenceDetected.Hook(func(divergence *integrity.Divergence) {
+		divergences = append(divergences, divergence)
+	})
+
+	// the reference file doesn't exist yet
+	result, err := verifier.Check(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.Healthy())
+	require.True(t, verifier.Healthy())
+	require.Len(t, result.SourceErrors, 1)
+	require.Zero(t, result.HashesCompared)
+	require.Equal(t, te.LastMilestoneIndex(), result.LedgerIndex)
+	require.Equal(t, te.ProtocolParameters().TokenSupply, result.LedgerTokenSupply)
+	require.Positive(t, result.MilestoneDiffsChecked)
+
+	ledgerStateHash, err := te.Storage().ComputeLedgerStateHash(true)
+	require.NoError(t, err)
+	require.Equal(t, iotago.EncodeHex(ledgerStateHash.Hash), result.LedgerStateHash)
+
+	published := verifier.PublishedHashes()
+	require.Equal(t, result.LedgerStateHash, published.LedgerStateHashes[result.LedgerIndex])
+	require.Len(t, published.MilestoneDiffHashes, result.MilestoneDiffsChecked)
+
+	// the hashes published by another node with the same ledger match
+	writeReferenceFile(t, referenceFilePath, published)
+
+	result, err = verifier.Check(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.Healthy())
+	require.Empty(t, result.SourceErrors)
+	require.Equal(t, 1+result.MilestoneDiffsChecked, result.HashesCompared)
+	require.Empty(t, divergences)
+
+	// diverging hashes are detected
+	diverging := integrity.NewReferenceHashes()
+	diverging.LedgerStateHashes[result.LedgerIndex] = iotago.EncodeHex(make([]byte, 32))
+	diverging.MilestoneDiffHashes[result.LedgerIndex] = iotago.EncodeHex(make([]byte, 32))
+	writeReferenceFile(t, referenceFilePath, diverging)
+
+	result, err = verifier.Check(context.Background())
+	require.NoError(t, err)
+	require.False(t, result.Healthy())
+	require.False(t, verifier.Healthy())
+	require.Len(t, result.Divergences, 2)
+	require.Len(t, divergences, 2)
+	require.Equal(t, uint64(2), verifier.DivergencesTotal())
+	require.Equal(t, uint64(3), verifier.ChecksTotal())
+
+	kinds := map[integrity.DivergenceKind]struct{}{}
+	for _, divergence := range result.Divergences {
+		require.Equal(t, result.LedgerIndex, divergence.MilestoneIndex)
+		kinds[divergence.Kind] = struct{}{}
+	}
+	require.Contains(t, kinds, integrity.DivergenceLedgerStateHash)
+	require.Contains(t, kinds, integrity.DivergenceMilestoneDiffHash)
+
+	// the node is healthy again if the references match
+	writeReferenceFile(t, referenceFilePath, verifier.PublishedHashes())
+
+	result, err = verifier.Check(context.Background())
+	require.NoError(t, err)
+	require.True(t, result.Healthy())
+	require.True(t, verifier.Healthy())
+}
+
+func TestVerifierDoesNotBlockConfirmation(t *testing.T) {
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	ledgerStateHash, err := te.Storage().ComputeLedgerStateHash(true)
+	require.NoError(t, err)
+	checkIndex := ledgerStateHash.LedgerIndex
+
+	verifier := integrity.New(nil, te.Storage(), nil, 10)
+
+	// confirm milestones that create and spend outputs while the check is running
+	verifier.Events.CheckStarted.Hook(func(ledgerIndex iotago.MilestoneIndex) {
+		require.Equal(t, checkIndex, ledgerIndex)
+
+		confirmed := make(chan struct{})
+		go func() {
+			defer close(confirmed)
+
+			for _, wallets := range [][2]*utils.HDWallet{{seed1Wallet, seed2Wallet}, {seed2Wallet, seed1Wallet}} {
+				block := te.NewBlockBuilder("A").
+					Parents(te.LastMilestoneParents()).
+					FromWallet(wallets[0]).
+					Amount(1_000_000).
+					BuildTransactionToWallet(wallets[1]).
+					Store().
+					BookOnWallets()
+
+				te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{block.StoredBlockID()}, false)
+			}
+		}()
+
+		select {
+		case <-confirmed:
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "milestone confirmation is blocked by the integrity check")
+		}
+	})
+
+	result, err := verifier.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, checkIndex+2, te.LastMilestoneIndex())
+
+	// the result belongs to the ledger state at the start of the check
+	require.True(t, result.Healthy())
+	require.Equal(t, checkIndex, result.LedgerIndex)
+	require.Equal(t, iotago.EncodeHex(ledgerStateHash.Hash), result.LedgerStateHash)
+	require.Equal(t, te.ProtocolParameters().TokenSupply, result.LedgerTokenSupply)
+}
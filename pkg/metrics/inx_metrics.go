@@ -11,12 +11,20 @@ import (
 type INXEvents struct {
 	// PoWCompleted is fired when a PoW request is completed. It contains the block size and the duration.
 	PoWCompleted *event.Event2[int, time.Duration]
+	// BlockValidated is fired when a validator decided about a block. It contains the duration of the decision.
+	BlockValidated *event.Event1[time.Duration]
 }
 
 // INXMetrics defines INX metrics over the entire runtime of the node.
 type INXMetrics struct {
 	// The total number of completed PoW requests.
 	PoWCompletedCounter atomic.Uint32
+	// The total number of blocks accepted by the validator.
+	ValidatorAcceptedCounter atomic.Uint32
+	// The total number of blocks rejected by the validator.
+	ValidatorRejectedCounter atomic.Uint32
+	// The total number of blocks the validator didn't decide about, e.g. because of a timeout.
+	ValidatorFailedCounter atomic.Uint32
 
 	Events *INXEvents
 }
@@ -27,3 +35,18 @@ func (m *INXMetrics) PoWCompleted(blockSize int, duration time.Duration) {
 		m.Events.PoWCompleted.Trigger(blockSize, duration)
 	}
 }
+
+func (m *INXMetrics) BlockValidated(accepted bool, duration time.Duration) {
+	if accepted {
+		m.ValidatorAcceptedCounter.Inc()
+	} else {
+		m.ValidatorRejectedCounter.Inc()
+	}
+	if m.Events != nil && m.Events.BlockValidated != nil {
+		m.Events.BlockValidated.Trigger(duration)
+	}
+}
+
+func (m *INXMetrics) BlockValidationFailed() {
+	m.ValidatorFailedCounter.Inc()
+}
@@ -22,6 +22,10 @@ type ServerMetrics struct {
 	ConflictingTransactionBlocks atomic.Uint32
 	// The number of received invalid blocks.
 	InvalidBlocks atomic.Uint32
+	// The number of received blocks that were rejected by the block validator.
+	RejectedBlocks atomic.Uint32
+	// The number of received blocks that were dropped because they could not be submitted to the block validator.
+	ValidationDroppedBlocks atomic.Uint32
 	// The number of received invalid requests (both blocks and milestones).
 	InvalidRequests atomic.Uint32
 	// The number of received milestone requests.
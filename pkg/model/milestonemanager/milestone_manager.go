@@ -21,26 +21,33 @@ type MilestoneManager struct {
 	storage *storage.Storage
 	// used to determine the sync status of the node.
 	syncManager *syncmanager.SyncManager
-	// provides public and private keys for ranges of milestone indexes.
-	keyManager *keymanager.KeyManager
-	// amount of public keys in a milestone.
-	milestonePublicKeyCount int
+	// verifies the signatures of milestones.
+	signatureVerifier SignatureVerifier
 
 	// events
 	Events *packageEvents
 }
 
+// New creates a new MilestoneManager that verifies the milestone signatures with the given key manager.
 func New(
 	dbStorage *storage.Storage,
 	syncManager *syncmanager.SyncManager,
 	keyManager *keymanager.KeyManager,
 	milestonePublicKeyCount int) *MilestoneManager {
 
+	return NewWithSignatureVerifier(dbStorage, syncManager, NewKeyManagerVerifier(keyManager, milestonePublicKeyCount))
+}
+
+// NewWithSignatureVerifier creates a new MilestoneManager that verifies the milestone signatures with the given verification backend.
+func NewWithSignatureVerifier(
+	dbStorage *storage.Storage,
+	syncManager *syncmanager.SyncManager,
+	signatureVerifier SignatureVerifier) *MilestoneManager {
+
 	t := &MilestoneManager{
-		storage:                 dbStorage,
-		syncManager:             syncManager,
-		keyManager:              keyManager,
-		milestonePublicKeyCount: milestonePublicKeyCount,
+		storage:           dbStorage,
+		syncManager:       syncManager,
+		signatureVerifier: signatureVerifier,
 
 		Events: &packageEvents{
 			ReceivedValidMilestone: event.New2[*storage.CachedMilestone, bool](event.WithPreTriggerFunc(func(milestone *storage.CachedMilestone, _ bool) {
@@ -52,9 +59,9 @@ func New(
 	return t
 }
 
-// KeyManager returns the used key manager.
-func (m *MilestoneManager) KeyManager() *keymanager.KeyManager {
-	return m.keyManager
+// SignatureVerifier returns the used verification backend.
+func (m *MilestoneManager) SignatureVerifier() SignatureVerifier {
+	return m.signatureVerifier
 }
 
 // FindClosestNextMilestoneIndex searches for the next known milestone in the persistence layer.
@@ -100,7 +107,7 @@ func (m *MilestoneManager) VerifyMilestoneBlock(block *iotago.Block) *iotago.Mil
 		}
 	}
 
-	if err := milestonePayload.VerifySignatures(m.milestonePublicKeyCount, m.keyManager.PublicKeysSetForMilestoneIndex(milestonePayload.Index)); err != nil {
+	if err := m.signatureVerifier.VerifySignatures(milestonePayload); err != nil {
 		return nil
 	}
 
@@ -118,7 +125,7 @@ func (m *MilestoneManager) VerifyMilestonePayload(payload iotago.Payload) *iotag
 		return nil
 	}
 
-	if err := milestonePayload.VerifySignatures(m.milestonePublicKeyCount, m.keyManager.PublicKeysSetForMilestoneIndex(milestonePayload.Index)); err != nil {
+	if err := m.signatureVerifier.VerifySignatures(milestonePayload); err != nil {
 		return nil
 	}
 
@@ -0,0 +1,210 @@
+package milestonemanager
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/keymanager"
+)
+
+const (
+	// SignatureVerifierKeyManager is the name of the default verification backend.
+	SignatureVerifierKeyManager = "keyManager"
+	// SignatureVerifierThreshold is the name of the threshold signature verification backend.
+	SignatureVerifierThreshold = "threshold"
+)
+
+var (
+	// ErrKeyRangesNotUpdatable is returned if the key ranges of a verification backend can't be updated at runtime.
+	ErrKeyRangesNotUpdatable = errors.New("key ranges of the verification backend can't be updated")
+	// ErrInvalidKeyRanges is returned if the given key ranges are invalid.
+	ErrInvalidKeyRanges = errors.New("invalid key ranges")
+)
+
+// SignatureVerifier verifies the signatures of milestone payloads.
+type SignatureVerifier interface {
+	// Name returns the name of the verification backend.
+	Name() string
+	// VerifySignatures checks if the milestone payload was signed by the applicable keys.
+	VerifySignatures(milestonePayload *iotago.Milestone) error
+	// KeyRanges returns the currently used key ranges.
+	KeyRanges() []*keymanager.KeyRange
+	// UpdateKeyRanges replaces the currently used key ranges.
+	// Returns ErrKeyRangesNotUpdatable if the backend doesn't support updates at runtime.
+	UpdateKeyRanges(keyRanges []*keymanager.KeyRange) error
+}
+
+// ValidateKeyRanges checks if the given key ranges are valid.
+func ValidateKeyRanges(keyRanges []*keymanager.KeyRange) error {
+	if len(keyRanges) == 0 {
+		return errors.WithMessage(ErrInvalidKeyRanges, "no key ranges given")
+	}
+
+	for i, keyRange := range keyRanges {
+		if keyRange == nil {
+			return errors.WithMessagef(ErrInvalidKeyRanges, "key range %d is empty", i)
+		}
+
+		if keyRange.EndIndex != 0 && keyRange.EndIndex < keyRange.StartIndex {
+			return errors.WithMessagef(ErrInvalidKeyRanges, "end index %d of key range %d is smaller than start index %d", keyRange.EndIndex, i, keyRange.StartIndex)
+		}
+	}
+
+	return nil
+}
+
+// KeyManagerFromKeyRanges creates a new KeyManager from the given key ranges.
+func KeyManagerFromKeyRanges(keyRanges []*keymanager.KeyRange) *keymanager.KeyManager {
+	keyManager := keymanager.New()
+	for _, keyRange := range keyRanges {
+		keyManager.AddKeyRange(ed25519.PublicKey(keyRange.PublicKey[:]), keyRange.StartIndex, keyRange.EndIndex)
+	}
+
+	return keyManager
+}
+
+// keyRangesHolder holds the key manager of a verification backend and allows to replace it at runtime.
+type keyRangesHolder struct {
+	keyManagerLock syncutils.RWMutex
+	keyManager     *keymanager.KeyManager
+}
+
+func (h *keyRangesHolder) publicKeysSetForMilestoneIndex(msIndex iotago.MilestoneIndex) iotago.MilestonePublicKeySet {
+	h.keyManagerLock.RLock()
+	defer h.keyManagerLock.RUnlock()
+
+	return h.keyManager.PublicKeysSetForMilestoneIndex(msIndex)
+}
+
+// KeyRanges returns the currently used key ranges.
+func (h *keyRangesHolder) KeyRanges() []*keymanager.KeyRange {
+	h.keyManagerLock.RLock()
+	defer h.keyManagerLock.RUnlock()
+
+	return h.keyManager.KeyRanges()
+}
+
+func (h *keyRangesHolder) setKeyRanges(keyRanges []*keymanager.KeyRange) error {
+	if err := ValidateKeyRanges(keyRanges); err != nil {
+		return err
+	}
+
+	keyManager := KeyManagerFromKeyRanges(keyRanges)
+
+	h.keyManagerLock.Lock()
+	defer h.keyManagerLock.Unlock()
+
+	h.keyManager = keyManager
+
+	return nil
+}
+
+// KeyManagerVerifier verifies the signatures of milestones with the public keys of an in-process KeyManager.
+// All signatures of a milestone need to be valid and need to be issued by applicable keys.
+type KeyManagerVerifier struct {
+	keyRangesHolder
+
+	milestonePublicKeyCount int
+}
+
+// NewKeyManagerVerifier creates a new KeyManagerVerifier.
+func NewKeyManagerVerifier(keyManager *keymanager.KeyManager, milestonePublicKeyCount int) *KeyManagerVerifier {
+	return &KeyManagerVerifier{
+		keyRangesHolder:         keyRangesHolder{keyManager: keyManager},
+		milestonePublicKeyCount: milestonePublicKeyCount,
+	}
+}
+
+// Name returns the name of the verification backend.
+func (v *KeyManagerVerifier) Name() string {
+	return SignatureVerifierKeyManager
+}
+
+// VerifySignatures checks if the milestone payload was signed by the applicable keys.
+func (v *KeyManagerVerifier) VerifySignatures(milestonePayload *iotago.Milestone) error {
+	return milestonePayload.VerifySignatures(v.milestonePublicKeyCount, v.publicKeysSetForMilestoneIndex(milestonePayload.Index))
+}
+
+// UpdateKeyRanges replaces the currently used key ranges.
+func (v *KeyManagerVerifier) UpdateKeyRanges(keyRanges []*keymanager.KeyRange) error {
+	return v.setKeyRanges(keyRanges)
+}
+
+// ThresholdVerifier verifies the signatures of milestones with a threshold signature scheme.
+// A milestone is valid if it contains at least "threshold" valid signatures of applicable keys.
+// Signatures of keys that are not applicable are ignored, invalid signatures of applicable keys are not.
+type ThresholdVerifier struct {
+	keyRangesHolder
+
+	threshold int
+}
+
+// NewThresholdVerifier creates a new ThresholdVerifier.
+func NewThresholdVerifier(keyManager *keymanager.KeyManager, threshold int) (*ThresholdVerifier, error) {
+	if threshold <= 0 {
+		return nil, fmt.Errorf("%w: threshold must be greater than zero", iotago.ErrMilestoneInvalidMinSignatureThreshold)
+	}
+
+	return &ThresholdVerifier{
+		keyRangesHolder: keyRangesHolder{keyManager: keyManager},
+		threshold:       threshold,
+	}, nil
+}
+
+// Name returns the name of the verification backend.
+func (v *ThresholdVerifier) Name() string {
+	return SignatureVerifierThreshold
+}
+
+// VerifySignatures checks if the milestone payload was signed by enough applicable keys.
+func (v *ThresholdVerifier) VerifySignatures(milestonePayload *iotago.Milestone) error {
+	return verifySignaturesWithThreshold(milestonePayload, v.threshold, v.publicKeysSetForMilestoneIndex(milestonePayload.Index), ed25519.Verify)
+}
+
+// UpdateKeyRanges replaces the currently used key ranges.
+func (v *ThresholdVerifier) UpdateKeyRanges(keyRanges []*keymanager.KeyRange) error {
+	return v.setKeyRanges(keyRanges)
+}
+
+// verifyFunc verifies an ed25519 signature of the message.
+type verifyFunc func(publicKey ed25519.PublicKey, message []byte, signature []byte) bool
+
+// verifySignaturesWithThreshold checks if the milestone contains at least "threshold" valid signatures of applicable keys.
+func verifySignaturesWithThreshold(milestonePayload *iotago.Milestone, threshold int, applicablePubKeys iotago.MilestonePublicKeySet, verify verifyFunc) error {
+	if len(applicablePubKeys) < threshold {
+		return iotago.ErrMilestoneSignatureThresholdGreaterThanApplicablePublicKeySet
+	}
+
+	msEssence, err := milestonePayload.Essence()
+	if err != nil {
+		return fmt.Errorf("unable to compute milestone essence for signature verification: %w", err)
+	}
+
+	validSignatures := 0
+	for msSigIndex, msSig := range milestonePayload.Signatures {
+		edSig, ok := msSig.(*iotago.Ed25519Signature)
+		if !ok {
+			return fmt.Errorf("%w: unsupported signature type at index %d", iotago.ErrMilestoneInvalidSignature, msSigIndex)
+		}
+
+		if _, has := applicablePubKeys[edSig.PublicKey]; !has {
+			// signatures of other keys don't count towards the threshold
+			continue
+		}
+
+		if !verify(edSig.PublicKey[:], msEssence[:], edSig.Signature[:]) {
+			return fmt.Errorf("%w: at index %d, %s", iotago.ErrMilestoneInvalidSignature, msSigIndex, edSig)
+		}
+		validSignatures++
+	}
+
+	if validSignatures < threshold {
+		return fmt.Errorf("%w: wanted min. %d but only had %d", iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold, threshold, validSignatures)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package milestonemanager
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/keymanager"
+)
+
+const (
+	// SignatureVerifierHSM is the name of the verification backend that delegates the signature checks to an HSM-style signer.
+	SignatureVerifierHSM = "hsm"
+)
+
+var (
+	// ErrHSMKeyNotFound is returned if the HSM-style signer doesn't hold the requested key.
+	ErrHSMKeyNotFound = errors.New("key not found in HSM")
+)
+
+// HSMSigner is an HSM-style signer that holds the milestone keys and checks signatures on behalf of the node.
+type HSMSigner interface {
+	// PublicKeys returns the public keys held by the signer.
+	PublicKeys() ([]iotago.MilestonePublicKey, error)
+	// Verify checks the signature of the message with the given key held by the signer.
+	Verify(publicKey iotago.MilestonePublicKey, message []byte, signature []byte) (bool, error)
+}
+
+// LocalHSMStub is an in-process HSMSigner that can be used in private networks and for testing,
+// as long as no real HSM is available.
+type LocalHSMStub struct {
+	publicKeysFunc func() []iotago.MilestonePublicKey
+}
+
+// NewLocalHSMStub creates a new LocalHSMStub that holds the given public keys.
+func NewLocalHSMStub(publicKeys []iotago.MilestonePublicKey) *LocalHSMStub {
+	return NewLocalHSMStubWithPublicKeysFunc(func() []iotago.MilestonePublicKey {
+		return publicKeys
+	})
+}
+
+// NewLocalHSMStubWithPublicKeysFunc creates a new LocalHSMStub that holds the public keys
+// returned by the given function, which is called for every request.
+func NewLocalHSMStubWithPublicKeysFunc(publicKeysFunc func() []iotago.MilestonePublicKey) *LocalHSMStub {
+	return &LocalHSMStub{
+		publicKeysFunc: publicKeysFunc,
+	}
+}
+
+// PublicKeys returns the public keys held by the signer.
+func (s *LocalHSMStub) PublicKeys() ([]iotago.MilestonePublicKey, error) {
+	return s.publicKeysFunc(), nil
+}
+
+// Verify checks the signature of the message with the given key held by the signer.
+func (s *LocalHSMStub) Verify(publicKey iotago.MilestonePublicKey, message []byte, signature []byte) (bool, error) {
+	for _, heldPublicKey := range s.publicKeysFunc() {
+		if heldPublicKey == publicKey {
+			return ed25519.Verify(publicKey[:], message, signature), nil
+		}
+	}
+
+	return false, fmt.Errorf("%w: %s", ErrHSMKeyNotFound, iotago.EncodeHex(publicKey[:]))
+}
+
+// HSMVerifier verifies the signatures of milestones with an HSM-style signer.
+// The key ranges define which keys are applicable for a milestone index,
+// but only keys that are held by the signer are accepted.
+type HSMVerifier struct {
+	keyRangesHolder
+
+	signer                  HSMSigner
+	milestonePublicKeyCount int
+}
+
+// NewHSMVerifier creates a new HSMVerifier.
+func NewHSMVerifier(signer HSMSigner, keyManager *keymanager.KeyManager, milestonePublicKeyCount int) *HSMVerifier {
+	return &HSMVerifier{
+		keyRangesHolder:         keyRangesHolder{keyManager: keyManager},
+		signer:                  signer,
+		milestonePublicKeyCount: milestonePublicKeyCount,
+	}
+}
+
+// NewLocalHSMVerifier creates a new HSMVerifier with a LocalHSMStub that holds the keys of the current key ranges,
+// so keys of key ranges that are updated at runtime are held by the stub as well.
+func NewLocalHSMVerifier(keyManager *keymanager.KeyManager, milestonePublicKeyCount int) *HSMVerifier {
+	v := NewHSMVerifier(nil, keyManager, milestonePublicKeyCount)
+	v.signer = NewLocalHSMStubWithPublicKeysFunc(v.publicKeysOfKeyRanges)
+
+	return v
+}
+
+// publicKeysOfKeyRanges returns the public keys of the currently used key ranges.
+func (v *HSMVerifier) publicKeysOfKeyRanges() []iotago.MilestonePublicKey {
+	keyRanges := v.KeyRanges()
+
+	publicKeys := make([]iotago.MilestonePublicKey, 0, len(keyRanges))
+	for _, keyRange := range keyRanges {
+		publicKeys = append(publicKeys, keyRange.PublicKey)
+	}
+
+	return publicKeys
+}
+
+// Name returns the name of the verification backend.
+func (v *HSMVerifier) Name() string {
+	return SignatureVerifierHSM
+}
+
+// VerifySignatures checks if the milestone payload was signed by the applicable keys held by the signer.
+func (v *HSMVerifier) VerifySignatures(milestonePayload *iotago.Milestone) error {
+	signerPublicKeys, err := v.signer.PublicKeys()
+	if err != nil {
+		return fmt.Errorf("unable to get public keys of the HSM: %w", err)
+	}
+
+	signerPublicKeysSet := make(iotago.MilestonePublicKeySet, len(signerPublicKeys))
+	for _, publicKey := range signerPublicKeys {
+		signerPublicKeysSet[publicKey] = struct{}{}
+	}
+
+	// only keys that are applicable and held by the signer are accepted
+	applicablePubKeys := iotago.MilestonePublicKeySet{}
+	for publicKey := range v.publicKeysSetForMilestoneIndex(milestonePayload.Index) {
+		if _, has := signerPublicKeysSet[publicKey]; has {
+			applicablePubKeys[publicKey] = struct{}{}
+		}
+	}
+
+	if len(milestonePayload.Signatures) < v.milestonePublicKeyCount {
+		return fmt.Errorf("%w: wanted min. %d but only had %d", iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold, v.milestonePublicKeyCount, len(milestonePayload.Signatures))
+	}
+
+	for msSigIndex, msSig := range milestonePayload.Signatures {
+		edSig, ok := msSig.(*iotago.Ed25519Signature)
+		if !ok {
+			return fmt.Errorf("%w: unsupported signature type at index %d", iotago.ErrMilestoneInvalidSignature, msSigIndex)
+		}
+
+		if _, has := applicablePubKeys[edSig.PublicKey]; !has {
+			return fmt.Errorf("%w: public key %s is not applicable", iotago.ErrMilestoneNonApplicablePublicKey, iotago.EncodeHex(edSig.PublicKey[:]))
+		}
+	}
+
+	var verifyErr error
+	if err := verifySignaturesWithThreshold(milestonePayload, v.milestonePublicKeyCount, applicablePubKeys, func(publicKey ed25519.PublicKey, message []byte, signature []byte) bool {
+		var msPublicKey iotago.MilestonePublicKey
+		copy(msPublicKey[:], publicKey)
+
+		valid, err := v.signer.Verify(msPublicKey, message, signature)
+		if err != nil {
+			verifyErr = err
+
+			return false
+		}
+
+		return valid
+	}); err != nil {
+		if verifyErr != nil {
+			return fmt.Errorf("unable to verify signature with the HSM: %w", verifyErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// UpdateKeyRanges replaces the currently used key ranges.
+func (v *HSMVerifier) UpdateKeyRanges(keyRanges []*keymanager.KeyRange) error {
+	return v.setKeyRanges(keyRanges)
+}
@@ -0,0 +1,340 @@
+package milestonemanager
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/keymanager"
+)
+
+const (
+	// SignatureVerifierKeyRotationFile is the name of the verification backend that sources the key ranges from a signed key rotation file.
+	SignatureVerifierKeyRotationFile = "keyRotationFile"
+
+	// keyRotationFileSigningDomain is the domain tag at the start of the signed message of a key rotation file,
+	// so the signatures can't be reused for other messages.
+	keyRotationFileSigningDomain = "hornet-milestone-key-rotation"
+)
+
+var (
+	// ErrInvalidKeyRotationFile is returned if a key rotation file is malformed or not signed by enough trusted keys.
+	ErrInvalidKeyRotationFile = errors.New("invalid key rotation file")
+)
+
+// KeyRotationFileKeyRange is a key range in a key rotation file.
+type KeyRotationFileKeyRange struct {
+	// The hex encoded ed25519 public key.
+	Key string `json:"key"`
+	// The start milestone index of the public key.
+	StartIndex iotago.MilestoneIndex `json:"start"`
+	// The end milestone index of the public key. Zero means the key is valid forever.
+	EndIndex iotago.MilestoneIndex `json:"end"`
+}
+
+// KeyRotationFileSignature is a signature of the key ranges in a key rotation file.
+type KeyRotationFileSignature struct {
+	// The hex encoded ed25519 public key of the signer.
+	PublicKey string `json:"publicKey"`
+	// The hex encoded ed25519 signature.
+	Signature string `json:"signature"`
+}
+
+// KeyRotationFile contains key ranges that are signed by trusted keys.
+type KeyRotationFile struct {
+	// The version of the key rotation file. Only files with a higher version than the applied one are accepted.
+	Version uint32 `json:"version"`
+	// The ID of the network the key rotation file is valid for.
+	NetworkID iotago.NetworkID `json:"networkId,string"`
+	// The key ranges used for the milestone signature verification.
+	KeyRanges []*KeyRotationFileKeyRange `json:"keyRanges"`
+	// The signatures of the key ranges.
+	Signatures []*KeyRotationFileSignature `json:"signatures"`
+}
+
+// keyRangesFromKeyRotationFile parses the key ranges of a key rotation file.
+func keyRangesFromKeyRotationFile(fileKeyRanges []*KeyRotationFileKeyRange) ([]*keymanager.KeyRange, error) {
+	keyRanges := make([]*keymanager.KeyRange, 0, len(fileKeyRanges))
+	for i, fileKeyRange := range fileKeyRanges {
+		if fileKeyRange == nil {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "key range %d is empty", i)
+		}
+
+		publicKeyBytes, err := iotago.DecodeHex(fileKeyRange.Key)
+		if err != nil {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "invalid public key of key range %d: %s", i, err)
+		}
+
+		if len(publicKeyBytes) != ed25519.PublicKeySize {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "invalid public key length of key range %d: %d", i, len(publicKeyBytes))
+		}
+
+		keyRange := &keymanager.KeyRange{
+			StartIndex: fileKeyRange.StartIndex,
+			EndIndex:   fileKeyRange.EndIndex,
+		}
+		copy(keyRange.PublicKey[:], publicKeyBytes)
+
+		keyRanges = append(keyRanges, keyRange)
+	}
+
+	return keyRanges, nil
+}
+
+// KeyRotationFileSigningMessage returns the message that is signed in a key rotation file.
+// It consists of the domain tag, the network ID, the version and the public key,
+// the start index and the end index of all key ranges in the given order.
+func KeyRotationFileSigningMessage(networkID iotago.NetworkID, version uint32, keyRanges []*keymanager.KeyRange) []byte {
+	message := make([]byte, 0, len(keyRotationFileSigningDomain)+12+len(keyRanges)*(ed25519.PublicKeySize+8))
+	message = append(message, keyRotationFileSigningDomain...)
+	message = binary.LittleEndian.AppendUint64(message, networkID)
+	message = binary.LittleEndian.AppendUint32(message, version)
+	for _, keyRange := range keyRanges {
+		message = append(message, keyRange.PublicKey[:]...)
+		message = binary.LittleEndian.AppendUint32(message, keyRange.StartIndex)
+		message = binary.LittleEndian.AppendUint32(message, keyRange.EndIndex)
+	}
+
+	return message
+}
+
+// NewKeyRotationFile creates a new key rotation file with the given version and key ranges for the given network,
+// signed by the given private keys.
+func NewKeyRotationFile(networkID iotago.NetworkID, version uint32, keyRanges []*keymanager.KeyRange, privateKeys []ed25519.PrivateKey) *KeyRotationFile {
+	message := KeyRotationFileSigningMessage(networkID, version, keyRanges)
+
+	file := &KeyRotationFile{
+		Version:    version,
+		NetworkID:  networkID,
+		KeyRanges:  make([]*KeyRotationFileKeyRange, 0, len(keyRanges)),
+		Signatures: make([]*KeyRotationFileSignature, 0, len(privateKeys)),
+	}
+
+	for _, keyRange := range keyRanges {
+		file.KeyRanges = append(file.KeyRanges, &KeyRotationFileKeyRange{
+			Key:        iotago.EncodeHex(keyRange.PublicKey[:]),
+			StartIndex: keyRange.StartIndex,
+			EndIndex:   keyRange.EndIndex,
+		})
+	}
+
+	for _, privateKey := range privateKeys {
+		//nolint:forcetypeassert // we can safely assume that this is an ed25519.PublicKey
+		publicKey := privateKey.Public().(ed25519.PublicKey)
+
+		file.Signatures = append(file.Signatures, &KeyRotationFileSignature{
+			PublicKey: iotago.EncodeHex(publicKey),
+			Signature: iotago.EncodeHex(ed25519.Sign(privateKey, message)),
+		})
+	}
+
+	return file
+}
+
+// ReadKeyRotationFile reads a key rotation file from disk.
+func ReadKeyRotationFile(filePath string) (*KeyRotationFile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read key rotation file: %w", err)
+	}
+
+	file := &KeyRotationFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "unable to parse key rotation file: %s", err)
+	}
+
+	return file, nil
+}
+
+// Write writes the key rotation file to disk.
+// The file is written to a temporary file first and renamed afterwards to not leave a corrupted file behind.
+func (f *KeyRotationFile) Write(filePath string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal key rotation file: %w", err)
+	}
+
+	tempFilePath := filepath.Join(filepath.Dir(filePath), fmt.Sprintf(".%s.tmp", filepath.Base(filePath)))
+	if err := os.WriteFile(tempFilePath, data, 0o600); err != nil {
+		return fmt.Errorf("unable to write key rotation file: %w", err)
+	}
+
+	if err := os.Rename(tempFilePath, filePath); err != nil {
+		return fmt.Errorf("unable to write key rotation file: %w", err)
+	}
+
+	return nil
+}
+
+// Verify checks that the file belongs to the given network and that its version and key ranges
+// are signed by at least minSignatures of the trusted keys, and returns the key ranges.
+func (f *KeyRotationFile) Verify(networkID iotago.NetworkID, trustedPublicKeys []ed25519.PublicKey, minSignatures int) ([]*keymanager.KeyRange, error) {
+	if minSignatures <= 0 {
+		return nil, errors.WithMessage(ErrInvalidKeyRotationFile, "the minimum amount of signatures must be greater than zero")
+	}
+
+	if f.NetworkID != networkID {
+		return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "network ID %d doesn't match the network ID %d of the node", f.NetworkID, networkID)
+	}
+
+	keyRanges, err := keyRangesFromKeyRotationFile(f.KeyRanges)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateKeyRanges(keyRanges); err != nil {
+		return nil, errors.WithMessage(ErrInvalidKeyRotationFile, err.Error())
+	}
+
+	trustedKeys := make(map[string]ed25519.PublicKey, len(trustedPublicKeys))
+	for _, publicKey := range trustedPublicKeys {
+		trustedKeys[string(publicKey)] = publicKey
+	}
+
+	message := KeyRotationFileSigningMessage(f.NetworkID, f.Version, keyRanges)
+
+	signers := make(map[string]struct{})
+	for i, signature := range f.Signatures {
+		if signature == nil {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "signature %d is empty", i)
+		}
+
+		publicKeyBytes, err := iotago.DecodeHex(signature.PublicKey)
+		if err != nil {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "invalid public key of signature %d: %s", i, err)
+		}
+
+		publicKey, trusted := trustedKeys[string(publicKeyBytes)]
+		if !trusted {
+			// signatures of untrusted keys are ignored
+			continue
+		}
+
+		signatureBytes, err := iotago.DecodeHex(signature.Signature)
+		if err != nil {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "invalid signature %d: %s", i, err)
+		}
+
+		if !ed25519.Verify(publicKey, message, signatureBytes) {
+			return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "invalid signature %d of key %s", i, signature.PublicKey)
+		}
+
+		signers[string(publicKeyBytes)] = struct{}{}
+	}
+
+	if len(signers) < minSignatures {
+		return nil, errors.WithMessagef(ErrInvalidKeyRotationFile, "signed by %d trusted keys, but at least %d are needed", len(signers), minSignatures)
+	}
+
+	return keyRanges, nil
+}
+
+// KeyRotationFileVerifier verifies the signatures of milestones with the key ranges of a signed key rotation file.
+// The key ranges can't be updated directly, only by applying a new key rotation file that is signed by the trusted keys.
+// Files with a version that is not newer than the applied one are rejected, so older rotations can't be replayed.
+type KeyRotationFileVerifier struct {
+	*KeyManagerVerifier
+
+	filePath          string
+	networkID         iotago.NetworkID
+	trustedPublicKeys []ed25519.PublicKey
+	minSignatures     int
+
+	// applyLock is used to apply key rotation files one after another.
+	applyLock syncutils.Mutex
+	// the version of the applied key rotation file.
+	version uint32
+}
+
+// NewKeyRotationFileVerifier creates a new KeyRotationFileVerifier and loads the key ranges from the given file.
+func NewKeyRotationFileVerifier(filePath string, networkID iotago.NetworkID, trustedPublicKeys []ed25519.PublicKey, minSignatures int, milestonePublicKeyCount int) (*KeyRotationFileVerifier, error) {
+	v := &KeyRotationFileVerifier{
+		KeyManagerVerifier: NewKeyManagerVerifier(keymanager.New(), milestonePublicKeyCount),
+		filePath:           filePath,
+		networkID:          networkID,
+		trustedPublicKeys:  trustedPublicKeys,
+		minSignatures:      minSignatures,
+	}
+
+	if err := v.Reload(); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Name returns the name of the verification backend.
+func (v *KeyRotationFileVerifier) Name() string {
+	return SignatureVerifierKeyRotationFile
+}
+
+// Version returns the version of the applied key rotation file.
+func (v *KeyRotationFileVerifier) Version() uint32 {
+	v.applyLock.Lock()
+	defer v.applyLock.Unlock()
+
+	return v.version
+}
+
+// UpdateKeyRanges is not supported, because the key ranges need to be signed by the trusted keys.
+func (v *KeyRotationFileVerifier) UpdateKeyRanges(_ []*keymanager.KeyRange) error {
+	return errors.WithMessage(ErrKeyRangesNotUpdatable, "apply a signed key rotation file instead")
+}
+
+// Reload reads the key rotation file from disk again and uses its key ranges if it is valid.
+// The file on disk may contain the applied version again, but not an older one.
+func (v *KeyRotationFileVerifier) Reload() error {
+	file, err := ReadKeyRotationFile(v.filePath)
+	if err != nil {
+		return err
+	}
+
+	v.applyLock.Lock()
+	defer v.applyLock.Unlock()
+
+	if file.Version < v.version {
+		return errors.WithMessagef(ErrInvalidKeyRotationFile, "version %d is older than the applied version %d", file.Version, v.version)
+	}
+
+	return v.applyWithoutLocking(file, false)
+}
+
+// ApplyKeyRotationFile verifies the given key rotation file, persists it and uses its key ranges.
+// The version of the file needs to be newer than the applied one.
+func (v *KeyRotationFileVerifier) ApplyKeyRotationFile(file *KeyRotationFile) error {
+	v.applyLock.Lock()
+	defer v.applyLock.Unlock()
+
+	if file.Version <= v.version {
+		return errors.WithMessagef(ErrInvalidKeyRotationFile, "version %d is not newer than the applied version %d", file.Version, v.version)
+	}
+
+	return v.applyWithoutLocking(file, true)
+}
+
+func (v *KeyRotationFileVerifier) applyWithoutLocking(file *KeyRotationFile, persist bool) error {
+	keyRanges, err := file.Verify(v.networkID, v.trustedPublicKeys, v.minSignatures)
+	if err != nil {
+		return err
+	}
+
+	if persist {
+		if err := file.Write(v.filePath); err != nil {
+			return err
+		}
+	}
+
+	if err := v.setKeyRanges(keyRanges); err != nil {
+		return err
+	}
+	v.version = file.Version
+
+	return nil
+}
@@ -0,0 +1,215 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package milestonemanager_test
+
+import (
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/milestonemanager"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/keymanager"
+)
+
+func newPrivateKeys(t *testing.T, count int) []ed25519.PrivateKey {
+	privateKeys := make([]ed25519.PrivateKey, count)
+	for i := range privateKeys {
+		_, privateKey, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		privateKeys[i] = privateKey
+	}
+
+	return privateKeys
+}
+
+func milestonePublicKey(privateKey ed25519.PrivateKey) iotago.MilestonePublicKey {
+	var publicKey iotago.MilestonePublicKey
+	copy(publicKey[:], privateKey.Public().(ed25519.PublicKey))
+
+	return publicKey
+}
+
+func keyRangesForPrivateKeys(privateKeys []ed25519.PrivateKey, startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) []*keymanager.KeyRange {
+	keyRanges := make([]*keymanager.KeyRange, len(privateKeys))
+	for i, privateKey := range privateKeys {
+		keyRanges[i] = &keymanager.KeyRange{
+			PublicKey:  milestonePublicKey(privateKey),
+			StartIndex: startIndex,
+			EndIndex:   endIndex,
+		}
+	}
+
+	return keyRanges
+}
+
+func newSignedMilestone(t *testing.T, index iotago.MilestoneIndex, privateKeys []ed25519.PrivateKey) *iotago.Milestone {
+	milestonePayload := iotago.NewMilestone(index, 1651838930, 2, iotago.MilestoneID{}, iotago.BlockIDs{tpkg.RandBlockID()}, iotago.MilestoneMerkleProof{}, iotago.MilestoneMerkleProof{})
+
+	publicKeys := make([]iotago.MilestonePublicKey, len(privateKeys))
+	keyMapping := iotago.MilestonePublicKeyMapping{}
+	for i, privateKey := range privateKeys {
+		publicKeys[i] = milestonePublicKey(privateKey)
+		keyMapping[publicKeys[i]] = privateKey
+	}
+
+	require.NoError(t, milestonePayload.Sign(publicKeys, iotago.InMemoryEd25519MilestoneSigner(keyMapping)))
+
+	return milestonePayload
+}
+
+func TestKeyManagerVerifier(t *testing.T) {
+	privateKeys := newPrivateKeys(t, 3)
+	otherPrivateKeys := newPrivateKeys(t, 2)
+
+	verifier := milestonemanager.NewKeyManagerVerifier(milestonemanager.KeyManagerFromKeyRanges(keyRangesForPrivateKeys(privateKeys, 0, 0)), 2)
+	require.Equal(t, milestonemanager.SignatureVerifierKeyManager, verifier.Name())
+
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[:2])))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[:1])), iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold)
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 5, otherPrivateKeys)), iotago.ErrMilestoneNonApplicablePublicKey)
+
+	// rotate the keys at runtime
+	require.ErrorIs(t, verifier.UpdateKeyRanges(nil), milestonemanager.ErrInvalidKeyRanges)
+	require.ErrorIs(t, verifier.UpdateKeyRanges(keyRangesForPrivateKeys(otherPrivateKeys, 10, 5)), milestonemanager.ErrInvalidKeyRanges)
+	require.NoError(t, verifier.UpdateKeyRanges(append(keyRangesForPrivateKeys(privateKeys, 0, 9), keyRangesForPrivateKeys(otherPrivateKeys, 10, 0)...)))
+	require.Len(t, verifier.KeyRanges(), 5)
+
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 9, privateKeys[:2])))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 10, privateKeys[:2])), iotago.ErrMilestoneNonApplicablePublicKey)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, otherPrivateKeys)))
+}
+
+func TestThresholdVerifier(t *testing.T) {
+	privateKeys := newPrivateKeys(t, 5)
+	otherPrivateKeys := newPrivateKeys(t, 2)
+
+	_, err := milestonemanager.NewThresholdVerifier(keymanager.New(), 0)
+	require.Error(t, err)
+
+	verifier, err := milestonemanager.NewThresholdVerifier(milestonemanager.KeyManagerFromKeyRanges(keyRangesForPrivateKeys(privateKeys, 0, 0)), 3)
+	require.NoError(t, err)
+
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[:3])))
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys)))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[:2])), iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold)
+
+	// signatures of unknown keys are ignored, but don't count towards the threshold
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, append(append([]ed25519.PrivateKey{}, privateKeys[:3]...), otherPrivateKeys...))))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 5, append(append([]ed25519.PrivateKey{}, privateKeys[:2]...), otherPrivateKeys...))), iotago.ErrMilestoneTooFewSignaturesForVerificationThreshold)
+
+	// invalid signatures of applicable keys are rejected
+	milestonePayload := newSignedMilestone(t, 5, privateKeys[:3])
+	milestonePayload.Signatures[0].(*iotago.Ed25519Signature).Signature[0] ^= 0xFF
+	require.ErrorIs(t, verifier.VerifySignatures(milestonePayload), iotago.ErrMilestoneInvalidSignature)
+}
+
+func TestHSMVerifier(t *testing.T) {
+	privateKeys := newPrivateKeys(t, 3)
+
+	// the HSM only holds the first two keys
+	hsmPublicKeys := []iotago.MilestonePublicKey{milestonePublicKey(privateKeys[0]), milestonePublicKey(privateKeys[1])}
+	verifier := milestonemanager.NewHSMVerifier(milestonemanager.NewLocalHSMStub(hsmPublicKeys), milestonemanager.KeyManagerFromKeyRanges(keyRangesForPrivateKeys(privateKeys, 0, 0)), 2)
+
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[:2])))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys[1:])), iotago.ErrMilestoneNonApplicablePublicKey)
+
+	milestonePayload := newSignedMilestone(t, 5, privateKeys[:2])
+	milestonePayload.Signatures[1].(*iotago.Ed25519Signature).Signature[0] ^= 0xFF
+	require.ErrorIs(t, verifier.VerifySignatures(milestonePayload), iotago.ErrMilestoneInvalidSignature)
+}
+
+func TestLocalHSMVerifierKeyRangesUpdate(t *testing.T) {
+	privateKeys := newPrivateKeys(t, 2)
+	rotatedPrivateKeys := newPrivateKeys(t, 2)
+
+	verifier := milestonemanager.NewLocalHSMVerifier(milestonemanager.KeyManagerFromKeyRanges(keyRangesForPrivateKeys(privateKeys, 0, 0)), 2)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys)))
+	require.Error(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+
+	// the keys of key ranges that are added later are held by the local HSM stub as well
+	require.NoError(t, verifier.UpdateKeyRanges(append(keyRangesForPrivateKeys(privateKeys, 0, 9), keyRangesForPrivateKeys(rotatedPrivateKeys, 10, 0)...)))
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+	require.ErrorIs(t, verifier.VerifySignatures(newSignedMilestone(t, 10, privateKeys)), iotago.ErrMilestoneNonApplicablePublicKey)
+}
+
+func TestKeyRotationFileVerifier(t *testing.T) {
+	privateKeys := newPrivateKeys(t, 2)
+	rotatedPrivateKeys := newPrivateKeys(t, 2)
+	trustedPrivateKeys := newPrivateKeys(t, 3)
+	untrustedPrivateKeys := newPrivateKeys(t, 2)
+
+	trustedPublicKeys := make([]ed25519.PublicKey, len(trustedPrivateKeys))
+	for i, privateKey := range trustedPrivateKeys {
+		trustedPublicKeys[i] = privateKey.Public().(ed25519.PublicKey)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "keyrotation.json")
+	networkID := iotago.NetworkIDFromString("testnet")
+
+	// the file doesn't exist yet
+	_, err := milestonemanager.NewKeyRotationFileVerifier(filePath, networkID, trustedPublicKeys, 2, 2)
+	require.Error(t, err)
+
+	// not enough trusted signatures
+	require.NoError(t, milestonemanager.NewKeyRotationFile(networkID, 1, keyRangesForPrivateKeys(privateKeys, 0, 0), append([]ed25519.PrivateKey{trustedPrivateKeys[0]}, untrustedPrivateKeys...)).Write(filePath))
+	_, err = milestonemanager.NewKeyRotationFileVerifier(filePath, networkID, trustedPublicKeys, 2, 2)
+	require.ErrorIs(t, err, milestonemanager.ErrInvalidKeyRotationFile)
+
+	// files of other networks are rejected
+	require.NoError(t, milestonemanager.NewKeyRotationFile(iotago.NetworkIDFromString("othernet"), 1, keyRangesForPrivateKeys(privateKeys, 0, 0), trustedPrivateKeys[:2]).Write(filePath))
+	_, err = milestonemanager.NewKeyRotationFileVerifier(filePath, networkID, trustedPublicKeys, 2, 2)
+	require.ErrorIs(t, err, milestonemanager.ErrInvalidKeyRotationFile)
+
+	initialFile := milestonemanager.NewKeyRotationFile(networkID, 1, keyRangesForPrivateKeys(privateKeys, 0, 0), trustedPrivateKeys[:2])
+	require.NoError(t, initialFile.Write(filePath))
+	verifier, err := milestonemanager.NewKeyRotationFileVerifier(filePath, networkID, trustedPublicKeys, 2, 2)
+	require.NoError(t, err)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 5, privateKeys)))
+
+	// direct updates are not allowed
+	require.ErrorIs(t, verifier.UpdateKeyRanges(keyRangesForPrivateKeys(rotatedPrivateKeys, 0, 0)), milestonemanager.ErrKeyRangesNotUpdatable)
+
+	// tampered key ranges are rejected
+	rotationFile := milestonemanager.NewKeyRotationFile(networkID, 2, append(keyRangesForPrivateKeys(privateKeys, 0, 9), keyRangesForPrivateKeys(rotatedPrivateKeys, 10, 0)...), trustedPrivateKeys[1:])
+	rotationFile.KeyRanges[0].EndIndex = 0
+	require.ErrorIs(t, verifier.ApplyKeyRotationFile(rotationFile), milestonemanager.ErrInvalidKeyRotationFile)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, privateKeys)))
+
+	// apply a valid rotation
+	rotationFile.KeyRanges[0].EndIndex = 9
+	require.NoError(t, verifier.ApplyKeyRotationFile(rotationFile))
+	require.Equal(t, uint32(2), verifier.Version())
+	require.Error(t, verifier.VerifySignatures(newSignedMilestone(t, 10, privateKeys)))
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+
+	// older or equal versions can't be replayed to roll back the keys
+	require.ErrorIs(t, verifier.ApplyKeyRotationFile(initialFile), milestonemanager.ErrInvalidKeyRotationFile)
+	require.ErrorIs(t, verifier.ApplyKeyRotationFile(rotationFile), milestonemanager.ErrInvalidKeyRotationFile)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+
+	// changing the version invalidates the signatures
+	replayedFile := milestonemanager.NewKeyRotationFile(networkID, 1, keyRangesForPrivateKeys(privateKeys, 0, 0), trustedPrivateKeys[:2])
+	replayedFile.Version = 3
+	require.ErrorIs(t, verifier.ApplyKeyRotationFile(replayedFile), milestonemanager.ErrInvalidKeyRotationFile)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+
+	// the rotation was persisted
+	reloadedVerifier, err := milestonemanager.NewKeyRotationFileVerifier(filePath, networkID, trustedPublicKeys, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, reloadedVerifier.KeyRanges(), 4)
+	require.Equal(t, uint32(2), reloadedVerifier.Version())
+
+	// an older file on disk is not used
+	require.NoError(t, initialFile.Write(filePath))
+	require.ErrorIs(t, verifier.Reload(), milestonemanager.ErrInvalidKeyRotationFile)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+
+	// a broken file on disk is not used
+	require.NoError(t, os.WriteFile(filePath, []byte("{"), 0o600))
+	require.ErrorIs(t, verifier.Reload(), milestonemanager.ErrInvalidKeyRotationFile)
+	require.NoError(t, verifier.VerifySignatures(newSignedMilestone(t, 10, rotatedPrivateKeys)))
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 
@@ -99,6 +100,78 @@ var errorToConflictMapping = map[error]Conflict{
 	iotago.ErrTransDepIdentOutputNextInvalid: ConflictInvalidChainStateTransition,
 }
 
+const (
+	// ConflictDetailsNoInputIndex is used if a conflict can't be attributed to a single input.
+	ConflictDetailsNoInputIndex = -1
+
+	// the maximum length of the stored reason of a conflict.
+	maxConflictDetailsReasonLength = 1024
+)
+
+// ConflictDetails explains why a transaction was marked as conflicting.
+type ConflictDetails struct {
+	// The index of the input that caused the conflict, or ConflictDetailsNoInputIndex if unknown.
+	InputIndex int
+	// The violated condition, as reported by the semantic validation.
+	Reason string
+	// The IDs of the outputs referenced by the inputs of the transaction.
+	OutputIDs iotago.OutputIDs
+}
+
+// NewConflictDetails creates new ConflictDetails.
+// The reason is truncated on a rune boundary if it exceeds the maximum length that is stored.
+func NewConflictDetails(inputIndex int, reason string, outputIDs iotago.OutputIDs) *ConflictDetails {
+	if len(reason) > maxConflictDetailsReasonLength {
+		// don't cut a multi-byte character in half
+		end := maxConflictDetailsReasonLength
+		for end > 0 && !utf8.RuneStart(reason[end]) {
+			end--
+		}
+		reason = reason[:end]
+	}
+
+	return &ConflictDetails{
+		InputIndex: inputIndex,
+		Reason:     reason,
+		OutputIDs:  outputIDs,
+	}
+}
+
+// InputConflictError is returned if a transaction conflicts because of the input at the given index.
+type InputConflictError struct {
+	// The index of the input that caused the conflict.
+	InputIndex int
+	// The error of the validation that failed.
+	Err error
+}
+
+// NewInputConflictError creates a new InputConflictError.
+func NewInputConflictError(inputIndex int, err error) *InputConflictError {
+	return &InputConflictError{
+		InputIndex: inputIndex,
+		Err:        err,
+	}
+}
+
+func (e *InputConflictError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InputConflictError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictInputIndexFromError returns the index of the input that caused the error,
+// or ConflictDetailsNoInputIndex if the error can't be attributed to a single input.
+func ConflictInputIndexFromError(err error) int {
+	var inputConflictError *InputConflictError
+	if errors.As(err, &inputConflictError) {
+		return inputConflictError.InputIndex
+	}
+
+	return ConflictDetailsNoInputIndex
+}
+
 func ConflictFromSemanticValidationError(err error) Conflict {
 	var chainError *iotago.ChainTransitionError
 	if errors.As(err, &chainError) {
@@ -131,6 +204,9 @@ type BlockMetadata struct {
 
 	conflict Conflict
 
+	// conflictDetails explains the conflict of the transaction in detail.
+	conflictDetails *ConflictDetails
+
 	// youngestConeRootIndex is the highest referenced index of the past cone of this block.
 	youngestConeRootIndex iotago.MilestoneIndex
 
@@ -245,16 +321,21 @@ func (m *BlockMetadata) IsConflictingTx() bool {
 	return m.metadata.HasBit(BlockMetadataConflictingTx)
 }
 
-func (m *BlockMetadata) SetConflictingTx(conflict Conflict) {
+func (m *BlockMetadata) SetConflictingTx(conflict Conflict, details *ConflictDetails) {
 	m.Lock()
 	defer m.Unlock()
 
 	conflictingTx := conflict != ConflictNone
+	if !conflictingTx {
+		details = nil
+	}
 
 	if conflictingTx != m.metadata.HasBit(BlockMetadataConflictingTx) ||
-		m.conflict != conflict {
+		m.conflict != conflict ||
+		m.conflictDetails != details {
 		m.metadata = m.metadata.ModifyBit(BlockMetadataConflictingTx, conflictingTx)
 		m.conflict = conflict
+		m.conflictDetails = details
 		m.SetModified(true)
 	}
 }
@@ -266,6 +347,15 @@ func (m *BlockMetadata) Conflict() Conflict {
 	return m.conflict
 }
 
+// ConflictDetails returns the details of the conflict, or nil if they are unknown.
+// Blocks that were marked as conflicting by older versions of the node have no details.
+func (m *BlockMetadata) ConflictDetails() *ConflictDetails {
+	m.RLock()
+	defer m.RUnlock()
+
+	return m.conflictDetails
+}
+
 func (m *BlockMetadata) IsMilestone() bool {
 	m.RLock()
 	defer m.RUnlock()
@@ -331,6 +421,12 @@ func (m *BlockMetadata) ObjectStorageValue() (data []byte) {
 		4 bytes iotago.MilestoneIndex coneRootCalculationIndex
 		1 byte  parents count
 		parents count * 32 bytes parent id
+		optional conflict details:
+			2 bytes int16 input index
+			2 bytes uint16 reason length
+			reason length bytes reason
+			1 byte  output IDs count
+			output IDs count * 34 bytes output ID
 	*/
 
 	marshalUtil := marshalutil.New(23 + len(m.parents)*iotago.BlockIDLength)
@@ -347,6 +443,16 @@ func (m *BlockMetadata) ObjectStorageValue() (data []byte) {
 		marshalUtil.WriteBytes(parent[:])
 	}
 
+	if m.conflictDetails != nil {
+		marshalUtil.WriteInt16(int16(m.conflictDetails.InputIndex))
+		marshalUtil.WriteUint16(uint16(len(m.conflictDetails.Reason)))
+		marshalUtil.WriteBytes([]byte(m.conflictDetails.Reason))
+		marshalUtil.WriteByte(byte(len(m.conflictDetails.OutputIDs)))
+		for _, outputID := range m.conflictDetails.OutputIDs {
+			marshalUtil.WriteBytes(outputID[:])
+		}
+	}
+
 	return marshalUtil.Bytes()
 }
 
@@ -362,6 +468,12 @@ func MetadataFactory(key []byte, data []byte) (objectstorage.StorableObject, err
 		4 bytes iotago.MilestoneIndex coneRootCalculationIndex
 		1 byte  parents count
 		parents count * 32 bytes parent id
+		optional conflict details:
+			2 bytes int16 input index
+			2 bytes uint16 reason length
+			reason length bytes reason
+			1 byte  output IDs count
+			output IDs count * 34 bytes output ID
 	*/
 
 	m := &BlockMetadata{}
@@ -421,5 +533,49 @@ func MetadataFactory(key []byte, data []byte) (objectstorage.StorableObject, err
 		copy(m.parents[i][:], parentBytes)
 	}
 
+	// the conflict details are optional, they were not stored by older versions of the node
+	doneReading, err := marshalUtil.DoneReading()
+	if err != nil {
+		return nil, err
+	}
+	if doneReading {
+		return m, nil
+	}
+
+	inputIndex, err := marshalUtil.ReadInt16()
+	if err != nil {
+		return nil, err
+	}
+
+	reasonLength, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+
+	reasonBytes, err := marshalUtil.ReadBytes(int(reasonLength))
+	if err != nil {
+		return nil, err
+	}
+
+	outputIDsCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	outputIDs := make(iotago.OutputIDs, outputIDsCount)
+	for i := 0; i < int(outputIDsCount); i++ {
+		outputIDBytes, err := marshalUtil.ReadBytes(iotago.OutputIDLength)
+		if err != nil {
+			return nil, err
+		}
+		copy(outputIDs[i][:], outputIDBytes)
+	}
+
+	m.conflictDetails = &ConflictDetails{
+		InputIndex: int(inputIndex),
+		Reason:     string(reasonBytes),
+		OutputIDs:  outputIDs,
+	}
+
 	return m, nil
 }
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrLedgerStateNotAvailable is returned if the ledger state at a milestone index can't be computed,
+	// because the index is newer than the ledger index or the milestone diffs were already pruned.
+	ErrLedgerStateNotAvailable = errors.New("ledger state not available")
+)
+
+// LedgerStateHash contains the hash of the ledger state at a certain ledger index.
+type LedgerStateHash struct {
+	// The index of the ledger state.
+	LedgerIndex iotago.MilestoneIndex
+	// The unspent treasury output, or nil if there is none.
+	TreasuryOutput *utxo.TreasuryOutput
+	// The amount of unspent outputs.
+	UTXOsCount int
+	// The amount of solid entry points.
+	SolidEntryPointsCount int
+	// The sum of the deposits of all unspent outputs and the treasury.
+	LedgerTokenSupply uint64
+	// The sha256 hash of the ledger index, the treasury output and all unspent outputs.
+	Hash []byte
+	// The sha256 hash of the ledger state including the solid entry points.
+	// Only set for the current ledger state.
+	HashWithSolidEntryPoints []byte
+}
+
+// hashLedgerState writes the ledger index, the treasury output and the unspent outputs in lexicographical order into a new sha256 hash.
+func (s *Storage) hashLedgerState(ledgerIndex iotago.MilestoneIndex, treasuryOutput *utxo.TreasuryOutput, outputIDs iotago.OutputIDs) (hash.Hash, *LedgerStateHash, error) {
+
+	ledgerStateHash := &LedgerStateHash{
+		LedgerIndex:    ledgerIndex,
+		TreasuryOutput: treasuryOutput,
+		UTXOsCount:     len(outputIDs),
+	}
+
+	// compute the sha256 of the ledger state
+	lsHash := sha256.New()
+
+	// write current ledger index
+	if err := binary.Write(lsHash, binary.LittleEndian, ledgerIndex); err != nil {
+		return nil, nil, fmt.Errorf("unable to serialize ledger index: %w", err)
+	}
+
+	if treasuryOutput != nil {
+		// write current treasury output
+		if _, err := lsHash.Write(treasuryOutput.MilestoneID[:]); err != nil {
+			return nil, nil, fmt.Errorf("unable to hash treasury output milestone ID: %w", err)
+		}
+		if err := binary.Write(lsHash, binary.LittleEndian, treasuryOutput.Amount); err != nil {
+			return nil, nil, fmt.Errorf("unable to serialize treasury output amount: %w", err)
+		}
+		ledgerStateHash.LedgerTokenSupply += treasuryOutput.Amount
+	}
+
+	// write all unspent outputs in lexicographical order
+	for _, outputID := range outputIDs.RemoveDupsAndSort() {
+		output, err := s.utxoManager.ReadOutputByOutputIDWithoutLocking(outputID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ledgerStateHash.LedgerTokenSupply += output.Deposit()
+
+		if err = binary.Write(lsHash, binary.LittleEndian, output.SnapshotBytes()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// calculate sha256 hash of the ledger state
+	ledgerStateHash.Hash = lsHash.Sum(nil)
+
+	return lsHash, ledgerStateHash, nil
+}
+
+// ComputeLedgerStateHash computes the hash of the current ledger state.
+// The ledger and the solid entry points are read locked during the computation if lockLedger is set.
+func (s *Storage) ComputeLedgerStateHash(lockLedger bool) (*LedgerStateHash, error) {
+	if lockLedger {
+		s.utxoManager.ReadLockLedger()
+		defer s.utxoManager.ReadUnlockLedger()
+
+		s.ReadLockSolidEntryPoints()
+		defer s.ReadUnlockSolidEntryPoints()
+	}
+
+	ledgerIndex, err := s.utxoManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, err
+	}
+
+	// read out treasury tx
+	treasuryOutput, err := s.utxoManager.UnspentTreasuryOutputWithoutLocking()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get unspent treasury output: %w", err)
+	}
+
+	// get all UTXOs
+	outputIDs, err := s.utxoManager.UnspentOutputsIDs(utxo.ReadLockLedger(false))
+	if err != nil {
+		return nil, err
+	}
+
+	lsHash, ledgerStateHash, err := s.hashLedgerState(ledgerIndex, treasuryOutput, outputIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var solidEntryPoints iotago.BlockIDs
+	s.ForEachSolidEntryPointWithoutLocking(func(sep *SolidEntryPoint) bool {
+		solidEntryPoints = append(solidEntryPoints, sep.BlockID)
+
+		return true
+	})
+	ledgerStateHash.SolidEntryPointsCount = len(solidEntryPoints)
+
+	// write all solid entry points in lexicographical order
+	for _, solidEntryPoint := range solidEntryPoints.RemoveDupsAndSort() {
+		if err := binary.Write(lsHash, binary.LittleEndian, solidEntryPoint[:]); err != nil {
+			return nil, fmt.Errorf("unable to calculate snapshot hash: %w", err)
+		}
+	}
+
+	ledgerStateHash.HashWithSolidEntryPoints = lsHash.Sum(nil)
+
+	return ledgerStateHash, nil
+}
+
+// ComputeLedgerStateHashAtIndex computes the hash of the ledger state at the given milestone index.
+// The ledger state is reconstructed by rolling back the milestone diffs of all newer milestones,
+// so the milestone diffs down to the given index must not be pruned yet.
+// The ledger is only read locked to read the ledger index, so milestones can be confirmed during the computation.
+// The solid entry points of former ledger states are unknown, so HashWithSolidEntryPoints is not set.
+func (s *Storage) ComputeLedgerStateHashAtIndex(msIndex iotago.MilestoneIndex) (*LedgerStateHash, error) {
+	readLedgerIndexAndTreasury := func() (iotago.MilestoneIndex, *utxo.TreasuryOutput, error) {
+		s.utxoManager.ReadLockLedger()
+		defer s.utxoManager.ReadUnlockLedger()
+
+		ledgerIndex, err := s.utxoManager.ReadLedgerIndexWithoutLocking()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		treasuryOutput, err := s.utxoManager.UnspentTreasuryOutputWithoutLocking()
+		if err != nil {
+			return 0, nil, fmt.Errorf("unable to get unspent treasury output: %w", err)
+		}
+
+		return ledgerIndex, treasuryOutput, nil
+	}
+
+	ledgerIndex, treasuryOutput, err := readLedgerIndexAndTreasury()
+	if err != nil {
+		return nil, err
+	}
+
+	if msIndex > ledgerIndex {
+		return nil, errors.WithMessagef(ErrLedgerStateNotAvailable, "milestone index %d is newer than the ledger index %d", msIndex, ledgerIndex)
+	}
+
+	if pruningIndex := s.SnapshotInfo().PruningIndex(); msIndex < pruningIndex {
+		return nil, errors.WithMessagef(ErrLedgerStateNotAvailable, "milestone index %d is older than the pruning index %d", msIndex, pruningIndex)
+	}
+
+	// the unspent outputs are collected without locking the ledger, so newer milestones may be applied in the meantime.
+	// every output is unspent at any ledger index between the index read before and the index read afterwards,
+	// so rolling back all milestone diffs down to the target index results in the ledger state at the target index.
+	outputIDs, err := s.utxoManager.UnspentOutputsIDs(utxo.ReadLockLedger(false))
+	if err != nil {
+		return nil, err
+	}
+
+	latestLedgerIndex, _, err := readLedgerIndexAndTreasury()
+	if err != nil {
+		return nil, err
+	}
+
+	unspentOutputIDs := make(map[iotago.OutputID]struct{}, len(outputIDs))
+	for _, outputID := range outputIDs {
+		unspentOutputIDs[outputID] = struct{}{}
+	}
+
+	// roll back the milestone diffs of all newer milestones.
+	// applied milestone diffs are never modified, so they can be read without locking the ledger.
+	for index := latestLedgerIndex; index > msIndex; index-- {
+		msDiff, err := s.utxoManager.MilestoneDiffWithoutLocking(index)
+		if err != nil {
+			return nil, errors.WithMessagef(ErrLedgerStateNotAvailable, "loading milestone diff %d failed: %s", index, err)
+		}
+
+		for _, output := range msDiff.Outputs {
+			delete(unspentOutputIDs, output.OutputID())
+		}
+
+		for _, spent := range msDiff.Spents {
+			unspentOutputIDs[spent.OutputID()] = struct{}{}
+		}
+
+		if msDiff.SpentTreasuryOutput != nil {
+			treasuryOutput = msDiff.SpentTreasuryOutput
+		}
+	}
+
+	outputIDs = make(iotago.OutputIDs, 0, len(unspentOutputIDs))
+	for outputID := range unspentOutputIDs {
+		outputIDs = append(outputIDs, outputID)
+	}
+
+	_, ledgerStateHash, err := s.hashLedgerState(msIndex, treasuryOutput, outputIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ledgerStateHash, nil
+}
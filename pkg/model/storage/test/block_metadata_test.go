@@ -0,0 +1,92 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct,golint,stylecheck // we don't care about these linters in test cases
+package storage_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func metadataRoundTrip(t *testing.T, metadata *storage.BlockMetadata) *storage.BlockMetadata {
+	restored, err := storage.MetadataFactory(metadata.ObjectStorageKey(), metadata.ObjectStorageValue())
+	require.NoError(t, err)
+
+	return restored.(*storage.BlockMetadata)
+}
+
+func TestBlockMetadata_ConflictDetails(t *testing.T) {
+	parents := iotago.BlockIDs{tpkg.RandBlockID(), tpkg.RandBlockID()}
+
+	// blocks without conflict details can still be restored
+	metadata := storage.NewBlockMetadata(tpkg.RandBlockID(), parents)
+	metadata.SetConflictingTx(storage.ConflictInputUTXONotFound, nil)
+
+	restored := metadataRoundTrip(t, metadata)
+	require.Equal(t, parents, restored.Parents())
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXONotFound), restored.Conflict())
+	require.Nil(t, restored.ConflictDetails())
+
+	details := storage.NewConflictDetails(1, "input 1's timelocks are not expired", iotago.OutputIDs{tpkg.RandOutputID(0), tpkg.RandOutputID(1)})
+	metadata.SetConflictingTx(storage.ConflictTimelockNotExpired, details)
+
+	restored = metadataRoundTrip(t, metadata)
+	require.Equal(t, parents, restored.Parents())
+	require.True(t, restored.IsConflictingTx())
+	require.Equal(t, storage.Conflict(storage.ConflictTimelockNotExpired), restored.Conflict())
+	require.Equal(t, details, restored.ConflictDetails())
+
+	// the details are removed if the conflict is resolved
+	metadata.SetConflictingTx(storage.ConflictNone, details)
+	restored = metadataRoundTrip(t, metadata)
+	require.False(t, restored.IsConflictingTx())
+	require.Nil(t, restored.ConflictDetails())
+}
+
+func TestNewConflictDetails_TruncatesReason(t *testing.T) {
+	details := storage.NewConflictDetails(storage.ConflictDetailsNoInputIndex, strings.Repeat("a", 2000), nil)
+	require.Len(t, details.Reason, 1024)
+
+	// the reason is not truncated in the middle of a multi-byte character
+	details = storage.NewConflictDetails(storage.ConflictDetailsNoInputIndex, strings.Repeat("€", 1000), nil)
+	require.True(t, utf8.ValidString(details.Reason))
+	require.Equal(t, strings.Repeat("€", 341), details.Reason)
+
+	// a reason that fits is not changed
+	details = storage.NewConflictDetails(storage.ConflictDetailsNoInputIndex, strings.Repeat("€", 341), nil)
+	require.Equal(t, strings.Repeat("€", 341), details.Reason)
+}
+
+func TestConflictInputIndexFromError(t *testing.T) {
+	timelockErr := fmt.Errorf("%w: input %d's timelocks are not expired", iotago.ErrTimelockNotExpired, 3)
+	unlockErr := fmt.Errorf("%w: input %d is not unlocked through input %d's unlock", iotago.ErrInvalidInputUnlock, 2, 0)
+
+	tests := []struct {
+		err        error
+		inputIndex int
+	}{
+		{storage.NewInputConflictError(3, timelockErr), 3},
+		{fmt.Errorf("chain validation failed: %w", storage.NewInputConflictError(2, unlockErr)), 2},
+		{storage.NewInputConflictError(0, errors.New("test")), 0},
+		// the index is not parsed from the error message
+		{unlockErr, storage.ConflictDetailsNoInputIndex},
+		{fmt.Errorf("%w: in %d, out %d", iotago.ErrInputOutputSumMismatch, 10, 20), storage.ConflictDetailsNoInputIndex},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.inputIndex, storage.ConflictInputIndexFromError(test.err), test.err.Error())
+	}
+
+	// the conflict reason is still derived from the wrapped error
+	inputConflictErr := storage.NewInputConflictError(3, timelockErr)
+	require.Equal(t, timelockErr.Error(), inputConflictErr.Error())
+	require.Equal(t, storage.Conflict(storage.ConflictTimelockNotExpired), storage.ConflictFromSemanticValidationError(inputConflictErr))
+	require.Equal(t, storage.Conflict(storage.ConflictInvalidInputUnlock), storage.ConflictFromSemanticValidationError(storage.NewInputConflictError(2, unlockErr)))
+}
@@ -0,0 +1,82 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct,golint,stylecheck // we don't care about these linters in test cases
+package storage_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestComputeLedgerStateHashAtIndex(t *testing.T) {
+	seed1, _ := hex.DecodeString("96d9ff7a79e4b0a5f3e5848ae7867064402da92a62eabb4ebbe463f12d1f3b1aace1775488f51cb1e3a80732a03ef60b111d6833ab605aa9f8faebeb33bbe3d9")
+	seed2, _ := hex.DecodeString("b15209ddc93cbdb600137ea6a8f88cdd7c5d480d5815c9352a0fb5c4e4b86f7151dcb44c2ba635657a2df5a8fd48cb9bab674a9eceea527dbbb254ef8c9f9cd7")
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, 2, 15, 1, false)
+	defer te.CleanupTestEnvironment(true)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	ledgerStateHashes := make(map[iotago.MilestoneIndex][]byte)
+	storeCurrentLedgerStateHash := func() {
+		ledgerStateHash, err := te.Storage().ComputeLedgerStateHash(true)
+		require.NoError(t, err)
+		require.Equal(t, te.ProtocolParameters().TokenSupply, ledgerStateHash.LedgerTokenSupply)
+		ledgerStateHashes[ledgerStateHash.LedgerIndex] = ledgerStateHash.Hash
+	}
+	storeCurrentLedgerStateHash()
+
+	// move funds back and forth to create and spend outputs in every milestone
+	for _, wallets := range [][2]*utils.HDWallet{{seed1Wallet, seed2Wallet}, {seed2Wallet, seed1Wallet}, {seed1Wallet, seed2Wallet}} {
+		block := te.NewBlockBuilder("A").
+			Parents(te.LastMilestoneParents()).
+			FromWallet(wallets[0]).
+			Amount(1_000_000).
+			BuildTransactionToWallet(wallets[1]).
+			Store().
+			BookOnWallets()
+
+		te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{block.StoredBlockID()}, false)
+		storeCurrentLedgerStateHash()
+	}
+
+	require.Len(t, ledgerStateHashes, 4)
+
+	for msIndex, expectedHash := range ledgerStateHashes {
+		ledgerStateHash, err := te.Storage().ComputeLedgerStateHashAtIndex(msIndex)
+		require.NoError(t, err)
+		require.Equal(t, msIndex, ledgerStateHash.LedgerIndex)
+		require.Equal(t, expectedHash, ledgerStateHash.Hash)
+		require.Equal(t, te.ProtocolParameters().TokenSupply, ledgerStateHash.LedgerTokenSupply)
+		require.Nil(t, ledgerStateHash.HashWithSolidEntryPoints)
+	}
+
+	_, err := te.Storage().ComputeLedgerStateHashAtIndex(te.LastMilestoneIndex() + 1)
+	require.ErrorIs(t, err, storage.ErrLedgerStateNotAvailable)
+
+	// the hash over a range of milestone diffs is the hash over the single milestone diff hashes
+	msDiffsHash := sha256.New()
+	for msIndex := te.LastMilestoneIndex() - 2; msIndex <= te.LastMilestoneIndex(); msIndex++ {
+		msDiff, err := te.UTXOManager().MilestoneDiff(msIndex)
+		require.NoError(t, err)
+
+		msDiffHash, err := msDiff.SHA256Sum()
+		require.NoError(t, err)
+		msDiffsHash.Write(msDiffHash)
+	}
+
+	hash, err := te.UTXOManager().MilestoneDiffsSHA256Sum(te.LastMilestoneIndex()-2, te.LastMilestoneIndex())
+	require.NoError(t, err)
+	require.Equal(t, msDiffsHash.Sum(nil), hash)
+
+	_, err = te.UTXOManager().MilestoneDiffsSHA256Sum(te.LastMilestoneIndex(), te.LastMilestoneIndex()-1)
+	require.Error(t, err)
+}
@@ -223,5 +223,33 @@ func (u *Manager) MilestoneDiff(msIndex iotago.MilestoneIndex) (*MilestoneDiff,
 	return u.MilestoneDiffWithoutLocking(msIndex)
 }
 
+// MilestoneDiffsSHA256Sum computes the sha256 over the hashes of the milestone diffs in the given range (both inclusive).
+// It can be used to compare ranges of milestone diffs of different nodes without transferring all the hashes.
+// Applied milestone diffs are never modified, so the ledger is not locked during the computation.
+func (u *Manager) MilestoneDiffsSHA256Sum(startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) ([]byte, error) {
+	if startIndex > endIndex {
+		return nil, fmt.Errorf("start index %d is greater than end index %d", startIndex, endIndex)
+	}
+
+	msDiffsHash := sha256.New()
+	for msIndex := startIndex; msIndex <= endIndex; msIndex++ {
+		msDiff, err := u.MilestoneDiffWithoutLocking(msIndex)
+		if err != nil {
+			return nil, fmt.Errorf("loading milestone diff %d failed: %w", msIndex, err)
+		}
+
+		msDiffHash, err := msDiff.SHA256Sum()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := msDiffsHash.Write(msDiffHash); err != nil {
+			return nil, fmt.Errorf("unable to hash milestone diff %d: %w", msIndex, err)
+		}
+	}
+
+	return msDiffsHash.Sum(nil), nil
+}
+
 // code guards.
 var _ kvStorable = &MilestoneDiff{}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
 	peer2 "github.com/libp2p/go-libp2p/core/peer"
@@ -24,6 +25,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/event"
 	"github.com/iotaledger/hornet/v2/pkg/p2p"
 )
 
@@ -293,10 +295,19 @@ func parseEntryNode(entryNodeMultiAddrStr string, preferIPv6 bool) (entryNode *p
 	return peer.NewPeer(identity.New(*pubKey), ip, services), nil
 }
 
+// ManagerEvents are events happening around a Manager.
+type ManagerEvents struct {
+	// Fired when a neighbor filter made a decision about a peer.
+	NeighborFiltered *event.Event1[*FilterDecision]
+}
+
 type Manager struct {
 	// the logger used to log events.
 	*logger.WrappedLogger
 
+	// Events happening around the Manager.
+	Events *ManagerEvents
+
 	// bindAddress is the bind address for autopeering.
 	bindAddress string
 	// entryNodes are the entry nodes for autopeering.
@@ -311,12 +322,18 @@ type Manager struct {
 	discoveryProtocol *discover.Protocol
 	// selectionProtocol is the peer selection protocol.
 	selectionProtocol *selection.Protocol
+	// neighborFilters are applied in order to potential neighbors.
+	neighborFilters     []NeighborFilter
+	neighborFiltersLock sync.RWMutex
 }
 
 func NewManager(log *logger.Logger, bindAddress string, entryNodes []string, preferIPv6 bool, p2pServiceKey service.Key) *Manager {
 
 	return &Manager{
-		WrappedLogger:      logger.NewWrappedLogger(log),
+		WrappedLogger: logger.NewWrappedLogger(log),
+		Events: &ManagerEvents{
+			NeighborFiltered: event.New1[*FilterDecision](),
+		},
 		bindAddress:        bindAddress,
 		entryNodes:         entryNodes,
 		preferIPv6:         preferIPv6,
@@ -324,6 +341,7 @@ func NewManager(log *logger.Logger, bindAddress string, entryNodes []string, pre
 		localPeerContainer: nil,
 		discoveryProtocol:  nil,
 		selectionProtocol:  nil,
+		neighborFilters:    nil,
 	}
 }
 
@@ -347,6 +365,55 @@ func (a *Manager) Discovery() *discover.Protocol {
 	return a.discoveryProtocol
 }
 
+// AddNeighborFilter adds a filter which is applied to potential neighbors.
+// Filters are applied in the order they were added, the first rejection wins.
+func (a *Manager) AddNeighborFilter(filter NeighborFilter) {
+	a.neighborFiltersLock.Lock()
+	defer a.neighborFiltersLock.Unlock()
+
+	a.neighborFilters = append(a.neighborFilters, filter)
+}
+
+// Neighbors returns the current neighbors of the peer selection.
+func (a *Manager) Neighbors() []*peer.Peer {
+	if a.selectionProtocol == nil {
+		return nil
+	}
+
+	return a.selectionProtocol.GetNeighbors()
+}
+
+// VerifiedPeers returns all peers verified by the peer discovery.
+func (a *Manager) VerifiedPeers() []*peer.Peer {
+	if a.discoveryProtocol == nil {
+		return nil
+	}
+
+	return a.discoveryProtocol.GetVerifiedPeers()
+}
+
+// applyNeighborFilters applies all neighbor filters to the given peer and returns whether it was accepted.
+func (a *Manager) applyNeighborFilters(p *peer.Peer) bool {
+	a.neighborFiltersLock.RLock()
+	defer a.neighborFiltersLock.RUnlock()
+
+	for _, filter := range a.neighborFilters {
+		accepted, reason := filter.Filter(p)
+		a.Events.NeighborFiltered.Trigger(&FilterDecision{
+			Peer:     p,
+			Filter:   filter.Name(),
+			Accepted: accepted,
+			Reason:   reason,
+		})
+
+		if !accepted {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (a *Manager) Init(localPeerContainer *LocalPeerContainer, initSelection bool) {
 
 	parseEntryNodes := func(entryNodesString []string, preferIPv6 bool) (result []*peer.Peer, err error) {
@@ -394,7 +461,7 @@ func (a *Manager) Init(localPeerContainer *LocalPeerContainer, initSelection boo
 			return false
 		}
 
-		return true
+		return a.applyNeighborFilters(p)
 	}
 
 	a.selectionProtocol = selection.New(
@@ -0,0 +1,309 @@
+package autopeering
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	peer2 "github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+)
+
+const (
+	// FullHistoryServiceKey is the service key a node uses to advertise that it keeps the full history of the tangle.
+	FullHistoryServiceKey service.Key = "fullhistory"
+
+	// FilterNameLatency is the name of the LatencyFilter.
+	FilterNameLatency = "latency"
+	// FilterNameFullHistory is the name of the FullHistoryFilter.
+	FilterNameFullHistory = "fullHistory"
+	// FilterNameSubnetDiversity is the name of the SubnetDiversityFilter.
+	FilterNameSubnetDiversity = "subnetDiversity"
+	// FilterNameHandshakeFailures is the name of the HandshakeFailureFilter.
+	FilterNameHandshakeFailures = "handshakeFailures"
+
+	// the prefix length of the subnets used to group IPv4 addresses.
+	subnetPrefixLengthIPv4 = 16
+	// the prefix length of the subnets used to group IPv6 addresses.
+	subnetPrefixLengthIPv6 = 32
+)
+
+// NeighborFilter decides whether a peer is a suitable autopeering neighbor.
+type NeighborFilter interface {
+	// Name returns the name of the filter.
+	Name() string
+	// Filter returns whether the peer is accepted as a neighbor and the reason for the decision.
+	Filter(p *peer.Peer) (bool, string)
+}
+
+// FilterDecision is the decision of a NeighborFilter about a peer.
+type FilterDecision struct {
+	// The peer the decision was made for.
+	Peer *peer.Peer
+	// The name of the filter which made the decision.
+	Filter string
+	// Whether the peer was accepted.
+	Accepted bool
+	// The reason for the decision.
+	Reason string
+}
+
+// PeersFunc returns a list of autopeering peers.
+type PeersFunc func() []*peer.Peer
+
+// AdvertisesFullHistory tells whether the given peer advertises to keep the full history of the tangle.
+func AdvertisesFullHistory(p *peer.Peer) bool {
+	return p.Services().Get(FullHistoryServiceKey) != nil
+}
+
+// LatencyFunc returns the latency to the given peer and whether it is known.
+type LatencyFunc func(p *peer.Peer) (time.Duration, bool)
+
+// LatencyFilter ranks peers by their latency and only accepts the peers with the lowest latency.
+// Peers with an unknown latency or a latency above a threshold are rejected.
+type LatencyFilter struct {
+	maxLatency     time.Duration
+	bestCandidates int
+	latencyFunc    LatencyFunc
+	neighborsFunc  PeersFunc
+	candidatesFunc PeersFunc
+}
+
+// NewLatencyFilter creates a new LatencyFilter.
+// A peer is only accepted if less than bestCandidates other candidates, which are not neighbors yet,
+// have a lower latency. The ranking is disabled if bestCandidates is 0.
+func NewLatencyFilter(maxLatency time.Duration, bestCandidates int, latencyFunc LatencyFunc, neighborsFunc PeersFunc, candidatesFunc PeersFunc) *LatencyFilter {
+	return &LatencyFilter{
+		maxLatency:     maxLatency,
+		bestCandidates: bestCandidates,
+		latencyFunc:    latencyFunc,
+		neighborsFunc:  neighborsFunc,
+		candidatesFunc: candidatesFunc,
+	}
+}
+
+// Name returns the name of the filter.
+func (f *LatencyFilter) Name() string {
+	return FilterNameLatency
+}
+
+// Filter rejects the peer if its latency is unknown, above the threshold,
+// or if it doesn't rank among the candidates with the lowest latency.
+func (f *LatencyFilter) Filter(p *peer.Peer) (bool, string) {
+	latency, known := f.latencyFunc(p)
+	if !known {
+		return false, "latency unknown"
+	}
+
+	if latency > f.maxLatency {
+		return false, fmt.Sprintf("latency %v above %v", latency.Truncate(time.Millisecond), f.maxLatency)
+	}
+
+	if f.bestCandidates == 0 {
+		return true, fmt.Sprintf("latency %v", latency.Truncate(time.Millisecond))
+	}
+
+	neighbors := make(map[string]struct{})
+	for _, neighbor := range f.neighborsFunc() {
+		neighbors[neighbor.ID().String()] = struct{}{}
+	}
+
+	fasterCandidates := 0
+	for _, candidate := range f.candidatesFunc() {
+		if candidate.ID() == p.ID() {
+			continue
+		}
+
+		if _, isNeighbor := neighbors[candidate.ID().String()]; isNeighbor {
+			continue
+		}
+
+		if candidateLatency, known := f.latencyFunc(candidate); known && candidateLatency < latency {
+			fasterCandidates++
+		}
+	}
+
+	if fasterCandidates >= f.bestCandidates {
+		return false, fmt.Sprintf("latency %v, %d candidates with a lower latency", latency.Truncate(time.Millisecond), fasterCandidates)
+	}
+
+	return true, fmt.Sprintf("latency %v, ranked %d", latency.Truncate(time.Millisecond), fasterCandidates+1)
+}
+
+// FullHistoryFilter prefers peers which advertise to keep the full history of the tangle.
+// As long as less than the configured amount of neighbors keep the full history,
+// other peers are rejected if there are known peers with full history available.
+type FullHistoryFilter struct {
+	minNeighbors   int
+	neighborsFunc  PeersFunc
+	candidatesFunc PeersFunc
+}
+
+// NewFullHistoryFilter creates a new FullHistoryFilter.
+func NewFullHistoryFilter(minNeighbors int, neighborsFunc PeersFunc, candidatesFunc PeersFunc) *FullHistoryFilter {
+	return &FullHistoryFilter{
+		minNeighbors:   minNeighbors,
+		neighborsFunc:  neighborsFunc,
+		candidatesFunc: candidatesFunc,
+	}
+}
+
+// Name returns the name of the filter.
+func (f *FullHistoryFilter) Name() string {
+	return FilterNameFullHistory
+}
+
+// Filter rejects the peer if it doesn't keep the full history, not enough neighbors keep the full history
+// and there are other peers available that keep the full history.
+func (f *FullHistoryFilter) Filter(p *peer.Peer) (bool, string) {
+	if AdvertisesFullHistory(p) {
+		return true, "peer keeps full history"
+	}
+
+	neighbors := make(map[string]struct{})
+	fullHistoryNeighbors := 0
+	for _, neighbor := range f.neighborsFunc() {
+		neighbors[neighbor.ID().String()] = struct{}{}
+
+		if neighbor.ID() == p.ID() || !AdvertisesFullHistory(neighbor) {
+			continue
+		}
+		fullHistoryNeighbors++
+	}
+
+	if fullHistoryNeighbors >= f.minNeighbors {
+		return true, fmt.Sprintf("%d neighbors keep full history", fullHistoryNeighbors)
+	}
+
+	for _, candidate := range f.candidatesFunc() {
+		if candidate.ID() == p.ID() {
+			continue
+		}
+
+		if _, isNeighbor := neighbors[candidate.ID().String()]; isNeighbor {
+			continue
+		}
+
+		if AdvertisesFullHistory(candidate) {
+			return false, "peers with full history available"
+		}
+	}
+
+	return true, "no peers with full history available"
+}
+
+// SubnetDiversityFilter limits the amount of neighbors within the same IP subnet.
+// IPv4 addresses are grouped by /16, IPv6 addresses by /32 subnets.
+type SubnetDiversityFilter struct {
+	maxPeersPerSubnet int
+	neighborsFunc     PeersFunc
+}
+
+// NewSubnetDiversityFilter creates a new SubnetDiversityFilter.
+func NewSubnetDiversityFilter(maxPeersPerSubnet int, neighborsFunc PeersFunc) *SubnetDiversityFilter {
+	return &SubnetDiversityFilter{
+		maxPeersPerSubnet: maxPeersPerSubnet,
+		neighborsFunc:     neighborsFunc,
+	}
+}
+
+// Name returns the name of the filter.
+func (f *SubnetDiversityFilter) Name() string {
+	return FilterNameSubnetDiversity
+}
+
+// Filter rejects the peer if the maximum amount of neighbors within its subnet is reached.
+func (f *SubnetDiversityFilter) Filter(p *peer.Peer) (bool, string) {
+	subnet := subnetOf(p.IP())
+
+	peersInSubnet := 0
+	for _, neighbor := range f.neighborsFunc() {
+		if neighbor.ID() == p.ID() {
+			continue
+		}
+
+		if subnet.Contains(neighbor.IP()) {
+			peersInSubnet++
+		}
+	}
+
+	if peersInSubnet >= f.maxPeersPerSubnet {
+		return false, fmt.Sprintf("%d neighbors in subnet %s", peersInSubnet, subnet)
+	}
+
+	return true, fmt.Sprintf("%d neighbors in subnet %s", peersInSubnet, subnet)
+}
+
+// subnetOf returns the subnet the given IP is grouped into.
+func subnetOf(ip net.IP) *net.IPNet {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		mask := net.CIDRMask(subnetPrefixLengthIPv4, net.IPv4len*8)
+
+		return &net.IPNet{IP: ipv4.Mask(mask), Mask: mask}
+	}
+
+	mask := net.CIDRMask(subnetPrefixLengthIPv6, net.IPv6len*8)
+
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+// HandshakeFailureFilter excludes peers for a certain duration after the gossip handshake with them failed.
+type HandshakeFailureFilter struct {
+	exclusionDuration time.Duration
+	failuresLock      sync.Mutex
+	failures          map[peer2.ID]time.Time
+}
+
+// NewHandshakeFailureFilter creates a new HandshakeFailureFilter.
+func NewHandshakeFailureFilter(exclusionDuration time.Duration) *HandshakeFailureFilter {
+	return &HandshakeFailureFilter{
+		exclusionDuration: exclusionDuration,
+		failures:          make(map[peer2.ID]time.Time),
+	}
+}
+
+// Name returns the name of the filter.
+func (f *HandshakeFailureFilter) Name() string {
+	return FilterNameHandshakeFailures
+}
+
+// ReportFailure marks the given peer as failed and excludes it for the configured duration.
+func (f *HandshakeFailureFilter) ReportFailure(peerID peer2.ID) {
+	f.failuresLock.Lock()
+	defer f.failuresLock.Unlock()
+
+	f.cleanup()
+	f.failures[peerID] = time.Now()
+}
+
+// Filter rejects the peer if the gossip handshake with it failed within the exclusion duration.
+func (f *HandshakeFailureFilter) Filter(p *peer.Peer) (bool, string) {
+	peerID, err := HivePeerToPeerID(p)
+	if err != nil {
+		return false, fmt.Sprintf("unable to convert peer to peerID: %s", err)
+	}
+
+	f.failuresLock.Lock()
+	defer f.failuresLock.Unlock()
+
+	f.cleanup()
+
+	failedAt, failed := f.failures[peerID]
+	if failed {
+		return false, fmt.Sprintf("gossip handshake failed at %s", failedAt.Format(time.RFC3339))
+	}
+
+	return true, "no failed gossip handshake"
+}
+
+// cleanup removes all expired failures. The caller must hold the failuresLock.
+func (f *HandshakeFailureFilter) cleanup() {
+	for peerID, failedAt := range f.failures {
+		if time.Since(failedAt) > f.exclusionDuration {
+			delete(f.failures, peerID)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package autopeering_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hornet/v2/pkg/p2p/autopeering"
+)
+
+func newTestPeer(t *testing.T, ip string, fullHistory bool) *peer.Peer {
+	pubKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 14626)
+	services.Update("test", "tcp", 15600)
+	if fullHistory {
+		services.Update(autopeering.FullHistoryServiceKey, "tcp", 15600)
+	}
+
+	return peer.NewPeer(identity.New(pubKey), net.ParseIP(ip), services)
+}
+
+func peersFunc(peers ...*peer.Peer) autopeering.PeersFunc {
+	return func() []*peer.Peer {
+		return peers
+	}
+}
+
+func TestLatencyFilter(t *testing.T) {
+	fast := newTestPeer(t, "10.0.0.1", false)
+	slow := newTestPeer(t, "10.0.0.2", false)
+	unknown := newTestPeer(t, "10.0.0.3", false)
+
+	latencies := map[identity.ID]time.Duration{
+		fast.ID(): 50 * time.Millisecond,
+		slow.ID(): time.Second,
+	}
+	latencyFunc := func(p *peer.Peer) (time.Duration, bool) {
+		latency, known := latencies[p.ID()]
+
+		return latency, known
+	}
+
+	filter := autopeering.NewLatencyFilter(500*time.Millisecond, 0, latencyFunc, peersFunc(), peersFunc(fast, slow, unknown))
+
+	accepted, _ := filter.Filter(fast)
+	require.True(t, accepted)
+	accepted, _ = filter.Filter(slow)
+	require.False(t, accepted)
+
+	// peers are only accepted once their latency was measured
+	accepted, reason := filter.Filter(unknown)
+	require.False(t, accepted)
+	require.Equal(t, "latency unknown", reason)
+}
+
+func TestLatencyFilterRanking(t *testing.T) {
+	peer1 := newTestPeer(t, "10.0.0.1", false)
+	peer2 := newTestPeer(t, "10.0.0.2", false)
+	peer3 := newTestPeer(t, "10.0.0.3", false)
+	unknown := newTestPeer(t, "10.0.0.4", false)
+
+	latencies := map[identity.ID]time.Duration{
+		peer1.ID(): 10 * time.Millisecond,
+		peer2.ID(): 20 * time.Millisecond,
+		peer3.ID(): 30 * time.Millisecond,
+	}
+	latencyFunc := func(p *peer.Peer) (time.Duration, bool) {
+		latency, known := latencies[p.ID()]
+
+		return latency, known
+	}
+	candidates := peersFunc(peer1, peer2, peer3, unknown)
+
+	// only the two candidates with the lowest latency are accepted
+	filter := autopeering.NewLatencyFilter(500*time.Millisecond, 2, latencyFunc, peersFunc(), candidates)
+
+	accepted, _ := filter.Filter(peer1)
+	require.True(t, accepted)
+	accepted, reason := filter.Filter(peer2)
+	require.True(t, accepted)
+	require.Equal(t, "latency 20ms, ranked 2", reason)
+	accepted, reason = filter.Filter(peer3)
+	require.False(t, accepted)
+	require.Equal(t, "latency 30ms, 2 candidates with a lower latency", reason)
+
+	// peers that are already neighbors don't take a place in the ranking of the candidates
+	filter = autopeering.NewLatencyFilter(500*time.Millisecond, 2, latencyFunc, peersFunc(peer1), candidates)
+
+	accepted, _ = filter.Filter(peer3)
+	require.True(t, accepted)
+
+	// the threshold still applies to the best candidates
+	filter = autopeering.NewLatencyFilter(15*time.Millisecond, 2, latencyFunc, peersFunc(), candidates)
+
+	accepted, _ = filter.Filter(peer2)
+	require.False(t, accepted)
+}
+
+func TestLatencyTracker(t *testing.T) {
+	reachable := newTestPeer(t, "10.0.0.1", false)
+	unreachable := newTestPeer(t, "10.0.0.2", false)
+	gone := newTestPeer(t, "10.0.0.3", false)
+
+	rtts := map[identity.ID]time.Duration{
+		reachable.ID(): 20 * time.Millisecond,
+		gone.ID():      10 * time.Millisecond,
+	}
+	peers := []*peer.Peer{reachable, unreachable, gone}
+
+	tracker := autopeering.NewLatencyTracker(time.Minute, func(p *peer.Peer) error {
+		rtt, reachable := rtts[p.ID()]
+		if !reachable {
+			return errors.New("timeout")
+		}
+		time.Sleep(rtt)
+
+		return nil
+	}, func() []*peer.Peer { return peers })
+
+	_, known := tracker.Latency(reachable)
+	require.False(t, known)
+
+	tracker.Measure(context.Background())
+
+	latency, known := tracker.Latency(reachable)
+	require.True(t, known)
+	require.GreaterOrEqual(t, latency, 20*time.Millisecond)
+	_, known = tracker.Latency(unreachable)
+	require.False(t, known)
+	_, known = tracker.Latency(gone)
+	require.True(t, known)
+
+	// the latency of peers that are no longer returned is forgotten
+	peers = []*peer.Peer{reachable, unreachable}
+	tracker.Measure(context.Background())
+
+	_, known = tracker.Latency(gone)
+	require.False(t, known)
+	latency, known = tracker.Latency(reachable)
+	require.True(t, known)
+	require.GreaterOrEqual(t, latency, 20*time.Millisecond)
+}
+
+func TestFullHistoryFilter(t *testing.T) {
+	fullHistory1 := newTestPeer(t, "10.0.0.1", true)
+	fullHistory2 := newTestPeer(t, "10.0.0.2", true)
+	pruned := newTestPeer(t, "10.0.0.3", false)
+
+	// full history peers are always accepted
+	filter := autopeering.NewFullHistoryFilter(1, peersFunc(), peersFunc(fullHistory1, pruned))
+	accepted, _ := filter.Filter(fullHistory1)
+	require.True(t, accepted)
+
+	// pruned peers are rejected as long as full history peers are available
+	accepted, _ = filter.Filter(pruned)
+	require.False(t, accepted)
+
+	// pruned peers are accepted if no full history peers are available
+	filter = autopeering.NewFullHistoryFilter(1, peersFunc(), peersFunc(pruned))
+	accepted, _ = filter.Filter(pruned)
+	require.True(t, accepted)
+
+	// full history peers that are already neighbors are not available
+	filter = autopeering.NewFullHistoryFilter(2, peersFunc(fullHistory1), peersFunc(fullHistory1, pruned))
+	accepted, _ = filter.Filter(pruned)
+	require.True(t, accepted)
+
+	// pruned peers are accepted if enough neighbors keep the full history
+	filter = autopeering.NewFullHistoryFilter(1, peersFunc(fullHistory1), peersFunc(fullHistory1, fullHistory2, pruned))
+	accepted, _ = filter.Filter(pruned)
+	require.True(t, accepted)
+}
+
+func TestSubnetDiversityFilter(t *testing.T) {
+	neighbor := newTestPeer(t, "10.0.1.1", false)
+	sameSubnet := newTestPeer(t, "10.0.200.1", false)
+	otherSubnet := newTestPeer(t, "10.1.0.1", false)
+	neighborIPv6 := newTestPeer(t, "2001:db8:1::1", false)
+	sameSubnetIPv6 := newTestPeer(t, "2001:db8:2::1", false)
+
+	filter := autopeering.NewSubnetDiversityFilter(1, peersFunc(neighbor, neighborIPv6))
+
+	accepted, _ := filter.Filter(sameSubnet)
+	require.False(t, accepted)
+	accepted, _ = filter.Filter(otherSubnet)
+	require.True(t, accepted)
+	accepted, _ = filter.Filter(sameSubnetIPv6)
+	require.False(t, accepted)
+
+	// the peer itself is not counted
+	accepted, _ = filter.Filter(neighbor)
+	require.True(t, accepted)
+
+	filter = autopeering.NewSubnetDiversityFilter(2, peersFunc(neighbor))
+	accepted, _ = filter.Filter(sameSubnet)
+	require.True(t, accepted)
+}
+
+func TestHandshakeFailureFilter(t *testing.T) {
+	failed := newTestPeer(t, "10.0.0.1", false)
+	other := newTestPeer(t, "10.0.0.2", false)
+
+	failedID, err := autopeering.HivePeerToPeerID(failed)
+	require.NoError(t, err)
+
+	filter := autopeering.NewHandshakeFailureFilter(50 * time.Millisecond)
+	filter.ReportFailure(failedID)
+
+	accepted, _ := filter.Filter(failed)
+	require.False(t, accepted)
+	accepted, _ = filter.Filter(other)
+	require.True(t, accepted)
+
+	// the exclusion expires
+	require.Eventually(t, func() bool {
+		accepted, _ := filter.Filter(failed)
+
+		return accepted
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,96 @@
+package autopeering
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+const (
+	// the weight of a new measurement in the moving average of the latency.
+	latencySmoothing = 0.3
+)
+
+// PingFunc sends a discovery ping to the given peer and blocks until the reply is received.
+type PingFunc func(p *peer.Peer) error
+
+// LatencyTracker measures the latency to the autopeering peers by the round trip time of discovery pings.
+// Contrary to the latency measured by libp2p, it is available before a connection to the peer exists,
+// so it can be used to select neighbors.
+type LatencyTracker struct {
+	interval  time.Duration
+	pingFunc  PingFunc
+	peersFunc PeersFunc
+
+	latenciesLock sync.RWMutex
+	latencies     map[identity.ID]time.Duration
+}
+
+// NewLatencyTracker creates a new LatencyTracker that pings the peers returned by peersFunc in the given interval.
+func NewLatencyTracker(interval time.Duration, pingFunc PingFunc, peersFunc PeersFunc) *LatencyTracker {
+	return &LatencyTracker{
+		interval:  interval,
+		pingFunc:  pingFunc,
+		peersFunc: peersFunc,
+		latencies: make(map[identity.ID]time.Duration),
+	}
+}
+
+// Latency returns the moving average of the round trip time to the given peer and whether it is known.
+func (t *LatencyTracker) Latency(p *peer.Peer) (time.Duration, bool) {
+	t.latenciesLock.RLock()
+	defer t.latenciesLock.RUnlock()
+
+	latency, known := t.latencies[p.ID()]
+
+	return latency, known
+}
+
+// Measure pings all peers once and updates their latency.
+// The latency of peers that didn't reply or that are gone is unknown afterwards.
+// A ping is retried on timeouts, so peers that drop packets get a higher latency.
+func (t *LatencyTracker) Measure(ctx context.Context) {
+	peers := t.peersFunc()
+
+	measured := make(map[identity.ID]time.Duration, len(peers))
+	for _, p := range peers {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		if err := t.pingFunc(p); err != nil {
+			continue
+		}
+		measured[p.ID()] = time.Since(start)
+	}
+
+	t.latenciesLock.Lock()
+	defer t.latenciesLock.Unlock()
+
+	for id, rtt := range measured {
+		if latency, known := t.latencies[id]; known {
+			measured[id] = time.Duration(float64(latency)*(1-latencySmoothing) + float64(rtt)*latencySmoothing)
+		}
+	}
+	t.latencies = measured
+}
+
+// Run measures the latency to the peers in the configured interval until the given context is done.
+func (t *LatencyTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		t.Measure(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
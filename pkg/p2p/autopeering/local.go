@@ -55,7 +55,8 @@ func NewLocalPeerContainer(p2pServiceKey service.Key,
 	dbEngine hivedb.Engine,
 	p2pBindMultiAddresses []string,
 	autopeeringBindAddr string,
-	runAsEntryNode bool) (*LocalPeerContainer, error) {
+	runAsEntryNode bool,
+	advertiseFullHistory bool) (*LocalPeerContainer, error) {
 
 	// let the autopeering discover the IP
 	// TODO: is this really necessary?
@@ -101,6 +102,11 @@ func NewLocalPeerContainer(p2pServiceKey service.Key,
 		}
 
 		ownServices.Update(p2pServiceKey, "tcp", libp2pBindPort)
+
+		if advertiseFullHistory {
+			// the port has no meaning here, the service only signals that the node keeps the full history
+			ownServices.Update(FullHistoryServiceKey, "tcp", libp2pBindPort)
+		}
 	}
 
 	store, err := database.StoreWithDefaultSettings(filepath.Join(p2pDatabasePath, "autopeering"), true, dbEngine, database.AllowedEnginesDefault...)
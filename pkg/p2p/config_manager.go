@@ -7,11 +7,38 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/event"
 )
 
+// PeerConfigDiff contains the changes between two lists of peers of the peering config.
+type PeerConfigDiff struct {
+	// The peers that were added.
+	Added []*PeerConfig
+	// The peers that were removed.
+	Removed []*PeerConfig
+	// The peers whose alias changed.
+	AliasUpdated []*PeerConfig
+}
+
+// Empty tells whether the diff contains no changes.
+func (d *PeerConfigDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.AliasUpdated) == 0
+}
+
+// ConfigManagerEvents are events happening around a ConfigManager.
+type ConfigManagerEvents struct {
+	// Fired when the peers were reloaded from the peering config.
+	Reloaded *event.Event1[*PeerConfigDiff]
+	// Fired when reloading the peers from the peering config failed.
+	ReloadFailed *event.Event1[error]
+}
+
 // ConfigManager handles the list of peers that are stored in the peering config.
 // It calls a function if the list changed.
 type ConfigManager struct {
+	// Events happening around the ConfigManager.
+	Events        *ConfigManagerEvents
 	storeCallback func([]*PeerConfig) error
 	storeOnChange bool
 	peersLock     sync.RWMutex
@@ -21,6 +48,10 @@ type ConfigManager struct {
 // NewConfigManager creates a new config manager.
 func NewConfigManager(storeCallback func([]*PeerConfig) error) *ConfigManager {
 	return &ConfigManager{
+		Events: &ConfigManagerEvents{
+			Reloaded:     event.New1[*PeerConfigDiff](),
+			ReloadFailed: event.New1[error](),
+		},
 		storeCallback: storeCallback,
 		storeOnChange: false,
 		peers:         []*PeerConfig{},
@@ -106,6 +137,105 @@ func (pm *ConfigManager) RemovePeer(peerID peer.ID) error {
 	return errors.New("peer not found")
 }
 
+// Reload replaces all known peers with the given peers, e.g. after the peering config was modified on disk.
+// The new peers are validated first, if they are invalid, the known peers stay untouched.
+// The returned diff contains the changes that need to be applied to the Manager.
+// The peers are not stored again, since they stem from the peering config.
+func (pm *ConfigManager) Reload(peers []*PeerConfig) (*PeerConfigDiff, error) {
+	newPeers, err := peerConfigsByID(peers, false)
+	if err != nil {
+		err = fmt.Errorf("invalid peering config: %w", err)
+		pm.Events.ReloadFailed.Trigger(err)
+
+		return nil, err
+	}
+
+	pm.peersLock.Lock()
+
+	// wrong values of the current peers were already ignored when they were added
+	oldPeers, _ := peerConfigsByID(pm.peers, true)
+
+	diff := &PeerConfigDiff{
+		Added:        []*PeerConfig{},
+		Removed:      []*PeerConfig{},
+		AliasUpdated: []*PeerConfig{},
+	}
+
+	for peerID, oldPeer := range oldPeers {
+		newPeer, has := newPeers[peerID]
+		switch {
+		case !has:
+			diff.Removed = append(diff.Removed, oldPeer)
+		case newPeer.MultiAddress != oldPeer.MultiAddress:
+			// the address changed, so the peer needs to be reconnected
+			diff.Removed = append(diff.Removed, oldPeer)
+			diff.Added = append(diff.Added, newPeer)
+		case newPeer.Alias != oldPeer.Alias:
+			diff.AliasUpdated = append(diff.AliasUpdated, newPeer)
+		}
+	}
+
+	for peerID, newPeer := range newPeers {
+		if _, has := oldPeers[peerID]; !has {
+			diff.Added = append(diff.Added, newPeer)
+		}
+	}
+
+	pm.peers = make([]*PeerConfig, 0, len(peers))
+	for _, p := range peers {
+		pm.peers = append(pm.peers, &PeerConfig{
+			MultiAddress: p.MultiAddress,
+			Alias:        p.Alias,
+		})
+	}
+
+	pm.peersLock.Unlock()
+
+	if !diff.Empty() {
+		pm.Events.Reloaded.Trigger(diff)
+	}
+
+	return diff, nil
+}
+
+// maps the given peer configs by their peer ID.
+// if ignoreInvalid is false, an error is returned for invalid or duplicated entries.
+func peerConfigsByID(peers []*PeerConfig, ignoreInvalid bool) (map[peer.ID]*PeerConfig, error) {
+	result := make(map[peer.ID]*PeerConfig, len(peers))
+
+	for i, p := range peers {
+		multiAddr, err := multiaddr.NewMultiaddr(p.MultiAddress)
+		if err != nil {
+			if ignoreInvalid {
+				continue
+			}
+
+			return nil, fmt.Errorf("invalid peer address at pos %d: %w", i, err)
+		}
+
+		addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			if ignoreInvalid {
+				continue
+			}
+
+			return nil, fmt.Errorf("invalid peer address info at pos %d: %w", i, err)
+		}
+
+		if _, has := result[addrInfo.ID]; has {
+			if ignoreInvalid {
+				continue
+			}
+
+			return nil, fmt.Errorf("duplicated peer at pos %d: %s", i, addrInfo.ID)
+		}
+
+		result[addrInfo.ID] = p
+	}
+
+	return result, nil
+}
+
 // StoreOnChange sets whether storing changes to the config is active or not.
 func (pm *ConfigManager) StoreOnChange(store bool) {
 	pm.storeOnChange = store
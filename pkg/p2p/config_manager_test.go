@@ -0,0 +1,96 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package p2p_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+func TestConfigManagerReload(t *testing.T) {
+	peer1 := randPeerID(t)
+	peer2 := randPeerID(t)
+	peer3 := randPeerID(t)
+
+	addr := func(port int, id fmt.Stringer) string {
+		return fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", port, id)
+	}
+
+	storeCalled := false
+	configManager := p2p.NewConfigManager(func(_ []*p2p.PeerConfig) error {
+		storeCalled = true
+
+		return nil
+	})
+	configManager.StoreOnChange(true)
+
+	diff, err := configManager.Reload([]*p2p.PeerConfig{
+		{MultiAddress: addr(15600, peer1), Alias: "peer1"},
+		{MultiAddress: addr(15600, peer2), Alias: "peer2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, diff.Added, 2)
+	require.Empty(t, diff.Removed)
+	require.Empty(t, diff.AliasUpdated)
+	require.Len(t, configManager.Peers(), 2)
+
+	// reloading the same config results in no changes
+	reloadedEventCalled := false
+	configManager.Events.Reloaded.Hook(func(_ *p2p.PeerConfigDiff) {
+		reloadedEventCalled = true
+	})
+
+	diff, err = configManager.Reload(configManager.Peers())
+	require.NoError(t, err)
+	require.True(t, diff.Empty())
+	require.False(t, reloadedEventCalled)
+
+	// remove peer 1, rename peer 2, move peer 3 to a new address
+	diff, err = configManager.Reload([]*p2p.PeerConfig{
+		{MultiAddress: addr(15600, peer2), Alias: "renamed"},
+		{MultiAddress: addr(15600, peer3)},
+	})
+	require.NoError(t, err)
+	require.True(t, reloadedEventCalled)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, addr(15600, peer1), diff.Removed[0].MultiAddress)
+	require.Len(t, diff.AliasUpdated, 1)
+	require.Equal(t, "renamed", diff.AliasUpdated[0].Alias)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, addr(15600, peer3), diff.Added[0].MultiAddress)
+
+	diff, err = configManager.Reload([]*p2p.PeerConfig{
+		{MultiAddress: addr(15600, peer2), Alias: "renamed"},
+		{MultiAddress: addr(15601, peer3)},
+	})
+	require.NoError(t, err)
+	require.Len(t, diff.Removed, 1)
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, addr(15601, peer3), diff.Added[0].MultiAddress)
+
+	// invalid configs keep the known peers untouched
+	var reloadErr error
+	configManager.Events.ReloadFailed.Hook(func(err error) {
+		reloadErr = err
+	})
+
+	_, err = configManager.Reload([]*p2p.PeerConfig{
+		{MultiAddress: "invalid"},
+	})
+	require.Error(t, err)
+	require.Equal(t, err, reloadErr)
+
+	_, err = configManager.Reload([]*p2p.PeerConfig{
+		{MultiAddress: addr(15600, peer1)},
+		{MultiAddress: addr(15601, peer1)},
+	})
+	require.Error(t, err)
+
+	require.Len(t, configManager.Peers(), 2)
+
+	// the peers stem from the peering config, so they are not stored again
+	require.False(t, storeCalled)
+}
@@ -0,0 +1,303 @@
+package p2p
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrFirewallRuleInvalid gets returned if a firewall rule is malformed.
+	ErrFirewallRuleInvalid = errors.New("invalid firewall rule")
+	// ErrFirewallRuleExists gets returned if an identical firewall rule is already present.
+	ErrFirewallRuleExists = errors.New("firewall rule already exists")
+	// ErrFirewallRuleNotFound gets returned if a firewall rule with the given ID does not exist.
+	ErrFirewallRuleNotFound = errors.New("firewall rule not found")
+)
+
+// FirewallAction defines what happens to a connection matching a firewall rule.
+type FirewallAction string
+
+const (
+	// FirewallActionAllow lets the connection pass.
+	FirewallActionAllow FirewallAction = "allow"
+	// FirewallActionDeny closes the connection.
+	FirewallActionDeny FirewallAction = "deny"
+)
+
+// FirewallRule is a single rule of the Firewall.
+// All non-empty criteria of a rule have to match for the rule to apply.
+type FirewallRule struct {
+	// The ID of the rule, derived from its content.
+	ID string `json:"id" koanf:"id"`
+	// The action to take if the rule matches.
+	Action FirewallAction `json:"action" koanf:"action"`
+	// The peer ID the rule applies to (optional).
+	PeerID string `json:"peerId,omitempty" koanf:"peerId"`
+	// The IP range in CIDR notation the rule applies to (optional).
+	CIDR string `json:"cidr,omitempty" koanf:"cidr"`
+	// The peer relation the rule applies to (optional).
+	Relation PeerRelation `json:"relation,omitempty" koanf:"relation"`
+	// A free text comment to describe the rule.
+	Comment string `json:"comment,omitempty" koanf:"comment"`
+
+	peerID peer.ID
+	ipNet  *net.IPNet
+}
+
+// computeID derives the ID of the rule from its matching criteria and action.
+func (r *FirewallRule) computeID() string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%s", r.Action, r.PeerID, r.CIDR, r.Relation)))
+
+	return hex.EncodeToString(hash[:8])
+}
+
+// init validates the rule and parses its criteria.
+func (r *FirewallRule) init() error {
+	switch r.Action {
+	case FirewallActionAllow, FirewallActionDeny:
+	default:
+		return errors.WithMessagef(ErrFirewallRuleInvalid, "unknown action '%s'", r.Action)
+	}
+
+	switch r.Relation {
+	case "", PeerRelationKnown, PeerRelationUnknown, PeerRelationAutopeered:
+	default:
+		return errors.WithMessagef(ErrFirewallRuleInvalid, "unknown relation '%s'", r.Relation)
+	}
+
+	if r.PeerID == "" && r.CIDR == "" && r.Relation == "" {
+		return errors.WithMessage(ErrFirewallRuleInvalid, "at least one of peerId, cidr or relation has to be specified")
+	}
+
+	r.peerID = ""
+	if r.PeerID != "" {
+		peerID, err := peer.Decode(r.PeerID)
+		if err != nil {
+			return errors.WithMessagef(ErrFirewallRuleInvalid, "invalid peerId: %s", err)
+		}
+		r.peerID = peerID
+	}
+
+	r.ipNet = nil
+	if r.CIDR != "" {
+		_, ipNet, err := net.ParseCIDR(r.CIDR)
+		if err != nil {
+			return errors.WithMessagef(ErrFirewallRuleInvalid, "invalid cidr: %s", err)
+		}
+		r.ipNet = ipNet
+	}
+
+	r.ID = r.computeID()
+
+	return nil
+}
+
+// Matches tells whether the rule applies to a connection with the given properties.
+func (r *FirewallRule) Matches(peerID peer.ID, remoteAddr multiaddr.Multiaddr, relation PeerRelation) bool {
+	if r.peerID != "" && r.peerID != peerID {
+		return false
+	}
+
+	if r.Relation != "" && r.Relation != relation {
+		return false
+	}
+
+	if r.ipNet != nil {
+		if remoteAddr == nil {
+			return false
+		}
+
+		ip, err := manet.ToIP(remoteAddr)
+		if err != nil {
+			return false
+		}
+
+		if !r.ipNet.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Firewall decides whether connections to remote peers are allowed,
+// based on an ordered list of rules. The first matching rule wins,
+// if no rule matches, the default action is applied.
+// It calls a function if the list of rules changed.
+type Firewall struct {
+	storeCallback func([]*FirewallRule) error
+	defaultAction FirewallAction
+	rulesLock     sync.RWMutex
+	rules         []*FirewallRule
+}
+
+// NewFirewall creates a new Firewall.
+func NewFirewall(defaultAction FirewallAction, storeCallback func([]*FirewallRule) error) (*Firewall, error) {
+	switch defaultAction {
+	case FirewallActionAllow, FirewallActionDeny:
+	default:
+		return nil, fmt.Errorf("unknown firewall default action '%s'", defaultAction)
+	}
+
+	return &Firewall{
+		storeCallback: storeCallback,
+		defaultAction: defaultAction,
+		rules:         []*FirewallRule{},
+	}, nil
+}
+
+// DefaultAction returns the action applied if no rule matches.
+func (f *Firewall) DefaultAction() FirewallAction {
+	return f.defaultAction
+}
+
+// Rules returns a copy of all rules in order of evaluation.
+func (f *Firewall) Rules() []*FirewallRule {
+	f.rulesLock.RLock()
+	defer f.rulesLock.RUnlock()
+
+	rules := make([]*FirewallRule, len(f.rules))
+	for i, rule := range f.rules {
+		ruleCopy := *rule
+		rules[i] = &ruleCopy
+	}
+
+	return rules
+}
+
+// LoadRules replaces all rules of the Firewall without storing them.
+func (f *Firewall) LoadRules(rules []*FirewallRule) error {
+	loaded := make([]*FirewallRule, 0, len(rules))
+	seen := make(map[string]struct{}, len(rules))
+
+	for i, rule := range rules {
+		ruleCopy := *rule
+		if err := ruleCopy.init(); err != nil {
+			return fmt.Errorf("firewall rule at pos %d: %w", i, err)
+		}
+
+		if _, has := seen[ruleCopy.ID]; has {
+			continue
+		}
+		seen[ruleCopy.ID] = struct{}{}
+
+		loaded = append(loaded, &ruleCopy)
+	}
+
+	f.rulesLock.Lock()
+	defer f.rulesLock.Unlock()
+
+	f.rules = loaded
+
+	return nil
+}
+
+// AddRule validates the given rule and appends it to the rules of the Firewall.
+func (f *Firewall) AddRule(rule *FirewallRule) (*FirewallRule, error) {
+	ruleCopy := *rule
+	if err := ruleCopy.init(); err != nil {
+		return nil, err
+	}
+
+	f.rulesLock.Lock()
+	defer f.rulesLock.Unlock()
+
+	for _, r := range f.rules {
+		if r.ID == ruleCopy.ID {
+			return nil, ErrFirewallRuleExists
+		}
+	}
+
+	f.rules = append(f.rules, &ruleCopy)
+
+	result := ruleCopy
+
+	return &result, f.store()
+}
+
+// RemoveRule removes the rule with the given ID.
+func (f *Firewall) RemoveRule(ruleID string) error {
+	f.rulesLock.Lock()
+	defer f.rulesLock.Unlock()
+
+	for i, r := range f.rules {
+		if r.ID != ruleID {
+			continue
+		}
+
+		// delete with preserving order, since the order defines the priority
+		f.rules = append(f.rules[:i], f.rules[i+1:]...)
+
+		return f.store()
+	}
+
+	return ErrFirewallRuleNotFound
+}
+
+// Evaluate returns whether a connection with the given properties is allowed
+// and the rule that lead to the decision (nil if the default action was applied).
+func (f *Firewall) Evaluate(peerID peer.ID, remoteAddr multiaddr.Multiaddr, relation PeerRelation) (bool, *FirewallRule) {
+	f.rulesLock.RLock()
+	defer f.rulesLock.RUnlock()
+
+	for _, rule := range f.rules {
+		if rule.Matches(peerID, remoteAddr, relation) {
+			ruleCopy := *rule
+
+			return rule.Action == FirewallActionAllow, &ruleCopy
+		}
+	}
+
+	return f.defaultAction == FirewallActionAllow, nil
+}
+
+// EvaluatePeer evaluates the rules for a connection to the given peer whose address is not known yet.
+// It returns whether the decision already follows from the peer and its relation, whether the connection
+// is allowed and the rule that lead to the decision (nil if the default action was applied).
+// The decision is undetermined if a rule with a CIDR would be evaluated before any rule matches.
+func (f *Firewall) EvaluatePeer(peerID peer.ID, relation PeerRelation) (bool, bool, *FirewallRule) {
+	f.rulesLock.RLock()
+	defer f.rulesLock.RUnlock()
+
+	for _, rule := range f.rules {
+		if rule.peerID != "" && rule.peerID != peerID {
+			continue
+		}
+
+		if rule.Relation != "" && rule.Relation != relation {
+			continue
+		}
+
+		if rule.ipNet != nil {
+			// whether the rule matches depends on the address
+			return false, false, nil
+		}
+
+		ruleCopy := *rule
+
+		return true, rule.Action == FirewallActionAllow, &ruleCopy
+	}
+
+	return true, f.defaultAction == FirewallActionAllow, nil
+}
+
+// store calls the storeCallback. The caller must hold the rulesLock.
+func (f *Firewall) store() error {
+	if f.storeCallback == nil {
+		return nil
+	}
+
+	if err := f.storeCallback(f.rules); err != nil {
+		return fmt.Errorf("failed to store firewall rules: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package p2p_test
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+func randPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestFirewall(t *testing.T) {
+	var stored []*p2p.FirewallRule
+	firewall, err := p2p.NewFirewall(p2p.FirewallActionAllow, func(rules []*p2p.FirewallRule) error {
+		stored = rules
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	peer1 := randPeerID(t)
+	peer2 := randPeerID(t)
+	addrInRange := multiaddr.StringCast("/ip4/10.0.1.5/tcp/15600")
+	addrOutOfRange := multiaddr.StringCast("/ip4/192.168.1.5/tcp/15600")
+
+	// default action
+	allowed, rule := firewall.Evaluate(peer1, addrInRange, p2p.PeerRelationUnknown)
+	require.True(t, allowed)
+	require.Nil(t, rule)
+
+	// invalid rules
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: "drop", CIDR: "10.0.0.0/16"})
+	require.ErrorIs(t, err, p2p.ErrFirewallRuleInvalid)
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny})
+	require.ErrorIs(t, err, p2p.ErrFirewallRuleInvalid)
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, CIDR: "10.0.0.0"})
+	require.ErrorIs(t, err, p2p.ErrFirewallRuleInvalid)
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, PeerID: "invalid"})
+	require.ErrorIs(t, err, p2p.ErrFirewallRuleInvalid)
+
+	// the first matching rule wins
+	allowPeer1, err := firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionAllow, PeerID: peer1.String()})
+	require.NoError(t, err)
+	denyRange, err := firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, CIDR: "10.0.0.0/16"})
+	require.NoError(t, err)
+	denyAutopeered, err := firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, Relation: p2p.PeerRelationAutopeered})
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, CIDR: "10.0.0.0/16", Comment: "duplicate"})
+	require.ErrorIs(t, err, p2p.ErrFirewallRuleExists)
+
+	allowed, rule = firewall.Evaluate(peer1, addrInRange, p2p.PeerRelationUnknown)
+	require.True(t, allowed)
+	require.Equal(t, allowPeer1.ID, rule.ID)
+
+	allowed, rule = firewall.Evaluate(peer2, addrInRange, p2p.PeerRelationKnown)
+	require.False(t, allowed)
+	require.Equal(t, denyRange.ID, rule.ID)
+
+	allowed, rule = firewall.Evaluate(peer2, addrOutOfRange, p2p.PeerRelationAutopeered)
+	require.False(t, allowed)
+	require.Equal(t, denyAutopeered.ID, rule.ID)
+
+	allowed, rule = firewall.Evaluate(peer2, addrOutOfRange, p2p.PeerRelationKnown)
+	require.True(t, allowed)
+	require.Nil(t, rule)
+
+	// removing a rule
+	require.NoError(t, firewall.RemoveRule(denyRange.ID))
+	require.ErrorIs(t, firewall.RemoveRule(denyRange.ID), p2p.ErrFirewallRuleNotFound)
+	require.Len(t, stored, 2)
+
+	allowed, _ = firewall.Evaluate(peer2, addrInRange, p2p.PeerRelationKnown)
+	require.True(t, allowed)
+
+	// loading the stored rules restores the same state
+	restored, err := p2p.NewFirewall(p2p.FirewallActionDeny, nil)
+	require.NoError(t, err)
+	require.NoError(t, restored.LoadRules(stored))
+	require.Equal(t, firewall.Rules(), restored.Rules())
+
+	allowed, rule = restored.Evaluate(peer2, addrOutOfRange, p2p.PeerRelationKnown)
+	require.False(t, allowed)
+	require.Nil(t, rule)
+}
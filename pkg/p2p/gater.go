@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/control"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// ConnectionGaterEvents are events happening around a ConnectionGater.
+type ConnectionGaterEvents struct {
+	// Fired when a connection was denied by the firewall.
+	// The address is nil if the dial to a peer was denied before an address was chosen.
+	// The rule is nil if the default action of the firewall was applied.
+	ConnectionDenied *event.Event3[peer.ID, multiaddr.Multiaddr, *FirewallRule]
+}
+
+// ConnectionGater applies the rules of a Firewall to the connections of a libp2p host.
+// Denied peers are not dialed and inbound connections of denied peers are closed
+// before the connection is upgraded, so no stream is ever opened on a denied connection.
+// The relations of the peers, which are used to evaluate the rules, are kept up to date by the Manager.
+type ConnectionGater struct {
+	// Events happening around the ConnectionGater.
+	Events *ConnectionGaterEvents
+	// the firewall that decides whether connections are allowed.
+	firewall *Firewall
+	// the relations of the peers as known by the Manager.
+	relationsLock sync.RWMutex
+	relations     map[peer.ID]PeerRelation
+}
+
+var _ connmgr.ConnectionGater = (*ConnectionGater)(nil)
+
+// NewConnectionGater creates a new ConnectionGater that applies the rules of the given Firewall.
+func NewConnectionGater(firewall *Firewall) *ConnectionGater {
+	return &ConnectionGater{
+		Events: &ConnectionGaterEvents{
+			ConnectionDenied: event.New3[peer.ID, multiaddr.Multiaddr, *FirewallRule](),
+		},
+		firewall:  firewall,
+		relations: make(map[peer.ID]PeerRelation),
+	}
+}
+
+// Firewall returns the firewall used by the ConnectionGater.
+func (g *ConnectionGater) Firewall() *Firewall {
+	return g.firewall
+}
+
+// Allowed tells whether a connection to the given peer using the given address is allowed.
+// The ConnectionDenied event is triggered if the connection is denied.
+func (g *ConnectionGater) Allowed(peerID peer.ID, remoteAddr multiaddr.Multiaddr) bool {
+	allowed, rule := g.firewall.Evaluate(peerID, remoteAddr, g.relation(peerID))
+	if !allowed {
+		g.Events.ConnectionDenied.Trigger(peerID, remoteAddr, rule)
+	}
+
+	return allowed
+}
+
+// InterceptPeerDial denies dials to peers that are denied regardless of their address.
+func (g *ConnectionGater) InterceptPeerDial(peerID peer.ID) bool {
+	decided, allowed, rule := g.firewall.EvaluatePeer(peerID, g.relation(peerID))
+	if !decided || allowed {
+		return true
+	}
+
+	g.Events.ConnectionDenied.Trigger(peerID, nil, rule)
+
+	return false
+}
+
+// InterceptAddrDial denies dials to addresses of peers that are denied.
+func (g *ConnectionGater) InterceptAddrDial(peerID peer.ID, remoteAddr multiaddr.Multiaddr) bool {
+	return g.Allowed(peerID, remoteAddr)
+}
+
+// InterceptAccept allows all inbound connections, since the peer is only known after the handshake.
+func (g *ConnectionGater) InterceptAccept(_ network.ConnMultiaddrs) bool {
+	return true
+}
+
+// InterceptSecured denies connections of peers that are denied, as soon as the identity of the peer is known.
+func (g *ConnectionGater) InterceptSecured(_ network.Direction, peerID peer.ID, addrs network.ConnMultiaddrs) bool {
+	return g.Allowed(peerID, addrs.RemoteMultiaddr())
+}
+
+// InterceptUpgraded allows all upgraded connections, since they already passed InterceptSecured.
+func (g *ConnectionGater) InterceptUpgraded(_ network.Conn) (bool, control.DisconnectReason) {
+	return true, 0
+}
+
+// relation returns the relation of the given peer.
+func (g *ConnectionGater) relation(peerID peer.ID) PeerRelation {
+	g.relationsLock.RLock()
+	defer g.relationsLock.RUnlock()
+
+	if relation, has := g.relations[peerID]; has {
+		return relation
+	}
+
+	return PeerRelationUnknown
+}
+
+// setRelation stores the relation of the given peer.
+func (g *ConnectionGater) setRelation(peerID peer.ID, relation PeerRelation) {
+	g.relationsLock.Lock()
+	defer g.relationsLock.Unlock()
+
+	if relation == PeerRelationUnknown {
+		delete(g.relations, peerID)
+
+		return
+	}
+
+	g.relations[peerID] = relation
+}
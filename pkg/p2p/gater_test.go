@@ -0,0 +1,96 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package p2p_test
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+type testConnMultiaddrs struct {
+	remote multiaddr.Multiaddr
+}
+
+func (c *testConnMultiaddrs) LocalMultiaddr() multiaddr.Multiaddr {
+	return multiaddr.StringCast("/ip4/127.0.0.1/tcp/15600")
+}
+
+func (c *testConnMultiaddrs) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.remote
+}
+
+func TestConnectionGater(t *testing.T) {
+	firewall, err := p2p.NewFirewall(p2p.FirewallActionAllow, nil)
+	require.NoError(t, err)
+
+	gater := p2p.NewConnectionGater(firewall)
+	require.Equal(t, firewall, gater.Firewall())
+
+	type denial struct {
+		peerID     peer.ID
+		remoteAddr multiaddr.Multiaddr
+		rule       *p2p.FirewallRule
+	}
+	var denied []denial
+	gater.Events.ConnectionDenied.Hook(func(peerID peer.ID, remoteAddr multiaddr.Multiaddr, rule *p2p.FirewallRule) {
+		denied = append(denied, denial{peerID: peerID, remoteAddr: remoteAddr, rule: rule})
+	})
+
+	peer1 := randPeerID(t)
+	peer2 := randPeerID(t)
+	addrInRange := multiaddr.StringCast("/ip4/10.0.1.5/tcp/15600")
+	addrOutOfRange := multiaddr.StringCast("/ip4/192.168.1.5/tcp/15600")
+
+	// everything is allowed by the default action
+	require.True(t, gater.InterceptPeerDial(peer1))
+	require.True(t, gater.InterceptAddrDial(peer1, addrInRange))
+	require.True(t, gater.InterceptAccept(&testConnMultiaddrs{remote: addrInRange}))
+	require.True(t, gater.InterceptSecured(network.DirInbound, peer1, &testConnMultiaddrs{remote: addrInRange}))
+	require.Empty(t, denied)
+
+	denyPeer1, err := firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, PeerID: peer1.String()})
+	require.NoError(t, err)
+
+	// a denied peer is neither dialed nor able to finish the handshake of an inbound connection
+	require.False(t, gater.InterceptPeerDial(peer1))
+	require.False(t, gater.InterceptAddrDial(peer1, addrOutOfRange))
+	require.True(t, gater.InterceptAccept(&testConnMultiaddrs{remote: addrOutOfRange}))
+	require.False(t, gater.InterceptSecured(network.DirInbound, peer1, &testConnMultiaddrs{remote: addrOutOfRange}))
+	require.True(t, gater.InterceptSecured(network.DirInbound, peer2, &testConnMultiaddrs{remote: addrOutOfRange}))
+
+	require.Len(t, denied, 3)
+	require.Equal(t, peer1, denied[0].peerID)
+	require.Nil(t, denied[0].remoteAddr)
+	require.Equal(t, denyPeer1.ID, denied[0].rule.ID)
+	require.Equal(t, addrOutOfRange, denied[1].remoteAddr)
+	require.Equal(t, addrOutOfRange, denied[2].remoteAddr)
+	denied = nil
+
+	require.NoError(t, firewall.RemoveRule(denyPeer1.ID))
+
+	// whether a rule with a CIDR matches is only known once the address is known
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionDeny, CIDR: "10.0.0.0/16"})
+	require.NoError(t, err)
+
+	require.True(t, gater.InterceptPeerDial(peer2))
+	require.False(t, gater.InterceptAddrDial(peer2, addrInRange))
+	require.True(t, gater.InterceptAddrDial(peer2, addrOutOfRange))
+	require.False(t, gater.InterceptSecured(network.DirInbound, peer2, &testConnMultiaddrs{remote: addrInRange}))
+	require.Len(t, denied, 2)
+
+	// the default action applies to dials if no rule can match
+	firewall, err = p2p.NewFirewall(p2p.FirewallActionDeny, nil)
+	require.NoError(t, err)
+	gater = p2p.NewConnectionGater(firewall)
+
+	_, err = firewall.AddRule(&p2p.FirewallRule{Action: p2p.FirewallActionAllow, PeerID: peer1.String()})
+	require.NoError(t, err)
+
+	require.True(t, gater.InterceptPeerDial(peer1))
+	require.False(t, gater.InterceptPeerDial(peer2))
+}
@@ -7,6 +7,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	p2pevent "github.com/libp2p/go-libp2p/core/event"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
@@ -93,6 +94,8 @@ type ManagerEvents struct {
 	Reconnected *event.Event1[*Peer]
 	// Fired when the relation to a peer has been updated.
 	RelationUpdated *event.Event2[*Peer, PeerRelation]
+	// Fired when the reachability of the node changed.
+	ReachabilityChanged *event.Event1[network.Reachability]
 	// Fired when the Manager's state changes.
 	StateChange *event.Event1[ManagerState]
 	// Fired when internal error happens.
@@ -112,6 +115,10 @@ type ManagerOptions struct {
 	reconnectInterval time.Duration
 	// The randomized jitter applied to the reconnect interval.
 	reconnectIntervalJitter time.Duration
+	// The connection gater used to filter connections by the rules of a firewall.
+	connectionGater *ConnectionGater
+	// The transport preferences used to prefer the transport that last succeeded.
+	transportPreferences *TransportPreferences
 }
 
 // ManagerOption is a function setting a ManagerOptions option.
@@ -133,6 +140,24 @@ func WithManagerReconnectInterval(interval time.Duration, jitter time.Duration)
 	}
 }
 
+// WithManagerConnectionGater lets the Manager provide the relations of the peers to the given ConnectionGater
+// and close existing connections that are denied by new firewall rules.
+// The same ConnectionGater must be used as connection gater of the libp2p host.
+func WithManagerConnectionGater(connectionGater *ConnectionGater) ManagerOption {
+	return func(opts *ManagerOptions) {
+		opts.connectionGater = connectionGater
+	}
+}
+
+// WithManagerTransportPreferences lets the Manager record the addresses that last succeeded
+// to connect to peers, so that the same transport is preferred on reconnects.
+// The same TransportPreferences should be used as dial ranker of the libp2p host.
+func WithManagerTransportPreferences(transportPreferences *TransportPreferences) ManagerOption {
+	return func(opts *ManagerOptions) {
+		opts.transportPreferences = transportPreferences
+	}
+}
+
 // applies the given ManagerOption.
 func (mo *ManagerOptions) apply(opts ...ManagerOption) {
 	for _, opt := range opts {
@@ -158,18 +183,19 @@ func NewManager(host host.Host, opts ...ManagerOption) *Manager {
 
 	peeringManager := &Manager{
 		Events: &ManagerEvents{
-			Connect:            event.New1[*Peer](),
-			Disconnect:         event.New1[*Peer](),
-			Allowed:            event.New1[peer.ID](),
-			Disallowed:         event.New1[peer.ID](),
-			Connected:          event.New2[*Peer, network.Conn](),
-			Disconnected:       event.New2[*Peer, error](),
-			ScheduledReconnect: event.New2[*Peer, time.Duration](),
-			Reconnecting:       event.New1[*Peer](),
-			Reconnected:        event.New1[*Peer](),
-			RelationUpdated:    event.New2[*Peer, PeerRelation](),
-			StateChange:        event.New1[ManagerState](),
-			Error:              event.New1[error](),
+			Connect:             event.New1[*Peer](),
+			Disconnect:          event.New1[*Peer](),
+			Allowed:             event.New1[peer.ID](),
+			Disallowed:          event.New1[peer.ID](),
+			Connected:           event.New2[*Peer, network.Conn](),
+			Disconnected:        event.New2[*Peer, error](),
+			ScheduledReconnect:  event.New2[*Peer, time.Duration](),
+			Reconnecting:        event.New1[*Peer](),
+			Reconnected:         event.New1[*Peer](),
+			RelationUpdated:     event.New2[*Peer, PeerRelation](),
+			ReachabilityChanged: event.New1[network.Reachability](),
+			StateChange:         event.New1[ManagerState](),
+			Error:               event.New1[error](),
 		},
 		host:                   host,
 		peers:                  map[peer.ID]*Peer{},
@@ -188,6 +214,7 @@ func NewManager(host host.Host, opts ...ManagerOption) *Manager {
 		disconnectedChan:       make(chan *disconnectmsg, 10),
 		forEachChan:            make(chan *foreachmsg, 10),
 		callChan:               make(chan *callmsg, 10),
+		enforceFirewallChan:    make(chan *enforcefirewallmsg, 10),
 	}
 	peeringManager.WrappedLogger = logger.NewWrappedLogger(peeringManager.opts.logger)
 
@@ -212,6 +239,8 @@ type Manager struct {
 	opts *ManagerOptions
 	// tells whether the manager was shut down.
 	stopped atomic.Bool
+	// the reachability of the node as determined by AutoNAT.
+	reachability atomic.Int32
 	// event loop channels
 	connectPeerChan        chan *connectpeermsg
 	connectPeerAttemptChan chan *connectpeerattemptmsg
@@ -226,6 +255,7 @@ type Manager struct {
 	disconnectedChan       chan *disconnectmsg
 	forEachChan            chan *foreachmsg
 	callChan               chan *callmsg
+	enforceFirewallChan    chan *enforcefirewallmsg
 }
 
 // Start starts the Manager's event loop.
@@ -237,6 +267,15 @@ func (m *Manager) Start(ctx context.Context) {
 	// manage libp2p network events
 	m.host.Network().Notify((*netNotifiee)(m))
 
+	// track the reachability of the node
+	reachabilitySub, err := m.host.EventBus().Subscribe(new(p2pevent.EvtLocalReachabilityChanged))
+	if err != nil {
+		m.LogWarnf("unable to track reachability: %s", err)
+	} else {
+		defer func() { _ = reachabilitySub.Close() }()
+		go m.trackReachability(ctx, reachabilitySub)
+	}
+
 	m.Events.StateChange.Trigger(ManagerStateStarted)
 
 	// run the event loop machinery
@@ -300,6 +339,9 @@ drainLoop:
 		case callMsg := <-m.callChan:
 			callMsg.back <- struct{}{}
 
+		case enforceFirewallMsg := <-m.enforceFirewallChan:
+			enforceFirewallMsg.back <- ErrManagerShutdown
+
 		default:
 			break drainLoop
 		}
@@ -389,6 +431,19 @@ func (m *Manager) IsAllowed(peerID peer.ID) bool {
 	return <-back
 }
 
+// EnforceFirewall closes all existing connections that are denied by the current firewall rules.
+// The ConnectionDenied event of the ConnectionGater is triggered for every closed connection.
+func (m *Manager) EnforceFirewall() error {
+	if m.stopped.Load() {
+		return ErrManagerShutdown
+	}
+
+	back := make(chan error)
+	m.enforceFirewallChan <- &enforcefirewallmsg{back: back}
+
+	return <-back
+}
+
 // PeerForEachFunc is used in Manager.ForEach.
 // Returning false indicates to stop looping.
 // This function must not call any methods on Manager.
@@ -426,8 +481,7 @@ func (m *Manager) ConnectedCount(relation ...PeerRelation) int {
 func (m *Manager) PeerInfoSnapshot(id peer.ID) *PeerInfoSnapshot {
 	var info *PeerInfoSnapshot
 	m.Call(id, func(p *Peer) {
-		info = p.InfoSnapshot()
-		info.Connected = m.host.Network().Connectedness(p.ID) == network.Connected
+		info = m.infoSnapshot(p)
 	})
 
 	return info
@@ -437,9 +491,7 @@ func (m *Manager) PeerInfoSnapshot(id peer.ID) *PeerInfoSnapshot {
 func (m *Manager) PeerInfoSnapshots() []*PeerInfoSnapshot {
 	infos := make([]*PeerInfoSnapshot, 0)
 	m.ForEach(func(p *Peer) bool {
-		info := p.InfoSnapshot()
-		info.Connected = m.host.Network().Connectedness(p.ID) == network.Connected
-		infos = append(infos, info)
+		infos = append(infos, m.infoSnapshot(p))
 
 		return true
 	})
@@ -447,9 +499,41 @@ func (m *Manager) PeerInfoSnapshots() []*PeerInfoSnapshot {
 	return infos
 }
 
+// creates a snapshot of the given peer including information about its connections.
+// this function must only be called within the Manager's event loop.
+func (m *Manager) infoSnapshot(p *Peer) *PeerInfoSnapshot {
+	conns := m.host.Network().ConnsToPeer(p.ID)
+
+	info := p.InfoSnapshot()
+	info.Connected = m.host.Network().Connectedness(p.ID) == network.Connected
+	info.Relayed = isRelayed(conns)
+
+	for _, conn := range conns {
+		transport := TransportOf(conn.RemoteMultiaddr())
+		stats, has := info.Transports[transport]
+		if !has {
+			stats = &TransportStats{}
+			info.Transports[transport] = stats
+		}
+		stats.OpenConnections++
+	}
+
+	return info
+}
+
 // PeerFunc gets called with the given Peer.
 type PeerFunc func(p *Peer)
 
+// Reachability returns the reachability of the node as determined by AutoNAT.
+func (m *Manager) Reachability() network.Reachability {
+	return network.Reachability(m.reachability.Load())
+}
+
+// ConnectionGater returns the connection gater used by the Manager (nil if the firewall is disabled).
+func (m *Manager) ConnectionGater() *ConnectionGater {
+	return m.opts.connectionGater
+}
+
 // Call calls the given PeerFunc synchronized within the Manager's event loop, if the peer exists.
 // PeerFunc must not call any function on Manager.
 func (m *Manager) Call(peerID peer.ID, f PeerFunc) {
@@ -537,6 +621,10 @@ type callmsg struct {
 	back   chan struct{}
 }
 
+type enforcefirewallmsg struct {
+	back chan error
+}
+
 // runs the Manager's event loop, we do operations on the Manager in this way,
 // because dealing with the natural concurrency of handling network connections
 // becomes very messy, especially since libp2p's notifiee system isn't clear on
@@ -624,6 +712,7 @@ func (m *Manager) eventLoop(ctx context.Context) {
 		case connectedMsg := <-m.connectedChan:
 			p := m.peers[connectedMsg.conn.RemotePeer()]
 			m.addPeerAsUnknownIfAbsent(connectedMsg.conn)
+			m.recordTransport(connectedMsg.conn)
 			if p != nil {
 				m.resetReconnect(p.ID)
 				if !p.connectedEventCalled {
@@ -654,6 +743,10 @@ func (m *Manager) eventLoop(ctx context.Context) {
 		case callMsg := <-m.callChan:
 			m.call(callMsg.peerID, callMsg.f)
 			callMsg.back <- struct{}{}
+
+		case enforceFirewallMsg := <-m.enforceFirewallChan:
+			m.enforceFirewall()
+			enforceFirewallMsg.back <- nil
 		}
 	}
 }
@@ -696,6 +789,7 @@ func (m *Manager) connectPeer(ctx context.Context, connectPeerMsg *connectpeerms
 	}
 
 	m.peers[connectPeerMsg.addrInfo.ID] = p
+	m.updateGaterRelation(connectPeerMsg.addrInfo.ID)
 	m.Events.Connect.Trigger(p)
 
 	// perform an actual connection attempt to the given peer.
@@ -747,7 +841,7 @@ func (m *Manager) reconnectPeer(ctx context.Context, peerID peer.ID) {
 
 	m.Events.Reconnecting.Trigger(p)
 
-	addrInfo := peer.AddrInfo{ID: peerID, Addrs: p.Addrs}
+	addrInfo := peer.AddrInfo{ID: peerID, Addrs: m.preferredAddrsFirst(peerID, p.Addrs)}
 
 	// perform an actual connection attempt to the given peer.
 	// connection attempts should happen in a separate goroutine
@@ -775,6 +869,10 @@ func (m *Manager) disconnectPeer(peerID peer.ID) (bool, error) {
 	}
 	m.host.ConnManager().Unprotect(peerID, PeerConnectivityProtectionTag)
 	delete(m.peers, peerID)
+	m.updateGaterRelation(peerID)
+	if m.opts.transportPreferences != nil {
+		m.opts.transportPreferences.Remove(peerID)
+	}
 	m.Events.Disconnect.Trigger(p)
 
 	return true, m.host.Network().ClosePeer(peerID)
@@ -791,6 +889,7 @@ func (m *Manager) allowPeer(peerID peer.ID) error {
 	}
 
 	m.allowedPeers[peerID] = struct{}{}
+	m.updateGaterRelation(peerID)
 	m.Events.Allowed.Trigger(peerID)
 
 	return nil
@@ -803,6 +902,7 @@ func (m *Manager) disallowPeer(peerID peer.ID) {
 	}
 
 	delete(m.allowedPeers, peerID)
+	m.updateGaterRelation(peerID)
 	m.Events.Disallowed.Trigger(peerID)
 }
 
@@ -822,6 +922,7 @@ func (m *Manager) updateRelation(peerID peer.ID, newRelation PeerRelation) {
 	}
 	oldRelation := p.Relation
 	p.Relation = newRelation
+	m.updateGaterRelation(p.ID)
 
 	switch newRelation {
 	case PeerRelationUnknown:
@@ -908,15 +1009,122 @@ func (m *Manager) addPeerAsUnknownIfAbsent(conn network.Conn) {
 			relation = PeerRelationAutopeered
 		}
 		m.peers[conn.RemotePeer()] = NewPeer(conn.RemotePeer(), relation, addrs, "")
+		m.updateGaterRelation(conn.RemotePeer())
+	}
+}
+
+// stores the reachability of the node reported by the libp2p event bus.
+func (m *Manager) trackReachability(ctx context.Context, sub p2pevent.Subscription) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case evt, ok := <-sub.Out():
+			if !ok {
+				return
+			}
+
+			reachabilityEvt, ok := evt.(p2pevent.EvtLocalReachabilityChanged)
+			if !ok {
+				continue
+			}
+
+			if network.Reachability(m.reachability.Swap(int32(reachabilityEvt.Reachability))) != reachabilityEvt.Reachability {
+				m.Events.ReachabilityChanged.Trigger(reachabilityEvt.Reachability)
+			}
+		}
 	}
 }
 
+// closes all existing connections that are denied by the current firewall rules.
+// the peers are kept in the Manager, so known peers are still subject to reconnects,
+// which are denied by the connection gater as long as the rule exists.
+func (m *Manager) enforceFirewall() {
+	if m.opts.connectionGater == nil {
+		return
+	}
+
+	for _, conn := range m.host.Network().Conns() {
+		if m.opts.connectionGater.Allowed(conn.RemotePeer(), conn.RemoteMultiaddr()) {
+			continue
+		}
+
+		// closing the connection triggers the disconnected event,
+		// which takes care of the cleanup and reconnects.
+		_ = conn.Close()
+	}
+}
+
+// updates the relation of the given peer in the connection gater,
+// so that the firewall rules are evaluated with the relation known by the Manager.
+func (m *Manager) updateGaterRelation(peerID peer.ID) {
+	if m.opts.connectionGater == nil {
+		return
+	}
+
+	relation := PeerRelationUnknown
+	if p, has := m.peers[peerID]; has {
+		relation = p.Relation
+	} else if m.isAllowed(peerID) {
+		relation = PeerRelationAutopeered
+	}
+
+	m.opts.connectionGater.setRelation(peerID, relation)
+}
+
+// records the transport of the given connection for the statistics of the peer.
+// outbound connections are remembered as preferred, so the same transport is used for reconnects.
+func (m *Manager) recordTransport(conn network.Conn) {
+	p, has := m.peers[conn.RemotePeer()]
+	if !has {
+		return
+	}
+
+	transport := TransportOf(conn.RemoteMultiaddr())
+	p.LastTransport = transport
+	p.transportConnects[transport]++
+
+	if m.opts.transportPreferences != nil && conn.Stat().Direction == network.DirOutbound && transport != TransportRelay {
+		m.opts.transportPreferences.SetPreferred(conn.RemotePeer(), conn.RemoteMultiaddr())
+	}
+}
+
+// sorts the given addresses so that the addresses using the
+// transport that last succeeded to connect to the peer come first.
+func (m *Manager) preferredAddrsFirst(peerID peer.ID, addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
+	if m.opts.transportPreferences == nil {
+		return addrs
+	}
+
+	preferredAddr, has := m.opts.transportPreferences.Preferred(peerID)
+	if !has {
+		return addrs
+	}
+	preferredTransport := TransportOf(preferredAddr)
+
+	sorted := make([]multiaddr.Multiaddr, 0, len(addrs))
+	for _, addr := range addrs {
+		if TransportOf(addr) == preferredTransport {
+			sorted = append(sorted, addr)
+		}
+	}
+	for _, addr := range addrs {
+		if TransportOf(addr) != preferredTransport {
+			sorted = append(sorted, addr)
+		}
+	}
+
+	return sorted
+}
+
 // removes a not known peer if it has no more connections.
 func (m *Manager) cleanupPeerIfNotKnown(peerID peer.ID) {
 	p, has := m.peers[peerID]
 	if has && p.Relation != PeerRelationKnown && len(m.host.Network().ConnsToPeer(peerID)) == 0 {
 		m.host.ConnManager().Unprotect(peerID, PeerConnectivityProtectionTag)
 		delete(m.peers, peerID)
+		m.updateGaterRelation(peerID)
 	}
 }
 
@@ -951,7 +1159,7 @@ func (m *Manager) call(peerID peer.ID, f PeerFunc) {
 }
 
 func (m *Manager) hookEvents() (unhook func()) {
-	return lo.Batch(
+	unhook = lo.Batch(
 		// logger
 		m.Events.Connect.Hook(func(p *Peer) {
 			m.LogInfof("connecting %s: %s", p.ID.ShortString(), p.Addrs)
@@ -985,6 +1193,10 @@ func (m *Manager) hookEvents() (unhook func()) {
 			m.LogInfof("updated relation of %s from '%s' to '%s'", p.ID.ShortString(), oldRel, p.Relation)
 		}).Unhook,
 
+		m.Events.ReachabilityChanged.Hook(func(reachability network.Reachability) {
+			m.LogInfof("reachability changed to '%s'", reachability)
+		}).Unhook,
+
 		m.Events.StateChange.Hook(func(mngState ManagerState) {
 			m.LogInfo(mngState)
 		}).Unhook,
@@ -993,6 +1205,22 @@ func (m *Manager) hookEvents() (unhook func()) {
 			m.LogWarn(err)
 		}).Unhook,
 	)
+
+	if m.opts.connectionGater == nil {
+		return unhook
+	}
+
+	return lo.Batch(
+		unhook,
+		m.opts.connectionGater.Events.ConnectionDenied.Hook(func(peerID peer.ID, remoteAddr multiaddr.Multiaddr, rule *FirewallRule) {
+			if rule == nil {
+				m.LogInfof("denied connection of %s (%s) by default action", peerID.ShortString(), remoteAddr)
+
+				return
+			}
+			m.LogInfof("denied connection of %s (%s) by firewall rule %s", peerID.ShortString(), remoteAddr, rule.ID)
+		}).Unhook,
+	)
 }
 
 // lets Manager implement network.Notifiee, we do this as a separate
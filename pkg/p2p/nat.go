@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// ReachabilityPublic forces the node to consider itself publicly reachable.
+	ReachabilityPublic = "public"
+	// ReachabilityPrivate forces the node to consider itself not publicly reachable.
+	ReachabilityPrivate = "private"
+)
+
+// NATTraversalConfig defines the NAT traversal features of the libp2p host.
+type NATTraversalConfig struct {
+	// Whether to help other peers to determine their reachability (AutoNAT service).
+	AutoNATServiceEnabled bool
+	// Forces the reachability of the node instead of detecting it via AutoNAT ("", "public" or "private").
+	ForceReachability string
+	// Whether to reserve slots on the static relays if the node is not publicly reachable (circuit relay v2 client).
+	RelayClientEnabled bool
+	// The multiaddresses of the relays used by the relay client.
+	StaticRelays []string
+	// Whether to act as a relay for other peers if the node is publicly reachable (circuit relay v2 service).
+	RelayServiceEnabled bool
+	// Whether to upgrade relayed connections to direct connections via hole punching (DCUtR).
+	HolePunchingEnabled bool
+}
+
+// LibP2POptions returns the libp2p options to enable the configured NAT traversal features.
+func (c *NATTraversalConfig) LibP2POptions() ([]libp2p.Option, error) {
+	var opts []libp2p.Option
+
+	if c.AutoNATServiceEnabled {
+		opts = append(opts, libp2p.EnableNATService())
+	}
+
+	switch c.ForceReachability {
+	case "":
+	case ReachabilityPublic:
+		opts = append(opts, libp2p.ForceReachabilityPublic())
+	case ReachabilityPrivate:
+		opts = append(opts, libp2p.ForceReachabilityPrivate())
+	default:
+		return nil, fmt.Errorf("unknown reachability '%s'", c.ForceReachability)
+	}
+
+	if c.RelayClientEnabled {
+		if len(c.StaticRelays) == 0 {
+			return nil, fmt.Errorf("the relay client needs at least one static relay")
+		}
+
+		staticRelays := make([]peer.AddrInfo, 0, len(c.StaticRelays))
+		for i, relay := range c.StaticRelays {
+			multiAddr, err := multiaddr.NewMultiaddr(relay)
+			if err != nil {
+				return nil, fmt.Errorf("invalid static relay address at pos %d: %w", i, err)
+			}
+
+			addrInfo, err := peer.AddrInfoFromP2pAddr(multiAddr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid static relay address info at pos %d: %w", i, err)
+			}
+			staticRelays = append(staticRelays, *addrInfo)
+		}
+
+		opts = append(opts, libp2p.EnableAutoRelayWithStaticRelays(staticRelays))
+	}
+
+	if c.RelayServiceEnabled {
+		opts = append(opts, libp2p.EnableRelayService())
+	}
+
+	if c.HolePunchingEnabled {
+		opts = append(opts, libp2p.EnableHolePunching())
+	}
+
+	return opts, nil
+}
+
+// isRelayedAddr tells whether the given multiaddress is a circuit relay address.
+func isRelayedAddr(addr multiaddr.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(multiaddr.P_CIRCUIT)
+
+	return err == nil
+}
+
+// isRelayed tells whether all the given connections are relayed.
+func isRelayed(conns []network.Conn) bool {
+	if len(conns) == 0 {
+		return false
+	}
+
+	for _, conn := range conns {
+		if !isRelayedAddr(conn.RemoteMultiaddr()) {
+			return false
+		}
+	}
+
+	return true
+}
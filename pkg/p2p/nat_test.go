@@ -0,0 +1,94 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package p2p_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/protocol/circuitv2/client"
+	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+func newNATNode(t *testing.T, natConfig *p2p.NATTraversalConfig) host.Host {
+	sk, _, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	natOpts, err := natConfig.LibP2POptions()
+	require.NoError(t, err)
+
+	h, err := libp2p.New(append([]libp2p.Option{
+		libp2p.Identity(sk),
+		libp2p.ListenAddrStrings("/ip4/127.0.0.1/tcp/0"),
+		libp2p.Transport(tcp.NewTCPTransport),
+	}, natOpts...)...)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = h.Close() })
+
+	return h
+}
+
+func TestNATTraversalConfig(t *testing.T) {
+	_, err := (&p2p.NATTraversalConfig{ForceReachability: "somewhere"}).LibP2POptions()
+	require.Error(t, err)
+
+	_, err = (&p2p.NATTraversalConfig{RelayClientEnabled: true}).LibP2POptions()
+	require.Error(t, err)
+
+	_, err = (&p2p.NATTraversalConfig{RelayClientEnabled: true, StaticRelays: []string{"/ip4/127.0.0.1/tcp/15600"}}).LibP2POptions()
+	require.Error(t, err)
+}
+
+func TestManagerRelayedConnection(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay := newNATNode(t, &p2p.NATTraversalConfig{
+		ForceReachability:   p2p.ReachabilityPublic,
+		RelayServiceEnabled: true,
+	})
+	relayAddrInfo := peer.AddrInfo{ID: relay.ID(), Addrs: relay.Addrs()}
+
+	privateNode := newNATNode(t, &p2p.NATTraversalConfig{
+		ForceReachability: p2p.ReachabilityPrivate,
+	})
+	privateManager := p2p.NewManager(privateNode)
+	go privateManager.Start(ctx)
+
+	otherNode := newNATNode(t, &p2p.NATTraversalConfig{})
+	otherManager := p2p.NewManager(otherNode)
+	go otherManager.Start(ctx)
+
+	// the forced reachability is reported by the manager
+	require.Eventually(t, func() bool {
+		return privateManager.Reachability() == network.ReachabilityPrivate
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// the private node reserves a slot on the relay
+	require.NoError(t, privateNode.Connect(ctx, relayAddrInfo))
+	_, err := client.Reserve(ctx, privateNode, relayAddrInfo)
+	require.NoError(t, err)
+
+	// the other node connects to the private node via the relay
+	circuitAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("%s/p2p/%s/p2p-circuit", relay.Addrs()[0], relay.ID()))
+	require.NoError(t, err)
+
+	require.NoError(t, otherManager.ConnectPeer(&peer.AddrInfo{ID: privateNode.ID(), Addrs: []multiaddr.Multiaddr{circuitAddr}}, p2p.PeerRelationKnown))
+
+	require.Eventually(t, func() bool {
+		info := otherManager.PeerInfoSnapshot(privateNode.ID())
+
+		return info != nil && info.Connected && info.Relayed
+	}, 5*time.Second, 50*time.Millisecond)
+}
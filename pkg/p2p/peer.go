@@ -10,10 +10,11 @@ import (
 // NewPeer creates a new Peer.
 func NewPeer(peerID peer.ID, relation PeerRelation, addrs []multiaddr.Multiaddr, alias string) *Peer {
 	return &Peer{
-		ID:       peerID,
-		Relation: relation,
-		Addrs:    addrs,
-		Alias:    alias,
+		ID:                peerID,
+		Relation:          relation,
+		Addrs:             addrs,
+		Alias:             alias,
+		transportConnects: make(map[Transport]uint32),
 	}
 }
 
@@ -33,19 +34,28 @@ type Peer struct {
 	Addrs []multiaddr.Multiaddr
 	// The alias of the peer for better recognizing it.
 	Alias string
+	// The transport of the last established connection.
+	LastTransport Transport
 
 	connectedEventCalled bool
 	reconnectTimer       *time.Timer
+	transportConnects    map[Transport]uint32
 }
 
 // InfoSnapshot returns a snapshot of the peer in time of calling Info().
 func (p *Peer) InfoSnapshot() *PeerInfoSnapshot {
 	info := &PeerInfoSnapshot{
-		Peer:      p,
-		ID:        p.ID.String(),
-		Addresses: p.Addrs,
-		Alias:     p.Alias,
-		Relation:  string(p.Relation),
+		Peer:          p,
+		ID:            p.ID.String(),
+		Addresses:     p.Addrs,
+		Alias:         p.Alias,
+		Relation:      string(p.Relation),
+		LastTransport: p.LastTransport,
+		Transports:    make(map[Transport]*TransportStats, len(p.transportConnects)),
+	}
+
+	for transport, connects := range p.transportConnects {
+		info.Transports[transport] = &TransportStats{Connects: connects}
 	}
 
 	return info
@@ -67,6 +77,12 @@ type PeerInfoSnapshot struct {
 	DroppedSentPackets uint32 `json:"droppedSentPackets"`
 	// Whether the peer is connected.
 	Connected bool `json:"connected"`
+	// Whether the peer is only connected via a circuit relay.
+	Relayed bool `json:"relayed"`
 	// The relation to the peer.
 	Relation string `json:"relation"`
+	// The transport of the last established connection.
+	LastTransport Transport `json:"lastTransport,omitempty"`
+	// The connection statistics per transport.
+	Transports map[Transport]*TransportStats `json:"transports,omitempty"`
 }
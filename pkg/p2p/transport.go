@@ -0,0 +1,169 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/swarm"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	// the head start given to the address that last succeeded to connect to a peer.
+	preferredAddrHeadStart = 1 * time.Second
+)
+
+// Transport defines the transport of a connection.
+type Transport string
+
+const (
+	// TransportUnknown is used for unknown transports.
+	TransportUnknown Transport = "unknown"
+	// TransportTCP is used for TCP connections.
+	TransportTCP Transport = "tcp"
+	// TransportQUIC is used for QUIC connections.
+	TransportQUIC Transport = "quic-v1"
+	// TransportWebTransport is used for WebTransport connections.
+	TransportWebTransport Transport = "webtransport"
+	// TransportRelay is used for connections via a circuit relay.
+	TransportRelay Transport = "p2p-circuit"
+)
+
+// TransportOf returns the transport used by the given multiaddress.
+func TransportOf(addr multiaddr.Multiaddr) Transport {
+	if addr == nil {
+		return TransportUnknown
+	}
+
+	hasProtocol := func(code int) bool {
+		_, err := addr.ValueForProtocol(code)
+
+		return err == nil
+	}
+
+	switch {
+	case hasProtocol(multiaddr.P_CIRCUIT):
+		return TransportRelay
+	case hasProtocol(multiaddr.P_WEBTRANSPORT):
+		return TransportWebTransport
+	case hasProtocol(multiaddr.P_QUIC_V1):
+		return TransportQUIC
+	case hasProtocol(multiaddr.P_TCP):
+		return TransportTCP
+	default:
+		return TransportUnknown
+	}
+}
+
+// TransportStats holds statistics about the connections to a peer via a transport.
+type TransportStats struct {
+	// The amount of currently open connections via the transport.
+	OpenConnections int `json:"openConnections"`
+	// The amount of connections established via the transport.
+	Connects uint32 `json:"connects"`
+}
+
+// TransportPreferences keeps track of the addresses that last succeeded to connect to peers
+// and gives them a head start when dialing, so the transport that worked before is preferred.
+type TransportPreferences struct {
+	sync.RWMutex
+	// the address that last succeeded per peer.
+	preferredAddrs map[peer.ID]string
+	// the amount of peers that prefer an address.
+	preferredAddrsCount map[string]int
+}
+
+// NewTransportPreferences creates a new TransportPreferences.
+func NewTransportPreferences() *TransportPreferences {
+	return &TransportPreferences{
+		preferredAddrs:      make(map[peer.ID]string),
+		preferredAddrsCount: make(map[string]int),
+	}
+}
+
+// SetPreferred marks the given address as the one that last succeeded to connect to the given peer.
+func (tp *TransportPreferences) SetPreferred(peerID peer.ID, addr multiaddr.Multiaddr) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	tp.removePreferred(peerID)
+
+	addrStr := addr.String()
+	tp.preferredAddrs[peerID] = addrStr
+	tp.preferredAddrsCount[addrStr]++
+}
+
+// Preferred returns the address that last succeeded to connect to the given peer.
+func (tp *TransportPreferences) Preferred(peerID peer.ID) (multiaddr.Multiaddr, bool) {
+	tp.RLock()
+	defer tp.RUnlock()
+
+	addrStr, has := tp.preferredAddrs[peerID]
+	if !has {
+		return nil, false
+	}
+
+	addr, err := multiaddr.NewMultiaddr(addrStr)
+	if err != nil {
+		return nil, false
+	}
+
+	return addr, true
+}
+
+// Remove forgets the preferred address of the given peer.
+func (tp *TransportPreferences) Remove(peerID peer.ID) {
+	tp.Lock()
+	defer tp.Unlock()
+
+	tp.removePreferred(peerID)
+}
+
+func (tp *TransportPreferences) removePreferred(peerID peer.ID) {
+	addrStr, has := tp.preferredAddrs[peerID]
+	if !has {
+		return
+	}
+
+	delete(tp.preferredAddrs, peerID)
+	tp.preferredAddrsCount[addrStr]--
+	if tp.preferredAddrsCount[addrStr] <= 0 {
+		delete(tp.preferredAddrsCount, addrStr)
+	}
+}
+
+// DialRanker ranks the addresses with swarm.DefaultDialRanker, but dials
+// the preferred addresses first and delays the other addresses.
+// It can be used as network.DialRanker of the libp2p host.
+func (tp *TransportPreferences) DialRanker(addrs []multiaddr.Multiaddr) []network.AddrDelay {
+	ranked := swarm.DefaultDialRanker(addrs)
+
+	tp.RLock()
+	defer tp.RUnlock()
+
+	hasPreferred := false
+	for _, addrDelay := range ranked {
+		if _, has := tp.preferredAddrsCount[addrDelay.Addr.String()]; has {
+			hasPreferred = true
+
+			break
+		}
+	}
+
+	if !hasPreferred {
+		return ranked
+	}
+
+	for i, addrDelay := range ranked {
+		if _, has := tp.preferredAddrsCount[addrDelay.Addr.String()]; has {
+			ranked[i].Delay = 0
+
+			continue
+		}
+		ranked[i].Delay += preferredAddrHeadStart
+	}
+
+	return ranked
+}
@@ -0,0 +1,64 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package p2p_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/p2p"
+)
+
+func TestTransportOf(t *testing.T) {
+	require.Equal(t, p2p.TransportTCP, p2p.TransportOf(multiaddr.StringCast("/ip4/127.0.0.1/tcp/15600")))
+	require.Equal(t, p2p.TransportQUIC, p2p.TransportOf(multiaddr.StringCast("/ip4/127.0.0.1/udp/15600/quic-v1")))
+	require.Equal(t, p2p.TransportWebTransport, p2p.TransportOf(multiaddr.StringCast("/ip4/127.0.0.1/udp/15600/quic-v1/webtransport")))
+	require.Equal(t, p2p.TransportRelay, p2p.TransportOf(multiaddr.StringCast("/ip4/127.0.0.1/tcp/15600/p2p/12D3KooWLn5fKHvvPdqkJT4nSUFNaNEWvTwvXzCrUqj1BMbTRJnP/p2p-circuit")))
+	require.Equal(t, p2p.TransportUnknown, p2p.TransportOf(multiaddr.StringCast("/ip4/127.0.0.1")))
+	require.Equal(t, p2p.TransportUnknown, p2p.TransportOf(nil))
+}
+
+func TestTransportPreferencesDialRanker(t *testing.T) {
+	tcpAddr := multiaddr.StringCast("/ip4/1.2.3.4/tcp/15600")
+	quicAddr := multiaddr.StringCast("/ip4/1.2.3.4/udp/15600/quic-v1")
+	addrs := []multiaddr.Multiaddr{tcpAddr, quicAddr}
+
+	delays := func(tp *p2p.TransportPreferences) map[p2p.Transport]time.Duration {
+		result := make(map[p2p.Transport]time.Duration)
+		for _, addrDelay := range tp.DialRanker(addrs) {
+			result[p2p.TransportOf(addrDelay.Addr)] = addrDelay.Delay
+		}
+
+		return result
+	}
+
+	tp := p2p.NewTransportPreferences()
+
+	// by default QUIC is dialed first
+	ranked := delays(tp)
+	require.Less(t, ranked[p2p.TransportQUIC], ranked[p2p.TransportTCP])
+
+	// TCP succeeded last time, so it is preferred
+	peerID := randPeerID(t)
+	tp.SetPreferred(peerID, tcpAddr)
+	preferred, has := tp.Preferred(peerID)
+	require.True(t, has)
+	require.True(t, preferred.Equal(tcpAddr))
+
+	ranked = delays(tp)
+	require.Zero(t, ranked[p2p.TransportTCP])
+	require.Greater(t, ranked[p2p.TransportQUIC], ranked[p2p.TransportTCP])
+
+	// the preference is updated with the next successful connection
+	tp.SetPreferred(peerID, quicAddr)
+	ranked = delays(tp)
+	require.Zero(t, ranked[p2p.TransportQUIC])
+	require.Greater(t, ranked[p2p.TransportTCP], ranked[p2p.TransportQUIC])
+
+	tp.Remove(peerID)
+	_, has = tp.Preferred(peerID)
+	require.False(t, has)
+	require.Less(t, delays(tp)[p2p.TransportQUIC], delays(tp)[p2p.TransportTCP])
+}
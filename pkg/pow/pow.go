@@ -2,28 +2,86 @@ package pow
 
 import (
 	"context"
+	"runtime"
 	"time"
 
+	"github.com/iotaledger/hive.go/runtime/syncutils"
 	"github.com/iotaledger/hive.go/serializer/v2"
 	inxpow "github.com/iotaledger/inx-app/pkg/pow"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
 
+const (
+	defaultQueueSize = 100
+)
+
+// by default as many PoW jobs as there are CPU cores run at the same time.
+var defaultParallelJobs = runtime.NumCPU()
+
+// Option is a function setting a PoW handler option.
+type Option func(h *Handler)
+
+// WithQueueSize sets the maximum amount of PoW jobs waiting in the queue.
+func WithQueueSize(queueSize int) Option {
+	return func(h *Handler) {
+		h.queueSize = queueSize
+	}
+}
+
+// WithParallelJobs sets the maximum amount of PoW jobs running at the same time (0 = number of CPU cores).
+func WithParallelJobs(parallelJobs int) Option {
+	return func(h *Handler) {
+		h.parallelJobs = parallelJobs
+	}
+}
+
 // Handler handles PoW requests of the node and uses local PoW.
 // It refreshes the tips of blocks during PoW.
+// The PoW requests are queued and processed by priority.
 type Handler struct {
 	refreshTipsInterval time.Duration
+	queueSize           int
+	parallelJobs        int
+
+	queueLock syncutils.Mutex
+	queue     jobHeap
+	sequence  uint64
+	running   int
 }
 
 // New creates a new PoW handler instance.
-func New(refreshTipsInterval time.Duration) *Handler {
-	return &Handler{
+func New(refreshTipsInterval time.Duration, opts ...Option) *Handler {
+	h := &Handler{
 		refreshTipsInterval: refreshTipsInterval,
+		queueSize:           defaultQueueSize,
+		parallelJobs:        defaultParallelJobs,
 	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.parallelJobs <= 0 {
+		h.parallelJobs = defaultParallelJobs
+	}
+
+	return h
 }
 
 // DoPoW does the proof-of-work required to hit the target score configured on this Handler.
 // The given iota.Block's nonce is automatically updated.
-func (h *Handler) DoPoW(ctx context.Context, block *iotago.Block, deSeriMode serializer.DeSerializationMode, protoParams *iotago.ProtocolParameters, parallelism int, refreshTipsFunc inxpow.RefreshTipsFunc) (blockSize int, err error) {
+// The request waits in the queue of the handler until a slot is free or the context is canceled.
+func (h *Handler) DoPoW(ctx context.Context, block *iotago.Block, deSeriMode serializer.DeSerializationMode, protoParams *iotago.ProtocolParameters, parallelism int, refreshTipsFunc inxpow.RefreshTipsFunc, opts ...JobOption) (blockSize int, err error) {
+	jobOptions := jobOpts(opts)
+
+	if err := h.acquire(ctx, jobOptions.priority); err != nil {
+		return 0, err
+	}
+	defer h.release()
+
+	if jobOptions.onStarted != nil {
+		jobOptions.onStarted()
+	}
+
 	return inxpow.DoPoW(ctx, block, deSeriMode, protoParams, parallelism, h.refreshTipsInterval, refreshTipsFunc)
 }
@@ -0,0 +1,199 @@
+package pow
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrQueueFull is returned if a PoW job can't be queued because the queue is full.
+	ErrQueueFull = errors.New("proof of work queue is full")
+)
+
+// Priority defines the priority of a PoW job in the queue.
+type Priority int
+
+const (
+	// PriorityLow is used for background jobs, e.g. reattachments.
+	PriorityLow Priority = iota
+	// PriorityNormal is used for blocks submitted by clients.
+	PriorityNormal
+	// PriorityHigh is used for blocks that should be attached as fast as possible.
+	PriorityHigh
+)
+
+// JobOption is a function setting a PoW job option.
+type JobOption func(opts *jobOptions)
+
+type jobOptions struct {
+	priority  Priority
+	onStarted func()
+}
+
+func jobOpts(opts []JobOption) *jobOptions {
+	result := &jobOptions{
+		priority:  PriorityNormal,
+		onStarted: nil,
+	}
+
+	for _, opt := range opts {
+		opt(result)
+	}
+
+	return result
+}
+
+// WithPriority sets the priority of the PoW job in the queue.
+func WithPriority(priority Priority) JobOption {
+	return func(opts *jobOptions) {
+		opts.priority = priority
+	}
+}
+
+// WithStartedCallback sets a function that is called when the PoW job leaves the queue and the PoW starts.
+func WithStartedCallback(onStarted func()) JobOption {
+	return func(opts *jobOptions) {
+		opts.onStarted = onStarted
+	}
+}
+
+// queuedJob is a PoW job waiting in the queue.
+type queuedJob struct {
+	priority Priority
+	sequence uint64
+	// ready is closed if the job is allowed to start.
+	ready chan struct{}
+	// index is the position in the heap.
+	index int
+}
+
+// jobHeap orders the queued jobs by priority first, and by the order they were queued second.
+type jobHeap []*queuedJob
+
+func (h jobHeap) Len() int {
+	return len(h)
+}
+
+func (h jobHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+
+	return h[i].sequence < h[j].sequence
+}
+
+func (h jobHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *jobHeap) Push(x any) {
+	//nolint:forcetypeassert // only queuedJobs are pushed
+	job := x.(*queuedJob)
+	job.index = len(*h)
+	*h = append(*h, job)
+}
+
+func (h *jobHeap) Pop() any {
+	old := *h
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	job.index = -1
+	*h = old[:n-1]
+
+	return job
+}
+
+// acquire waits until the job is allowed to start.
+// The caller has to call release after the job finished if no error was returned.
+func (h *Handler) acquire(ctx context.Context, priority Priority) error {
+	h.queueLock.Lock()
+
+	if h.slotAvailable() && h.queue.Len() == 0 {
+		h.running++
+		h.queueLock.Unlock()
+
+		return nil
+	}
+
+	if h.queue.Len() >= h.queueSize {
+		h.queueLock.Unlock()
+
+		return ErrQueueFull
+	}
+
+	h.sequence++
+	job := &queuedJob{
+		priority: priority,
+		sequence: h.sequence,
+		ready:    make(chan struct{}),
+	}
+	heap.Push(&h.queue, job)
+	h.queueLock.Unlock()
+
+	select {
+	case <-job.ready:
+		return nil
+
+	case <-ctx.Done():
+		h.queueLock.Lock()
+		defer h.queueLock.Unlock()
+
+		select {
+		case <-job.ready:
+			// the job was started in the meantime, so the slot needs to be freed again
+			h.running--
+			h.dispatch()
+		default:
+			heap.Remove(&h.queue, job.index)
+		}
+
+		return ctx.Err()
+	}
+}
+
+// release frees the slot of a finished job and starts the next queued job.
+func (h *Handler) release() {
+	h.queueLock.Lock()
+	defer h.queueLock.Unlock()
+
+	h.running--
+	h.dispatch()
+}
+
+// slotAvailable returns whether another PoW job is allowed to run.
+// The queue lock needs to be held by the caller.
+func (h *Handler) slotAvailable() bool {
+	return h.running < h.parallelJobs
+}
+
+// dispatch starts queued jobs as long as there are free slots.
+// The queue lock needs to be held by the caller.
+func (h *Handler) dispatch() {
+	for h.slotAvailable() && h.queue.Len() > 0 {
+		//nolint:forcetypeassert // only queuedJobs are pushed
+		job := heap.Pop(&h.queue).(*queuedJob)
+		h.running++
+		close(job.ready)
+	}
+}
+
+// QueuedJobs returns the amount of PoW jobs waiting in the queue.
+func (h *Handler) QueuedJobs() int {
+	h.queueLock.Lock()
+	defer h.queueLock.Unlock()
+
+	return h.queue.Len()
+}
+
+// RunningJobs returns the amount of PoW jobs that are currently running.
+func (h *Handler) RunningJobs() int {
+	h.queueLock.Lock()
+	defer h.queueLock.Unlock()
+
+	return h.running
+}
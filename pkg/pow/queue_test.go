@@ -0,0 +1,139 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package pow_test
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/pow"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/tpkg"
+)
+
+// doPoW runs a PoW job that returns immediately after it was started,
+// because no PoW is required by the protocol parameters.
+func doPoW(ctx context.Context, h *pow.Handler, opts ...pow.JobOption) error {
+	block := &iotago.Block{
+		ProtocolVersion: tpkg.TestProtocolVersion,
+		Parents:         tpkg.SortedRandBlockIDs(1),
+	}
+
+	_, err := h.DoPoW(ctx, block, serializer.DeSeriModeNoValidation, &iotago.ProtocolParameters{MinPoWScore: 0}, 1, nil, opts...)
+
+	return err
+}
+
+// occupySlot starts a PoW job that blocks until the returned function is called.
+func occupySlot(t *testing.T, h *pow.Handler) func() {
+	started := make(chan struct{})
+	finish := make(chan struct{})
+
+	go func() {
+		require.NoError(t, doPoW(context.Background(), h, pow.WithStartedCallback(func() {
+			close(started)
+			<-finish
+		})))
+	}()
+	<-started
+
+	return func() {
+		close(finish)
+	}
+}
+
+func TestQueuePriority(t *testing.T) {
+	h := pow.New(time.Second, pow.WithQueueSize(10), pow.WithParallelJobs(1))
+
+	finish := occupySlot(t, h)
+	require.Equal(t, 1, h.RunningJobs())
+
+	var orderLock sync.Mutex
+	order := make([]pow.Priority, 0)
+
+	var wg sync.WaitGroup
+	for i, priority := range []pow.Priority{pow.PriorityLow, pow.PriorityNormal, pow.PriorityHigh} {
+		wg.Add(1)
+		go func(priority pow.Priority) {
+			defer wg.Done()
+
+			require.NoError(t, doPoW(context.Background(), h, pow.WithPriority(priority), pow.WithStartedCallback(func() {
+				orderLock.Lock()
+				defer orderLock.Unlock()
+
+				order = append(order, priority)
+			})))
+		}(priority)
+
+		// make sure the jobs are queued in the given order
+		expected := i + 1
+		require.Eventually(t, func() bool {
+			return h.QueuedJobs() == expected
+		}, time.Second, time.Millisecond)
+	}
+
+	finish()
+	wg.Wait()
+
+	require.Equal(t, []pow.Priority{pow.PriorityHigh, pow.PriorityNormal, pow.PriorityLow}, order)
+	require.Equal(t, 0, h.QueuedJobs())
+	require.Eventually(t, func() bool {
+		return h.RunningJobs() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestQueueFullAndCancel(t *testing.T) {
+	h := pow.New(time.Second, pow.WithQueueSize(1), pow.WithParallelJobs(1))
+
+	finish := occupySlot(t, h)
+	defer finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queued := make(chan error, 1)
+	go func() {
+		queued <- doPoW(ctx, h)
+	}()
+
+	require.Eventually(t, func() bool {
+		return h.QueuedJobs() == 1
+	}, time.Second, time.Millisecond)
+
+	require.ErrorIs(t, doPoW(context.Background(), h, pow.WithPriority(pow.PriorityHigh)), pow.ErrQueueFull)
+
+	// the queued job leaves the queue if its context is canceled
+	cancel()
+	require.ErrorIs(t, <-queued, context.Canceled)
+	require.Equal(t, 0, h.QueuedJobs())
+	require.Equal(t, 1, h.RunningJobs())
+}
+
+func TestQueueDefaultParallelJobs(t *testing.T) {
+	h := pow.New(time.Second, pow.WithQueueSize(1), pow.WithParallelJobs(0))
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		finish := occupySlot(t, h)
+		defer finish()
+	}
+	require.Equal(t, runtime.NumCPU(), h.RunningJobs())
+
+	// by default the amount of parallel jobs is limited to the number of CPU cores
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error)
+	go func() {
+		queued <- doPoW(ctx, h)
+	}()
+
+	require.Eventually(t, func() bool {
+		return h.QueuedJobs() == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-queued, context.Canceled)
+}
@@ -28,13 +28,24 @@ import (
 
 const (
 	WorkerCount = 64
+
+	// ValidationWorkerCount is the amount of workers that validate received blocks with the block validator.
+	ValidationWorkerCount = 16
+	// ValidationQueueSize is the maximum amount of received blocks that wait for the block validator.
+	// Blocks that are received while the queue is full are dropped, and are processed again if they are received again.
+	ValidationQueueSize = 10000
 )
 
 var (
 	ErrBlockNotSolid      = errors.New("block is not solid")
 	ErrBlockBelowMaxDepth = errors.New("block is below max depth")
+	ErrBlockRejected      = errors.New("block was rejected by the block validator")
 )
 
+// BlockValidator decides whether a block that was received via gossip or issued by the node is accepted.
+// It returns an error if the block should be rejected.
+type BlockValidator func(block *storage.Block) error
+
 // Broadcast defines a data which should be broadcasted.
 type Broadcast struct {
 	// The data to broadcast.
@@ -49,6 +60,8 @@ type MessageProcessorEvents struct {
 	BlockProcessed *event.Event3[*storage.Block, Requests, *Protocol]
 	// Fired when a block is meant to be broadcasted.
 	BroadcastBlock *event.Event1[*Broadcast]
+	// Fired when a received block is dropped because the validation queue is full or the processor was shut down.
+	ValidationDropped *event.Event2[iotago.BlockID, *Protocol]
 }
 
 // The Options for the MessageProcessor.
@@ -73,12 +86,20 @@ type MessageProcessor struct {
 	// holds the message processor options.
 	opts Options
 
+	// mutex to secure the block validator.
+	blockValidatorLock syncutils.RWMutex
+	// the optional block validator.
+	blockValidator BlockValidator
+
 	// events of the block processor.
 	Events *MessageProcessorEvents
 	// cache that holds processed incoming messages.
 	workUnits *objectstorage.ObjectStorage
 	// worker pool for incoming messages.
 	wp *workerpool.WorkerPool
+	// worker pool for received blocks that are validated by the block validator.
+	// the block validator may be slow, so the validation must not block the worker pool for incoming messages.
+	validationWP *workerpool.WorkerPool
 
 	// mutex to secure the shutdown flag.
 	shutdownMutex syncutils.RWMutex
@@ -104,10 +125,12 @@ func NewMessageProcessor(
 		serverMetrics:   serverMetrics,
 		protocolManager: protocolManager,
 		wp:              workerpool.New("MessageProcessor", WorkerCount),
+		validationWP:    workerpool.New("MessageProcessorValidation", ValidationWorkerCount),
 		opts:            *opts,
 		Events: &MessageProcessorEvents{
-			BlockProcessed: event.New3[*storage.Block, Requests, *Protocol](),
-			BroadcastBlock: event.New1[*Broadcast](),
+			BlockProcessed:    event.New3[*storage.Block, Requests, *Protocol](),
+			BroadcastBlock:    event.New1[*Broadcast](),
+			ValidationDropped: event.New2[iotago.BlockID, *Protocol](),
 		},
 	}
 
@@ -147,6 +170,7 @@ func NewMessageProcessor(
 // Run runs the processor and blocks until the shutdown signal is triggered.
 func (proc *MessageProcessor) Run(ctx context.Context) {
 	proc.wp.Start()
+	proc.validationWP.Start()
 	<-ctx.Done()
 	proc.Shutdown()
 }
@@ -159,9 +183,40 @@ func (proc *MessageProcessor) Shutdown() {
 
 	proc.shutdown = true
 	proc.wp.Shutdown()
+	proc.validationWP.Shutdown()
 	proc.workUnits.Shutdown()
 }
 
+// SetBlockValidator sets the validator that decides whether received and issued blocks are accepted.
+// Milestones and blocks that were requested by the node are not validated, since they are needed to solidify the tangle.
+func (proc *MessageProcessor) SetBlockValidator(validator BlockValidator) {
+	proc.blockValidatorLock.Lock()
+	defer proc.blockValidatorLock.Unlock()
+
+	proc.blockValidator = validator
+}
+
+func (proc *MessageProcessor) validator() BlockValidator {
+	proc.blockValidatorLock.RLock()
+	defer proc.blockValidatorLock.RUnlock()
+
+	return proc.blockValidator
+}
+
+// validateBlock returns an error if the block was rejected by the block validator.
+func (proc *MessageProcessor) validateBlock(block *storage.Block) error {
+	validator := proc.validator()
+	if validator == nil {
+		return nil
+	}
+
+	if err := validator(block); err != nil {
+		return errors.WithMessage(ErrBlockRejected, err.Error())
+	}
+
+	return nil
+}
+
 // Process submits the given message to the processor for processing.
 func (proc *MessageProcessor) Process(p *Protocol, msgType message.Type, data []byte) {
 	proc.wp.Submit(func() {
@@ -254,6 +309,13 @@ func (proc *MessageProcessor) Emit(block *storage.Block) error {
 		}
 	}
 
+	// the block validator is asked last, since it is the most expensive check
+	if !block.IsMilestone() {
+		if err := proc.validateBlock(block); err != nil {
+			return err
+		}
+	}
+
 	proc.Events.BlockProcessed.Trigger(block, (Requests)(nil), (*Protocol)(nil))
 	proc.Events.BroadcastBlock.Trigger(&Broadcast{Data: block.Data()})
 
@@ -444,6 +506,18 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 
 		processBlock(wu.block, isMilestonePayload, requests, p)
 
+		return
+
+	case wu.Is(Rejected):
+		wu.processingLock.Unlock()
+
+		// rejected blocks are only processed if they were requested,
+		// because they are needed to solidify the tangle.
+		requests := processRequests(wu, wu.block, false)
+		if requests.HasRequest() {
+			processBlock(wu.block, false, requests, p)
+		}
+
 		return
 	}
 
@@ -494,12 +568,72 @@ func (proc *MessageProcessor) processWorkUnit(wu *WorkUnit, p *Protocol) {
 
 	// safe to set the block here, because it is protected by the state "Hashing"
 	wu.block = block
-	wu.UpdateState(Hashed)
 
-	// increase the known block count for all other peers
-	wu.increaseKnownTxCount(p)
+	acceptBlock := func() {
+		wu.UpdateState(Hashed)
+
+		// increase the known block count for all other peers
+		wu.increaseKnownTxCount(p)
+
+		processBlock(block, isMilestonePayload, requests, p)
+	}
+
+	if isMilestonePayload || wu.requested || proc.validator() == nil {
+		acceptBlock()
+
+		return
+	}
+
+	// the block is validated in a separate worker pool, so a slow block validator doesn't block the processing
+	// of other messages. the work unit stays in the "Hashing" state until the block was validated.
+	if !proc.submitValidation(wu, func() {
+		if err := proc.validateBlock(block); err != nil {
+			// the peer is not punished, because the block is valid according to the protocol
+			wu.UpdateState(Rejected)
+			proc.serverMetrics.RejectedBlocks.Inc()
+
+			// the block may have been requested while it was validated
+			if requests := processRequests(wu, block, false); requests.HasRequest() {
+				processBlock(block, false, requests, p)
+			}
+
+			return
+		}
+
+		// the block may have been requested while it was validated
+		requests = append(requests, processRequests(wu, block, false)...)
+		acceptBlock()
+	}) {
+		proc.serverMetrics.ValidationDroppedBlocks.Inc()
+		proc.Events.ValidationDropped.Trigger(block.BlockID(), p)
+
+		// the block is processed again if it is received again
+		wu.block = nil
+		wu.UpdateState(0)
+	}
+}
+
+// submitValidation submits the validation of the block of the given WorkUnit to the validation worker pool.
+// It returns false if the validation queue is full or the message processor was shut down.
+func (proc *MessageProcessor) submitValidation(wu *WorkUnit, validationFunc func()) bool {
+	proc.shutdownMutex.RLock()
+	defer proc.shutdownMutex.RUnlock()
+
+	if proc.shutdown || proc.validationWP.PendingTasksCounter.Get() >= ValidationQueueSize {
+		return false
+	}
+
+	// the WorkUnit must stay cached until the block was validated,
+	// otherwise the block would be processed again if it is received during the validation.
+	cachedWorkUnit, _ := proc.workUnitFor(wu.receivedBytes) // workUnit +1
+
+	proc.validationWP.Submit(func() {
+		defer cachedWorkUnit.Release(true) // workUnit -1
+
+		validationFunc()
+	})
 
-	processBlock(block, isMilestonePayload, requests, p)
+	return true
 }
 
 func (proc *MessageProcessor) Broadcast(cachedBlockMeta *storage.CachedMetadata) {
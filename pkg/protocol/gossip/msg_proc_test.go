@@ -2,9 +2,13 @@
 package gossip_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	libp2p "github.com/libp2p/go-libp2p"
 	"github.com/libp2p/go-libp2p/core/crypto"
@@ -27,13 +31,7 @@ const (
 	ProtocolVersion uint8  = 99
 )
 
-func TestMessageProcessorEmit(t *testing.T) {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, ProtocolVersion, BelowMaxDepth, MinPoWScore, false)
-	defer te.CleanupTestEnvironment(true)
-
+func newTestMessageProcessor(t *testing.T, ctx context.Context, te *testsuite.TestEnvironment) (*gossip.MessageProcessor, *metrics.ServerMetrics) {
 	// we use Ed25519 because otherwise it takes longer as the default is RSA
 	sk, _, _ := crypto.GenerateKeyPair(crypto.Ed25519, -1)
 
@@ -63,6 +61,18 @@ func TestMessageProcessorEmit(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	return processor, serverMetrics
+}
+
+func TestMessageProcessorEmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, ProtocolVersion, BelowMaxDepth, MinPoWScore, false)
+	defer te.CleanupTestEnvironment(true)
+
+	processor, _ := newTestMessageProcessor(t, ctx, te)
+
 	blockData := `{
 		  "protocolVersion": 99,
 		  "parents": [
@@ -145,4 +155,116 @@ func TestMessageProcessorEmit(t *testing.T) {
 	// should fail because of wrong score
 	err = processor.Emit(block)
 	assert.Error(t, err)
+
+	// pow again, so we have a valid block
+	_, err = te.PoWHandler.DoPoW(context.Background(), iotaBlock, serializer.DeSeriModePerformValidation, te.ProtocolParameters(), 1, nil)
+	assert.NoError(t, err)
+
+	// need to create a new block, so the iotago block is serialized again
+	block, err = storage.NewBlock(iotaBlock, serializer.DeSeriModePerformValidation, te.ProtocolManager().Current())
+	assert.NoError(t, err)
+
+	// should fail because the block validator rejects the block
+	processor.SetBlockValidator(func(validatedBlock *storage.Block) error {
+		assert.Equal(t, block.BlockID(), validatedBlock.BlockID())
+
+		return errors.New("issuer not allowed")
+	})
+	err = processor.Emit(block)
+	assert.ErrorIs(t, err, gossip.ErrBlockRejected)
+
+	// should not fail if the block validator accepts the block
+	processor.SetBlockValidator(func(_ *storage.Block) error {
+		return nil
+	})
+	err = processor.Emit(block)
+	assert.NoError(t, err)
+}
+
+func TestMessageProcessorValidatesReceivedBlocksAsynchronously(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	te := testsuite.SetupTestEnvironment(t, &iotago.Ed25519Address{}, 0, ProtocolVersion, BelowMaxDepth, 1, false)
+	defer te.CleanupTestEnvironment(true)
+
+	processor, serverMetrics := newTestMessageProcessor(t, ctx, te)
+
+	// the blocks are only accepted after the validation was released
+	releaseValidation := make(chan struct{})
+	rejectedTag := []byte("rejected")
+	processor.SetBlockValidator(func(block *storage.Block) error {
+		<-releaseValidation
+
+		if bytes.Equal(block.Block().Payload.(*iotago.TaggedData).Tag, rejectedTag) {
+			return errors.New("tag not allowed")
+		}
+
+		return nil
+	})
+
+	processedBlocks := make(chan iotago.BlockID, 2*gossip.WorkerCount)
+	processor.Events.BlockProcessed.Hook(func(block *storage.Block, _ gossip.Requests, _ *gossip.Protocol) {
+		processedBlocks <- block.BlockID()
+	})
+
+	p := gossip.NewProtocol("peer", nil, 10, time.Second, time.Second, serverMetrics)
+
+	newBlockData := func(tag []byte) []byte {
+		iotaBlock := &iotago.Block{
+			ProtocolVersion: ProtocolVersion,
+			Parents:         iotago.BlockIDs{te.LastMilestoneBlockID()},
+			Payload:         &iotago.TaggedData{Tag: tag},
+		}
+
+		_, err := te.PoWHandler.DoPoW(context.Background(), iotaBlock, serializer.DeSeriModePerformValidation, te.ProtocolParameters(), 1, nil)
+		require.NoError(t, err)
+
+		data, err := iotaBlock.Serialize(serializer.DeSeriModePerformValidation, te.ProtocolParameters())
+		require.NoError(t, err)
+
+		return data
+	}
+
+	blocksData := make([][]byte, 0, gossip.WorkerCount+2)
+	for i := 0; i <= gossip.WorkerCount; i++ {
+		blocksData = append(blocksData, newBlockData([]byte(fmt.Sprintf("accepted%d", i))))
+	}
+	blocksData = append(blocksData, newBlockData(rejectedTag))
+
+	go processor.Run(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	// more blocks than workers of the message processor wait for the block validator
+	for _, data := range blocksData {
+		processor.Process(p, gossip.MessageTypeBlock, data)
+	}
+
+	// other messages are still processed
+	milestoneBlockID := te.LastMilestoneBlockID()
+	processor.Process(p, gossip.MessageTypeBlockRequest, milestoneBlockID[:])
+	select {
+	case <-p.SendQueue:
+	case <-time.After(10 * time.Second):
+		require.FailNow(t, "block request was not processed while blocks are validated")
+	}
+	require.Empty(t, processedBlocks)
+
+	close(releaseValidation)
+
+	for i := 0; i <= gossip.WorkerCount; i++ {
+		select {
+		case <-processedBlocks:
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "accepted block was not processed")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return serverMetrics.RejectedBlocks.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Empty(t, processedBlocks)
+
+	// no block was dropped, because the validation queue was never full
+	require.Zero(t, serverMetrics.ValidationDroppedBlocks.Load())
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotaledger/hive.go/runtime/syncutils"
 	"github.com/iotaledger/hornet/v2/pkg/model/storage"
 	iotago "github.com/iotaledger/iota.go/v3"
 )
@@ -59,6 +62,24 @@ type Requester struct {
 	running     bool
 	backPFuncs  []RequestBackPressureFunc
 	drainSignal chan struct{}
+
+	requestStatsLock syncutils.RWMutex
+	requestStats     map[string]*requestStatsEntry
+}
+
+// RequestStats contains information about how often and from which peers a request was sent.
+type RequestStats struct {
+	// The amount of times the request was taken from the queue to be sent.
+	Attempts int
+	// The amount of times the request was sent to each peer.
+	Peers map[peer.ID]int
+	// The time the request was sent the last time.
+	LastRequestTime time.Time
+}
+
+type requestStatsEntry struct {
+	request *Request
+	stats   *RequestStats
 }
 
 // NewRequester creates a new Requester.
@@ -73,11 +94,12 @@ func NewRequester(
 	reqOpts.apply(opts...)
 
 	return &Requester{
-		storage:     dbStorage,
-		service:     service,
-		rQueue:      rQueue,
-		opts:        reqOpts,
-		drainSignal: make(chan struct{}, 2),
+		storage:      dbStorage,
+		service:      service,
+		rQueue:       rQueue,
+		opts:         reqOpts,
+		drainSignal:  make(chan struct{}, 2),
+		requestStats: make(map[string]*requestStatsEntry),
 	}
 }
 
@@ -93,7 +115,11 @@ func (r *Requester) RunRequestQueueDrainer(ctx context.Context) {
 			// drain request queue
 			for request := r.rQueue.Next(); request != nil; request = r.rQueue.Next() {
 
+				r.recordRequestAttempt(request)
+
 				sendRequest := func(request *Request, proto *Protocol) {
+					r.recordRequestSent(request, proto.PeerID)
+
 					switch request.RequestType {
 					case RequestTypeBlockID:
 						proto.SendBlockRequest(request.BlockID)
@@ -149,6 +175,9 @@ reEnqueueLoop:
 			return
 		case <-reEnqueueTicker.C:
 
+			// remove the stats of requests that are not in the queue anymore
+			r.cleanupRequestStats()
+
 			// check whether we should hold off requesting more data
 			// if the node is currently under a lot of load
 			if r.checkBackPressureFunctions() {
@@ -166,6 +195,78 @@ reEnqueueLoop:
 	}
 }
 
+// RequestStats returns the stats of the request for the given data (block ID or milestone index).
+// Returns nil if the request was never sent or is not in the request queue anymore.
+func (r *Requester) RequestStats(data interface{}) *RequestStats {
+	r.requestStatsLock.RLock()
+	defer r.requestStatsLock.RUnlock()
+
+	entry, exists := r.requestStats[getRequestMapKey(data)]
+	if !exists {
+		return nil
+	}
+
+	peers := make(map[peer.ID]int, len(entry.stats.Peers))
+	for peerID, count := range entry.stats.Peers {
+		peers[peerID] = count
+	}
+
+	return &RequestStats{
+		Attempts:        entry.stats.Attempts,
+		Peers:           peers,
+		LastRequestTime: entry.stats.LastRequestTime,
+	}
+}
+
+// requestStatsEntryWithoutLocking returns the stats entry for the given request or creates a new one.
+func (r *Requester) requestStatsEntryWithoutLocking(request *Request) *requestStatsEntry {
+	requestMapKey := request.MapKey()
+
+	entry, exists := r.requestStats[requestMapKey]
+	if !exists {
+		entry = &requestStatsEntry{
+			request: request,
+			stats:   &RequestStats{Peers: make(map[peer.ID]int)},
+		}
+		r.requestStats[requestMapKey] = entry
+	}
+
+	return entry
+}
+
+// recordRequestAttempt records that the given request was taken from the queue to be sent.
+func (r *Requester) recordRequestAttempt(request *Request) {
+	r.requestStatsLock.Lock()
+	defer r.requestStatsLock.Unlock()
+
+	entry := r.requestStatsEntryWithoutLocking(request)
+	entry.stats.Attempts++
+}
+
+// recordRequestSent records that the given request was sent to the given peer.
+func (r *Requester) recordRequestSent(request *Request, peerID peer.ID) {
+	r.requestStatsLock.Lock()
+	defer r.requestStatsLock.Unlock()
+
+	entry := r.requestStatsEntryWithoutLocking(request)
+	entry.stats.Peers[peerID]++
+	entry.stats.LastRequestTime = time.Now()
+}
+
+// cleanupRequestStats removes the stats of all requests that are neither queued, pending nor processing.
+func (r *Requester) cleanupRequestStats() {
+	r.requestStatsLock.Lock()
+	defer r.requestStatsLock.Unlock()
+
+	for requestMapKey, entry := range r.requestStats {
+		if r.rQueue.IsQueued(entry.request) || r.rQueue.IsPending(entry.request) || r.rQueue.IsProcessing(entry.request) {
+			continue
+		}
+
+		delete(r.requestStats, requestMapKey)
+	}
+}
+
 // adds the request to the request queue and signals the request drainer to drain it.
 func (r *Requester) enqueueAndSignal(request *Request) bool {
 	if !r.rQueue.Enqueue(request) {
@@ -0,0 +1,94 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package gossip
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func randPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateKeyPair(crypto.Ed25519, -1)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestRequesterRequestStats(t *testing.T) {
+	rQueue := NewRequestQueue()
+	requester := NewRequester(nil, nil, rQueue)
+
+	peer1 := randPeerID(t)
+	peer2 := randPeerID(t)
+
+	blockID := tpkg.RandBlockID()
+	require.True(t, rQueue.Enqueue(NewBlockIDRequest(blockID, 5)))
+	require.True(t, rQueue.Enqueue(NewMilestoneIndexRequest(7)))
+
+	// there are no stats for requests that were never sent
+	require.Nil(t, requester.RequestStats(blockID))
+
+	// the drainer records an attempt for every request it takes from the queue
+	// and every peer the request is sent to
+	request := rQueue.Next()
+	require.Equal(t, blockID, request.BlockID)
+	requester.recordRequestAttempt(request)
+	requester.recordRequestSent(request, peer1)
+	requester.recordRequestSent(request, peer2)
+
+	rQueue.EnqueuePending(0)
+	request = rQueue.Next()
+	require.Equal(t, blockID, request.BlockID)
+	requester.recordRequestAttempt(request)
+	requester.recordRequestSent(request, peer1)
+
+	stats := requester.RequestStats(blockID)
+	require.NotNil(t, stats)
+	require.Equal(t, 2, stats.Attempts)
+	require.Equal(t, map[peer.ID]int{peer1: 2, peer2: 1}, stats.Peers)
+	require.False(t, stats.LastRequestTime.IsZero())
+
+	// the returned stats are a copy
+	stats.Peers[peer2] = 10
+	require.Equal(t, 1, requester.RequestStats(blockID).Peers[peer2])
+
+	// requests without peers that could have the data are attempted, but not sent
+	msRequest := rQueue.Next()
+	require.Equal(t, iotago.MilestoneIndex(7), msRequest.MilestoneIndex)
+	requester.recordRequestAttempt(msRequest)
+
+	msStats := requester.RequestStats(iotago.MilestoneIndex(7))
+	require.NotNil(t, msStats)
+	require.Equal(t, 1, msStats.Attempts)
+	require.Empty(t, msStats.Peers)
+	require.True(t, msStats.LastRequestTime.IsZero())
+
+	// the stats are kept as long as the request is pending or processing
+	requester.cleanupRequestStats()
+	require.NotNil(t, requester.RequestStats(blockID))
+	require.NotNil(t, requester.RequestStats(iotago.MilestoneIndex(7)))
+
+	require.NotNil(t, rQueue.Received(blockID))
+	require.True(t, rQueue.IsProcessing(blockID))
+	requester.cleanupRequestStats()
+	require.NotNil(t, requester.RequestStats(blockID))
+
+	// the stats are removed once the request left the queue
+	require.NotNil(t, rQueue.Processed(blockID))
+	requester.cleanupRequestStats()
+	require.Nil(t, requester.RequestStats(blockID))
+	require.NotNil(t, requester.RequestStats(iotago.MilestoneIndex(7)))
+
+	require.NotNil(t, rQueue.Received(iotago.MilestoneIndex(7)))
+	require.NotNil(t, rQueue.Processed(iotago.MilestoneIndex(7)))
+	requester.cleanupRequestStats()
+	require.Nil(t, requester.RequestStats(iotago.MilestoneIndex(7)))
+}
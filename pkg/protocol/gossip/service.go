@@ -29,6 +29,8 @@ type ServiceEvents struct {
 	ProtocolTerminated *event.Event1[*Protocol]
 	// Fired when an inbound stream gets canceled.
 	InboundStreamCanceled *event.Event2[network.Stream, StreamCancelReason]
+	// Fired when the gossip protocol stream to a peer could not be opened.
+	ProtocolHandshakeFailed *event.Event2[peer.ID, error]
 	// Fired when an internal error happens.
 	Error *event.Event1[error]
 }
@@ -180,10 +182,11 @@ func NewService(
 
 	gossipService := &Service{
 		Events: &ServiceEvents{
-			ProtocolStarted:       event.New1[*Protocol](),
-			ProtocolTerminated:    event.New1[*Protocol](),
-			InboundStreamCanceled: event.New2[network.Stream, StreamCancelReason](),
-			Error:                 event.New1[error](),
+			ProtocolStarted:         event.New1[*Protocol](),
+			ProtocolTerminated:      event.New1[*Protocol](),
+			InboundStreamCanceled:   event.New2[network.Stream, StreamCancelReason](),
+			ProtocolHandshakeFailed: event.New2[peer.ID, error](),
+			Error:                   event.New1[error](),
 		},
 		host:                host,
 		protocol:            protocol,
@@ -469,6 +472,8 @@ func (s *Service) handleConnected(ctx context.Context, peer *p2p.Peer, conn netw
 
 		stream, err := s.openStream(ctx, peer.ID)
 		if err != nil {
+			s.Events.ProtocolHandshakeFailed.Trigger(peer.ID, err)
+
 			// close the connection to the peer
 			_ = conn.Close()
 
@@ -571,6 +576,8 @@ func (s *Service) handleRelationUpdated(ctx context.Context, peer *p2p.Peer, old
 		// the service should however take care of duplicated streams
 		stream, err := s.openStream(ctx, peer.ID)
 		if err != nil {
+			s.Events.ProtocolHandshakeFailed.Trigger(peer.ID, err)
+
 			return err
 		}
 
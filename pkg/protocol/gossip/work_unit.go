@@ -16,6 +16,8 @@ const (
 	Hashing WorkUnitState = 1 << 0
 	Invalid WorkUnitState = 1 << 1
 	Hashed  WorkUnitState = 1 << 2
+	// Rejected is the state of a valid block that was rejected by the block validator.
+	Rejected WorkUnitState = 1 << 3
 )
 
 // newWorkUnit creates a new WorkUnit and initializes values by unmarshaling key.
@@ -0,0 +1,109 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// ParameterChange describes the change of a single protocol parameter.
+type ParameterChange struct {
+	// The name of the changed parameter.
+	Name string
+	// The value of the parameter under the current protocol parameters.
+	Current string
+	// The value of the parameter under the pending protocol parameters.
+	Pending string
+}
+
+// Upgrade is a scheduled protocol parameters change.
+type Upgrade struct {
+	// The milestone index at which the protocol parameters get activated.
+	TargetMilestoneIndex iotago.MilestoneIndex
+	// The protocol version of the pending protocol parameters.
+	ProtocolVersion byte
+	// Whether the protocol version is supported by this node.
+	Supported bool
+	// The decoded pending protocol parameters, or nil if they could not be decoded.
+	Params *iotago.ProtocolParameters
+	// The error that occurred while decoding the pending protocol parameters.
+	DecodeError error
+	// The changes of the pending protocol parameters compared to the current ones.
+	Changes []*ParameterChange
+}
+
+// Upgrades returns all scheduled protocol parameters changes,
+// with the pending protocol parameters decoded and compared to the current ones.
+func (m *Manager) Upgrades() []*Upgrade {
+	current := m.Current()
+	pending := m.Pending()
+
+	upgrades := make([]*Upgrade, 0, len(pending))
+	for _, protoParamsMsOption := range pending {
+		upgrade := &Upgrade{
+			TargetMilestoneIndex: protoParamsMsOption.TargetMilestoneIndex,
+			ProtocolVersion:      protoParamsMsOption.ProtocolVersion,
+			Supported:            m.SupportedVersions().Supports(protoParamsMsOption.ProtocolVersion),
+		}
+
+		// protocol parameters of unsupported versions may not be decodable by this node
+		params := &iotago.ProtocolParameters{}
+		if _, err := params.Deserialize(protoParamsMsOption.Params, serializer.DeSeriModePerformValidation, nil); err != nil {
+			upgrade.DecodeError = fmt.Errorf("unable to deserialize protocol parameters: %w", err)
+		} else {
+			upgrade.Params = params
+			upgrade.Changes = DiffParameters(current, params)
+		}
+
+		upgrades = append(upgrades, upgrade)
+	}
+
+	return upgrades
+}
+
+// NextUnsupported returns the next pending protocol parameters milestone option
+// with a protocol version that is not supported by this node, or nil if there is none.
+func (m *Manager) NextUnsupported() *iotago.ProtocolParamsMilestoneOpt {
+	m.pendingLock.RLock()
+	defer m.pendingLock.RUnlock()
+
+	for _, protoParamsMsOption := range m.pending {
+		if !m.SupportedVersions().Supports(protoParamsMsOption.ProtocolVersion) {
+			//nolint:forcetypeassert // we will replace that with generics anyway
+			return protoParamsMsOption.Clone().(*iotago.ProtocolParamsMilestoneOpt)
+		}
+	}
+
+	return nil
+}
+
+// DiffParameters returns the changed parameters between the current and the pending protocol parameters.
+func DiffParameters(current *iotago.ProtocolParameters, pending *iotago.ProtocolParameters) []*ParameterChange {
+	changes := make([]*ParameterChange, 0)
+
+	addChange := func(name string, currentValue string, pendingValue string) {
+		if currentValue == pendingValue {
+			return
+		}
+
+		changes = append(changes, &ParameterChange{
+			Name:    name,
+			Current: currentValue,
+			Pending: pendingValue,
+		})
+	}
+
+	addChange("version", strconv.FormatUint(uint64(current.Version), 10), strconv.FormatUint(uint64(pending.Version), 10))
+	addChange("networkName", current.NetworkName, pending.NetworkName)
+	addChange("bech32Hrp", string(current.Bech32HRP), string(pending.Bech32HRP))
+	addChange("minPowScore", strconv.FormatUint(uint64(current.MinPoWScore), 10), strconv.FormatUint(uint64(pending.MinPoWScore), 10))
+	addChange("belowMaxDepth", strconv.FormatUint(uint64(current.BelowMaxDepth), 10), strconv.FormatUint(uint64(pending.BelowMaxDepth), 10))
+	addChange("rentStructure.vByteCost", strconv.FormatUint(uint64(current.RentStructure.VByteCost), 10), strconv.FormatUint(uint64(pending.RentStructure.VByteCost), 10))
+	addChange("rentStructure.vByteFactorData", strconv.FormatUint(uint64(current.RentStructure.VBFactorData), 10), strconv.FormatUint(uint64(pending.RentStructure.VBFactorData), 10))
+	addChange("rentStructure.vByteFactorKey", strconv.FormatUint(uint64(current.RentStructure.VBFactorKey), 10), strconv.FormatUint(uint64(pending.RentStructure.VBFactorKey), 10))
+	addChange("tokenSupply", strconv.FormatUint(current.TokenSupply, 10), strconv.FormatUint(pending.TokenSupply, 10))
+
+	return changes
+}
@@ -0,0 +1,101 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package protocol_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/protocol"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestManagerUpgrades(t *testing.T) {
+	seedWallet := utils.NewHDWallet("Seed", make([]byte, 64), 0)
+
+	te := testsuite.SetupTestEnvironment(t, seedWallet.Address(), 2, 2, 15, 1, false)
+	defer te.CleanupTestEnvironment(true)
+
+	current := te.ProtocolParameters()
+
+	// schedule a supported protocol parameters change
+	supportedParams := *current
+	supportedParams.MinPoWScore = current.MinPoWScore + 1000
+	supportedParams.TokenSupply = current.TokenSupply / 2
+	supportedParamsBytes, err := supportedParams.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, te.Storage().StoreProtocolParametersMilestoneOption(&iotago.ProtocolParamsMilestoneOpt{
+		TargetMilestoneIndex: te.LastMilestoneIndex() + 10,
+		ProtocolVersion:      current.Version,
+		Params:               supportedParamsBytes,
+	}))
+
+	// schedule an unsupported protocol version
+	unsupportedParams := supportedParams
+	unsupportedParams.Version = current.Version + 1
+	unsupportedParamsBytes, err := unsupportedParams.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, te.Storage().StoreProtocolParametersMilestoneOption(&iotago.ProtocolParamsMilestoneOpt{
+		TargetMilestoneIndex: te.LastMilestoneIndex() + 20,
+		ProtocolVersion:      unsupportedParams.Version,
+		Params:               unsupportedParamsBytes,
+	}))
+
+	manager, err := protocol.NewManager(te.Storage(), te.LastMilestoneIndex())
+	require.NoError(t, err)
+
+	upgrades := manager.Upgrades()
+	require.Len(t, upgrades, 2)
+
+	require.Equal(t, te.LastMilestoneIndex()+10, upgrades[0].TargetMilestoneIndex)
+	require.True(t, upgrades[0].Supported)
+	require.NoError(t, upgrades[0].DecodeError)
+	require.Equal(t, supportedParams, *upgrades[0].Params)
+
+	changedNames := make([]string, 0, len(upgrades[0].Changes))
+	for _, change := range upgrades[0].Changes {
+		changedNames = append(changedNames, change.Name)
+	}
+	require.ElementsMatch(t, []string{"minPowScore", "tokenSupply"}, changedNames)
+
+	require.Equal(t, te.LastMilestoneIndex()+20, upgrades[1].TargetMilestoneIndex)
+	require.False(t, upgrades[1].Supported)
+
+	require.True(t, manager.NextPendingSupported())
+
+	nextUnsupported := manager.NextUnsupported()
+	require.NotNil(t, nextUnsupported)
+	require.Equal(t, te.LastMilestoneIndex()+20, nextUnsupported.TargetMilestoneIndex)
+	require.Equal(t, unsupportedParams.Version, nextUnsupported.ProtocolVersion)
+}
+
+func TestDiffParameters(t *testing.T) {
+	current := &iotago.ProtocolParameters{
+		Version:     2,
+		NetworkName: "testnet",
+		Bech32HRP:   iotago.PrefixTestnet,
+		MinPoWScore: 1500,
+		RentStructure: iotago.RentStructure{
+			VByteCost:    100,
+			VBFactorData: 1,
+			VBFactorKey:  10,
+		},
+		TokenSupply: 1000,
+	}
+
+	require.Empty(t, protocol.DiffParameters(current, current))
+
+	pending := *current
+	pending.RentStructure.VByteCost = 250
+
+	changes := protocol.DiffParameters(current, &pending)
+	require.Len(t, changes, 1)
+	require.Equal(t, "rentStructure.vByteCost", changes[0].Name)
+	require.Equal(t, "100", changes[0].Current)
+	require.Equal(t, "250", changes[0].Pending)
+}
@@ -26,6 +26,12 @@ const (
 
 	// ParameterPeerID is used to identify a peer.
 	ParameterPeerID = "peerID"
+
+	// ParameterFirewallRuleID is used to identify a firewall rule.
+	ParameterFirewallRuleID = "ruleID"
+
+	// QueryParameterEndIndex is used to define the end of a milestone index range.
+	QueryParameterEndIndex = "endIndex"
 )
 
 type (
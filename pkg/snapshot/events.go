@@ -9,6 +9,7 @@ type Events struct {
 	SnapshotMilestoneIndexChanged         *event.Event1[iotago.MilestoneIndex]
 	SnapshotMetricsUpdated                *event.Event1[*Metrics]
 	HandledConfirmedMilestoneIndexChanged *event.Event1[iotago.MilestoneIndex]
+	SnapshotFileCreated                   *event.Event1[*CreatedSnapshotFile]
 }
 
 func newEvents() *Events {
@@ -16,5 +17,6 @@ func newEvents() *Events {
 		SnapshotMilestoneIndexChanged:         event.New1[iotago.MilestoneIndex](),
 		SnapshotMetricsUpdated:                event.New1[*Metrics](),
 		HandledConfirmedMilestoneIndexChanged: event.New1[iotago.MilestoneIndex](),
+		SnapshotFileCreated:                   event.New1[*CreatedSnapshotFile](),
 	}
 }
@@ -0,0 +1,254 @@
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+)
+
+const (
+	// FileServerRouteFull is the route to download the latest full snapshot file.
+	// GET returns the full snapshot file.
+	FileServerRouteFull = "/full"
+
+	// FileServerRouteDelta is the route to download the latest delta snapshot file.
+	// GET returns the delta snapshot file.
+	FileServerRouteDelta = "/delta"
+
+	// FileServerRouteFullLatest is an alias of FileServerRouteFull that follows the naming of the public snapshot servers,
+	// so the route group can be used as a download target by other nodes.
+	FileServerRouteFullLatest = "/latest-full_snapshot.bin"
+
+	// FileServerRouteDeltaLatest is an alias of FileServerRouteDelta that follows the naming of the public snapshot servers,
+	// so the route group can be used as a download target by other nodes.
+	FileServerRouteDeltaLatest = "/latest-delta_snapshot.bin"
+)
+
+const (
+	// servedFileSuffix is the suffix of the copies of the snapshot files that are served.
+	servedFileSuffix = "_served"
+)
+
+// servedFileETag is the ETag of a served snapshot file.
+type servedFileETag struct {
+	// the file info of the served file the ETag was calculated for.
+	fileInfo os.FileInfo
+	etag     string
+}
+
+// FileServer serves the latest snapshot files.
+// The snapshot manager replaces the full snapshot file, but extends the delta snapshot file in place,
+// so the files are not served directly. Instead, a stable copy of every snapshot file is served,
+// that is only replaced, but never modified. Downloads that are in progress keep reading the old copy.
+// The ETag of a served file is the sha256 hash of its content.
+type FileServer struct {
+	snapshotFullPath  string
+	snapshotDeltaPath string
+
+	etagsLock syncutils.RWMutex
+	etags     map[Type]*servedFileETag
+	// the snapshot types whose ETags are queued for the calculation.
+	etagsQueued  map[Type]struct{}
+	etagsQueueCh chan Type
+}
+
+// NewFileServer creates a new FileServer for the given snapshot files.
+func NewFileServer(snapshotFullPath string, snapshotDeltaPath string) *FileServer {
+	return &FileServer{
+		snapshotFullPath:  snapshotFullPath,
+		snapshotDeltaPath: snapshotDeltaPath,
+		etags:             make(map[Type]*servedFileETag),
+		etagsQueued:       make(map[Type]struct{}),
+		// every snapshot type is queued at most once
+		etagsQueueCh: make(chan Type, 2),
+	}
+}
+
+func (s *FileServer) snapshotFilePath(snapshotType Type) string {
+	if snapshotType == Delta {
+		return s.snapshotDeltaPath
+	}
+
+	return s.snapshotFullPath
+}
+
+// ServedFilePath returns the path of the stable copy of the snapshot file of the given type.
+func (s *FileServer) ServedFilePath(snapshotType Type) string {
+	return s.snapshotFilePath(snapshotType) + servedFileSuffix
+}
+
+// UpdateServedFiles replaces the served copies with the current snapshot files.
+// The snapshot files must not be modified in the meantime, so this needs to be called
+// while the snapshot lock is held, e.g. in a handler of the SnapshotFileCreated event.
+func (s *FileServer) UpdateServedFiles() error {
+	for _, snapshotType := range []Type{Full, Delta} {
+		if err := s.updateServedFile(snapshotType); err != nil {
+			return fmt.Errorf("unable to update served %s snapshot file: %w", snapshotType, err)
+		}
+		s.queueETag(snapshotType)
+	}
+
+	return nil
+}
+
+func (s *FileServer) updateServedFile(snapshotType Type) error {
+	filePath := s.snapshotFilePath(snapshotType)
+	servedFilePath := s.ServedFilePath(snapshotType)
+
+	if _, err := os.Stat(filePath); err != nil {
+		if !os.IsNotExist(err) {
+			return err
+		}
+
+		// there is no snapshot file of this type (anymore), e.g. the delta snapshot file was removed by a new full snapshot
+		if err := os.Remove(servedFilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	if snapshotType == Full {
+		// the full snapshot file is always replaced and never modified in place, so a hard link is sufficient
+		tempFilePath := servedFilePath + "_tmp"
+		if err := os.Remove(tempFilePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		if err := os.Link(filePath, tempFilePath); err == nil {
+			return os.Rename(tempFilePath, servedFilePath)
+		}
+		// hard links are not supported on all file systems
+	}
+
+	return copyFile(filePath, servedFilePath)
+}
+
+// ETagsQueue returns the channel of the snapshot types whose ETags need to be calculated with CalculateETag.
+func (s *FileServer) ETagsQueue() <-chan Type {
+	return s.etagsQueueCh
+}
+
+func (s *FileServer) queueETag(snapshotType Type) {
+	s.etagsLock.Lock()
+	defer s.etagsLock.Unlock()
+
+	delete(s.etags, snapshotType)
+
+	if _, queued := s.etagsQueued[snapshotType]; queued {
+		return
+	}
+	s.etagsQueued[snapshotType] = struct{}{}
+	s.etagsQueueCh <- snapshotType
+}
+
+// CalculateETag calculates the ETag of the served snapshot file of the given type.
+func (s *FileServer) CalculateETag(snapshotType Type) error {
+	s.etagsLock.Lock()
+	delete(s.etagsQueued, snapshotType)
+	s.etagsLock.Unlock()
+
+	file, err := os.Open(s.ServedFilePath(snapshotType))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+	defer func() { _ = file.Close() }()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// the served file is never modified, so the hash belongs to the file with this file info
+	fileHash := sha256.New()
+	if _, err := io.Copy(fileHash, file); err != nil {
+		return err
+	}
+
+	s.etagsLock.Lock()
+	defer s.etagsLock.Unlock()
+
+	s.etags[snapshotType] = &servedFileETag{
+		fileInfo: fileInfo,
+		etag:     fmt.Sprintf(`"%s"`, hex.EncodeToString(fileHash.Sum(nil))),
+	}
+
+	return nil
+}
+
+// etag returns the ETag of the given served file, if it was already calculated.
+func (s *FileServer) etag(snapshotType Type, fileInfo os.FileInfo) (string, bool) {
+	s.etagsLock.RLock()
+	defer s.etagsLock.RUnlock()
+
+	servedETag, exists := s.etags[snapshotType]
+	if !exists || !os.SameFile(servedETag.fileInfo, fileInfo) {
+		return "", false
+	}
+
+	return servedETag.etag, true
+}
+
+// RegisterRoutes registers the routes of the snapshot files in the given route group.
+func (s *FileServer) RegisterRoutes(routeGroup *echo.Group) {
+	methods := []string{http.MethodGet, http.MethodHead}
+
+	for _, route := range []string{FileServerRouteFull, FileServerRouteFullLatest} {
+		routeGroup.Match(methods, route, func(c echo.Context) error {
+			return s.ServeSnapshotFile(c, Full)
+		})
+	}
+
+	for _, route := range []string{FileServerRouteDelta, FileServerRouteDeltaLatest} {
+		routeGroup.Match(methods, route, func(c echo.Context) error {
+			return s.ServeSnapshotFile(c, Delta)
+		})
+	}
+}
+
+// ServeSnapshotFile serves the latest snapshot file of the given type.
+// Range requests and conditional requests are handled by http.ServeContent.
+func (s *FileServer) ServeSnapshotFile(c echo.Context, snapshotType Type) error {
+	// the served copy is only replaced, but never modified,
+	// so the opened file stays the same during the whole request.
+	file, err := os.Open(s.ServedFilePath(snapshotType))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.WithMessagef(echo.ErrNotFound, "%s snapshot file not found", snapshotType)
+		}
+
+		return errors.WithMessagef(echo.ErrInternalServerError, "unable to open %s snapshot file: %s", snapshotType, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return errors.WithMessagef(echo.ErrInternalServerError, "unable to read %s snapshot file: %s", snapshotType, err)
+	}
+
+	fileName := filepath.Base(s.snapshotFilePath(snapshotType))
+
+	header := c.Response().Header()
+	if etag, exists := s.etag(snapshotType, fileInfo); exists {
+		header.Set("ETag", etag)
+	}
+	header.Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	header.Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+
+	http.ServeContent(c.Response(), c.Request(), fileName, fileInfo.ModTime(), file)
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// snapshotFileCreatedPayload is the payload that is sent to the webhook after a snapshot file was created.
+type snapshotFileCreatedPayload struct {
+	// The type of the snapshot ("full" or "delta").
+	Type string `json:"type"`
+	// The path to the snapshot file.
+	FilePath string `json:"filePath"`
+	// The path to the timestamped copy of the full snapshot file.
+	ArchivePath string `json:"archivePath,omitempty"`
+	// The index of the target milestone of the snapshot.
+	TargetMilestoneIndex iotago.MilestoneIndex `json:"targetMilestoneIndex"`
+	// The unix timestamp of the target milestone of the snapshot.
+	TargetMilestoneTimestamp int64 `json:"targetMilestoneTimestamp"`
+}
+
+// PostSnapshotHooks runs a shell command and calls a webhook after a snapshot file was created.
+// The created snapshot files are queued, so that slow hooks don't block the snapshot manager.
+type PostSnapshotHooks struct {
+	command      string
+	webhookURL   string
+	timeout      time.Duration
+	createdFiles chan *CreatedSnapshotFile
+}
+
+// NewPostSnapshotHooks creates new PostSnapshotHooks.
+// Hooks with an empty command or webhook URL are not executed.
+func NewPostSnapshotHooks(command string, webhookURL string, timeout time.Duration, queueSize int) *PostSnapshotHooks {
+	return &PostSnapshotHooks{
+		command:      command,
+		webhookURL:   webhookURL,
+		timeout:      timeout,
+		createdFiles: make(chan *CreatedSnapshotFile, queueSize),
+	}
+}
+
+// Enqueue queues the created snapshot file for the hooks.
+// It returns false if the queue is full.
+func (h *PostSnapshotHooks) Enqueue(createdFile *CreatedSnapshotFile) bool {
+	select {
+	case h.createdFiles <- createdFile:
+		return true
+	default:
+		return false
+	}
+}
+
+// Run executes the hooks for the queued snapshot files one after another until the context is done.
+// The errors of the hooks are passed to onError.
+func (h *PostSnapshotHooks) Run(ctx context.Context, onError func(err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case createdFile := <-h.createdFiles:
+			h.execute(ctx, createdFile, onError)
+		}
+	}
+}
+
+func (h *PostSnapshotHooks) execute(ctx context.Context, createdFile *CreatedSnapshotFile, onError func(err error)) {
+	payload := &snapshotFileCreatedPayload{
+		Type:                     createdFile.Type.String(),
+		FilePath:                 createdFile.FilePath,
+		ArchivePath:              createdFile.ArchivePath,
+		TargetMilestoneIndex:     createdFile.TargetMilestoneIndex,
+		TargetMilestoneTimestamp: createdFile.TargetMilestoneTimestamp.Unix(),
+	}
+
+	if h.command != "" {
+		if err := h.runCommand(ctx, payload); err != nil {
+			onError(fmt.Errorf("post-snapshot command failed: %w", err))
+		}
+	}
+
+	if h.webhookURL != "" {
+		if err := h.callWebhook(ctx, payload); err != nil {
+			onError(fmt.Errorf("post-snapshot webhook failed: %w", err))
+		}
+	}
+}
+
+// runCommand executes the configured shell command.
+// The information about the snapshot file is passed via environment variables.
+func (h *PostSnapshotHooks) runCommand(ctx context.Context, payload *snapshotFileCreatedPayload) error {
+	cmdCtx, cmdCancel := context.WithTimeout(ctx, h.timeout)
+	defer cmdCancel()
+
+	//nolint:gosec // the command is configured by the node operator
+	cmd := exec.CommandContext(cmdCtx, "sh", "-c", h.command)
+	cmd.Env = append(os.Environ(),
+		"HORNET_SNAPSHOT_TYPE="+payload.Type,
+		"HORNET_SNAPSHOT_PATH="+payload.FilePath,
+		"HORNET_SNAPSHOT_ARCHIVE_PATH="+payload.ArchivePath,
+		fmt.Sprintf("HORNET_SNAPSHOT_MILESTONE_INDEX=%d", payload.TargetMilestoneIndex),
+		fmt.Sprintf("HORNET_SNAPSHOT_MILESTONE_TIMESTAMP=%d", payload.TargetMilestoneTimestamp),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w, output: %s", err, output)
+	}
+
+	return nil
+}
+
+// callWebhook sends the information about the snapshot file to the configured webhook.
+func (h *PostSnapshotHooks) callWebhook(ctx context.Context, payload *snapshotFileCreatedPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, h.timeout)
+	defer reqCancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, h.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package snapshot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// archiveTimestampFormat is the format of the timestamp in the file name of archived full snapshots.
+	archiveTimestampFormat = "20060102T150405Z"
+)
+
+var (
+	// ErrInvalidSchedule is returned if the interval or the offset of a snapshot schedule is invalid.
+	ErrInvalidSchedule = errors.New("invalid snapshot schedule")
+)
+
+// Schedule defines the points in time at which snapshots are created.
+// The points in time are multiples of the interval since 00:00 UTC, shifted by the offset,
+// and are compared against the timestamps of the milestones.
+type Schedule struct {
+	interval time.Duration
+	offset   time.Duration
+}
+
+// NewSchedule creates a new snapshot schedule.
+// The interval must divide a day without remainder, so that every day has the same points in time,
+// and the offset must be smaller than the interval.
+func NewSchedule(interval time.Duration, offset time.Duration) (*Schedule, error) {
+	if interval <= 0 || (24*time.Hour)%interval != 0 {
+		return nil, errors.WithMessagef(ErrInvalidSchedule, "interval %s doesn't divide 24h", interval)
+	}
+
+	if offset < 0 || offset >= interval {
+		return nil, errors.WithMessagef(ErrInvalidSchedule, "offset %s is not within [0, %s)", offset, interval)
+	}
+
+	return &Schedule{
+		interval: interval,
+		offset:   offset,
+	}, nil
+}
+
+// slot returns the last scheduled point in time before or at the given timestamp.
+func (s *Schedule) slot(timestamp time.Time) time.Time {
+	shifted := timestamp.UTC().Add(-s.offset)
+
+	dayStart := time.Date(shifted.Year(), shifted.Month(), shifted.Day(), 0, 0, 0, 0, time.UTC)
+	sinceDayStart := shifted.Sub(dayStart)
+
+	return dayStart.Add(sinceDayStart - sinceDayStart%s.interval + s.offset)
+}
+
+// Due returns whether a scheduled point in time was passed between
+// the timestamp of the last snapshot and the given milestone timestamp.
+func (s *Schedule) Due(lastSnapshotTimestamp time.Time, milestoneTimestamp time.Time) bool {
+	return s.slot(milestoneTimestamp).After(s.slot(lastSnapshotTimestamp))
+}
+
+// CreatedSnapshotFile contains information about a snapshot file that was created by the snapshot manager.
+type CreatedSnapshotFile struct {
+	// Type is the type of the snapshot.
+	Type Type
+	// FilePath is the path to the snapshot file.
+	FilePath string
+	// ArchivePath is the path to the timestamped copy of a full snapshot file (empty if it was not archived).
+	ArchivePath string
+	// TargetMilestoneIndex is the index of the target milestone of the snapshot.
+	TargetMilestoneIndex iotago.MilestoneIndex
+	// TargetMilestoneTimestamp is the timestamp of the target milestone of the snapshot.
+	TargetMilestoneTimestamp time.Time
+}
+
+// archivedFullSnapshotFileRegex returns the regex that matches the names of the archived copies of the given full snapshot file.
+func archivedFullSnapshotFileRegex(filePath string) *regexp.Regexp {
+	ext := filepath.Ext(filePath)
+	base := strings.TrimSuffix(filepath.Base(filePath), ext)
+
+	return regexp.MustCompile(fmt.Sprintf(`^%s_\d{8}T\d{6}Z_\d+%s$`, regexp.QuoteMeta(base), regexp.QuoteMeta(ext)))
+}
+
+// ArchivedFullSnapshotFilePath returns the path of the timestamped copy of the given full snapshot file.
+func ArchivedFullSnapshotFilePath(filePath string, targetIndex iotago.MilestoneIndex, targetTimestamp time.Time) string {
+	ext := filepath.Ext(filePath)
+
+	return fmt.Sprintf("%s_%s_%d%s", strings.TrimSuffix(filePath, ext), targetTimestamp.UTC().Format(archiveTimestampFormat), targetIndex, ext)
+}
+
+// ArchivedFullSnapshotFiles returns the paths of all timestamped copies of the given full snapshot file, sorted from old to new.
+func ArchivedFullSnapshotFiles(filePath string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read snapshot directory: %w", err)
+	}
+
+	regex := archivedFullSnapshotFileRegex(filePath)
+
+	var archivedFiles []string
+	for _, entry := range entries {
+		if entry.IsDir() || !regex.MatchString(entry.Name()) {
+			continue
+		}
+		archivedFiles = append(archivedFiles, filepath.Join(filepath.Dir(filePath), entry.Name()))
+	}
+
+	// the timestamp is the first variable part of the name, so the lexical order is the chronological order.
+	sort.Strings(archivedFiles)
+
+	return archivedFiles, nil
+}
+
+// ArchiveFullSnapshotFile keeps a timestamped copy of the given full snapshot file
+// and removes the oldest copies so that at most "keep" copies exist.
+// The copy is a hard link if possible, since the full snapshot file is always replaced and never modified in place.
+func ArchiveFullSnapshotFile(filePath string, targetIndex iotago.MilestoneIndex, targetTimestamp time.Time, keep int) (string, error) {
+	archivePath := ArchivedFullSnapshotFilePath(filePath, targetIndex, targetTimestamp)
+
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("unable to remove existing archived snapshot file: %w", err)
+	}
+
+	if err := os.Link(filePath, archivePath); err != nil {
+		// hard links are not supported on all file systems
+		if err := copyFile(filePath, archivePath); err != nil {
+			return "", fmt.Errorf("unable to archive full snapshot file: %w", err)
+		}
+	}
+
+	archivedFiles, err := ArchivedFullSnapshotFiles(filePath)
+	if err != nil {
+		return "", err
+	}
+
+	for len(archivedFiles) > keep {
+		if err := os.Remove(archivedFiles[0]); err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("unable to remove archived snapshot file: %w", err)
+		}
+		archivedFiles = archivedFiles[1:]
+	}
+
+	return archivePath, nil
+}
+
+func copyFile(sourcePath string, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = source.Close() }()
+
+	target, tempFilePath, err := ioutils.CreateTempFile(targetPath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(target, source); err != nil {
+		_ = target.Close()
+		_ = os.Remove(tempFilePath)
+
+		return err
+	}
+
+	return ioutils.CloseFileAndRename(target, tempFilePath, targetPath)
+}
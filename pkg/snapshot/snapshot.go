@@ -66,6 +66,8 @@ type Manager struct {
 	solidEntryPointCheckThresholdFuture    syncmanager.MilestoneIndexDelta
 	snapshotDepth                          syncmanager.MilestoneIndexDelta
 	snapshotInterval                       syncmanager.MilestoneIndexDelta
+	snapshotSchedule                       *Schedule
+	fullSnapshotRetention                  int
 
 	snapshotLock         syncutils.Mutex
 	statusLock           syncutils.RWMutex
@@ -89,6 +91,8 @@ func NewSnapshotManager(
 	solidEntryPointCheckThresholdFuture syncmanager.MilestoneIndexDelta,
 	snapshotDepth syncmanager.MilestoneIndexDelta,
 	snapshotInterval iotago.MilestoneIndex,
+	snapshotSchedule *Schedule,
+	fullSnapshotRetention int,
 ) *Manager {
 
 	return &Manager{
@@ -105,6 +109,8 @@ func NewSnapshotManager(
 		solidEntryPointCheckThresholdFuture:    solidEntryPointCheckThresholdFuture,
 		snapshotDepth:                          snapshotDepth,
 		snapshotInterval:                       snapshotInterval,
+		snapshotSchedule:                       snapshotSchedule,
+		fullSnapshotRetention:                  fullSnapshotRetention,
 		Events:                                 newEvents(),
 	}
 }
@@ -154,6 +160,10 @@ func (s *Manager) shouldTakeSnapshot(confirmedMilestoneIndex iotago.MilestoneInd
 		return false
 	}
 
+	if s.snapshotSchedule != nil {
+		return s.isSnapshotScheduled(confirmedMilestoneIndex, snapshotInfo)
+	}
+
 	if (confirmedMilestoneIndex < s.snapshotDepth+s.snapshotInterval) || (confirmedMilestoneIndex-s.snapshotDepth) < snapshotInfo.PruningIndex()+1+s.solidEntryPointCheckThresholdPast {
 		// Not enough history to calculate solid entry points
 		return false
@@ -162,6 +172,29 @@ func (s *Manager) shouldTakeSnapshot(confirmedMilestoneIndex iotago.MilestoneInd
 	return confirmedMilestoneIndex-(s.snapshotDepth+s.snapshotInterval) >= snapshotInfo.SnapshotIndex()
 }
 
+// isSnapshotScheduled checks if the timestamp of the snapshot target milestone
+// passed a scheduled point in time since the last snapshot.
+func (s *Manager) isSnapshotScheduled(confirmedMilestoneIndex iotago.MilestoneIndex, snapshotInfo *storagepkg.SnapshotInfo) bool {
+	if (confirmedMilestoneIndex < s.snapshotDepth) || (confirmedMilestoneIndex-s.snapshotDepth) < snapshotInfo.PruningIndex()+1+s.solidEntryPointCheckThresholdPast {
+		// Not enough history to calculate solid entry points
+		return false
+	}
+
+	targetIndex := confirmedMilestoneIndex - s.snapshotDepth
+	if targetIndex <= snapshotInfo.SnapshotIndex() {
+		return false
+	}
+
+	targetTimestamp, err := s.storage.MilestoneTimestampByIndex(targetIndex)
+	if err != nil {
+		s.LogWarnf("unable to check snapshot schedule: target milestone (%d) not found", targetIndex)
+
+		return false
+	}
+
+	return s.snapshotSchedule.Due(snapshotInfo.SnapshotTimestamp(), targetTimestamp)
+}
+
 func checkSnapshotLimits(
 	snapshotInfo *storagepkg.SnapshotInfo,
 	confirmedMilestoneIndex iotago.MilestoneIndex,
@@ -216,6 +249,15 @@ func (s *Manager) setIsSnapshotting(value bool) {
 	s.statusLock.Unlock()
 }
 
+// ExecuteWithSnapshotLock executes the given function while the snapshot lock is held,
+// so no snapshot files are created or modified in the meantime.
+func (s *Manager) ExecuteWithSnapshotLock(f func()) {
+	s.snapshotLock.Lock()
+	defer s.snapshotLock.Unlock()
+
+	f()
+}
+
 // CreateFullSnapshot creates a full snapshot for the given target milestone index.
 func (s *Manager) CreateFullSnapshot(ctx context.Context, targetIndex iotago.MilestoneIndex, filePath string, writeToDatabase bool) error {
 	s.snapshotLock.Lock()
@@ -303,11 +345,13 @@ func (s *Manager) HandleNewConfirmedMilestoneEvent(ctx context.Context, confirme
 			return
 		}
 
+		targetIndex := confirmedMilestoneIndex - s.snapshotDepth
+
 		switch snapshotType {
 		case Full:
-			err = s.createFullSnapshotWithoutLocking(ctx, confirmedMilestoneIndex-s.snapshotDepth, s.snapshotTypeFilePath(snapshotType), true)
+			err = s.createFullSnapshotWithoutLocking(ctx, targetIndex, s.snapshotTypeFilePath(snapshotType), true)
 		case Delta:
-			err = s.createDeltaSnapshotWithoutLocking(ctx, confirmedMilestoneIndex-s.snapshotDepth)
+			err = s.createDeltaSnapshotWithoutLocking(ctx, targetIndex)
 		}
 
 		if err != nil {
@@ -315,12 +359,42 @@ func (s *Manager) HandleNewConfirmedMilestoneEvent(ctx context.Context, confirme
 				s.LogPanicf("%s: %s", ErrSnapshotCreationFailed, err)
 			}
 			s.LogWarnf("%s: %s", ErrSnapshotCreationFailed, err)
+		} else {
+			s.snapshotFileCreated(snapshotType, targetIndex)
 		}
 	}
 
 	s.Events.HandledConfirmedMilestoneIndexChanged.Trigger(confirmedMilestoneIndex)
 }
 
+// snapshotFileCreated archives a new full snapshot file if the retention is enabled
+// and informs the subscribers about the new snapshot file.
+func (s *Manager) snapshotFileCreated(snapshotType Type, targetIndex iotago.MilestoneIndex) {
+	targetTimestamp, err := s.storage.MilestoneTimestampByIndex(targetIndex)
+	if err != nil {
+		s.LogWarnf("target milestone (%d) not found", targetIndex)
+
+		return
+	}
+
+	createdFile := &CreatedSnapshotFile{
+		Type:                     snapshotType,
+		FilePath:                 s.snapshotTypeFilePath(snapshotType),
+		TargetMilestoneIndex:     targetIndex,
+		TargetMilestoneTimestamp: targetTimestamp,
+	}
+
+	if snapshotType == Full && s.fullSnapshotRetention > 0 {
+		archivePath, err := ArchiveFullSnapshotFile(createdFile.FilePath, targetIndex, targetTimestamp, s.fullSnapshotRetention)
+		if err != nil {
+			s.LogWarn(err)
+		}
+		createdFile.ArchivePath = archivePath
+	}
+
+	s.Events.SnapshotFileCreated.Trigger(createdFile)
+}
+
 func FormatSnapshotTimestamp(timestamp uint32) string {
 	result := "unknown"
 	if timestamp != 0 {
@@ -65,6 +65,15 @@ var snapshotNames = map[Type]string{
 	Delta: "delta",
 }
 
+// String returns the name of the snapshot type.
+func (t Type) String() string {
+	if name, exists := snapshotNames[t]; exists {
+		return name
+	}
+
+	return fmt.Sprintf("unknown (%d)", t)
+}
+
 // ReadWriteTruncateSeeker is the interface used to read, write and truncate a file.
 type ReadWriteTruncateSeeker interface {
 	io.ReadWriteSeeker
@@ -0,0 +1,211 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct,noctx // we don't care about these linters in test cases
+package snapshot_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+const (
+	routeSnapshots = "/api/snapshots/v1"
+)
+
+func newTestFileServer(t *testing.T) (*snapshot.FileServer, *httptest.Server, string, string) {
+	dir := t.TempDir()
+	fullPath := filepath.Join(dir, "full_snapshot.bin")
+	deltaPath := filepath.Join(dir, "delta_snapshot.bin")
+
+	fileServer := snapshot.NewFileServer(fullPath, deltaPath)
+
+	e := httpserver.NewEcho(nil, nil, false)
+	fileServer.RegisterRoutes(e.Group(routeSnapshots))
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+
+	return fileServer, server, fullPath, deltaPath
+}
+
+// calculateETags calculates the ETags of all queued snapshot files.
+func calculateETags(t *testing.T, fileServer *snapshot.FileServer) {
+	for {
+		select {
+		case snapshotType := <-fileServer.ETagsQueue():
+			require.NoError(t, fileServer.CalculateETag(snapshotType))
+		default:
+			return
+		}
+	}
+}
+
+func expectedETag(content []byte) string {
+	hash := sha256.Sum256(content)
+
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash[:]))
+}
+
+func doRequest(t *testing.T, method string, url string, headers map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequest(method, url, nil)
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	return res, body
+}
+
+func TestFileServer(t *testing.T) {
+	fileServer, server, fullPath, deltaPath := newTestFileServer(t)
+
+	fullURL := server.URL + routeSnapshots + snapshot.FileServerRouteFull
+	fullLatestURL := server.URL + routeSnapshots + snapshot.FileServerRouteFullLatest
+	deltaURL := server.URL + routeSnapshots + snapshot.FileServerRouteDelta
+	deltaLatestURL := server.URL + routeSnapshots + snapshot.FileServerRouteDeltaLatest
+
+	// no snapshot files exist yet
+	require.NoError(t, fileServer.UpdateServedFiles())
+	for _, url := range []string{fullURL, fullLatestURL, deltaURL, deltaLatestURL} {
+		res, _ := doRequest(t, http.MethodGet, url, nil)
+		require.Equal(t, http.StatusNotFound, res.StatusCode)
+	}
+
+	fullContent := []byte("full snapshot content")
+	deltaContent := []byte("delta snapshot content")
+	require.NoError(t, os.WriteFile(fullPath, fullContent, 0o600))
+	require.NoError(t, os.WriteFile(deltaPath, deltaContent, 0o600))
+
+	// the snapshot files are only served after they were updated
+	res, _ := doRequest(t, http.MethodGet, fullURL, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	require.NoError(t, fileServer.UpdateServedFiles())
+
+	// the ETag is only set after it was calculated
+	res, body := doRequest(t, http.MethodGet, fullURL, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, fullContent, body)
+	require.Empty(t, res.Header.Get("ETag"))
+	calculateETags(t, fileServer)
+
+	// the aliases serve the same files
+	for url, content := range map[string][]byte{
+		fullURL:        fullContent,
+		fullLatestURL:  fullContent,
+		deltaURL:       deltaContent,
+		deltaLatestURL: deltaContent,
+	} {
+		res, body := doRequest(t, http.MethodGet, url, nil)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, content, body)
+		require.Equal(t, expectedETag(content), res.Header.Get("ETag"))
+		require.Equal(t, "application/octet-stream", res.Header.Get("Content-Type"))
+	}
+
+	// HEAD returns the headers without the content
+	res, body = doRequest(t, http.MethodHead, deltaLatestURL, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Empty(t, body)
+	require.Equal(t, fmt.Sprintf("%d", len(deltaContent)), res.Header.Get("Content-Length"))
+	require.Equal(t, expectedETag(deltaContent), res.Header.Get("ETag"))
+	require.Equal(t, `attachment; filename="delta_snapshot.bin"`, res.Header.Get("Content-Disposition"))
+
+	// range requests
+	res, body = doRequest(t, http.MethodGet, fullURL, map[string]string{"Range": "bytes=5-12"})
+	require.Equal(t, http.StatusPartialContent, res.StatusCode)
+	require.Equal(t, fullContent[5:13], body)
+
+	// conditional requests
+	res, _ = doRequest(t, http.MethodGet, fullURL, map[string]string{"If-None-Match": expectedETag(fullContent)})
+	require.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	oldDeltaETag := expectedETag(deltaContent)
+	res, body = doRequest(t, http.MethodGet, deltaURL, map[string]string{"Range": "bytes=6-", "If-Range": oldDeltaETag})
+	require.Equal(t, http.StatusPartialContent, res.StatusCode)
+	require.Equal(t, deltaContent[6:], body)
+
+	// the delta snapshot file is extended in place by the snapshot manager
+	deltaFile, err := os.OpenFile(deltaPath, os.O_RDWR|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = deltaFile.WriteString(" with more milestone diffs")
+	require.NoError(t, err)
+	require.NoError(t, deltaFile.Close())
+	newDeltaContent, err := os.ReadFile(deltaPath)
+	require.NoError(t, err)
+
+	// the served copy is not modified until it is updated
+	res, body = doRequest(t, http.MethodGet, deltaURL, nil)
+	require.Equal(t, deltaContent, body)
+	require.Equal(t, oldDeltaETag, res.Header.Get("ETag"))
+
+	require.NoError(t, fileServer.UpdateServedFiles())
+	calculateETags(t, fileServer)
+
+	// resuming a download of the old file returns the whole new file
+	res, body = doRequest(t, http.MethodGet, deltaURL, map[string]string{"Range": "bytes=6-", "If-Range": oldDeltaETag})
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, newDeltaContent, body)
+	require.Equal(t, expectedETag(newDeltaContent), res.Header.Get("ETag"))
+
+	// a new full snapshot removes the delta snapshot file
+	require.NoError(t, os.Remove(deltaPath))
+	require.NoError(t, fileServer.UpdateServedFiles())
+	calculateETags(t, fileServer)
+
+	res, _ = doRequest(t, http.MethodGet, deltaLatestURL, nil)
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+	res, body = doRequest(t, http.MethodGet, fullLatestURL, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	require.Equal(t, fullContent, body)
+}
+
+func TestFileServerDownloadInProgress(t *testing.T) {
+	fileServer, server, fullPath, deltaPath := newTestFileServer(t)
+
+	// the content is bigger than the buffers of the connection, so the download is still in progress after the first read
+	deltaContent := bytes.Repeat([]byte("0123456789abcdef"), 1024*1024)
+	require.NoError(t, os.WriteFile(fullPath, []byte("full snapshot content"), 0o600))
+	require.NoError(t, os.WriteFile(deltaPath, deltaContent, 0o600))
+	require.NoError(t, fileServer.UpdateServedFiles())
+
+	res, err := http.Get(server.URL + routeSnapshots + snapshot.FileServerRouteDelta)
+	require.NoError(t, err)
+	defer func() { _ = res.Body.Close() }()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	firstPart := make([]byte, 1024)
+	_, err = io.ReadFull(res.Body, firstPart)
+	require.NoError(t, err)
+
+	// the snapshot manager rewrites the delta snapshot file in place during the download
+	deltaFile, err := os.OpenFile(deltaPath, os.O_RDWR, 0o600)
+	require.NoError(t, err)
+	require.NoError(t, deltaFile.Truncate(0))
+	_, err = deltaFile.Write(bytes.Repeat([]byte("x"), len(deltaContent)+100))
+	require.NoError(t, err)
+	require.NoError(t, deltaFile.Close())
+	require.NoError(t, fileServer.UpdateServedFiles())
+
+	// the download in progress still gets the content of the file it started with
+	rest, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, deltaContent, append(firstPart, rest...))
+}
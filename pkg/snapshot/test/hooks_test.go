@@ -0,0 +1,163 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct,noctx // we don't care about these linters in test cases
+package snapshot_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+)
+
+// runPostSnapshotHooks runs the hooks in the background and returns the channel the errors of the hooks are sent to.
+func runPostSnapshotHooks(t *testing.T, hooks *snapshot.PostSnapshotHooks) <-chan error {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error, 10)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		hooks.Run(ctx, func(err error) {
+			errs <- err
+		})
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+
+	return errs
+}
+
+func testCreatedSnapshotFile(dir string) *snapshot.CreatedSnapshotFile {
+	return &snapshot.CreatedSnapshotFile{
+		Type:                     snapshot.Full,
+		FilePath:                 filepath.Join(dir, "full_snapshot.bin"),
+		ArchivePath:              filepath.Join(dir, "full_snapshot_20221003T000000Z_1000.bin"),
+		TargetMilestoneIndex:     1000,
+		TargetMilestoneTimestamp: time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestPostSnapshotHooks(t *testing.T) {
+	dir := t.TempDir()
+	createdFile := testCreatedSnapshotFile(dir)
+
+	var payloadsLock sync.Mutex
+	var payloads []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		payload := make(map[string]interface{})
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+
+		payloadsLock.Lock()
+		defer payloadsLock.Unlock()
+		payloads = append(payloads, payload)
+	}))
+	defer server.Close()
+
+	outputPath := filepath.Join(dir, "hook_output")
+	command := `echo "$HORNET_SNAPSHOT_TYPE $HORNET_SNAPSHOT_PATH $HORNET_SNAPSHOT_ARCHIVE_PATH $HORNET_SNAPSHOT_MILESTONE_INDEX $HORNET_SNAPSHOT_MILESTONE_TIMESTAMP" >> ` + outputPath
+
+	hooks := snapshot.NewPostSnapshotHooks(command, server.URL, time.Second, 10)
+	errs := runPostSnapshotHooks(t, hooks)
+
+	require.True(t, hooks.Enqueue(createdFile))
+
+	require.Eventually(t, func() bool {
+		payloadsLock.Lock()
+		defer payloadsLock.Unlock()
+
+		return len(payloads) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the command is executed before the webhook is called
+	output, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{"full", createdFile.FilePath, createdFile.ArchivePath, "1000", "1664755200"}, " ")+"\n", string(output))
+
+	payloadsLock.Lock()
+	require.Equal(t, map[string]interface{}{
+		"type":                     "full",
+		"filePath":                 createdFile.FilePath,
+		"archivePath":              createdFile.ArchivePath,
+		"targetMilestoneIndex":     float64(1000),
+		"targetMilestoneTimestamp": float64(1664755200),
+	}, payloads[0])
+	payloadsLock.Unlock()
+
+	require.Empty(t, errs)
+}
+
+func TestPostSnapshotHooksErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hooks := snapshot.NewPostSnapshotHooks("echo failed && exit 1", server.URL, time.Second, 10)
+	errs := runPostSnapshotHooks(t, hooks)
+
+	require.True(t, hooks.Enqueue(testCreatedSnapshotFile(dir)))
+
+	// both hooks are executed, even if the first one fails
+	err := <-errs
+	require.ErrorContains(t, err, "post-snapshot command failed")
+	require.ErrorContains(t, err, "output: failed")
+
+	err = <-errs
+	require.ErrorContains(t, err, "post-snapshot webhook failed")
+	require.ErrorContains(t, err, "unexpected status code: 500")
+}
+
+func TestPostSnapshotHooksQueue(t *testing.T) {
+	dir := t.TempDir()
+
+	// the webhook blocks until it is released, so the queue fills up
+	release := make(chan struct{})
+	calls := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls <- struct{}{}
+		<-release
+	}))
+	defer server.Close()
+
+	hooks := snapshot.NewPostSnapshotHooks("", server.URL, 5*time.Second, 2)
+	errs := runPostSnapshotHooks(t, hooks)
+
+	require.True(t, hooks.Enqueue(testCreatedSnapshotFile(dir)))
+	<-calls
+
+	// the first file is processed, two more files fit into the queue
+	require.True(t, hooks.Enqueue(testCreatedSnapshotFile(dir)))
+	require.True(t, hooks.Enqueue(testCreatedSnapshotFile(dir)))
+	require.False(t, hooks.Enqueue(testCreatedSnapshotFile(dir)))
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "queued hooks were not executed")
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		return hooks.Enqueue(testCreatedSnapshotFile(dir))
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, errs)
+}
@@ -0,0 +1,170 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package snapshot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func newSchedule(t *testing.T, interval time.Duration, offset time.Duration) *snapshot.Schedule {
+	schedule, err := snapshot.NewSchedule(interval, offset)
+	require.NoError(t, err)
+
+	return schedule
+}
+
+func TestScheduleDue(t *testing.T) {
+	daily := newSchedule(t, 24*time.Hour, 0)
+
+	lastSnapshot := time.Date(2022, 10, 3, 12, 30, 0, 0, time.UTC)
+	require.False(t, daily.Due(lastSnapshot, time.Date(2022, 10, 3, 23, 59, 59, 0, time.UTC)))
+	require.True(t, daily.Due(lastSnapshot, time.Date(2022, 10, 4, 0, 0, 0, 0, time.UTC)))
+	require.True(t, daily.Due(lastSnapshot, time.Date(2022, 10, 6, 8, 0, 0, 0, time.UTC)))
+
+	// the schedule is based on UTC, independent of the location of the timestamps
+	location := time.FixedZone("UTC+2", 2*60*60)
+	require.False(t, daily.Due(lastSnapshot, time.Date(2022, 10, 4, 1, 59, 59, 0, location)))
+	require.True(t, daily.Due(lastSnapshot, time.Date(2022, 10, 4, 2, 0, 0, 0, location)))
+
+	// daily at 06:00 UTC
+	dailyWithOffset := newSchedule(t, 24*time.Hour, 6*time.Hour)
+	require.True(t, dailyWithOffset.Due(lastSnapshot.Add(-8*time.Hour), lastSnapshot))
+	require.False(t, dailyWithOffset.Due(lastSnapshot, time.Date(2022, 10, 4, 5, 59, 59, 0, time.UTC)))
+	require.True(t, dailyWithOffset.Due(lastSnapshot, time.Date(2022, 10, 4, 6, 0, 0, 0, time.UTC)))
+
+	hourly := newSchedule(t, time.Hour, 0)
+	require.False(t, hourly.Due(lastSnapshot, lastSnapshot.Add(29*time.Minute)))
+	require.True(t, hourly.Due(lastSnapshot, lastSnapshot.Add(30*time.Minute)))
+
+	// every 8 hours, starting at 02:00 UTC
+	eightHourly := newSchedule(t, 8*time.Hour, 2*time.Hour)
+	require.False(t, eightHourly.Due(lastSnapshot, time.Date(2022, 10, 3, 17, 59, 59, 0, time.UTC)))
+	require.True(t, eightHourly.Due(lastSnapshot, time.Date(2022, 10, 3, 18, 0, 0, 0, time.UTC)))
+	require.False(t, eightHourly.Due(time.Date(2022, 10, 3, 18, 0, 0, 0, time.UTC), time.Date(2022, 10, 4, 1, 59, 59, 0, time.UTC)))
+	require.True(t, eightHourly.Due(time.Date(2022, 10, 3, 18, 0, 0, 0, time.UTC), time.Date(2022, 10, 4, 2, 0, 0, 0, time.UTC)))
+
+	// the slots are aligned to the start of the day, also for timestamps before the unix epoch
+	require.True(t, eightHourly.Due(time.Date(1969, 12, 31, 17, 59, 59, 0, time.UTC), time.Date(1969, 12, 31, 18, 0, 0, 0, time.UTC)))
+}
+
+func TestNewScheduleInvalid(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		offset   time.Duration
+	}{
+		// the interval doesn't divide a day
+		{7 * time.Hour, 0},
+		{25 * time.Hour, 0},
+		{48 * time.Hour, 0},
+		{-time.Hour, 0},
+		// the offset is not within [0, interval)
+		{24 * time.Hour, 24 * time.Hour},
+		{6 * time.Hour, 7 * time.Hour},
+		{6 * time.Hour, -time.Hour},
+	}
+
+	for _, test := range tests {
+		_, err := snapshot.NewSchedule(test.interval, test.offset)
+		require.ErrorIs(t, err, snapshot.ErrInvalidSchedule, "interval %s, offset %s", test.interval, test.offset)
+	}
+}
+
+func TestSnapshotManagerSchedule(t *testing.T) {
+	te := testsuite.SetupTestEnvironment(t, tpkg.RandAddress(iotago.AddressEd25519).(*iotago.Ed25519Address), 0, 2, 15, 1, false)
+	defer te.CleanupTestEnvironment(true)
+
+	// the milestones of the test environment are issued every 100 seconds since the unix epoch
+	require.NoError(t, te.Storage().SetSnapshotIndex(0, time.Unix(0, 0)))
+
+	snapshotDepth := iotago.MilestoneIndex(5)
+	manager := snapshot.NewSnapshotManager(
+		logger.NewLogger("Snapshot"),
+		te.Storage(),
+		te.SyncManager(),
+		te.UTXOManager(),
+		true,
+		filepath.Join(te.TempDir, "full_snapshot.bin"),
+		filepath.Join(te.TempDir, "delta_snapshot.bin"),
+		50.0,
+		0,
+		1,
+		1,
+		snapshotDepth,
+		0,
+		newSchedule(t, time.Hour, 0),
+		0,
+	)
+
+	var createdFiles []*snapshot.CreatedSnapshotFile
+	manager.Events.SnapshotFileCreated.Hook(func(createdFile *snapshot.CreatedSnapshotFile) {
+		createdFiles = append(createdFiles, createdFile)
+	})
+
+	for len(createdFiles) < 2 {
+		te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{te.LastMilestoneBlockID()}, false)
+
+		confirmedIndex := te.SyncManager().ConfirmedMilestoneIndex()
+		require.Less(t, confirmedIndex, iotago.MilestoneIndex(100), "snapshots were not created in time")
+
+		manager.HandleNewConfirmedMilestoneEvent(context.Background(), confirmedIndex)
+	}
+
+	// the snapshots target the first milestones at or after 01:00 and 02:00 UTC
+	require.Equal(t, snapshot.Full, createdFiles[0].Type)
+	require.Equal(t, iotago.MilestoneIndex(36), createdFiles[0].TargetMilestoneIndex)
+	require.Equal(t, time.Unix(3600, 0), createdFiles[0].TargetMilestoneTimestamp)
+	require.Equal(t, snapshot.Delta, createdFiles[1].Type)
+	require.Equal(t, iotago.MilestoneIndex(72), createdFiles[1].TargetMilestoneIndex)
+	require.Equal(t, time.Unix(7200, 0), createdFiles[1].TargetMilestoneTimestamp)
+	require.Equal(t, iotago.MilestoneIndex(72+snapshotDepth), te.SyncManager().ConfirmedMilestoneIndex())
+	require.Equal(t, iotago.MilestoneIndex(72), te.Storage().SnapshotInfo().SnapshotIndex())
+}
+
+func TestArchiveFullSnapshotFile(t *testing.T) {
+	dir := t.TempDir()
+	fullPath := filepath.Join(dir, "full_snapshot.bin")
+
+	// files of other snapshots must not be touched by the retention
+	otherPath := filepath.Join(dir, "full_snapshot_old.bin")
+	require.NoError(t, os.WriteFile(otherPath, []byte("other"), 0o600))
+
+	timestamp := time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)
+
+	var archivePaths []string
+	for i := 0; i < 4; i++ {
+		content := []byte{byte(i)}
+		require.NoError(t, os.WriteFile(fullPath+"_tmp", content, 0o600))
+		require.NoError(t, os.Rename(fullPath+"_tmp", fullPath))
+
+		archivePath, err := snapshot.ArchiveFullSnapshotFile(fullPath, uint32(1000+i), timestamp.Add(time.Duration(i)*24*time.Hour), 2)
+		require.NoError(t, err)
+		require.Equal(t, snapshot.ArchivedFullSnapshotFilePath(fullPath, uint32(1000+i), timestamp.Add(time.Duration(i)*24*time.Hour)), archivePath)
+		archivePaths = append(archivePaths, archivePath)
+	}
+	require.Equal(t, filepath.Join(dir, "full_snapshot_20221003T000000Z_1000.bin"), archivePaths[0])
+
+	archivedFiles, err := snapshot.ArchivedFullSnapshotFiles(fullPath)
+	require.NoError(t, err)
+	require.Equal(t, archivePaths[2:], archivedFiles)
+
+	// replacing the full snapshot file must not change the archived copies
+	for i, archivePath := range archivePaths[2:] {
+		content, err := os.ReadFile(archivePath)
+		require.NoError(t, err)
+		require.Equal(t, []byte{byte(i + 2)}, content)
+	}
+
+	_, err = os.Stat(otherPath)
+	require.NoError(t, err)
+}
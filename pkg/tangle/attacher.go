@@ -19,6 +19,7 @@ var (
 	ErrBlockAttacherInvalidBlock         = errors.New("invalid block")
 	ErrBlockAttacherAttachingNotPossible = errors.New("attaching not possible")
 	ErrBlockAttacherPoWNotAvailable      = errors.New("proof of work is not available on this node")
+	ErrBlockAttacherPoWQueueFull         = errors.New("proof of work queue is full")
 )
 
 type BlockAttacherOption func(opts *BlockAttacherOptions)
@@ -84,7 +85,9 @@ func (t *Tangle) BlockAttacher(opts ...BlockAttacherOption) *BlockAttacher {
 	}
 }
 
-func (a *BlockAttacher) AttachBlock(ctx context.Context, iotaBlock *iotago.Block) (iotago.BlockID, error) {
+// AttachBlock attaches the given block to the tangle.
+// The PoW is done if needed, the given job options are used to queue the PoW job at the PoW handler.
+func (a *BlockAttacher) AttachBlock(ctx context.Context, iotaBlock *iotago.Block, powOpts ...pow.JobOption) (iotago.BlockID, error) {
 
 	protoParams := a.tangle.protocolManager.Current()
 
@@ -154,8 +157,12 @@ func (a *BlockAttacher) AttachBlock(ctx context.Context, iotaBlock *iotago.Block
 				}
 
 				ts := time.Now()
-				blockSize, err := a.opts.powHandler.DoPoW(powCtx, iotaBlock, serializer.DeSeriModePerformValidation, protoParams, powWorkerCount, tipSelFunc)
+				blockSize, err := a.opts.powHandler.DoPoW(powCtx, iotaBlock, serializer.DeSeriModePerformValidation, protoParams, powWorkerCount, tipSelFunc, powOpts...)
 				if err != nil {
+					if errors.Is(err, pow.ErrQueueFull) {
+						return iotago.EmptyBlockID(), ErrBlockAttacherPoWQueueFull
+					}
+
 					return iotago.EmptyBlockID(), err
 				}
 				if a.opts.powMetrics != nil {
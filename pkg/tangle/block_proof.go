@@ -0,0 +1,125 @@
+package tangle
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/dag"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag/proof"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	// milestoneConeCacheSize is the amount of milestones whose referenced blocks are kept for the creation of inclusion proofs.
+	milestoneConeCacheSize = 32
+)
+
+// milestoneCone contains the blocks referenced by a milestone in white-flag order.
+type milestoneCone struct {
+	milestone          *iotago.Milestone
+	referencedBlockIDs iotago.BlockIDs
+	appliedBlockIDs    iotago.BlockIDs
+}
+
+// milestoneConeCache keeps the cones of the latest requested milestones,
+// so the cone doesn't need to be reconstructed for every block of the same milestone.
+// The cone of a confirmed milestone never changes, so the entries don't need to be invalidated.
+type milestoneConeCache struct {
+	lock  syncutils.Mutex
+	cones map[iotago.MilestoneIndex]*milestoneCone
+	// the indexes of the cached milestones in the order they were added.
+	indexes []iotago.MilestoneIndex
+}
+
+func newMilestoneConeCache() *milestoneConeCache {
+	return &milestoneConeCache{
+		cones:   make(map[iotago.MilestoneIndex]*milestoneCone),
+		indexes: make([]iotago.MilestoneIndex, 0, milestoneConeCacheSize),
+	}
+}
+
+func (c *milestoneConeCache) get(msIndex iotago.MilestoneIndex) *milestoneCone {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.cones[msIndex]
+}
+
+func (c *milestoneConeCache) add(msIndex iotago.MilestoneIndex, cone *milestoneCone) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, exists := c.cones[msIndex]; exists {
+		return
+	}
+
+	if len(c.indexes) >= milestoneConeCacheSize {
+		// evict the oldest entry
+		delete(c.cones, c.indexes[0])
+		c.indexes = c.indexes[1:]
+	}
+
+	c.cones[msIndex] = cone
+	c.indexes = append(c.indexes, msIndex)
+}
+
+// milestoneCone returns the blocks referenced by the milestone with the given index.
+// The referenced blocks of the milestone are reconstructed from the stored block metadata.
+func (t *Tangle) milestoneCone(ctx context.Context, msIndex iotago.MilestoneIndex) (*milestoneCone, error) {
+	if cone := t.milestoneConeCache.get(msIndex); cone != nil {
+		return cone, nil
+	}
+
+	cachedMilestone := t.storage.CachedMilestoneByIndexOrNil(msIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil, errors.Wrapf(ErrMilestoneNotFound, "milestone %d", msIndex)
+	}
+	defer cachedMilestone.Release(true) // milestone -1
+
+	milestonePayload := cachedMilestone.Milestone().Milestone()
+
+	memcachedTraverserStorage := dag.NewMemcachedTraverserStorage(t.storage, storage.NewMetadataMemcache(t.storage.CachedBlockMetadata))
+	defer memcachedTraverserStorage.Cleanup(true)
+
+	referencedBlocks, err := whiteflag.ReferencedBlocksOfMilestone(ctx, memcachedTraverserStorage, msIndex, milestonePayload.Parents)
+	if err != nil {
+		return nil, err
+	}
+
+	cone := &milestoneCone{
+		milestone:          milestonePayload,
+		referencedBlockIDs: referencedBlocks.BlockIDs(),
+		appliedBlockIDs:    referencedBlocks.IncludedTransactionBlockIDs(),
+	}
+	t.milestoneConeCache.add(msIndex, cone)
+
+	return cone, nil
+}
+
+// BlockInclusionProof creates a proof that the given block is part of the merkle trees committed to by the milestone that referenced it.
+// The referenced blocks of a milestone are cached, so proofs for other blocks of the same milestone are created without traversing the cone again.
+func (t *Tangle) BlockInclusionProof(ctx context.Context, blockID iotago.BlockID) (*proof.InclusionProof, error) {
+
+	cachedBlockMeta := t.storage.CachedBlockMetadataOrNil(blockID) // meta +1
+	if cachedBlockMeta == nil {
+		return nil, common.ErrBlockNotFound
+	}
+	referenced, msIndex := cachedBlockMeta.Metadata().ReferencedWithIndex()
+	cachedBlockMeta.Release(true) // meta -1
+
+	if !referenced {
+		return nil, ErrBlockNotReferenced
+	}
+
+	cone, err := t.milestoneCone(ctx, msIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return proof.New(cone.milestone, cone.referencedBlockIDs, cone.appliedBlockIDs, blockID)
+}
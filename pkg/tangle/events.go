@@ -24,7 +24,9 @@ type Events struct {
 	LatestMilestoneChanged        *event.Event1[*storage.CachedMilestone]
 	LatestMilestoneIndexChanged   *event.Event1[iotago.MilestoneIndex]
 	MilestoneSolidificationFailed *event.Event1[iotago.MilestoneIndex]
-	MilestoneTimeout              *event.Event
+	// MilestoneSolidificationDiagnostics contains the diagnostics of the next milestone to solidify after a failed solidification attempt.
+	MilestoneSolidificationDiagnostics *event.Event1[*SolidificationDiagnostics]
+	MilestoneTimeout                   *event.Event
 
 	// metrics
 	BPSMetricsUpdated *event.Event1[*BPSMetrics]
@@ -70,13 +72,14 @@ func newEvents() *Events {
 		ConfirmedMilestoneChanged: event.New1[*storage.CachedMilestone](event.WithPreTriggerFunc(func(milestone *storage.CachedMilestone) {
 			milestone.Retain() // milestone pass +1
 		})),
-		ConfirmedMilestoneIndexChanged: event.New1[iotago.MilestoneIndex](),
-		ConfirmationMetricsUpdated:     event.New1[*whiteflag.ConfirmationMetrics](),
-		ReferencedBlocksCountUpdated:   event.New2[iotago.MilestoneIndex, int](),
-		MilestoneSolidificationFailed:  event.New1[iotago.MilestoneIndex](),
-		MilestoneTimeout:               event.New(),
-		LedgerUpdated:                  event.New3[iotago.MilestoneIndex, utxo.Outputs, utxo.Spents](),
-		TreasuryMutated:                event.New2[iotago.MilestoneIndex, *utxo.TreasuryMutationTuple](),
-		NewReceipt:                     event.New1[*iotago.ReceiptMilestoneOpt](),
+		ConfirmedMilestoneIndexChanged:     event.New1[iotago.MilestoneIndex](),
+		ConfirmationMetricsUpdated:         event.New1[*whiteflag.ConfirmationMetrics](),
+		ReferencedBlocksCountUpdated:       event.New2[iotago.MilestoneIndex, int](),
+		MilestoneSolidificationFailed:      event.New1[iotago.MilestoneIndex](),
+		MilestoneSolidificationDiagnostics: event.New1[*SolidificationDiagnostics](),
+		MilestoneTimeout:                   event.New(),
+		LedgerUpdated:                      event.New3[iotago.MilestoneIndex, utxo.Outputs, utxo.Spents](),
+		TreasuryMutated:                    event.New2[iotago.MilestoneIndex, *utxo.TreasuryMutationTuple](),
+		NewReceipt:                         event.New1[*iotago.ReceiptMilestoneOpt](),
 	}
 }
@@ -3,12 +3,28 @@ package tangle
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
 	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
 )
 
 const (
 	maxAllowedMilestoneAge = time.Minute * 5
+	minGossipStreams       = 1
+)
+
+var (
+	// ErrNodeNotAlmostSynced is returned if the node is not almost synced.
+	ErrNodeNotAlmostSynced = errors.New("node is not almost synced")
+	// ErrNodeNotSynced is returned if the node is not synced.
+	ErrNodeNotSynced = errors.New("node is not synced")
+	// ErrNotEnoughGossipStreams is returned if the node has not enough ongoing gossip streams.
+	ErrNotEnoughGossipStreams = errors.New("not enough ongoing gossip streams")
+	// ErrNextProtocolVersionUnsupported is returned if the next pending protocol version is not supported.
+	ErrNextProtocolVersionUnsupported = errors.New("next pending protocol version is not supported")
+	// ErrLatestMilestoneTooOld is returned if the latest milestone is too old.
+	ErrLatestMilestoneTooOld = errors.New("latest milestone is too old")
 )
 
 // IsNodeHealthy returns whether the node is synced, has active peers and its latest milestone is not too old.
@@ -21,32 +37,77 @@ func (t *Tangle) IsNodeHealthy(sync ...*syncmanager.SyncState) bool {
 		syncState = t.syncManager.SyncState()
 	}
 
-	if !syncState.NodeAlmostSynced {
+	if err := t.CheckNodeAlmostSynced(syncState); err != nil {
+		return false
+	}
+
+	if err := t.CheckGossipStreams(minGossipStreams); err != nil {
+		return false
+	}
+
+	if err := t.CheckProtocolSupported(); err != nil {
 		return false
 	}
 
+	return t.CheckLatestMilestoneAge(syncState, maxAllowedMilestoneAge) == nil
+}
+
+// CheckNodeAlmostSynced returns an error if the node is not almost synced.
+func (t *Tangle) CheckNodeAlmostSynced(syncState *syncmanager.SyncState) error {
+	if !syncState.NodeAlmostSynced {
+		return errors.WithMessagef(ErrNodeNotAlmostSynced, "confirmed milestone %d, latest milestone %d", syncState.ConfirmedMilestoneIndex, syncState.LatestMilestoneIndex)
+	}
+
+	return nil
+}
+
+// CheckNodeSynced returns an error if the node is not synced.
+func (t *Tangle) CheckNodeSynced(syncState *syncmanager.SyncState) error {
+	if !syncState.NodeSynced {
+		return errors.WithMessagef(ErrNodeNotSynced, "confirmed milestone %d, latest milestone %d", syncState.ConfirmedMilestoneIndex, syncState.LatestMilestoneIndex)
+	}
+
+	return nil
+}
+
+// CheckGossipStreams returns an error if the node has less than minStreams ongoing gossip streams.
+func (t *Tangle) CheckGossipStreams(minStreams int) error {
 	var gossipStreamsOngoing int
 	t.gossipService.ForEach(func(_ *gossip.Protocol) bool {
 		gossipStreamsOngoing++
 
-		// one stream is enough
-		return false
+		// stop counting if we have enough streams
+		return gossipStreamsOngoing < minStreams
 	})
 
-	if gossipStreamsOngoing == 0 {
-		return false
+	if gossipStreamsOngoing < minStreams {
+		return errors.WithMessagef(ErrNotEnoughGossipStreams, "%d/%d", gossipStreamsOngoing, minStreams)
 	}
 
+	return nil
+}
+
+// CheckProtocolSupported returns an error if the next pending protocol version is not supported by the node.
+func (t *Tangle) CheckProtocolSupported() error {
 	if !t.protocolManager.NextPendingSupported() {
-		return false
+		return ErrNextProtocolVersionUnsupported
 	}
 
+	return nil
+}
+
+// CheckLatestMilestoneAge returns an error if the latest milestone is older than maxAge.
+func (t *Tangle) CheckLatestMilestoneAge(syncState *syncmanager.SyncState, maxAge time.Duration) error {
 	// latest milestone timestamp
 	milestoneTimestamp, err := t.storage.MilestoneTimestampByIndex(syncState.LatestMilestoneIndex)
 	if err != nil {
-		return false
+		return errors.WithMessagef(ErrLatestMilestoneTooOld, "latest milestone %d not found", syncState.LatestMilestoneIndex)
+	}
+
+	// check whether the milestone is older than the allowed age
+	if milestoneAge := time.Since(milestoneTimestamp); milestoneAge >= maxAge {
+		return errors.WithMessagef(ErrLatestMilestoneTooOld, "age %v, allowed %v", milestoneAge.Truncate(time.Second), maxAge)
 	}
 
-	// check whether the milestone is older than 5 minutes
-	return time.Since(milestoneTimestamp) < maxAllowedMilestoneAge
+	return nil
 }
@@ -55,11 +55,28 @@ func (t *Tangle) SolidQueueCheck(
 	milestoneIndex iotago.MilestoneIndex,
 	parents iotago.BlockIDs) (solid bool, aborted bool) {
 
+	result, aborted := t.solidQueueCheck(ctx, memcachedTraverserStorage, milestoneIndex, parents)
+	if aborted {
+		return false, true
+	}
+
+	return result.solid, false
+}
+
+// solidQueueCheck works like SolidQueueCheck, but additionally returns
+// the missing blocks and the referenced solid entry points of the cone.
+func (t *Tangle) solidQueueCheck(
+	ctx context.Context,
+	memcachedTraverserStorage dag.TraverserStorage,
+	milestoneIndex iotago.MilestoneIndex,
+	parents iotago.BlockIDs) (result *solidQueueCheckResult, aborted bool) {
+
 	ts := time.Now()
 
 	blocksChecked := 0
 	var blockIDsToSolidify iotago.BlockIDs
 	blockIDsToRequest := make(map[iotago.BlockID]struct{})
+	solidEntryPoints := make(map[iotago.BlockID]struct{})
 
 	parentsTraverser := dag.NewParentsTraverser(memcachedTraverserStorage)
 
@@ -95,26 +112,40 @@ func (t *Tangle) SolidQueueCheck(
 			return nil
 		},
 		// called on solid entry points
-		// Ignore solid entry points (snapshot milestone included)
-		nil,
+		// collect them for the solidification diagnostics, but do not traverse them
+		func(blockID iotago.BlockID) error {
+			solidEntryPoints[blockID] = struct{}{}
+
+			return nil
+		},
 		false); err != nil {
 		if errors.Is(err, common.ErrOperationAborted) {
-			return false, true
+			return nil, true
 		}
 		t.LogPanic(err)
 	}
 
 	tCollect := time.Now()
 
+	result = &solidQueueCheckResult{
+		blocksChecked:    blocksChecked,
+		solidEntryPoints: make(iotago.BlockIDs, 0, len(solidEntryPoints)),
+	}
+	for blockID := range solidEntryPoints {
+		result.solidEntryPoints = append(result.solidEntryPoints, blockID)
+	}
+
 	if len(blockIDsToRequest) > 0 {
 		blockIDs := iotago.BlockIDs{}
 		for blockID := range blockIDsToRequest {
 			blockIDs = append(blockIDs, blockID)
 		}
+		result.missingBlockIDs = blockIDs
+
 		requested := t.requester.RequestMultiple(blockIDs, milestoneIndex, true)
 		t.LogWarnf("Stopped solidifier due to missing block -> Requested missing blocks (%d/%d), collect: %v", requested, len(blockIDs), tCollect.Sub(ts).Truncate(time.Millisecond))
 
-		return false, false
+		return result, false
 	}
 
 	// no blocks to request => the whole cone is solid
@@ -147,7 +178,9 @@ func (t *Tangle) SolidQueueCheck(
 
 	t.LogInfof("Solidifier finished: blocks: %d, collect: %v, solidity %v, propagation: %v, total: %v", blocksChecked, tCollect.Sub(ts).Truncate(time.Millisecond), tSolid.Sub(tCollect).Truncate(time.Millisecond), time.Since(tSolid).Truncate(time.Millisecond), time.Since(ts).Truncate(time.Millisecond))
 
-	return true, false
+	result.solid = true
+
+	return result, false
 }
 
 func (t *Tangle) newMilestoneSolidificationCtx() (context.Context, context.CancelFunc) {
@@ -269,17 +302,19 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex iotago.MilestoneIndex, forc
 	}()
 
 	t.LogInfof("Run solidity check for Milestone (%d) ...", milestoneIndexToSolidify)
-	if becameSolid, aborted := t.SolidQueueCheck(
+	solidityCheckStart := time.Now()
+	if result, aborted := t.solidQueueCheck(
 		milestoneSolidificationCtx,
 		memcachedTraverserStorage,
 		milestoneIndexToSolidify,
 		milestonePayloadToSolidify.Parents,
-	); !becameSolid {
+	); aborted || !result.solid {
 		if aborted {
 			// check was aborted due to older milestones/other solidifier running
 			t.LogInfof("Aborted solid queue check for milestone %d", milestoneIndexToSolidify)
 		} else {
 			// Milestone not solid yet and missing block were requested
+			t.updateSolidificationDiagnostics(milestoneIndexToSolidify, cachedMilestoneToSolidify.Milestone().MilestoneID(), solidityCheckStart, result)
 			t.Events.MilestoneSolidificationFailed.Trigger(milestoneIndexToSolidify)
 			t.LogInfof("Milestone couldn't be solidified! %d", milestoneIndexToSolidify)
 		}
@@ -432,6 +467,8 @@ func (t *Tangle) solidifyMilestone(newMilestoneIndex iotago.MilestoneIndex, forc
 		t.Events.NewReceipt.Trigger(newReceipt)
 	}
 
+	t.resetSolidificationDiagnostics(milestoneIndexToSolidify)
+
 	timeConfirmedMilestoneChangedStart = time.Now()
 	t.Events.ConfirmedMilestoneChanged.Trigger(cachedMilestoneToSolidify)
 	timeConfirmedMilestoneChangedEnd = time.Now()
@@ -0,0 +1,185 @@
+package tangle
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// MissingBlockRequestState is the state of the request of a missing block.
+type MissingBlockRequestState string
+
+const (
+	// MissingBlockRequestStateNotRequested means that there is no request for the missing block in the request queue.
+	MissingBlockRequestStateNotRequested MissingBlockRequestState = "notRequested"
+	// MissingBlockRequestStateQueued means that the request for the missing block is queued but was not sent yet.
+	MissingBlockRequestStateQueued MissingBlockRequestState = "queued"
+	// MissingBlockRequestStatePending means that the request for the missing block was sent, but no answer was received yet.
+	MissingBlockRequestStatePending MissingBlockRequestState = "pending"
+	// MissingBlockRequestStateProcessing means that the missing block was received and is currently processed.
+	MissingBlockRequestStateProcessing MissingBlockRequestState = "processing"
+)
+
+// MissingBlock contains information about a block that is missing in the cone of a milestone.
+type MissingBlock struct {
+	// The ID of the missing block.
+	BlockID iotago.BlockID
+	// The state of the request for the missing block.
+	RequestState MissingBlockRequestState
+	// The time the request was enqueued.
+	EnqueueTime time.Time
+	// The amount of times the request was taken from the queue to be sent.
+	RequestAttempts int
+	// The amount of times the request was sent to each peer.
+	RequestedFromPeers map[peer.ID]int
+	// The time the request was sent the last time.
+	LastRequestTime time.Time
+}
+
+// SolidificationDiagnostics contains information about the milestone that is currently being solidified.
+type SolidificationDiagnostics struct {
+	// The index of the milestone that is being solidified.
+	MilestoneIndex iotago.MilestoneIndex
+	// The ID of the milestone that is being solidified.
+	MilestoneID iotago.MilestoneID
+	// The time the first failed solidification attempt for the milestone was started.
+	WaitingSince time.Time
+	// The time of the last solidification attempt.
+	LastAttemptTime time.Time
+	// The amount of solidification attempts for the milestone.
+	Attempts int
+	// The amount of non-solid blocks that were found during the last attempt.
+	BlocksChecked int
+	// The blocks that were missing during the last attempt.
+	MissingBlocks []*MissingBlock
+	// The solid entry points that were referenced by the non-solid part of the cone of the milestone during the last attempt.
+	SolidEntryPoints iotago.BlockIDs
+}
+
+// WaitingDuration returns for how long the node has been waiting for the milestone to become solid.
+func (d *SolidificationDiagnostics) WaitingDuration() time.Duration {
+	return time.Since(d.WaitingSince)
+}
+
+// solidQueueCheckResult is the result of a solid queue check.
+type solidQueueCheckResult struct {
+	solid            bool
+	blocksChecked    int
+	missingBlockIDs  iotago.BlockIDs
+	solidEntryPoints iotago.BlockIDs
+}
+
+// SolidificationDiagnostics returns diagnostics about the next milestone to solidify
+// with the current state of the requests of the missing blocks.
+// Returns nil if no solidification attempt of the next milestone failed yet.
+func (t *Tangle) SolidificationDiagnostics() *SolidificationDiagnostics {
+	t.solidificationDiagnosticsLock.RLock()
+	defer t.solidificationDiagnosticsLock.RUnlock()
+
+	if t.solidificationDiagnostics == nil {
+		return nil
+	}
+
+	if t.solidificationDiagnostics.MilestoneIndex <= t.syncManager.ConfirmedMilestoneIndex() {
+		// the milestone was solidified in the meantime
+		return nil
+	}
+
+	return t.solidificationDiagnosticsWithoutLocking()
+}
+
+// solidificationDiagnosticsWithoutLocking returns a copy of the current diagnostics
+// with the current state of the requests of the missing blocks.
+func (t *Tangle) solidificationDiagnosticsWithoutLocking() *SolidificationDiagnostics {
+	diagnostics := *t.solidificationDiagnostics
+	diagnostics.SolidEntryPoints = append(iotago.BlockIDs{}, t.solidificationDiagnostics.SolidEntryPoints...)
+	diagnostics.MissingBlocks = make([]*MissingBlock, 0, len(t.solidificationDiagnostics.MissingBlocks))
+
+	// collect the state of all block requests at once
+	blockRequests := make(map[iotago.BlockID]*MissingBlock)
+	addRequests := func(requests []*gossip.Request, state MissingBlockRequestState) {
+		for _, request := range requests {
+			if request.RequestType != gossip.RequestTypeBlockID {
+				continue
+			}
+
+			blockRequests[request.BlockID] = &MissingBlock{
+				RequestState: state,
+				EnqueueTime:  request.EnqueueTime,
+			}
+		}
+	}
+
+	queued, pending, processing := t.requestQueue.Requests()
+	addRequests(queued, MissingBlockRequestStateQueued)
+	addRequests(pending, MissingBlockRequestStatePending)
+	addRequests(processing, MissingBlockRequestStateProcessing)
+
+	for _, missingBlock := range t.solidificationDiagnostics.MissingBlocks {
+		blockID := missingBlock.BlockID
+
+		missingBlockWithRequest := &MissingBlock{
+			BlockID:            blockID,
+			RequestState:       MissingBlockRequestStateNotRequested,
+			RequestedFromPeers: make(map[peer.ID]int),
+		}
+
+		if blockRequest, exists := blockRequests[blockID]; exists {
+			missingBlockWithRequest.RequestState = blockRequest.RequestState
+			missingBlockWithRequest.EnqueueTime = blockRequest.EnqueueTime
+		}
+
+		if stats := t.requester.RequestStats(blockID); stats != nil {
+			missingBlockWithRequest.RequestAttempts = stats.Attempts
+			missingBlockWithRequest.RequestedFromPeers = stats.Peers
+			missingBlockWithRequest.LastRequestTime = stats.LastRequestTime
+		}
+
+		diagnostics.MissingBlocks = append(diagnostics.MissingBlocks, missingBlockWithRequest)
+	}
+
+	return &diagnostics
+}
+
+// updateSolidificationDiagnostics stores the result of a failed solidification attempt
+// and triggers the MilestoneSolidificationDiagnostics event.
+func (t *Tangle) updateSolidificationDiagnostics(milestoneIndex iotago.MilestoneIndex, milestoneID iotago.MilestoneID, attemptTime time.Time, result *solidQueueCheckResult) {
+	t.solidificationDiagnosticsLock.Lock()
+
+	if t.solidificationDiagnostics == nil || t.solidificationDiagnostics.MilestoneIndex != milestoneIndex {
+		t.solidificationDiagnostics = &SolidificationDiagnostics{
+			MilestoneIndex: milestoneIndex,
+			MilestoneID:    milestoneID,
+			WaitingSince:   attemptTime,
+		}
+	}
+
+	missingBlocks := make([]*MissingBlock, 0, len(result.missingBlockIDs))
+	for _, blockID := range result.missingBlockIDs {
+		missingBlocks = append(missingBlocks, &MissingBlock{BlockID: blockID})
+	}
+
+	t.solidificationDiagnostics.LastAttemptTime = attemptTime
+	t.solidificationDiagnostics.Attempts++
+	t.solidificationDiagnostics.BlocksChecked = result.blocksChecked
+	t.solidificationDiagnostics.MissingBlocks = missingBlocks
+	t.solidificationDiagnostics.SolidEntryPoints = result.solidEntryPoints
+
+	diagnostics := t.solidificationDiagnosticsWithoutLocking()
+	t.solidificationDiagnosticsLock.Unlock()
+
+	t.Events.MilestoneSolidificationDiagnostics.Trigger(diagnostics)
+}
+
+// resetSolidificationDiagnostics removes the diagnostics of milestones that were solidified.
+func (t *Tangle) resetSolidificationDiagnostics(solidMilestoneIndex iotago.MilestoneIndex) {
+	t.solidificationDiagnosticsLock.Lock()
+	defer t.solidificationDiagnosticsLock.Unlock()
+
+	if t.solidificationDiagnostics != nil && t.solidificationDiagnostics.MilestoneIndex <= solidMilestoneIndex {
+		t.solidificationDiagnostics = nil
+	}
+}
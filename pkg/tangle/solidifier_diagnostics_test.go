@@ -0,0 +1,187 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package tangle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	appLogger "github.com/iotaledger/hive.go/app/logger"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/dag"
+	"github.com/iotaledger/hornet/v2/pkg/metrics"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/syncmanager"
+	"github.com/iotaledger/hornet/v2/pkg/protocol"
+	"github.com/iotaledger/hornet/v2/pkg/protocol/gossip"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// newTestTangle creates a tangle on an empty in-memory storage with the empty block as the only solid entry point.
+func newTestTangle(t *testing.T) (*Tangle, gossip.RequestQueue, *iotago.ProtocolParameters) {
+	cfg := configuration.New()
+	require.NoError(t, cfg.Set("logger.disableStacktrace", true))
+
+	// no need to check the error, since the global logger could already be initialized
+	_ = appLogger.InitGlobalLogger(cfg)
+
+	protoParams := &iotago.ProtocolParameters{
+		Version:       2,
+		NetworkName:   "testnet",
+		Bech32HRP:     iotago.PrefixTestnet,
+		BelowMaxDepth: 15,
+		TokenSupply:   1_000_000,
+	}
+
+	dbStorage, err := storage.New(mapdb.NewMapDB(), mapdb.NewMapDB())
+	require.NoError(t, err)
+	dbStorage.SolidEntryPointsAddWithoutLocking(iotago.EmptyBlockID(), 0)
+
+	protoParamsBytes, err := protoParams.Serialize(serializer.DeSeriModeNoValidation, nil)
+	require.NoError(t, err)
+	require.NoError(t, dbStorage.StoreProtocolParametersMilestoneOption(&iotago.ProtocolParamsMilestoneOpt{
+		ProtocolVersion: protoParams.Version,
+		Params:          protoParamsBytes,
+	}))
+
+	protocolManager, err := protocol.NewManager(dbStorage, 0)
+	require.NoError(t, err)
+
+	syncManager, err := syncmanager.New(0, protocolManager)
+	require.NoError(t, err)
+
+	requestQueue := gossip.NewRequestQueue()
+	requester := gossip.NewRequester(dbStorage, nil, requestQueue)
+
+	tangle := New(context.Background(), nil, logger.NewLogger("Tangle"), dbStorage, syncManager, nil, requestQueue, nil, nil, &metrics.ServerMetrics{}, requester, nil, protocolManager, time.Hour, time.Second, false)
+	t.Cleanup(tangle.StopMilestoneTimeoutTicker)
+
+	return tangle, requestQueue, protoParams
+}
+
+func storeTestBlock(t *testing.T, tangle *Tangle, protoParams *iotago.ProtocolParameters, parents iotago.BlockIDs) iotago.BlockID {
+	block, err := storage.NewBlock(&iotago.Block{
+		ProtocolVersion: protoParams.Version,
+		Parents:         parents,
+		Payload:         &iotago.TaggedData{Tag: tpkg.RandBytes(8)},
+	}, serializer.DeSeriModeNoValidation, protoParams)
+	require.NoError(t, err)
+
+	cachedBlock, _ := tangle.storage.StoreBlockIfAbsent(block) // block +1
+	defer cachedBlock.Release(true)                            // block -1
+
+	return block.BlockID()
+}
+
+// runTestSolidQueueCheck runs a solid queue check on the cone of the given parents.
+func runTestSolidQueueCheck(t *testing.T, tangle *Tangle, milestoneIndex iotago.MilestoneIndex, parents iotago.BlockIDs) *solidQueueCheckResult {
+	metadataMemcache := storage.NewMetadataMemcache(tangle.storage.CachedBlockMetadata)
+	memcachedTraverserStorage := dag.NewMemcachedTraverserStorage(tangle.storage, metadataMemcache)
+	defer func() {
+		memcachedTraverserStorage.Cleanup(true)
+		metadataMemcache.Cleanup(true)
+	}()
+
+	result, aborted := tangle.solidQueueCheck(context.Background(), memcachedTraverserStorage, milestoneIndex, parents)
+	require.False(t, aborted)
+
+	return result
+}
+
+func TestSolidificationDiagnostics(t *testing.T) {
+	tangle, requestQueue, protoParams := newTestTangle(t)
+
+	var triggered []*SolidificationDiagnostics
+	tangle.Events.MilestoneSolidificationDiagnostics.Hook(func(diagnostics *SolidificationDiagnostics) {
+		triggered = append(triggered, diagnostics)
+	})
+
+	// the cone of the milestone references a solid entry point and a missing block
+	missingBlockID := tpkg.RandBlockID()
+	blockA := storeTestBlock(t, tangle, protoParams, iotago.BlockIDs{iotago.EmptyBlockID()})
+	blockB := storeTestBlock(t, tangle, protoParams, iotago.BlockIDs{blockA, missingBlockID})
+
+	milestoneIndex := iotago.MilestoneIndex(1)
+	milestoneID := tpkg.RandMilestoneID()
+
+	require.Nil(t, tangle.SolidificationDiagnostics())
+
+	firstAttempt := time.Now()
+	result := runTestSolidQueueCheck(t, tangle, milestoneIndex, iotago.BlockIDs{blockB})
+	require.False(t, result.solid)
+	require.Equal(t, 2, result.blocksChecked)
+	require.Equal(t, iotago.BlockIDs{missingBlockID}, result.missingBlockIDs)
+	require.Equal(t, iotago.BlockIDs{iotago.EmptyBlockID()}, result.solidEntryPoints)
+
+	// the missing block was requested
+	require.True(t, requestQueue.IsQueued(missingBlockID))
+
+	tangle.updateSolidificationDiagnostics(milestoneIndex, milestoneID, firstAttempt, result)
+
+	diagnostics := tangle.SolidificationDiagnostics()
+	require.NotNil(t, diagnostics)
+	require.Equal(t, milestoneIndex, diagnostics.MilestoneIndex)
+	require.Equal(t, milestoneID, diagnostics.MilestoneID)
+	require.Equal(t, firstAttempt, diagnostics.WaitingSince)
+	require.Equal(t, firstAttempt, diagnostics.LastAttemptTime)
+	require.Equal(t, 1, diagnostics.Attempts)
+	require.Equal(t, 2, diagnostics.BlocksChecked)
+	require.Equal(t, iotago.BlockIDs{iotago.EmptyBlockID()}, diagnostics.SolidEntryPoints)
+	require.Len(t, diagnostics.MissingBlocks, 1)
+	require.Equal(t, missingBlockID, diagnostics.MissingBlocks[0].BlockID)
+	require.Equal(t, MissingBlockRequestStateQueued, diagnostics.MissingBlocks[0].RequestState)
+	require.False(t, diagnostics.MissingBlocks[0].EnqueueTime.IsZero())
+	require.Zero(t, diagnostics.MissingBlocks[0].RequestAttempts)
+	require.Empty(t, diagnostics.MissingBlocks[0].RequestedFromPeers)
+
+	require.Len(t, triggered, 1)
+	require.Equal(t, diagnostics, triggered[0])
+
+	// the state of the request is taken from the request queue at the time the diagnostics are queried
+	require.Equal(t, missingBlockID, requestQueue.Next().BlockID)
+	require.Equal(t, MissingBlockRequestStatePending, tangle.SolidificationDiagnostics().MissingBlocks[0].RequestState)
+
+	// another failed attempt for the same milestone keeps the time the node started waiting
+	secondAttempt := firstAttempt.Add(time.Second)
+	result = runTestSolidQueueCheck(t, tangle, milestoneIndex, iotago.BlockIDs{blockB})
+	require.False(t, result.solid)
+	tangle.updateSolidificationDiagnostics(milestoneIndex, milestoneID, secondAttempt, result)
+
+	diagnostics = tangle.SolidificationDiagnostics()
+	require.Equal(t, firstAttempt, diagnostics.WaitingSince)
+	require.Equal(t, secondAttempt, diagnostics.LastAttemptTime)
+	require.Equal(t, 2, diagnostics.Attempts)
+	require.Len(t, triggered, 2)
+
+	// the missing block is not requested anymore once it is not in the request queue
+	require.NotNil(t, requestQueue.Received(missingBlockID))
+	require.NotNil(t, requestQueue.Processed(missingBlockID))
+	require.Equal(t, MissingBlockRequestStateNotRequested, tangle.SolidificationDiagnostics().MissingBlocks[0].RequestState)
+
+	// solidifying an older milestone doesn't reset the diagnostics
+	tangle.resetSolidificationDiagnostics(milestoneIndex - 1)
+	require.NotNil(t, tangle.SolidificationDiagnostics())
+
+	// the diagnostics are reset once the milestone is solidified
+	tangle.resetSolidificationDiagnostics(milestoneIndex)
+	require.Nil(t, tangle.SolidificationDiagnostics())
+
+	// the diagnostics of a milestone that was confirmed in the meantime are not returned
+	tangle.updateSolidificationDiagnostics(milestoneIndex, milestoneID, secondAttempt, result)
+	require.NotNil(t, tangle.SolidificationDiagnostics())
+	require.NoError(t, tangle.syncManager.SetConfirmedMilestoneIndex(milestoneIndex))
+	require.Nil(t, tangle.SolidificationDiagnostics())
+
+	// a failed attempt for a new milestone starts new diagnostics
+	tangle.updateSolidificationDiagnostics(milestoneIndex+1, milestoneID, secondAttempt, result)
+	diagnostics = tangle.SolidificationDiagnostics()
+	require.Equal(t, milestoneIndex+1, diagnostics.MilestoneIndex)
+	require.Equal(t, secondAttempt, diagnostics.WaitingSince)
+	require.Equal(t, 1, diagnostics.Attempts)
+}
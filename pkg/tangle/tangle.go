@@ -95,6 +95,9 @@ type Tangle struct {
 
 	solidifierLock syncutils.RWMutex
 
+	solidificationDiagnostics     *SolidificationDiagnostics
+	solidificationDiagnosticsLock syncutils.RWMutex
+
 	oldNewBlocksCount        uint32
 	oldReferencedBlocksCount uint32
 
@@ -106,6 +109,9 @@ type Tangle struct {
 	lastConfirmedMilestoneMetricLock syncutils.RWMutex
 	lastConfirmedMilestoneMetric     *ConfirmedMilestoneMetric
 
+	// cache of the referenced blocks of milestones for the creation of inclusion proofs.
+	milestoneConeCache *milestoneConeCache
+
 	Events *Events
 }
 
@@ -155,6 +161,7 @@ func New(
 		blockProcessedNotifier:           valuenotifier.New[iotago.BlockID](),
 		blockSolidNotifier:               valuenotifier.New[iotago.BlockID](),
 		resyncPhaseDone:                  atomic.NewBool(false),
+		milestoneConeCache:               newMilestoneConeCache(),
 		Events:                           newEvents(),
 	}
 	t.futureConeSolidifier = NewFutureConeSolidifier(t.storage, t.markBlockAsSolid)
@@ -14,8 +14,10 @@ import (
 )
 
 var (
-	ErrParentsNotGiven = errors.New("no parents given")
-	ErrParentsNotSolid = errors.New("parents not solid")
+	ErrParentsNotGiven    = errors.New("no parents given")
+	ErrParentsNotSolid    = errors.New("parents not solid")
+	ErrBlockNotReferenced = errors.New("block not referenced yet")
+	ErrMilestoneNotFound  = errors.New("milestone not found")
 )
 
 // CheckSolidityAndComputeWhiteFlagMutations waits until all given parents are solid, an then calculates the white flag mutations
@@ -141,3 +143,28 @@ func (t *Tangle) CheckSolidityAndComputeWhiteFlagMutations(ctx context.Context,
 		whiteflag.DefaultWhiteFlagTraversalCondition,
 	)
 }
+
+// SimulateTransaction validates the given transaction against the ledger state of the confirmed milestone,
+// with the same rules that are used during white-flag confirmation, but without applying or gossiping it.
+func (t *Tangle) SimulateTransaction(transaction *iotago.Transaction, skipSignatureChecks bool) (*whiteflag.TransactionSimulation, error) {
+
+	// we need to read lock the ledger in order to ensure consistency,
+	// otherwise a new milestone could be applied during the simulation.
+	utxoManager := t.storage.UTXOManager()
+	utxoManager.ReadLockLedger()
+	defer utxoManager.ReadUnlockLedger()
+
+	ledgerIndex, err := utxoManager.ReadLedgerIndexWithoutLocking()
+	if err != nil {
+		return nil, err
+	}
+
+	cachedMilestone := t.storage.CachedMilestoneByIndexOrNil(ledgerIndex) // milestone +1
+	if cachedMilestone == nil {
+		return nil, errors.Wrapf(ErrMilestoneNotFound, "milestone %d", ledgerIndex)
+	}
+	msTimestamp := cachedMilestone.Milestone().TimestampUnix()
+	cachedMilestone.Release(true) // milestone -1
+
+	return whiteflag.SimulateTransaction(utxoManager, t.protocolManager.Current(), transaction, ledgerIndex, msTimestamp, skipSignatureChecks)
+}
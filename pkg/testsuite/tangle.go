@@ -88,6 +88,19 @@ func (te *TestEnvironment) AssertBlockConflictReason(blockID iotago.BlockID, con
 	require.Equal(te.TestInterface, conflict, cachedBlockMeta.Metadata().Conflict())
 }
 
+// AssertBlockConflictDetails checks that the conflict details of the block point to the given input.
+func (te *TestEnvironment) AssertBlockConflictDetails(blockID iotago.BlockID, inputIndex int, outputIDs iotago.OutputIDs) {
+	cachedBlockMeta := te.storage.CachedBlockMetadataOrNil(blockID)
+	require.NotNil(te.TestInterface, cachedBlockMeta)
+	defer cachedBlockMeta.Release(true) // meta -1
+
+	details := cachedBlockMeta.Metadata().ConflictDetails()
+	require.NotNil(te.TestInterface, details)
+	require.Equal(te.TestInterface, inputIndex, details.InputIndex)
+	require.Equal(te.TestInterface, outputIDs, details.OutputIDs)
+	require.NotEmpty(te.TestInterface, details.Reason)
+}
+
 // generateDotFileFromConfirmation generates a dot file from a whiteflag confirmation cone.
 func (te *TestEnvironment) generateDotFileFromConfirmation(conf *whiteflag.Confirmation) string {
 
@@ -1,8 +1,6 @@
 package toolset
 
 import (
-	"crypto/sha256"
-	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"os"
@@ -47,92 +45,27 @@ func calculateDatabaseLedgerHash(dbStorage *storage.Storage, outputJSON bool) er
 		fmt.Println("calculating ledger state hash ...")
 	}
 
-	ledgerIndex, err := dbStorage.UTXOManager().ReadLedgerIndex()
-	if err != nil {
-		return err
-	}
-
 	if err := checkSnapshotInfo(dbStorage); err != nil {
 		return err
 	}
 	snapshotInfo := dbStorage.SnapshotInfo()
 
-	// compute the sha256 of the ledger state
-	lsHash := sha256.New()
-
-	// write current ledger index
-	if err := binary.Write(lsHash, binary.LittleEndian, ledgerIndex); err != nil {
-		return fmt.Errorf("unable to serialize ledger index: %w", err)
-	}
-
-	var ledgerTokenSupply uint64
-
-	// read out treasury tx
-	treasuryOutput, err := dbStorage.UTXOManager().UnspentTreasuryOutputWithoutLocking()
-	if err != nil {
-		return fmt.Errorf("unable to get unspent treasury output: %w", err)
-	}
-
-	if treasuryOutput != nil {
-		// write current treasury output
-		if _, err := lsHash.Write(treasuryOutput.MilestoneID[:]); err != nil {
-			return fmt.Errorf("unable to hash treasury output milestone ID: %w", err)
-		}
-		if err := binary.Write(lsHash, binary.LittleEndian, treasuryOutput.Amount); err != nil {
-			return fmt.Errorf("unable to serialize treasury output amount: %w", err)
-		}
-		ledgerTokenSupply += treasuryOutput.Amount
-	}
-
-	// get all UTXOs
-	outputIDs, err := dbStorage.UTXOManager().UnspentOutputsIDs()
+	ledgerStateHash, err := dbStorage.ComputeLedgerStateHash(false)
 	if err != nil {
 		return err
 	}
-
-	// write all unspent outputs in lexicographical order
-	for _, outputID := range outputIDs.RemoveDupsAndSort() {
-		output, err := dbStorage.UTXOManager().ReadOutputByOutputID(outputID)
-		if err != nil {
-			return err
-		}
-
-		ledgerTokenSupply += output.Deposit()
-
-		outputBytes := output.SnapshotBytes()
-		if err = binary.Write(lsHash, binary.LittleEndian, outputBytes); err != nil {
-			return err
-		}
-	}
+	ledgerIndex := ledgerStateHash.LedgerIndex
+	treasuryOutput := ledgerStateHash.TreasuryOutput
 
 	protoParams, err := dbStorage.ProtocolParameters(ledgerIndex)
 	if err != nil {
 		return errors.Wrapf(ErrCritical, "loading protocol parameters failed: %s", err.Error())
 	}
 
-	if ledgerTokenSupply != protoParams.TokenSupply {
-		return errors.Wrapf(ErrCritical, "ledger token supply does not match the protocol parameters: %d vs %d", ledgerTokenSupply, protoParams.TokenSupply)
+	if ledgerStateHash.LedgerTokenSupply != protoParams.TokenSupply {
+		return errors.Wrapf(ErrCritical, "ledger token supply does not match the protocol parameters: %d vs %d", ledgerStateHash.LedgerTokenSupply, protoParams.TokenSupply)
 	}
 
-	// calculate sha256 hash of the current ledger state
-	snapshotHashSumWithoutSEPs := lsHash.Sum(nil)
-
-	var solidEntryPoints iotago.BlockIDs
-	dbStorage.ForEachSolidEntryPointWithoutLocking(func(sep *storage.SolidEntryPoint) bool {
-		solidEntryPoints = append(solidEntryPoints, sep.BlockID)
-
-		return true
-	})
-
-	// write all solid entry points in lexicographical order
-	for _, solidEntryPoint := range solidEntryPoints.RemoveDupsAndSort() {
-		if err := binary.Write(lsHash, binary.LittleEndian, solidEntryPoint[:]); err != nil {
-			return fmt.Errorf("unable to calculate snapshot hash: %w", err)
-		}
-	}
-
-	snapshotHashSumWithSEPs := lsHash.Sum(nil)
-
 	protocolParametersHashSum, err := dbStorage.ActiveProtocolParameterMilestoneOptionsHash(ledgerIndex)
 	if err != nil {
 		return fmt.Errorf("unable to calculate protocol parameters hash: %w", err)
@@ -177,11 +110,11 @@ func calculateDatabaseLedgerHash(dbStorage *storage.Storage, outputJSON bool) er
 			LedgerIndex:                         ledgerIndex,
 			SnapshotIndex:                       snapshotInfo.SnapshotIndex(),
 			PruningIndex:                        snapshotInfo.PruningIndex(),
-			UTXOsCount:                          len(outputIDs),
-			SolidEntryPointsCount:               len(solidEntryPoints),
-			LedgerTokenSupply:                   ledgerTokenSupply,
-			LedgerStateHash:                     hex.EncodeToString(snapshotHashSumWithoutSEPs),
-			LedgerStateHashWithSolidEntryPoints: hex.EncodeToString(snapshotHashSumWithSEPs),
+			UTXOsCount:                          ledgerStateHash.UTXOsCount,
+			SolidEntryPointsCount:               ledgerStateHash.SolidEntryPointsCount,
+			LedgerTokenSupply:                   ledgerStateHash.LedgerTokenSupply,
+			LedgerStateHash:                     hex.EncodeToString(ledgerStateHash.Hash),
+			LedgerStateHashWithSolidEntryPoints: hex.EncodeToString(ledgerStateHash.HashWithSolidEntryPoints),
 			ProtocolParametersHash:              hex.EncodeToString(protocolParametersHashSum),
 		}
 
@@ -217,11 +150,11 @@ func calculateDatabaseLedgerHash(dbStorage *storage.Storage, outputJSON bool) er
 		ledgerIndex,
 		snapshotInfo.SnapshotIndex(),
 		snapshotInfo.PruningIndex(),
-		len(outputIDs),
-		len(solidEntryPoints),
-		ledgerTokenSupply,
-		hex.EncodeToString(snapshotHashSumWithoutSEPs),
-		hex.EncodeToString(snapshotHashSumWithSEPs),
+		ledgerStateHash.UTXOsCount,
+		ledgerStateHash.SolidEntryPointsCount,
+		ledgerStateHash.LedgerTokenSupply,
+		hex.EncodeToString(ledgerStateHash.Hash),
+		hex.EncodeToString(ledgerStateHash.HashWithSolidEntryPoints),
 		hex.EncodeToString(protocolParametersHashSum),
 	)
 
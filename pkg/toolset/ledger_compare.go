@@ -0,0 +1,406 @@
+package toolset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/hornet/v2/components/coreapi"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+const (
+	// the route to get the ledger state hash at a milestone index.
+	routeControlLedgerStateHash = "/api/core/v2/control/ledger/state-hash/%d"
+	// the route to get the hash over the milestone diffs of a range of milestones.
+	routeControlLedgerMilestoneDiffsHash = "/api/core/v2/control/ledger/milestone-diffs-hash/%d?endIndex=%d"
+)
+
+// ledgerCompareAPI queries the ledger state of a node.
+type ledgerCompareAPI interface {
+	// info returns the info of the node.
+	info(ctx context.Context) (*nodeclient.InfoResponse, error)
+	// ledgerStateHash returns the hash of the ledger state at the given milestone index.
+	ledgerStateHash(ctx context.Context, msIndex iotago.MilestoneIndex) (string, error)
+	// milestoneDiffsHash returns the hash over the milestone diffs of the given range of milestones.
+	milestoneDiffsHash(ctx context.Context, startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) (string, error)
+	// milestoneUTXOChanges returns the outputs that were created and consumed at the given milestone index.
+	milestoneUTXOChanges(ctx context.Context, msIndex iotago.MilestoneIndex) (*nodeclient.MilestoneUTXOChangesResponse, error)
+}
+
+// ledgerCompareNodeClient queries the ledger state of a node via its REST API.
+type ledgerCompareNodeClient struct {
+	url       string
+	authToken string
+	client    *nodeclient.Client
+}
+
+func newLedgerCompareNodeClient(url string, authToken string) *ledgerCompareNodeClient {
+	return &ledgerCompareNodeClient{
+		url:       url,
+		authToken: authToken,
+		client:    nodeclient.New(url),
+	}
+}
+
+func (c *ledgerCompareNodeClient) get(ctx context.Context, route string, resObj interface{}) error {
+	if _, err := c.client.DoWithRequestHeaderHook(ctx, http.MethodGet, route, func(header http.Header) {
+		header.Set("Accept", nodeclient.MIMEApplicationJSON)
+		if c.authToken != "" {
+			header.Set("Authorization", "Bearer "+c.authToken)
+		}
+	}, nil, resObj); err != nil {
+		return fmt.Errorf("request to %s failed: %w", c.url, err)
+	}
+
+	return nil
+}
+
+func (c *ledgerCompareNodeClient) info(ctx context.Context) (*nodeclient.InfoResponse, error) {
+	info, err := c.client.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", c.url, err)
+	}
+
+	return info, nil
+}
+
+func (c *ledgerCompareNodeClient) ledgerStateHash(ctx context.Context, msIndex iotago.MilestoneIndex) (string, error) {
+	res := &coreapi.LedgerStateHashResponse{}
+	if err := c.get(ctx, fmt.Sprintf(routeControlLedgerStateHash, msIndex), res); err != nil {
+		return "", err
+	}
+
+	return res.LedgerStateHash, nil
+}
+
+func (c *ledgerCompareNodeClient) milestoneDiffsHash(ctx context.Context, startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) (string, error) {
+	res := &coreapi.MilestoneDiffsHashResponse{}
+	if err := c.get(ctx, fmt.Sprintf(routeControlLedgerMilestoneDiffsHash, startIndex, endIndex), res); err != nil {
+		return "", err
+	}
+
+	return res.MilestoneDiffsHash, nil
+}
+
+func (c *ledgerCompareNodeClient) milestoneUTXOChanges(ctx context.Context, msIndex iotago.MilestoneIndex) (*nodeclient.MilestoneUTXOChangesResponse, error) {
+	changes, err := c.client.MilestoneUTXOChangesByIndex(ctx, msIndex)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", c.url, err)
+	}
+
+	return changes, nil
+}
+
+// ledgerCompareNode is a node whose ledger state is compared.
+type ledgerCompareNode struct {
+	url          string
+	api          ledgerCompareAPI
+	ledgerIndex  iotago.MilestoneIndex
+	pruningIndex iotago.MilestoneIndex
+}
+
+// ledgerCompareNodeResult is the ledger state of a node at the compared milestone index.
+type ledgerCompareNodeResult struct {
+	URL             string                `json:"url"`
+	LedgerIndex     iotago.MilestoneIndex `json:"ledgerIndex"`
+	PruningIndex    iotago.MilestoneIndex `json:"pruningIndex"`
+	LedgerStateHash string                `json:"ledgerStateHash"`
+}
+
+// ledgerDivergence describes where the ledger state of a node diverges from the reference node.
+type ledgerDivergence struct {
+	ReferenceURL string `json:"referenceUrl"`
+	URL          string `json:"url"`
+	// The first milestone whose milestone diff differs.
+	FirstDivergingMilestoneIndex iotago.MilestoneIndex `json:"firstDivergingMilestoneIndex,omitempty"`
+	// The ledger states already diverged before the oldest milestone diff both nodes still have.
+	DivergedBeforePruningIndex bool `json:"divergedBeforePruningIndex"`
+	// The outputs that were created at the first diverging milestone only on the reference node.
+	CreatedOnlyOnReference []string `json:"createdOnlyOnReference,omitempty"`
+	// The outputs that were created at the first diverging milestone only on the node.
+	CreatedOnlyOnNode []string `json:"createdOnlyOnNode,omitempty"`
+	// The outputs that were consumed at the first diverging milestone only on the reference node.
+	ConsumedOnlyOnReference []string `json:"consumedOnlyOnReference,omitempty"`
+	// The outputs that were consumed at the first diverging milestone only on the node.
+	ConsumedOnlyOnNode []string `json:"consumedOnlyOnNode,omitempty"`
+}
+
+type ledgerCompareResult struct {
+	MilestoneIndex iotago.MilestoneIndex      `json:"milestoneIndex"`
+	Consistent     bool                       `json:"consistent"`
+	Nodes          []*ledgerCompareNodeResult `json:"nodes"`
+	Divergences    []*ledgerDivergence        `json:"divergences"`
+}
+
+// outputIDsDifference returns the output IDs that are contained in a but not in b.
+func outputIDsDifference(a []string, b []string) []string {
+	contained := make(map[string]struct{}, len(b))
+	for _, outputID := range b {
+		contained[outputID] = struct{}{}
+	}
+
+	var difference []string
+	for _, outputID := range a {
+		if _, has := contained[outputID]; !has {
+			difference = append(difference, outputID)
+		}
+	}
+
+	return difference
+}
+
+// bisectLedgerDivergence searches the first milestone whose milestone diff differs between the reference node and the node.
+func bisectLedgerDivergence(ctx context.Context, reference *ledgerCompareNode, node *ledgerCompareNode, msIndex iotago.MilestoneIndex) (*ledgerDivergence, error) {
+	divergence := &ledgerDivergence{
+		ReferenceURL: reference.url,
+		URL:          node.url,
+	}
+
+	// the milestone diffs are only available after the pruning index
+	startIndex := reference.pruningIndex
+	if node.pruningIndex > startIndex {
+		startIndex = node.pruningIndex
+	}
+	startIndex++
+
+	rangeDiffers := func(start iotago.MilestoneIndex, end iotago.MilestoneIndex) (bool, error) {
+		referenceHash, err := reference.api.milestoneDiffsHash(ctx, start, end)
+		if err != nil {
+			return false, err
+		}
+
+		nodeHash, err := node.api.milestoneDiffsHash(ctx, start, end)
+		if err != nil {
+			return false, err
+		}
+
+		return referenceHash != nodeHash, nil
+	}
+
+	if startIndex > msIndex {
+		divergence.DivergedBeforePruningIndex = true
+
+		return divergence, nil
+	}
+
+	// search the first range of milestone diffs that differs, the size of the ranges is limited by the nodes
+	var low, high iotago.MilestoneIndex
+	differs := false
+	for rangeStart := startIndex; rangeStart <= msIndex && !differs; rangeStart += coreapi.MaxMilestoneDiffsHashRange {
+		rangeEnd := msIndex
+		if msIndex-rangeStart >= coreapi.MaxMilestoneDiffsHashRange {
+			rangeEnd = rangeStart + coreapi.MaxMilestoneDiffsHashRange - 1
+		}
+
+		var err error
+		differs, err = rangeDiffers(rangeStart, rangeEnd)
+		if err != nil {
+			return nil, err
+		}
+		low, high = rangeStart, rangeEnd
+	}
+
+	if !differs {
+		// all available milestone diffs are equal, so the ledger states diverged before
+		divergence.DivergedBeforePruningIndex = true
+
+		return divergence, nil
+	}
+
+	// bisect the range until the first diverging milestone is found
+	for low < high {
+		mid := low + (high-low)/2
+
+		differs, err := rangeDiffers(low, mid)
+		if err != nil {
+			return nil, err
+		}
+
+		if differs {
+			high = mid
+		} else {
+			low = mid + 1
+		}
+	}
+	divergence.FirstDivergingMilestoneIndex = low
+
+	referenceChanges, err := reference.api.milestoneUTXOChanges(ctx, low)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeChanges, err := node.api.milestoneUTXOChanges(ctx, low)
+	if err != nil {
+		return nil, err
+	}
+
+	divergence.CreatedOnlyOnReference = outputIDsDifference(referenceChanges.CreatedOutputs, nodeChanges.CreatedOutputs)
+	divergence.CreatedOnlyOnNode = outputIDsDifference(nodeChanges.CreatedOutputs, referenceChanges.CreatedOutputs)
+	divergence.ConsumedOnlyOnReference = outputIDsDifference(referenceChanges.ConsumedOutputs, nodeChanges.ConsumedOutputs)
+	divergence.ConsumedOnlyOnNode = outputIDsDifference(nodeChanges.ConsumedOutputs, referenceChanges.ConsumedOutputs)
+
+	return divergence, nil
+}
+
+func compareLedgers(ctx context.Context, nodes []*ledgerCompareNode, msIndex iotago.MilestoneIndex) (*ledgerCompareResult, error) {
+
+	for _, node := range nodes {
+		info, err := node.api.info(ctx)
+		if err != nil {
+			return nil, err
+		}
+		node.ledgerIndex = info.Status.ConfirmedMilestone.Index
+		node.pruningIndex = info.Status.PruningIndex
+	}
+
+	if msIndex == 0 {
+		// use the newest milestone index all nodes have confirmed
+		msIndex = nodes[0].ledgerIndex
+		for _, node := range nodes[1:] {
+			if node.ledgerIndex < msIndex {
+				msIndex = node.ledgerIndex
+			}
+		}
+	}
+
+	result := &ledgerCompareResult{
+		MilestoneIndex: msIndex,
+		Nodes:          make([]*ledgerCompareNodeResult, 0, len(nodes)),
+		Divergences:    make([]*ledgerDivergence, 0),
+	}
+
+	// group the nodes by their ledger state hash
+	nodesByHash := make(map[string][]*ledgerCompareNode)
+	var hashes []string
+	for _, node := range nodes {
+		hash, err := node.api.ledgerStateHash(ctx, msIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, has := nodesByHash[hash]; !has {
+			hashes = append(hashes, hash)
+		}
+		nodesByHash[hash] = append(nodesByHash[hash], node)
+
+		result.Nodes = append(result.Nodes, &ledgerCompareNodeResult{
+			URL:             node.url,
+			LedgerIndex:     node.ledgerIndex,
+			PruningIndex:    node.pruningIndex,
+			LedgerStateHash: hash,
+		})
+	}
+
+	result.Consistent = len(hashes) == 1
+	if result.Consistent {
+		return result, nil
+	}
+
+	// the nodes of the biggest group are used as the reference
+	sort.SliceStable(hashes, func(i, j int) bool {
+		return len(nodesByHash[hashes[i]]) > len(nodesByHash[hashes[j]])
+	})
+	reference := nodesByHash[hashes[0]][0]
+
+	for _, hash := range hashes[1:] {
+		for _, node := range nodesByHash[hash] {
+			divergence, err := bisectLedgerDivergence(ctx, reference, node, msIndex)
+			if err != nil {
+				return nil, err
+			}
+			result.Divergences = append(result.Divergences, divergence)
+		}
+	}
+
+	return result, nil
+}
+
+func ledgerCompare(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	nodeURLsFlag := fs.StringSlice(FlagToolNodeURLs, nil, "URLs of the nodes to compare (at least two)")
+	authTokenFlag := fs.String(FlagToolAuthToken, "", "JWT token to access the protected routes of the nodes (optional)")
+	milestoneIndexFlag := fs.Uint32(FlagToolMilestoneIndex, 0, "the milestone index to compare the ledger states at (optional, default: newest milestone confirmed by all nodes)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolLedgerCompare)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s",
+			ToolLedgerCompare,
+			FlagToolNodeURLs,
+			"http://192.168.1.221:14265,http://192.168.1.222:14265",
+			FlagToolAuthToken,
+			"[JWT_TOKEN]",
+		))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*nodeURLsFlag) < 2 {
+		return fmt.Errorf("'%s' needs to contain at least two nodes", FlagToolNodeURLs)
+	}
+
+	nodes := make([]*ledgerCompareNode, 0, len(*nodeURLsFlag))
+	for _, nodeURL := range *nodeURLsFlag {
+		nodeURL = strings.TrimSuffix(nodeURL, "/")
+		nodes = append(nodes, &ledgerCompareNode{
+			url: nodeURL,
+			api: newLedgerCompareNodeClient(nodeURL, *authTokenFlag),
+		})
+	}
+
+	result, err := compareLedgers(getGracefulStopContext(), nodes, *milestoneIndexFlag)
+	if err != nil {
+		return err
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	fmt.Printf("comparing ledger states at milestone index %d:\n", result.MilestoneIndex)
+	for _, node := range result.Nodes {
+		fmt.Printf("    > %s (ledger index: %d, pruning index: %d): %s\n", node.URL, node.LedgerIndex, node.PruningIndex, node.LedgerStateHash)
+	}
+
+	if result.Consistent {
+		fmt.Println("\nthe ledger states of all nodes are equal")
+
+		return nil
+	}
+
+	for _, divergence := range result.Divergences {
+		fmt.Printf("\n%s diverges from %s\n", divergence.URL, divergence.ReferenceURL)
+
+		if divergence.DivergedBeforePruningIndex {
+			fmt.Println("    > the ledger states already diverged before the oldest milestone diff that is available on both nodes")
+
+			continue
+		}
+
+		fmt.Printf("    > first diverging milestone: %d\n", divergence.FirstDivergingMilestoneIndex)
+		printOutputIDs := func(title string, outputIDs []string) {
+			fmt.Printf("    > %s: %d\n", title, len(outputIDs))
+			for _, outputID := range outputIDs {
+				fmt.Printf("        - %s\n", outputID)
+			}
+		}
+		printOutputIDs("outputs created only on the reference node", divergence.CreatedOnlyOnReference)
+		printOutputIDs("outputs created only on the node", divergence.CreatedOnlyOnNode)
+		printOutputIDs("outputs consumed only on the reference node", divergence.ConsumedOnlyOnReference)
+		printOutputIDs("outputs consumed only on the node", divergence.ConsumedOnlyOnNode)
+	}
+
+	return nil
+}
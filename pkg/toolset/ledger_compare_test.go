@@ -0,0 +1,197 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package toolset
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/components/coreapi"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/nodeclient"
+)
+
+// fakeLedgerCompareNode is a node whose milestone diffs are equal to the ones of every other fake node,
+// except for the milestones it created or consumed additional outputs at.
+type fakeLedgerCompareNode struct {
+	ledgerIndex  iotago.MilestoneIndex
+	pruningIndex iotago.MilestoneIndex
+	// the additional outputs that were created at a milestone index.
+	extraCreated map[iotago.MilestoneIndex][]string
+	// the additional outputs that were consumed at a milestone index.
+	extraConsumed map[iotago.MilestoneIndex][]string
+}
+
+func newFakeLedgerCompareNode(ledgerIndex iotago.MilestoneIndex, pruningIndex iotago.MilestoneIndex) *fakeLedgerCompareNode {
+	return &fakeLedgerCompareNode{
+		ledgerIndex:   ledgerIndex,
+		pruningIndex:  pruningIndex,
+		extraCreated:  make(map[iotago.MilestoneIndex][]string),
+		extraConsumed: make(map[iotago.MilestoneIndex][]string),
+	}
+}
+
+func (n *fakeLedgerCompareNode) info(_ context.Context) (*nodeclient.InfoResponse, error) {
+	return &nodeclient.InfoResponse{
+		Status: nodeclient.InfoResStatus{
+			ConfirmedMilestone: nodeclient.InfoResMilestone{Index: n.ledgerIndex},
+			PruningIndex:       n.pruningIndex,
+		},
+	}, nil
+}
+
+func (n *fakeLedgerCompareNode) changes(msIndex iotago.MilestoneIndex) *nodeclient.MilestoneUTXOChangesResponse {
+	return &nodeclient.MilestoneUTXOChangesResponse{
+		Index:           msIndex,
+		CreatedOutputs:  append([]string{fmt.Sprintf("created-%d", msIndex)}, n.extraCreated[msIndex]...),
+		ConsumedOutputs: append([]string{fmt.Sprintf("consumed-%d", msIndex)}, n.extraConsumed[msIndex]...),
+	}
+}
+
+func (n *fakeLedgerCompareNode) diffsHash(startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) string {
+	var builder strings.Builder
+	for msIndex := startIndex; msIndex <= endIndex; msIndex++ {
+		changes := n.changes(msIndex)
+		builder.WriteString(fmt.Sprintf("%d:%v:%v;", msIndex, changes.CreatedOutputs, changes.ConsumedOutputs))
+	}
+
+	return builder.String()
+}
+
+func (n *fakeLedgerCompareNode) ledgerStateHash(_ context.Context, msIndex iotago.MilestoneIndex) (string, error) {
+	if msIndex > n.ledgerIndex {
+		return "", fmt.Errorf("milestone %d not confirmed yet", msIndex)
+	}
+
+	return n.diffsHash(1, msIndex), nil
+}
+
+func (n *fakeLedgerCompareNode) milestoneDiffsHash(_ context.Context, startIndex iotago.MilestoneIndex, endIndex iotago.MilestoneIndex) (string, error) {
+	if startIndex <= n.pruningIndex {
+		return "", fmt.Errorf("milestone diff %d was pruned", startIndex)
+	}
+	if endIndex > n.ledgerIndex {
+		return "", fmt.Errorf("milestone %d not confirmed yet", endIndex)
+	}
+	if endIndex < startIndex || endIndex-startIndex >= coreapi.MaxMilestoneDiffsHashRange {
+		return "", fmt.Errorf("invalid range %d-%d", startIndex, endIndex)
+	}
+
+	return n.diffsHash(startIndex, endIndex), nil
+}
+
+func (n *fakeLedgerCompareNode) milestoneUTXOChanges(_ context.Context, msIndex iotago.MilestoneIndex) (*nodeclient.MilestoneUTXOChangesResponse, error) {
+	if msIndex <= n.pruningIndex {
+		return nil, fmt.Errorf("milestone diff %d was pruned", msIndex)
+	}
+
+	return n.changes(msIndex), nil
+}
+
+func newTestLedgerCompareNode(url string, api *fakeLedgerCompareNode) *ledgerCompareNode {
+	return &ledgerCompareNode{
+		url:          url,
+		api:          api,
+		ledgerIndex:  api.ledgerIndex,
+		pruningIndex: api.pruningIndex,
+	}
+}
+
+func TestOutputIDsDifference(t *testing.T) {
+	require.Equal(t, []string{"a", "c"}, outputIDsDifference([]string{"a", "b", "c"}, []string{"b", "d"}))
+	require.Nil(t, outputIDsDifference([]string{"a"}, []string{"a", "b"}))
+	require.Nil(t, outputIDsDifference(nil, []string{"a"}))
+}
+
+func TestBisectLedgerDivergence(t *testing.T) {
+	ctx := context.Background()
+	msIndex := iotago.MilestoneIndex(2500)
+
+	for _, divergingIndex := range []iotago.MilestoneIndex{11, 500, 1010, 2009, 2010, 2500} {
+		t.Run(fmt.Sprintf("diverging at %d", divergingIndex), func(t *testing.T) {
+			referenceAPI := newFakeLedgerCompareNode(msIndex, 5)
+			nodeAPI := newFakeLedgerCompareNode(msIndex, 10)
+			nodeAPI.extraCreated[divergingIndex] = []string{"created-only-on-node"}
+			nodeAPI.extraConsumed[divergingIndex] = []string{"consumed-only-on-node"}
+			referenceAPI.extraConsumed[divergingIndex] = []string{"consumed-only-on-reference"}
+			// later differences don't hide the first diverging milestone
+			nodeAPI.extraCreated[divergingIndex+1] = []string{"created-later"}
+
+			divergence, err := bisectLedgerDivergence(ctx, newTestLedgerCompareNode("reference", referenceAPI), newTestLedgerCompareNode("node", nodeAPI), msIndex)
+			require.NoError(t, err)
+			require.Equal(t, "reference", divergence.ReferenceURL)
+			require.Equal(t, "node", divergence.URL)
+			require.False(t, divergence.DivergedBeforePruningIndex)
+			require.Equal(t, divergingIndex, divergence.FirstDivergingMilestoneIndex)
+			require.Empty(t, divergence.CreatedOnlyOnReference)
+			require.Equal(t, []string{"created-only-on-node"}, divergence.CreatedOnlyOnNode)
+			require.Equal(t, []string{"consumed-only-on-reference"}, divergence.ConsumedOnlyOnReference)
+			require.Equal(t, []string{"consumed-only-on-node"}, divergence.ConsumedOnlyOnNode)
+		})
+	}
+
+	t.Run("diverged before the pruning index", func(t *testing.T) {
+		// the ledger states differ, but all milestone diffs both nodes still have are equal
+		referenceAPI := newFakeLedgerCompareNode(msIndex, 5)
+		nodeAPI := newFakeLedgerCompareNode(msIndex, 1200)
+		nodeAPI.extraCreated[1000] = []string{"created-only-on-node"}
+
+		divergence, err := bisectLedgerDivergence(ctx, newTestLedgerCompareNode("reference", referenceAPI), newTestLedgerCompareNode("node", nodeAPI), msIndex)
+		require.NoError(t, err)
+		require.True(t, divergence.DivergedBeforePruningIndex)
+		require.Zero(t, divergence.FirstDivergingMilestoneIndex)
+		require.Empty(t, divergence.CreatedOnlyOnNode)
+	})
+
+	t.Run("no milestone diffs after the pruning index", func(t *testing.T) {
+		referenceAPI := newFakeLedgerCompareNode(msIndex, 5)
+		nodeAPI := newFakeLedgerCompareNode(msIndex, msIndex)
+
+		divergence, err := bisectLedgerDivergence(ctx, newTestLedgerCompareNode("reference", referenceAPI), newTestLedgerCompareNode("node", nodeAPI), msIndex)
+		require.NoError(t, err)
+		require.True(t, divergence.DivergedBeforePruningIndex)
+		require.Zero(t, divergence.FirstDivergingMilestoneIndex)
+	})
+}
+
+func TestCompareLedgers(t *testing.T) {
+	ctx := context.Background()
+
+	referenceAPI1 := newFakeLedgerCompareNode(120, 0)
+	referenceAPI2 := newFakeLedgerCompareNode(100, 0)
+	nodeAPI := newFakeLedgerCompareNode(110, 0)
+	nodeAPI.extraCreated[42] = []string{"created-only-on-node"}
+
+	nodes := []*ledgerCompareNode{
+		{url: "node", api: nodeAPI},
+		{url: "reference1", api: referenceAPI1},
+		{url: "reference2", api: referenceAPI2},
+	}
+
+	// the ledger states are compared at the newest milestone confirmed by all nodes
+	result, err := compareLedgers(ctx, nodes, 0)
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(100), result.MilestoneIndex)
+	require.False(t, result.Consistent)
+	require.Len(t, result.Nodes, 3)
+	require.Equal(t, "node", result.Nodes[0].URL)
+	require.Equal(t, iotago.MilestoneIndex(110), result.Nodes[0].LedgerIndex)
+	require.Equal(t, result.Nodes[1].LedgerStateHash, result.Nodes[2].LedgerStateHash)
+	require.NotEqual(t, result.Nodes[0].LedgerStateHash, result.Nodes[1].LedgerStateHash)
+
+	// the nodes of the biggest group are used as the reference
+	require.Len(t, result.Divergences, 1)
+	require.Equal(t, "reference1", result.Divergences[0].ReferenceURL)
+	require.Equal(t, "node", result.Divergences[0].URL)
+	require.Equal(t, iotago.MilestoneIndex(42), result.Divergences[0].FirstDivergingMilestoneIndex)
+
+	// the ledger states are equal before the first diverging milestone
+	result, err = compareLedgers(ctx, nodes, 41)
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(41), result.MilestoneIndex)
+	require.True(t, result.Consistent)
+	require.Empty(t, result.Divergences)
+}
@@ -0,0 +1,436 @@
+package toolset
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// snapshotDiffLedger is the ledger state of a compared snapshot.
+type snapshotDiffLedger struct {
+	FilePath             string                `json:"filePath"`
+	DeltaFilePath        string                `json:"deltaFilePath,omitempty"`
+	LedgerMilestoneIndex iotago.MilestoneIndex `json:"ledgerMilestoneIndex"`
+	OutputCount          uint64                `json:"outputCount"`
+	OutputsAmount        uint64                `json:"outputsAmount,string"`
+	TreasuryAmount       uint64                `json:"treasuryAmount,string"`
+}
+
+// snapshotOutputChange is an output that exists in both snapshots but differs.
+type snapshotOutputChange struct {
+	OutputID string          `json:"outputId"`
+	Source   *snapshotOutput `json:"source"`
+	Target   *snapshotOutput `json:"target"`
+}
+
+type snapshotDiffResult struct {
+	Source *snapshotDiffLedger `json:"source"`
+	Target *snapshotDiffLedger `json:"target"`
+	// The difference of the amount on the outputs (target - source).
+	OutputsAmountDifference int64 `json:"outputsAmountDifference,string"`
+	// The difference of the amount in the treasury (target - source).
+	TreasuryAmountDifference int64 `json:"treasuryAmountDifference,string"`
+	// The difference of the total supply (target - source).
+	SupplyDifference int64                   `json:"supplyDifference,string"`
+	Added            []*snapshotOutput       `json:"added"`
+	Removed          []*snapshotOutput       `json:"removed"`
+	Changed          []*snapshotOutputChange `json:"changed"`
+}
+
+func snapshotDiff(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	sourceSnapshotPathFlag := fs.String(FlagToolSnapshotPathSource, "", "the path to the source full snapshot file")
+	sourceDeltaSnapshotPathFlag := fs.String(FlagToolSnapshotPathSourceDelta, "", "the path to the delta snapshot file of the source (optional, its milestone diffs are applied on top of the source full snapshot)")
+	targetSnapshotPathFlag := fs.String(FlagToolSnapshotPathTarget, "", "the path to the target full snapshot file")
+	targetDeltaSnapshotPathFlag := fs.String(FlagToolSnapshotPathTargetDelta, "", "the path to the delta snapshot file of the target (optional, its milestone diffs are applied on top of the target full snapshot)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapDiff)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s",
+			ToolSnapDiff,
+			FlagToolSnapshotPathSource,
+			"snapshots/mainnet/full_snapshot_old.bin",
+			FlagToolSnapshotPathTarget,
+			"snapshots/mainnet/full_snapshot.bin"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*sourceSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathSource)
+	}
+	if len(*targetSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathTarget)
+	}
+
+	result, err := diffSnapshots(
+		getGracefulStopContext(),
+		&snapshotFilePaths{fullPath: *sourceSnapshotPathFlag, deltaPath: *sourceDeltaSnapshotPathFlag},
+		&snapshotFilePaths{fullPath: *targetSnapshotPathFlag, deltaPath: *targetDeltaSnapshotPathFlag},
+	)
+	if err != nil {
+		return err
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	printSnapshotDiffResult(result)
+
+	return nil
+}
+
+// snapshotFilePaths are the paths to a full snapshot and its optional delta snapshot.
+type snapshotFilePaths struct {
+	fullPath  string
+	deltaPath string
+}
+
+// snapshotLedgerOutputConsumer consumes the unspent outputs of the ledger state of a snapshot.
+type snapshotLedgerOutputConsumer func(output *utxo.Output, hrp iotago.NetworkPrefix) error
+
+// streamSnapshotLedger streams the ledger state of a full snapshot file.
+// If a delta snapshot is given, the ledger state after applying its milestone diffs is streamed instead.
+func streamSnapshotLedger(ctx context.Context, paths *snapshotFilePaths, outputConsumer snapshotLedgerOutputConsumer) (*snapshotDiffLedger, error) {
+	snapshotType, err := snapshot.ReadSnapshotTypeFromFile(paths.fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotType != snapshot.Full {
+		return nil, fmt.Errorf("%s is not a full snapshot", paths.fullPath)
+	}
+
+	if paths.deltaPath != "" {
+		return streamSnapshotLedgerWithDelta(ctx, paths, outputConsumer)
+	}
+
+	file, err := os.Open(paths.fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open snapshot file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	ledger := &snapshotDiffLedger{
+		FilePath: paths.fullPath,
+	}
+
+	var hrp iotago.NetworkPrefix
+	if err := snapshot.StreamFullSnapshotDataFrom(
+		ctx,
+		file,
+		func(header *snapshot.FullSnapshotHeader) error {
+			protoParams, err := header.ProtocolParameters()
+			if err != nil {
+				return err
+			}
+			hrp = protoParams.Bech32HRP
+			ledger.LedgerMilestoneIndex = header.LedgerMilestoneIndex
+
+			return nil
+		},
+		func(output *utxo.TreasuryOutput) error {
+			ledger.TreasuryAmount = output.Amount
+
+			return nil
+		},
+		func(output *utxo.Output) error {
+			ledger.OutputCount++
+			ledger.OutputsAmount += output.Deposit()
+
+			return outputConsumer(output, hrp)
+		},
+		func(_ *snapshot.MilestoneDiff) error { return nil },
+		func(_ iotago.BlockID, _ iotago.MilestoneIndex) error { return nil },
+		func(_ *iotago.ProtocolParamsMilestoneOpt) error { return nil },
+	); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot file %s: %w", paths.fullPath, err)
+	}
+
+	return ledger, nil
+}
+
+// snapshotMemoryLedger is an in-memory ledger state that milestone diffs of snapshots are applied to.
+type snapshotMemoryLedger struct {
+	ledgerIndex    iotago.MilestoneIndex
+	outputs        map[iotago.OutputID]*utxo.Output
+	treasuryAmount uint64
+}
+
+// applyMilestoneDiff applies or rolls back the milestone diff, the same way the node does when it loads snapshots.
+// If the ledger index equals the index of the milestone diff, its changes are rolled back,
+// otherwise, if the index is the next one, its changes are applied on top of the ledger state.
+func (l *snapshotMemoryLedger) applyMilestoneDiff(msDiff *snapshot.MilestoneDiff) error {
+	msIndex := msDiff.Milestone.Index
+
+	switch {
+	case l.ledgerIndex == msIndex:
+		for _, output := range msDiff.Created {
+			delete(l.outputs, output.OutputID())
+		}
+		for _, spent := range msDiff.Consumed {
+			l.outputs[spent.OutputID()] = spent.Output()
+		}
+		if msDiff.SpentTreasuryOutput != nil {
+			l.treasuryAmount = msDiff.SpentTreasuryOutput.Amount
+		}
+		l.ledgerIndex = msIndex - 1
+
+	case l.ledgerIndex+1 == msIndex:
+		for _, spent := range msDiff.Consumed {
+			delete(l.outputs, spent.OutputID())
+		}
+		for _, output := range msDiff.Created {
+			l.outputs[output.OutputID()] = output
+		}
+		if treasuryOutput := msDiff.TreasuryOutput(); treasuryOutput != nil {
+			l.treasuryAmount = treasuryOutput.Amount
+		}
+		l.ledgerIndex = msIndex
+
+	default:
+		return fmt.Errorf("milestone diff %d doesn't fit the ledger index %d", msIndex, l.ledgerIndex)
+	}
+
+	return nil
+}
+
+// streamSnapshotLedgerWithDelta builds the ledger state of the full snapshot at its target index in memory,
+// applies the milestone diffs of the delta snapshot on top of it and streams the resulting ledger state.
+func streamSnapshotLedgerWithDelta(ctx context.Context, paths *snapshotFilePaths, outputConsumer snapshotLedgerOutputConsumer) (*snapshotDiffLedger, error) {
+	// the milestone diffs of the delta snapshot can only be parsed with the protocol parameters of the full snapshot.
+	protocolStorage := storage.NewProtocolStorage(mapdb.NewMapDB())
+
+	ledger := &snapshotMemoryLedger{
+		outputs: make(map[iotago.OutputID]*utxo.Output),
+	}
+
+	fullFile, err := os.Open(paths.fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open full snapshot file: %w", err)
+	}
+	defer func() { _ = fullFile.Close() }()
+
+	var hrp iotago.NetworkPrefix
+	var fullTargetIndex iotago.MilestoneIndex
+	if err := snapshot.StreamFullSnapshotDataFrom(
+		ctx,
+		fullFile,
+		func(header *snapshot.FullSnapshotHeader) error {
+			protoParams, err := header.ProtocolParameters()
+			if err != nil {
+				return err
+			}
+			hrp = protoParams.Bech32HRP
+			ledger.ledgerIndex = header.LedgerMilestoneIndex
+			fullTargetIndex = header.TargetMilestoneIndex
+
+			return nil
+		},
+		func(output *utxo.TreasuryOutput) error {
+			ledger.treasuryAmount = output.Amount
+
+			return nil
+		},
+		func(output *utxo.Output) error {
+			ledger.outputs[output.OutputID()] = output
+
+			return nil
+		},
+		// the milestone diffs of the full snapshot roll the ledger state back to its target index
+		ledger.applyMilestoneDiff,
+		func(_ iotago.BlockID, _ iotago.MilestoneIndex) error { return nil },
+		protocolStorage.StoreProtocolParametersMilestoneOption,
+	); err != nil {
+		return nil, fmt.Errorf("unable to read full snapshot file %s: %w", paths.fullPath, err)
+	}
+
+	if ledger.ledgerIndex != fullTargetIndex {
+		return nil, fmt.Errorf("the ledger index %d of the full snapshot %s doesn't match its target index %d", ledger.ledgerIndex, paths.fullPath, fullTargetIndex)
+	}
+
+	deltaFile, err := os.Open(paths.deltaPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open delta snapshot file: %w", err)
+	}
+	defer func() { _ = deltaFile.Close() }()
+
+	var deltaTargetIndex iotago.MilestoneIndex
+	if err := snapshot.StreamDeltaSnapshotDataFrom(
+		ctx,
+		deltaFile,
+		func() (*storage.ProtocolStorage, error) { return protocolStorage, nil },
+		func(header *snapshot.DeltaSnapshotHeader) error {
+			deltaTargetIndex = header.TargetMilestoneIndex
+
+			return nil
+		},
+		ledger.applyMilestoneDiff,
+		func(_ iotago.BlockID, _ iotago.MilestoneIndex) error { return nil },
+		func(_ *iotago.ProtocolParamsMilestoneOpt) error { return nil },
+	); err != nil {
+		return nil, fmt.Errorf("unable to read delta snapshot file %s: %w", paths.deltaPath, err)
+	}
+
+	if ledger.ledgerIndex != deltaTargetIndex {
+		return nil, fmt.Errorf("the ledger index %d after applying the delta snapshot %s doesn't match its target index %d", ledger.ledgerIndex, paths.deltaPath, deltaTargetIndex)
+	}
+
+	result := &snapshotDiffLedger{
+		FilePath:             paths.fullPath,
+		DeltaFilePath:        paths.deltaPath,
+		LedgerMilestoneIndex: ledger.ledgerIndex,
+		TreasuryAmount:       ledger.treasuryAmount,
+	}
+
+	outputIDs := make(iotago.OutputIDs, 0, len(ledger.outputs))
+	for outputID := range ledger.outputs {
+		outputIDs = append(outputIDs, outputID)
+	}
+	sort.Slice(outputIDs, func(i, j int) bool {
+		return bytes.Compare(outputIDs[i][:], outputIDs[j][:]) < 0
+	})
+
+	for _, outputID := range outputIDs {
+		output := ledger.outputs[outputID]
+
+		result.OutputCount++
+		result.OutputsAmount += output.Deposit()
+
+		if err := outputConsumer(output, hrp); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// diffSnapshots compares the ledger states of two snapshots.
+func diffSnapshots(ctx context.Context, sourcePaths *snapshotFilePaths, targetPaths *snapshotFilePaths) (*snapshotDiffResult, error) {
+
+	type sourceOutput struct {
+		output *utxo.Output
+		hrp    iotago.NetworkPrefix
+	}
+
+	sourceOutputs := make(map[iotago.OutputID]*sourceOutput)
+	source, err := streamSnapshotLedger(ctx, sourcePaths, func(output *utxo.Output, hrp iotago.NetworkPrefix) error {
+		sourceOutputs[output.OutputID()] = &sourceOutput{output: output, hrp: hrp}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &snapshotDiffResult{
+		Source:  source,
+		Added:   make([]*snapshotOutput, 0),
+		Removed: make([]*snapshotOutput, 0),
+		Changed: make([]*snapshotOutputChange, 0),
+	}
+
+	target, err := streamSnapshotLedger(ctx, targetPaths, func(output *utxo.Output, hrp iotago.NetworkPrefix) error {
+		targetOutput, err := newSnapshotOutput(output, hrp)
+		if err != nil {
+			return err
+		}
+
+		existing, exists := sourceOutputs[output.OutputID()]
+		if !exists {
+			result.Added = append(result.Added, targetOutput)
+
+			return nil
+		}
+		delete(sourceOutputs, output.OutputID())
+
+		if bytes.Equal(existing.output.SnapshotBytes(), output.SnapshotBytes()) {
+			return nil
+		}
+
+		existingOutput, err := newSnapshotOutput(existing.output, existing.hrp)
+		if err != nil {
+			return err
+		}
+
+		result.Changed = append(result.Changed, &snapshotOutputChange{
+			OutputID: targetOutput.OutputID,
+			Source:   existingOutput,
+			Target:   targetOutput,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Target = target
+
+	// all outputs that are left were not found in the target snapshot
+	for _, existing := range sourceOutputs {
+		removedOutput, err := newSnapshotOutput(existing.output, existing.hrp)
+		if err != nil {
+			return nil, err
+		}
+		result.Removed = append(result.Removed, removedOutput)
+	}
+	sort.Slice(result.Removed, func(i, j int) bool {
+		return result.Removed[i].OutputID < result.Removed[j].OutputID
+	})
+
+	result.OutputsAmountDifference = int64(target.OutputsAmount) - int64(source.OutputsAmount)
+	result.TreasuryAmountDifference = int64(target.TreasuryAmount) - int64(source.TreasuryAmount)
+	result.SupplyDifference = result.OutputsAmountDifference + result.TreasuryAmountDifference
+
+	return result, nil
+}
+
+func printSnapshotDiffResult(result *snapshotDiffResult) {
+	for _, ledger := range []struct {
+		title  string
+		ledger *snapshotDiffLedger
+	}{
+		{"source", result.Source},
+		{"target", result.Target},
+	} {
+		filePath := ledger.ledger.FilePath
+		if ledger.ledger.DeltaFilePath != "" {
+			filePath += " + " + ledger.ledger.DeltaFilePath
+		}
+		fmt.Printf("%s: %s (ledger index: %d, outputs: %d, amount: %d, treasury: %d)\n", ledger.title, filePath, ledger.ledger.LedgerMilestoneIndex, ledger.ledger.OutputCount, ledger.ledger.OutputsAmount, ledger.ledger.TreasuryAmount)
+	}
+
+	fmt.Printf("\nadded outputs: %d\n", len(result.Added))
+	for _, output := range result.Added {
+		printSnapshotOutput("    + ", output)
+	}
+
+	fmt.Printf("\nremoved outputs: %d\n", len(result.Removed))
+	for _, output := range result.Removed {
+		printSnapshotOutput("    - ", output)
+	}
+
+	fmt.Printf("\nchanged outputs: %d\n", len(result.Changed))
+	for _, change := range result.Changed {
+		printSnapshotOutput("    - ", change.Source)
+		printSnapshotOutput("    + ", change.Target)
+	}
+
+	fmt.Printf("\namount difference: %d, treasury difference: %d, supply difference: %d\n", result.OutputsAmountDifference, result.TreasuryAmountDifference, result.SupplyDifference)
+}
@@ -0,0 +1,150 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package toolset
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// writeTestGenesisSnapshot generates a genesis snapshot of the test ledger description.
+func writeTestGenesisSnapshot(t *testing.T, dir string, name string, treasuryAllocation uint64, address iotago.Address, aliasID iotago.AliasID) string {
+	protoParamsPath := filepath.Join(dir, "protocol_parameters.json")
+	writeTestProtocolParameters(t, protoParamsPath)
+
+	ledgerPath := filepath.Join(dir, "ledger.yaml")
+	require.NoError(t, os.WriteFile(ledgerPath, []byte(fmt.Sprintf(testLedgerDescription, address.String(), aliasID.ToHex())), 0600))
+
+	snapshotPath := filepath.Join(dir, name)
+	require.NoError(t, snapshotGen([]string{
+		"--" + FlagToolProtocolParametersPath, protoParamsPath,
+		"--" + FlagToolSnapGenMintAddress, address.String(),
+		"--" + FlagToolSnapGenTreasuryAllocation, fmt.Sprintf("%d", treasuryAllocation),
+		"--" + FlagToolSnapGenLedgerPath, ledgerPath,
+		"--" + FlagToolOutputPath, snapshotPath,
+	}))
+
+	return snapshotPath
+}
+
+// writeTestDeltaSnapshot writes a delta snapshot on top of a genesis snapshot with a single milestone diff
+// that consumes the given outputs and creates a new basic output with the given amount.
+func writeTestDeltaSnapshot(t *testing.T, filePath string, consumed utxo.Outputs, createdAmount uint64, address iotago.Address) *utxo.Output {
+	pub, prv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	var pubKey iotago.MilestonePublicKey
+	copy(pubKey[:], pub)
+
+	milestone := iotago.NewMilestone(1, tpkg.RandMilestoneTimestamp(), 2, iotago.MilestoneID{}, iotago.BlockIDs{tpkg.RandBlockID()}, tpkg.Rand32ByteHash(), tpkg.Rand32ByteHash())
+	require.NoError(t, milestone.Sign([]iotago.MilestonePublicKey{pubKey}, iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{pubKey: prv})))
+
+	spendingTransactionID := TransactionIDFromIndex(100)
+	created := utxo.CreateOutput(
+		iotago.OutputIDFromTransactionIDAndIndex(spendingTransactionID, 0),
+		tpkg.RandBlockID(),
+		milestone.Index,
+		milestone.Timestamp,
+		&iotago.BasicOutput{
+			Amount: createdAmount,
+			Conditions: iotago.UnlockConditions{
+				&iotago.AddressUnlockCondition{Address: address},
+			},
+		},
+	)
+
+	msDiff := &snapshot.MilestoneDiff{
+		Milestone: milestone,
+		Created:   utxo.Outputs{created},
+	}
+	for _, output := range consumed {
+		msDiff.Consumed = append(msDiff.Consumed, utxo.NewSpent(output, spendingTransactionID, milestone.Index, milestone.Timestamp))
+	}
+
+	file, err := os.Create(filePath)
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+
+	msDiffWritten := false
+	_, err = snapshot.StreamDeltaSnapshotDataTo(
+		file,
+		&snapshot.DeltaSnapshotHeader{
+			Version:                  snapshot.SupportedFormatVersion,
+			Type:                     snapshot.Delta,
+			TargetMilestoneIndex:     milestone.Index,
+			TargetMilestoneTimestamp: milestone.Timestamp,
+		},
+		func() (*snapshot.MilestoneDiff, error) {
+			if msDiffWritten {
+				return nil, nil
+			}
+			msDiffWritten = true
+
+			return msDiff, nil
+		},
+		func() (iotago.BlockID, error) { return iotago.EmptyBlockID(), snapshot.ErrNoMoreSEPToProduce },
+	)
+	require.NoError(t, err)
+
+	return created
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	dir := t.TempDir()
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+
+	// the genesis output holds the tokens that are not allocated to the treasury,
+	// so a smaller treasury allocation only changes the genesis output.
+	sourcePath := writeTestGenesisSnapshot(t, dir, "source_snapshot.bin", testTreasuryAllocation, address, aliasID)
+	targetPath := writeTestGenesisSnapshot(t, dir, "target_snapshot.bin", testTreasuryAllocation-1000, address, aliasID)
+
+	result, err := diffSnapshots(context.Background(), &snapshotFilePaths{fullPath: sourcePath}, &snapshotFilePaths{fullPath: targetPath})
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), result.Source.OutputCount)
+	require.Equal(t, uint64(5), result.Target.OutputCount)
+	require.Empty(t, result.Added)
+	require.Empty(t, result.Removed)
+	require.Len(t, result.Changed, 1)
+	require.Equal(t, iotago.OutputID{}.ToHex(), result.Changed[0].OutputID)
+	require.Equal(t, result.Changed[0].Source.Amount+1000, result.Changed[0].Target.Amount)
+	require.Equal(t, int64(1000), result.OutputsAmountDifference)
+	require.Equal(t, int64(-1000), result.TreasuryAmountDifference)
+	require.Equal(t, int64(0), result.SupplyDifference)
+
+	// the milestone diffs of a delta snapshot are applied on top of its full snapshot
+	_, outputs := readTestSnapshot(t, sourcePath)
+	consumed := outputs[1]
+	deltaPath := filepath.Join(dir, "delta_snapshot.bin")
+	created := writeTestDeltaSnapshot(t, deltaPath, utxo.Outputs{consumed}, consumed.Deposit()+500, address)
+
+	result, err = diffSnapshots(context.Background(), &snapshotFilePaths{fullPath: sourcePath}, &snapshotFilePaths{fullPath: sourcePath, deltaPath: deltaPath})
+	require.NoError(t, err)
+	require.Equal(t, iotago.MilestoneIndex(0), result.Source.LedgerMilestoneIndex)
+	require.Equal(t, iotago.MilestoneIndex(1), result.Target.LedgerMilestoneIndex)
+	require.Equal(t, deltaPath, result.Target.DeltaFilePath)
+	require.Equal(t, uint64(5), result.Target.OutputCount)
+	require.Len(t, result.Added, 1)
+	require.Equal(t, created.OutputID().ToHex(), result.Added[0].OutputID)
+	require.Len(t, result.Removed, 1)
+	require.Equal(t, consumed.OutputID().ToHex(), result.Removed[0].OutputID)
+	require.Empty(t, result.Changed)
+	require.Equal(t, int64(500), result.OutputsAmountDifference)
+	require.Equal(t, int64(0), result.TreasuryAmountDifference)
+	require.Equal(t, int64(500), result.SupplyDifference)
+
+	// a delta snapshot is not a full snapshot
+	_, err = diffSnapshots(context.Background(), &snapshotFilePaths{fullPath: deltaPath}, &snapshotFilePaths{fullPath: sourcePath})
+	require.ErrorContains(t, err, "is not a full snapshot")
+}
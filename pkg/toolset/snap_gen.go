@@ -144,12 +144,13 @@ func snapshotGen(args []string) error {
 	treasuryAllocationFlag := fs.Uint64(FlagToolSnapGenTreasuryAllocation, 0, "the amount of tokens to reside within the treasury, the delta from the supply will be allocated to 'mintAddress'")
 	genesisAddressesPathFlag := fs.String(FlagToolGenesisAddressesPath, "", "the file path to the genesis bech32 addresses file (optional)")
 	genesisAddressesFlag := fs.String(FlagToolGenesisAddresses, "", "additional genesis bech32 addresses with balances (optional, format: addr1:balance1,addr2:balance2,...)")
+	ledgerPathFlag := fs.String(FlagToolSnapGenLedgerPath, "", "the file path to a JSON or YAML description of additional basic, alias, foundry and NFT outputs (optional)")
 	outputFilePathFlag := fs.String(FlagToolOutputPath, "", "the file path to the generated snapshot file")
 
 	fs.Usage = func() {
 		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapGen)
 		fs.PrintDefaults()
-		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %s --%s %s",
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %s --%s %s --%s %s",
 			ToolSnapGen,
 			FlagToolProtocolParametersPath,
 			"protocol_parameters.json",
@@ -157,6 +158,8 @@ func snapshotGen(args []string) error {
 			"[MINT_ADDRESS]",
 			FlagToolSnapGenTreasuryAllocation,
 			"500000000",
+			FlagToolSnapGenLedgerPath,
+			"ledger.yaml",
 			FlagToolOutputPath,
 			"genesis_snapshot.bin"))
 	}
@@ -238,6 +241,27 @@ func snapshotGen(args []string) error {
 	// sort the addresses to have a deterministic order
 	genesisAddresses.Sort()
 
+	var ledgerOutputs []*utxo.Output
+	if len(*ledgerPathFlag) > 0 {
+		ledgerPath := *ledgerPathFlag
+		if _, err := os.Stat(ledgerPath); err != nil || os.IsNotExist(err) {
+			return fmt.Errorf("'%s' (%s) does not exist", FlagToolSnapGenLedgerPath, ledgerPath)
+		}
+
+		println("loading ledger description from file ...")
+		ledger, err := loadLedgerDescription(ledgerPath)
+		if err != nil {
+			return fmt.Errorf("failed to load ledger description: %w", err)
+		}
+
+		// the ledger outputs use the transaction IDs right after the genesis output,
+		// so their output IDs don't depend on the genesis addresses.
+		ledgerOutputs, err = ledger.LedgerOutputs(protoParams, 1)
+		if err != nil {
+			return fmt.Errorf("invalid ledger description: %w", err)
+		}
+	}
+
 	// create snapshot file
 	var targetIndex iotago.MilestoneIndex
 	fullHeader := &snapshot.FullSnapshotHeader{
@@ -276,8 +300,13 @@ func snapshotGen(args []string) error {
 
 	genesisBalancesTotal := genesisAddresses.TotalBalance()
 
+	ledgerOutputsTotal := uint64(0)
+	for _, output := range ledgerOutputs {
+		ledgerOutputsTotal += output.Deposit()
+	}
+
 	// calculate the remaining amount for the "genesis mint address"
-	balanceMintAddress := int64(protoParams.TokenSupply) - int64(treasury) - int64(genesisBalancesTotal)
+	balanceMintAddress := int64(protoParams.TokenSupply) - int64(treasury) - int64(genesisBalancesTotal) - int64(ledgerOutputsTotal)
 
 	var mintAddress iotago.Address
 	genesisOutputAdded := false
@@ -303,6 +332,7 @@ func snapshotGen(args []string) error {
 	}
 
 	// unspent transaction outputs
+	ledgerOutputsIndex := 0
 	genesisBalancesIndex := int64(0)
 	outputProducerFunc := func() (*utxo.Output, error) {
 		if !genesisOutputAdded {
@@ -322,12 +352,19 @@ func snapshotGen(args []string) error {
 				}), nil
 		}
 
+		if ledgerOutputsIndex < len(ledgerOutputs) {
+			ledgerOutput := ledgerOutputs[ledgerOutputsIndex]
+			ledgerOutputsIndex++
+
+			return ledgerOutput, nil
+		}
+
 		if genesisBalancesIndex < int64(len(genesisAddresses.Balances)) {
 			genesisAddress := genesisAddresses.Balances[genesisBalancesIndex]
 			genesisBalancesIndex++
 
 			return utxo.CreateOutput(
-				iotago.OutputIDFromTransactionIDAndIndex(TransactionIDFromIndex(genesisBalancesIndex), 0),
+				iotago.OutputIDFromTransactionIDAndIndex(TransactionIDFromIndex(int64(len(ledgerOutputs))+genesisBalancesIndex), 0),
 				iotago.EmptyBlockID(),
 				0,
 				0,
@@ -383,6 +420,8 @@ func snapshotGen(args []string) error {
 		return fmt.Errorf("unable to rename temp snapshot file: %w", err)
 	}
 
+	printLedgerOutputs(ledgerOutputs)
+
 	fmt.Println("Snapshot creation successful!")
 
 	return nil
@@ -0,0 +1,692 @@
+package toolset
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/filter"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	ledgerOutputTypeBasic   = "basic"
+	ledgerOutputTypeAlias   = "alias"
+	ledgerOutputTypeFoundry = "foundry"
+	ledgerOutputTypeNFT     = "nft"
+)
+
+// LedgerDescription is a declarative description of outputs that are added to a generated snapshot.
+// It can be written in JSON or YAML.
+type LedgerDescription struct {
+	Outputs []*LedgerOutput `json:"outputs"`
+}
+
+// LedgerNativeToken describes native tokens held by an output.
+// The native token is either identified by its ID, or by the alias ID and serial number of its foundry.
+type LedgerNativeToken struct {
+	ID           string `json:"id"`
+	AliasID      string `json:"aliasId"`
+	SerialNumber uint32 `json:"serialNumber"`
+	// Amount is a decimal or "0x" prefixed hex number.
+	Amount string `json:"amount"`
+}
+
+// LedgerTokenScheme describes the simple token scheme of a foundry.
+// All values are decimal or "0x" prefixed hex numbers.
+type LedgerTokenScheme struct {
+	MintedTokens  string `json:"mintedTokens"`
+	MeltedTokens  string `json:"meltedTokens"`
+	MaximumSupply string `json:"maximumSupply"`
+}
+
+// LedgerStorageDepositReturn describes a storage deposit return unlock condition.
+type LedgerStorageDepositReturn struct {
+	ReturnAddress string `json:"returnAddress"`
+	Amount        uint64 `json:"amount"`
+}
+
+// LedgerTimelock describes a timelock unlock condition.
+type LedgerTimelock struct {
+	UnixTime uint32 `json:"unixTime"`
+}
+
+// LedgerExpiration describes an expiration unlock condition.
+type LedgerExpiration struct {
+	ReturnAddress string `json:"returnAddress"`
+	UnixTime      uint32 `json:"unixTime"`
+}
+
+// LedgerOutput describes a single output of the ledger.
+// Which fields are allowed depends on the type of the output (basic, alias, foundry, nft).
+// Addresses are bech32 addresses or hex encoded ed25519 addresses.
+// Tags and metadata are "0x" prefixed hex strings, or plain text otherwise.
+type LedgerOutput struct {
+	Type string `json:"type"`
+	// Amount of base tokens. The minimum storage deposit of the output is used if no amount is given.
+	Amount       uint64               `json:"amount"`
+	NativeTokens []*LedgerNativeToken `json:"nativeTokens"`
+
+	// Unlock conditions of basic and NFT outputs.
+	Address              string                      `json:"address"`
+	StorageDepositReturn *LedgerStorageDepositReturn `json:"storageDepositReturn"`
+	Timelock             *LedgerTimelock             `json:"timelock"`
+	Expiration           *LedgerExpiration           `json:"expiration"`
+
+	// Features.
+	Sender            string `json:"sender"`
+	Issuer            string `json:"issuer"`
+	Metadata          string `json:"metadata"`
+	Tag               string `json:"tag"`
+	ImmutableMetadata string `json:"immutableMetadata"`
+
+	// Alias outputs. The alias ID is derived from the output ID if it is not given.
+	// The alias ID of foundry outputs references the controlling alias.
+	AliasID         string `json:"aliasId"`
+	StateController string `json:"stateController"`
+	Governor        string `json:"governor"`
+	StateIndex      uint32 `json:"stateIndex"`
+	StateMetadata   string `json:"stateMetadata"`
+	// FoundryCounter is set to the highest serial number of the foundries of the alias if it is not given.
+	FoundryCounter uint32 `json:"foundryCounter"`
+
+	// Foundry outputs.
+	SerialNumber uint32             `json:"serialNumber"`
+	TokenScheme  *LedgerTokenScheme `json:"tokenScheme"`
+
+	// NFT outputs. The NFT ID is derived from the output ID if it is not given.
+	NFTID string `json:"nftId"`
+}
+
+// loadLedgerDescription loads a ledger description from a JSON or YAML file.
+func loadLedgerDescription(filePath string) (*LedgerDescription, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		// YAML is converted to JSON, so the same field names are used for both formats
+		var content interface{}
+		if err := yaml.Unmarshal(data, &content); err != nil {
+			return nil, err
+		}
+
+		data, err = json.Marshal(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ledger := &LedgerDescription{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(ledger); err != nil {
+		return nil, err
+	}
+
+	return ledger, nil
+}
+
+// LedgerOutputs creates the outputs of the ledger description.
+// The output IDs are derived from the position of the outputs in the description,
+// the first output uses the transaction ID of the given index.
+// The outputs are validated against the given protocol parameters.
+func (l *LedgerDescription) LedgerOutputs(protoParams *iotago.ProtocolParameters, firstTransactionIndex int64) ([]*utxo.Output, error) {
+	outputIDs := make([]iotago.OutputID, len(l.Outputs))
+	outputs := make(iotago.Outputs, len(l.Outputs))
+
+	for i, ledgerOutput := range l.Outputs {
+		outputIDs[i] = iotago.OutputIDFromTransactionIDAndIndex(TransactionIDFromIndex(firstTransactionIndex+int64(i)), 0)
+
+		output, err := ledgerOutput.output(outputIDs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid output %d: %w", i, err)
+		}
+		outputs[i] = output
+	}
+
+	if err := adjustFoundryCounters(outputs); err != nil {
+		return nil, err
+	}
+
+	for i, output := range outputs {
+		if l.Outputs[i].Amount == 0 {
+			setOutputAmount(output, protoParams.RentStructure.MinRent(output))
+		}
+
+		if _, err := output.Serialize(serializer.DeSeriModePerformValidation, protoParams); err != nil {
+			return nil, fmt.Errorf("invalid output %d: %w", i, err)
+		}
+	}
+
+	if err := iotago.SyntacticallyValidateOutputs(outputs,
+		iotago.OutputsSyntacticalDepositAmount(protoParams),
+		iotago.OutputsSyntacticalNativeTokens(),
+		iotago.OutputsSyntacticalExpirationAndTimelock(),
+		iotago.OutputsSyntacticalAlias(),
+		iotago.OutputsSyntacticalFoundry(),
+		iotago.OutputsSyntacticalNFT(),
+		iotago.OutputsSyntacticalChainConstrainedOutputUniqueness(),
+	); err != nil {
+		return nil, err
+	}
+
+	if err := validateNativeTokenSupply(outputs); err != nil {
+		return nil, err
+	}
+
+	result := make([]*utxo.Output, len(outputs))
+	for i, output := range outputs {
+		result[i] = utxo.CreateOutput(outputIDs[i], iotago.EmptyBlockID(), 0, 0, output)
+	}
+
+	return result, nil
+}
+
+func (o *LedgerOutput) output(outputID iotago.OutputID) (iotago.Output, error) {
+	nativeTokens, err := o.nativeTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Type {
+	case ledgerOutputTypeBasic:
+		conditions, err := o.unlockConditions()
+		if err != nil {
+			return nil, err
+		}
+
+		features, err := o.features(true)
+		if err != nil {
+			return nil, err
+		}
+
+		return &iotago.BasicOutput{
+			Amount:       o.Amount,
+			NativeTokens: nativeTokens,
+			Conditions:   conditions,
+			Features:     features,
+		}, nil
+
+	case ledgerOutputTypeAlias:
+		aliasID := iotago.AliasIDFromOutputID(outputID)
+		if o.AliasID != "" {
+			if aliasID, err = filter.ParseAliasID(o.AliasID); err != nil {
+				return nil, err
+			}
+		}
+
+		stateController, err := parseRequiredAddress("stateController", o.StateController)
+		if err != nil {
+			return nil, err
+		}
+
+		governor, err := parseRequiredAddress("governor", o.Governor)
+		if err != nil {
+			return nil, err
+		}
+
+		stateMetadata, err := parseBytes(o.StateMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		features, err := o.features(false)
+		if err != nil {
+			return nil, err
+		}
+
+		immutableFeatures, err := o.immutableFeatures(true)
+		if err != nil {
+			return nil, err
+		}
+
+		return &iotago.AliasOutput{
+			Amount:         o.Amount,
+			NativeTokens:   nativeTokens,
+			AliasID:        aliasID,
+			StateIndex:     o.StateIndex,
+			StateMetadata:  stateMetadata,
+			FoundryCounter: o.FoundryCounter,
+			Conditions: iotago.UnlockConditions{
+				&iotago.StateControllerAddressUnlockCondition{Address: stateController},
+				&iotago.GovernorAddressUnlockCondition{Address: governor},
+			},
+			Features:          features,
+			ImmutableFeatures: immutableFeatures,
+		}, nil
+
+	case ledgerOutputTypeFoundry:
+		if o.AliasID == "" {
+			return nil, errors.New("aliasId of the controlling alias missing")
+		}
+
+		aliasID, err := filter.ParseAliasID(o.AliasID)
+		if err != nil {
+			return nil, err
+		}
+
+		if o.SerialNumber == 0 {
+			return nil, errors.New("serialNumber missing")
+		}
+
+		tokenScheme, err := o.tokenScheme()
+		if err != nil {
+			return nil, err
+		}
+
+		metadata, err := parseBytes(o.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		var features iotago.Features
+		if len(metadata) > 0 {
+			features = append(features, &iotago.MetadataFeature{Data: metadata})
+		}
+
+		immutableFeatures, err := o.immutableFeatures(false)
+		if err != nil {
+			return nil, err
+		}
+
+		//nolint:forcetypeassert // AliasID.ToAddress always returns an AliasAddress
+		aliasAddress := aliasID.ToAddress().(*iotago.AliasAddress)
+
+		return &iotago.FoundryOutput{
+			Amount:       o.Amount,
+			NativeTokens: nativeTokens,
+			SerialNumber: o.SerialNumber,
+			TokenScheme:  tokenScheme,
+			Conditions: iotago.UnlockConditions{
+				&iotago.ImmutableAliasUnlockCondition{Address: aliasAddress},
+			},
+			Features:          features,
+			ImmutableFeatures: immutableFeatures,
+		}, nil
+
+	case ledgerOutputTypeNFT:
+		nftID := iotago.NFTIDFromOutputID(outputID)
+		if o.NFTID != "" {
+			if nftID, err = filter.ParseNFTID(o.NFTID); err != nil {
+				return nil, err
+			}
+		}
+
+		conditions, err := o.unlockConditions()
+		if err != nil {
+			return nil, err
+		}
+
+		features, err := o.features(true)
+		if err != nil {
+			return nil, err
+		}
+
+		immutableFeatures, err := o.immutableFeatures(true)
+		if err != nil {
+			return nil, err
+		}
+
+		return &iotago.NFTOutput{
+			Amount:            o.Amount,
+			NativeTokens:      nativeTokens,
+			NFTID:             nftID,
+			Conditions:        conditions,
+			Features:          features,
+			ImmutableFeatures: immutableFeatures,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown output type '%s' (basic, alias, foundry, nft)", o.Type)
+	}
+}
+
+// unlockConditions returns the unlock conditions of basic and NFT outputs, ordered by their type.
+func (o *LedgerOutput) unlockConditions() (iotago.UnlockConditions, error) {
+	address, err := parseRequiredAddress("address", o.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	conditions := iotago.UnlockConditions{
+		&iotago.AddressUnlockCondition{Address: address},
+	}
+
+	if o.StorageDepositReturn != nil {
+		returnAddress, err := parseRequiredAddress("storageDepositReturn.returnAddress", o.StorageDepositReturn.ReturnAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, &iotago.StorageDepositReturnUnlockCondition{
+			ReturnAddress: returnAddress,
+			Amount:        o.StorageDepositReturn.Amount,
+		})
+	}
+
+	if o.Timelock != nil {
+		conditions = append(conditions, &iotago.TimelockUnlockCondition{
+			UnixTime: o.Timelock.UnixTime,
+		})
+	}
+
+	if o.Expiration != nil {
+		returnAddress, err := parseRequiredAddress("expiration.returnAddress", o.Expiration.ReturnAddress)
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, &iotago.ExpirationUnlockCondition{
+			ReturnAddress: returnAddress,
+			UnixTime:      o.Expiration.UnixTime,
+		})
+	}
+
+	return conditions, nil
+}
+
+// features returns the sender, metadata and optionally the tag feature, ordered by their type.
+func (o *LedgerOutput) features(withTag bool) (iotago.Features, error) {
+	var features iotago.Features
+
+	if o.Sender != "" {
+		sender, err := parseAddress(o.Sender)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sender: %w", err)
+		}
+		features = append(features, &iotago.SenderFeature{Address: sender})
+	}
+
+	metadata, err := parseBytes(o.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) > 0 {
+		features = append(features, &iotago.MetadataFeature{Data: metadata})
+	}
+
+	if withTag {
+		tag, err := parseBytes(o.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if len(tag) > 0 {
+			features = append(features, &iotago.TagFeature{Tag: tag})
+		}
+	} else if o.Tag != "" {
+		return nil, fmt.Errorf("tag is not allowed for %s outputs", o.Type)
+	}
+
+	return features, nil
+}
+
+// immutableFeatures returns optionally the issuer and the metadata feature, ordered by their type.
+func (o *LedgerOutput) immutableFeatures(withIssuer bool) (iotago.Features, error) {
+	var features iotago.Features
+
+	if withIssuer {
+		if o.Issuer != "" {
+			issuer, err := parseAddress(o.Issuer)
+			if err != nil {
+				return nil, fmt.Errorf("invalid issuer: %w", err)
+			}
+			features = append(features, &iotago.IssuerFeature{Address: issuer})
+		}
+	} else if o.Issuer != "" {
+		return nil, fmt.Errorf("issuer is not allowed for %s outputs", o.Type)
+	}
+
+	metadata, err := parseBytes(o.ImmutableMetadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) > 0 {
+		features = append(features, &iotago.MetadataFeature{Data: metadata})
+	}
+
+	return features, nil
+}
+
+// nativeTokens returns the native tokens of the output, ordered by their ID.
+func (o *LedgerOutput) nativeTokens() (iotago.NativeTokens, error) {
+	if len(o.NativeTokens) == 0 {
+		return nil, nil
+	}
+
+	nativeTokens := make(iotago.NativeTokens, 0, len(o.NativeTokens))
+	for _, nativeToken := range o.NativeTokens {
+		id, err := nativeToken.id()
+		if err != nil {
+			return nil, err
+		}
+
+		amount, err := parseBigInt("native token amount", nativeToken.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		nativeTokens = append(nativeTokens, &iotago.NativeToken{ID: id, Amount: amount})
+	}
+
+	sort.Slice(nativeTokens, func(i int, j int) bool {
+		return bytes.Compare(nativeTokens[i].ID[:], nativeTokens[j].ID[:]) < 0
+	})
+
+	return nativeTokens, nil
+}
+
+func (o *LedgerOutput) tokenScheme() (*iotago.SimpleTokenScheme, error) {
+	if o.TokenScheme == nil {
+		return nil, errors.New("tokenScheme missing")
+	}
+
+	mintedTokens, err := parseBigInt("mintedTokens", o.TokenScheme.MintedTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	meltedTokens := new(big.Int)
+	if o.TokenScheme.MeltedTokens != "" {
+		if meltedTokens, err = parseBigInt("meltedTokens", o.TokenScheme.MeltedTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	maximumSupply, err := parseBigInt("maximumSupply", o.TokenScheme.MaximumSupply)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iotago.SimpleTokenScheme{
+		MintedTokens:  mintedTokens,
+		MeltedTokens:  meltedTokens,
+		MaximumSupply: maximumSupply,
+	}, nil
+}
+
+func (n *LedgerNativeToken) id() (iotago.NativeTokenID, error) {
+	if n.ID != "" {
+		return filter.ParseNativeTokenID(n.ID)
+	}
+
+	if n.AliasID == "" || n.SerialNumber == 0 {
+		return iotago.NativeTokenID{}, errors.New("native token needs either an id, or the aliasId and serialNumber of its foundry")
+	}
+
+	aliasID, err := filter.ParseAliasID(n.AliasID)
+	if err != nil {
+		return iotago.NativeTokenID{}, err
+	}
+
+	// the native token ID is the ID of the foundry
+	foundryOutput := &iotago.FoundryOutput{
+		SerialNumber: n.SerialNumber,
+		TokenScheme:  &iotago.SimpleTokenScheme{},
+		Conditions: iotago.UnlockConditions{
+			//nolint:forcetypeassert // AliasID.ToAddress always returns an AliasAddress
+			&iotago.ImmutableAliasUnlockCondition{Address: aliasID.ToAddress().(*iotago.AliasAddress)},
+		},
+	}
+
+	return foundryOutput.NativeTokenID()
+}
+
+// adjustFoundryCounters checks that the controlling alias of every foundry exists,
+// and sets the foundry counter of aliases without a given foundry counter.
+func adjustFoundryCounters(outputs iotago.Outputs) error {
+	aliases := make(map[iotago.AliasID]*iotago.AliasOutput)
+	foundryCounterGiven := make(map[iotago.AliasID]bool)
+	for _, output := range outputs {
+		if aliasOutput, ok := output.(*iotago.AliasOutput); ok {
+			aliases[aliasOutput.AliasID] = aliasOutput
+			foundryCounterGiven[aliasOutput.AliasID] = aliasOutput.FoundryCounter != 0
+		}
+	}
+
+	for i, output := range outputs {
+		foundryOutput, ok := output.(*iotago.FoundryOutput)
+		if !ok {
+			continue
+		}
+
+		//nolint:forcetypeassert // foundries are always controlled by an alias
+		aliasID := foundryOutput.Ident().(*iotago.AliasAddress).AliasID()
+
+		aliasOutput, exists := aliases[aliasID]
+		if !exists {
+			return fmt.Errorf("invalid output %d: controlling alias %s of the foundry not found", i, aliasID.ToHex())
+		}
+
+		if foundryOutput.SerialNumber > aliasOutput.FoundryCounter {
+			if foundryCounterGiven[aliasID] {
+				return fmt.Errorf("invalid output %d: serial number %d of the foundry is higher than the foundry counter %d of the alias", i, foundryOutput.SerialNumber, aliasOutput.FoundryCounter)
+			}
+			aliasOutput.FoundryCounter = foundryOutput.SerialNumber
+		}
+	}
+
+	return nil
+}
+
+// validateNativeTokenSupply checks that every native token has a foundry,
+// and that the native tokens in the ledger match the circulating supply of the foundries.
+func validateNativeTokenSupply(outputs iotago.Outputs) error {
+	sums, err := outputs.NativeTokenSum()
+	if err != nil {
+		return err
+	}
+
+	foundries := make(map[iotago.NativeTokenID]*iotago.FoundryOutput)
+	for _, output := range outputs {
+		if foundryOutput, ok := output.(*iotago.FoundryOutput); ok {
+			foundries[foundryOutput.MustNativeTokenID()] = foundryOutput
+		}
+	}
+
+	for nativeTokenID := range sums {
+		if _, exists := foundries[nativeTokenID]; !exists {
+			return fmt.Errorf("foundry of native token %s not found", nativeTokenID.ToHex())
+		}
+	}
+
+	for nativeTokenID, foundryOutput := range foundries {
+		//nolint:forcetypeassert // only the simple token scheme is supported
+		tokenScheme := foundryOutput.TokenScheme.(*iotago.SimpleTokenScheme)
+
+		circulatingSupply := new(big.Int).Sub(tokenScheme.MintedTokens, tokenScheme.MeltedTokens)
+
+		sum, exists := sums[nativeTokenID]
+		if !exists {
+			sum = new(big.Int)
+		}
+
+		if sum.Cmp(circulatingSupply) != 0 {
+			return fmt.Errorf("native token %s: the amount in the ledger (%s) doesn't match the circulating supply of the foundry (%s)", nativeTokenID.ToHex(), sum.String(), circulatingSupply.String())
+		}
+	}
+
+	return nil
+}
+
+func setOutputAmount(output iotago.Output, amount uint64) {
+	switch o := output.(type) {
+	case *iotago.BasicOutput:
+		o.Amount = amount
+	case *iotago.AliasOutput:
+		o.Amount = amount
+	case *iotago.FoundryOutput:
+		o.Amount = amount
+	case *iotago.NFTOutput:
+		o.Amount = amount
+	}
+}
+
+func parseRequiredAddress(name string, address string) (iotago.Address, error) {
+	if address == "" {
+		return nil, fmt.Errorf("%s missing", name)
+	}
+
+	result, err := parseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return result, nil
+}
+
+// parseBytes parses "0x" prefixed hex strings, other strings are used as plain text.
+func parseBytes(s string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") {
+		return []byte(s), nil
+	}
+
+	return iotago.DecodeHex(s)
+}
+
+func parseBigInt(name string, s string) (*big.Int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%s missing", name)
+	}
+
+	result, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s: %s", name, s)
+	}
+
+	return result, nil
+}
+
+// printLedgerOutputs prints the IDs of the ledger outputs, so they can be referenced in test scenarios.
+func printLedgerOutputs(outputs []*utxo.Output) {
+	if len(outputs) == 0 {
+		return
+	}
+
+	fmt.Println("Ledger outputs:")
+	for _, output := range outputs {
+		switch o := output.Output().(type) {
+		case *iotago.AliasOutput:
+			fmt.Printf("  %s: alias, amount %d, aliasId %s\n", output.OutputID().ToHex(), o.Amount, o.AliasID.ToHex())
+		case *iotago.FoundryOutput:
+			fmt.Printf("  %s: foundry, amount %d, nativeTokenId %s\n", output.OutputID().ToHex(), o.Amount, o.MustNativeTokenID().ToHex())
+		case *iotago.NFTOutput:
+			fmt.Printf("  %s: nft, amount %d, nftId %s\n", output.OutputID().ToHex(), o.Amount, o.NFTID.ToHex())
+		default:
+			fmt.Printf("  %s: basic, amount %d\n", output.OutputID().ToHex(), output.Deposit())
+		}
+	}
+}
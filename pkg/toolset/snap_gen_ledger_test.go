@@ -0,0 +1,224 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package toolset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	testTokenSupply        = 1_000_000_000_000
+	testTreasuryAllocation = 500_000_000
+)
+
+var testLedgerDescription = `
+outputs:
+  - type: basic
+    amount: 1000000
+    address: %[1]s
+    tag: genesis
+    nativeTokens:
+      - aliasId: %[2]s
+        serialNumber: 1
+        amount: "100"
+  - type: alias
+    aliasId: %[2]s
+    stateController: %[1]s
+    governor: %[1]s
+  - type: foundry
+    aliasId: %[2]s
+    serialNumber: 1
+    tokenScheme:
+      mintedTokens: "150"
+      maximumSupply: "1000"
+    nativeTokens:
+      - aliasId: %[2]s
+        serialNumber: 1
+        amount: "0x32"
+  - type: nft
+    address: %[1]s
+    immutableMetadata: hello
+`
+
+var testInvalidSupplyLedgerDescription = `
+outputs:
+  - type: alias
+    aliasId: %[2]s
+    stateController: %[1]s
+    governor: %[1]s
+  - type: foundry
+    aliasId: %[2]s
+    serialNumber: 1
+    tokenScheme:
+      mintedTokens: "150"
+      maximumSupply: "1000"
+    nativeTokens:
+      - aliasId: %[2]s
+        serialNumber: 1
+        amount: "149"
+`
+
+func writeTestProtocolParameters(t *testing.T, filePath string) *iotago.ProtocolParameters {
+	protoParams := &iotago.ProtocolParameters{
+		Version:       2,
+		NetworkName:   "testnet",
+		Bech32HRP:     iotago.PrefixTestnet,
+		MinPoWScore:   0,
+		BelowMaxDepth: 15,
+		RentStructure: iotago.RentStructure{
+			VByteCost:    100,
+			VBFactorData: 1,
+			VBFactorKey:  10,
+		},
+		TokenSupply: testTokenSupply,
+	}
+
+	data, err := json.Marshal(protoParams)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filePath, data, 0600))
+
+	return protoParams
+}
+
+// readTestSnapshot reads the header and the unspent outputs of the full snapshot file.
+func readTestSnapshot(t *testing.T, filePath string) (*snapshot.FullSnapshotHeader, []*utxo.Output) {
+	fileHandle, err := os.Open(filePath)
+	require.NoError(t, err)
+	defer func() { _ = fileHandle.Close() }()
+
+	var header *snapshot.FullSnapshotHeader
+	var outputs []*utxo.Output
+	require.NoError(t, snapshot.StreamFullSnapshotDataFrom(
+		context.Background(),
+		fileHandle,
+		func(h *snapshot.FullSnapshotHeader) error {
+			header = h
+
+			return nil
+		},
+		func(_ *utxo.TreasuryOutput) error { return nil },
+		func(output *utxo.Output) error {
+			outputs = append(outputs, output)
+
+			return nil
+		},
+		func(_ *snapshot.MilestoneDiff) error { return nil },
+		func(_ iotago.BlockID, _ iotago.MilestoneIndex) error { return nil },
+		func(_ *iotago.ProtocolParamsMilestoneOpt) error { return nil },
+	))
+
+	return header, outputs
+}
+
+func TestSnapshotGenLedger(t *testing.T) {
+	dir := t.TempDir()
+
+	protoParamsPath := filepath.Join(dir, "protocol_parameters.json")
+	protoParams := writeTestProtocolParameters(t, protoParamsPath)
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+
+	ledgerPath := filepath.Join(dir, "ledger.yaml")
+	require.NoError(t, os.WriteFile(ledgerPath, []byte(fmt.Sprintf(testLedgerDescription, address.String(), aliasID.ToHex())), 0600))
+
+	mintAddress := tpkg.RandAddress(iotago.AddressEd25519)
+	snapshotPath := filepath.Join(dir, "genesis_snapshot.bin")
+	require.NoError(t, snapshotGen([]string{
+		"--" + FlagToolProtocolParametersPath, protoParamsPath,
+		"--" + FlagToolSnapGenMintAddress, mintAddress.String(),
+		"--" + FlagToolSnapGenTreasuryAllocation, fmt.Sprintf("%d", testTreasuryAllocation),
+		"--" + FlagToolSnapGenLedgerPath, ledgerPath,
+		"--" + FlagToolOutputPath, snapshotPath,
+	}))
+
+	header, outputs := readTestSnapshot(t, snapshotPath)
+	require.Equal(t, uint64(testTreasuryAllocation), header.TreasuryOutput.Amount)
+	require.Len(t, outputs, 5)
+	require.Equal(t, uint64(len(outputs)), header.OutputCount)
+
+	// the whole token supply is distributed to the treasury, the ledger outputs and the mint address
+	supply := header.TreasuryOutput.Amount
+	for _, output := range outputs {
+		supply += output.Deposit()
+	}
+	require.Equal(t, protoParams.TokenSupply, supply)
+
+	// the genesis output holds the remaining tokens
+	genesisOutput := outputs[0]
+	require.Equal(t, iotago.OutputID{}, genesisOutput.OutputID())
+	require.True(t, mintAddress.Equal(genesisOutput.Output().UnlockConditionSet().Address().Address))
+
+	// the ledger outputs use the transaction IDs right after the genesis output
+	ledgerOutputs := outputs[1:]
+	for i, output := range ledgerOutputs {
+		require.Equal(t, iotago.OutputIDFromTransactionIDAndIndex(TransactionIDFromIndex(int64(i+1)), 0), output.OutputID())
+	}
+
+	// the native tokens reference the foundry by the alias ID and the serial number
+	foundryOutput := ledgerOutputs[2].Output().(*iotago.FoundryOutput)
+	require.Equal(t, aliasID, foundryOutput.Ident().(*iotago.AliasAddress).AliasID())
+	require.Equal(t, uint32(1), foundryOutput.SerialNumber)
+	nativeTokenID := foundryOutput.MustNativeTokenID()
+
+	basicOutput := ledgerOutputs[0].Output().(*iotago.BasicOutput)
+	require.Equal(t, uint64(1_000_000), basicOutput.Amount)
+	require.True(t, address.Equal(basicOutput.UnlockConditionSet().Address().Address))
+	require.Equal(t, []byte("genesis"), basicOutput.FeatureSet().TagFeature().Tag)
+	require.Len(t, basicOutput.NativeTokens, 1)
+	require.Equal(t, nativeTokenID, basicOutput.NativeTokens[0].ID)
+	require.Equal(t, big.NewInt(100), basicOutput.NativeTokens[0].Amount)
+
+	// outputs without an amount hold the minimum storage deposit
+	aliasOutput := ledgerOutputs[1].Output().(*iotago.AliasOutput)
+	require.Equal(t, aliasID, aliasOutput.AliasID)
+	require.Equal(t, protoParams.RentStructure.MinRent(aliasOutput), aliasOutput.Amount)
+	// the foundry counter is set to the serial number of the foundry
+	require.Equal(t, uint32(1), aliasOutput.FoundryCounter)
+
+	require.Equal(t, protoParams.RentStructure.MinRent(foundryOutput), foundryOutput.Amount)
+	require.Len(t, foundryOutput.NativeTokens, 1)
+	require.Equal(t, big.NewInt(50), foundryOutput.NativeTokens[0].Amount)
+
+	nftOutput := ledgerOutputs[3].Output().(*iotago.NFTOutput)
+	require.Equal(t, iotago.NFTIDFromOutputID(ledgerOutputs[3].OutputID()), nftOutput.NFTID)
+	require.Equal(t, []byte("hello"), nftOutput.ImmutableFeatureSet().MetadataFeature().Data)
+}
+
+func TestSnapshotGenLedgerInvalidSupply(t *testing.T) {
+	dir := t.TempDir()
+
+	protoParamsPath := filepath.Join(dir, "protocol_parameters.json")
+	writeTestProtocolParameters(t, protoParamsPath)
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+
+	// the native tokens in the ledger don't match the circulating supply of the foundry
+	ledgerPath := filepath.Join(dir, "ledger.yaml")
+	require.NoError(t, os.WriteFile(ledgerPath, []byte(fmt.Sprintf(testInvalidSupplyLedgerDescription, address.String(), aliasID.ToHex())), 0600))
+
+	snapshotPath := filepath.Join(dir, "genesis_snapshot.bin")
+	err := snapshotGen([]string{
+		"--" + FlagToolProtocolParametersPath, protoParamsPath,
+		"--" + FlagToolSnapGenMintAddress, tpkg.RandAddress(iotago.AddressEd25519).String(),
+		"--" + FlagToolSnapGenLedgerPath, ledgerPath,
+		"--" + FlagToolOutputPath, snapshotPath,
+	})
+	require.ErrorContains(t, err, "doesn't match the circulating supply of the foundry")
+
+	// no snapshot file is created for an invalid ledger description
+	require.NoFileExists(t, snapshotPath)
+}
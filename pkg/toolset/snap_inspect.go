@@ -0,0 +1,434 @@
+package toolset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/hive.go/app/configuration"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hornet/v2/pkg/filter"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/snapshot"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	snapshotInspectShowOutputs          = "outputs"
+	snapshotInspectShowMilestoneDiffs   = "milestoneDiffs"
+	snapshotInspectShowSolidEntryPoints = "solidEntryPoints"
+)
+
+// snapshotOutput is an output contained in a snapshot file.
+type snapshotOutput struct {
+	OutputID                 string                `json:"outputId"`
+	BlockID                  string                `json:"blockId"`
+	MilestoneIndexBooked     iotago.MilestoneIndex `json:"milestoneIndexBooked"`
+	MilestoneTimestampBooked uint32                `json:"milestoneTimestampBooked"`
+	Type                     string                `json:"type"`
+	Amount                   uint64                `json:"amount,string"`
+	Address                  string                `json:"address,omitempty"`
+	Output                   json.RawMessage       `json:"output"`
+}
+
+func newSnapshotOutput(output *utxo.Output, hrp iotago.NetworkPrefix) (*snapshotOutput, error) {
+	outputJSON, err := json.Marshal(output.Output())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal output %s: %w", output.OutputID().ToHex(), err)
+	}
+
+	var address string
+	if ownerAddress := outputOwnerAddress(output.Output()); ownerAddress != nil {
+		address = ownerAddress.Bech32(hrp)
+	}
+
+	return &snapshotOutput{
+		OutputID:                 output.OutputID().ToHex(),
+		BlockID:                  output.BlockID().ToHex(),
+		MilestoneIndexBooked:     output.MilestoneIndexBooked(),
+		MilestoneTimestampBooked: output.MilestoneTimestampBooked(),
+		Type:                     output.OutputType().String(),
+		Amount:                   output.Deposit(),
+		Address:                  address,
+		Output:                   outputJSON,
+	}, nil
+}
+
+// outputOwnerAddress returns the address that owns the output.
+// For alias outputs this is the state controller, for foundry outputs the controlling alias.
+func outputOwnerAddress(output iotago.Output) iotago.Address {
+	conditions := output.UnlockConditionSet()
+
+	if condition := conditions.Address(); condition != nil {
+		return condition.Address
+	}
+	if condition := conditions.StateControllerAddress(); condition != nil {
+		return condition.Address
+	}
+	if condition := conditions.ImmutableAlias(); condition != nil {
+		return condition.Address
+	}
+
+	return nil
+}
+
+func printSnapshotOutput(indent string, output *snapshotOutput) {
+	fmt.Printf("%s%s: %s, amount %d, address %s, booked at milestone %d\n", indent, output.OutputID, output.Type, output.Amount, output.Address, output.MilestoneIndexBooked)
+}
+
+// snapshotOutputFilter selects the outputs of a snapshot that are inspected.
+type snapshotOutputFilter struct {
+	filter    *filter.Filter
+	minAmount uint64
+	maxAmount uint64
+}
+
+func (f *snapshotOutputFilter) match(output *utxo.Output) bool {
+	if output.Deposit() < f.minAmount {
+		return false
+	}
+	if f.maxAmount > 0 && output.Deposit() > f.maxAmount {
+		return false
+	}
+
+	return f.filter.MatchOutput(output.OutputID(), output.Output())
+}
+
+type snapshotInspectOutputs struct {
+	LedgerMilestoneIndex iotago.MilestoneIndex `json:"ledgerMilestoneIndex"`
+	TotalCount           uint64                `json:"totalCount"`
+	TotalAmount          uint64                `json:"totalAmount,string"`
+	TreasuryAmount       uint64                `json:"treasuryAmount,string"`
+	MatchingCount        uint64                `json:"matchingCount"`
+	MatchingAmount       uint64                `json:"matchingAmount,string"`
+	Outputs              []*snapshotOutput     `json:"outputs"`
+}
+
+type snapshotInspectMilestoneDiff struct {
+	MilestoneIndex      iotago.MilestoneIndex `json:"milestoneIndex"`
+	MilestoneID         string                `json:"milestoneId"`
+	MilestoneTimestamp  time.Time             `json:"milestoneTimestamp"`
+	CreatedCount        int                   `json:"createdCount"`
+	ConsumedCount       int                   `json:"consumedCount"`
+	Created             []*snapshotOutput     `json:"created"`
+	Consumed            []*snapshotOutput     `json:"consumed"`
+	SpentTreasuryOutput *utxo.TreasuryOutput  `json:"spentTreasuryOutput,omitempty"`
+}
+
+type snapshotInspectSolidEntryPoint struct {
+	BlockID        string                `json:"blockId"`
+	MilestoneIndex iotago.MilestoneIndex `json:"milestoneIndex"`
+}
+
+type snapshotInspectResult struct {
+	FilePath         string                            `json:"filePath"`
+	Outputs          *snapshotInspectOutputs           `json:"outputs,omitempty"`
+	MilestoneDiffs   []*snapshotInspectMilestoneDiff   `json:"milestoneDiffs,omitempty"`
+	SolidEntryPoints []*snapshotInspectSolidEntryPoint `json:"solidEntryPoints,omitempty"`
+}
+
+func snapshotInspect(args []string) error {
+
+	fs := configuration.NewUnsortedFlagSet("", flag.ContinueOnError)
+	fullSnapshotPathFlag := fs.String(FlagToolSnapshotPathFull, "", "the path to the full snapshot file")
+	deltaSnapshotPathFlag := fs.String(FlagToolSnapshotPathDelta, "", "the path to the delta snapshot file (optional, the milestone diffs and solid entry points are taken from the delta snapshot)")
+	showFlag := fs.StringSlice(FlagToolSnapInspectShow, []string{snapshotInspectShowOutputs}, fmt.Sprintf("the content of the snapshot to show (%s, %s, %s)", snapshotInspectShowOutputs, snapshotInspectShowMilestoneDiffs, snapshotInspectShowSolidEntryPoints))
+	outputTypesFlag := fs.StringSlice(FlagToolOutputType, nil, "only show outputs of the given types (e.g. basic, alias, foundry, nft)")
+	addressesFlag := fs.StringSlice(FlagToolAddress, nil, "only show outputs that can be unlocked by the given bech32 addresses")
+	minAmountFlag := fs.Uint64(FlagToolMinAmount, 0, "only show outputs with at least the given amount")
+	maxAmountFlag := fs.Uint64(FlagToolMaxAmount, 0, "only show outputs with at most the given amount (0 = no limit)")
+	limitFlag := fs.Int(FlagToolLimit, 0, "the maximum amount of listed outputs (0 = no limit)")
+	outputJSONFlag := fs.Bool(FlagToolOutputJSON, false, FlagToolDescriptionOutputJSON)
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolSnapInspect)
+		fs.PrintDefaults()
+		println(fmt.Sprintf("\nexample: %s --%s %s --%s %s --%s %s --%s %s",
+			ToolSnapInspect,
+			FlagToolSnapshotPathFull,
+			"snapshots/mainnet/full_snapshot.bin",
+			FlagToolSnapInspectShow,
+			snapshotInspectShowOutputs,
+			FlagToolOutputType,
+			"nft",
+			FlagToolMinAmount,
+			"1000000"))
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if len(*fullSnapshotPathFlag) == 0 {
+		return fmt.Errorf("'%s' not specified", FlagToolSnapshotPathFull)
+	}
+
+	show := make(map[string]struct{})
+	for _, section := range filter.SplitValues(*showFlag) {
+		switch section {
+		case snapshotInspectShowOutputs, snapshotInspectShowMilestoneDiffs, snapshotInspectShowSolidEntryPoints:
+			show[section] = struct{}{}
+		default:
+			return fmt.Errorf("unknown value for '%s': %s", FlagToolSnapInspectShow, section)
+		}
+	}
+
+	outputFilter := &snapshotOutputFilter{
+		filter:    &filter.Filter{},
+		minAmount: *minAmountFlag,
+		maxAmount: *maxAmountFlag,
+	}
+	for _, value := range filter.SplitValues(*outputTypesFlag) {
+		outputType, err := filter.ParseOutputType(value)
+		if err != nil {
+			return err
+		}
+		outputFilter.filter.OutputTypes = append(outputFilter.filter.OutputTypes, outputType)
+	}
+	for _, value := range filter.SplitValues(*addressesFlag) {
+		address, err := filter.ParseAddress(value)
+		if err != nil {
+			return err
+		}
+		outputFilter.filter.Addresses = append(outputFilter.filter.Addresses, address)
+	}
+
+	result, err := inspectSnapshot(getGracefulStopContext(), *fullSnapshotPathFlag, *deltaSnapshotPathFlag, show, outputFilter, *limitFlag)
+	if err != nil {
+		return err
+	}
+
+	if *outputJSONFlag {
+		return printJSON(result)
+	}
+
+	printSnapshotInspectResult(result)
+
+	return nil
+}
+
+// inspectSnapshot streams through the full snapshot and the optional delta snapshot and collects the requested content.
+// The outputs are always taken from the full snapshot, the milestone diffs and solid entry points
+// are taken from the delta snapshot if one is given.
+func inspectSnapshot(ctx context.Context, fullPath string, deltaPath string, show map[string]struct{}, outputFilter *snapshotOutputFilter, limit int) (*snapshotInspectResult, error) {
+	_, showOutputs := show[snapshotInspectShowOutputs]
+	_, showMilestoneDiffs := show[snapshotInspectShowMilestoneDiffs]
+	_, showSolidEntryPoints := show[snapshotInspectShowSolidEntryPoints]
+
+	result := &snapshotInspectResult{
+		FilePath: fullPath,
+	}
+	if deltaPath != "" {
+		result.FilePath = deltaPath
+	}
+
+	// the milestone diffs of the delta snapshot can only be parsed with the protocol parameters of the full snapshot.
+	protocolStorage := storage.NewProtocolStorage(mapdb.NewMapDB())
+
+	var hrp iotago.NetworkPrefix
+	inspectOutputs := &snapshotInspectOutputs{
+		Outputs: make([]*snapshotOutput, 0),
+	}
+
+	addOutputs := func(outputs utxo.Outputs) ([]*snapshotOutput, error) {
+		result := make([]*snapshotOutput, 0)
+		for _, output := range outputs {
+			if !outputFilter.match(output) {
+				continue
+			}
+
+			snapOutput, err := newSnapshotOutput(output, hrp)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, snapOutput)
+		}
+
+		return result, nil
+	}
+
+	msDiffConsumer := func(msDiff *snapshot.MilestoneDiff) error {
+		if !showMilestoneDiffs {
+			return nil
+		}
+
+		milestoneID, err := msDiff.Milestone.ID()
+		if err != nil {
+			return err
+		}
+
+		consumedOutputs := make(utxo.Outputs, 0, len(msDiff.Consumed))
+		for _, spent := range msDiff.Consumed {
+			consumedOutputs = append(consumedOutputs, spent.Output())
+		}
+
+		created, err := addOutputs(msDiff.Created)
+		if err != nil {
+			return err
+		}
+
+		consumed, err := addOutputs(consumedOutputs)
+		if err != nil {
+			return err
+		}
+
+		result.MilestoneDiffs = append(result.MilestoneDiffs, &snapshotInspectMilestoneDiff{
+			MilestoneIndex:      msDiff.Milestone.Index,
+			MilestoneID:         milestoneID.ToHex(),
+			MilestoneTimestamp:  time.Unix(int64(msDiff.Milestone.Timestamp), 0),
+			CreatedCount:        len(msDiff.Created),
+			ConsumedCount:       len(msDiff.Consumed),
+			Created:             created,
+			Consumed:            consumed,
+			SpentTreasuryOutput: msDiff.SpentTreasuryOutput,
+		})
+
+		return nil
+	}
+
+	sepConsumer := func(blockID iotago.BlockID, msIndex iotago.MilestoneIndex) error {
+		if !showSolidEntryPoints {
+			return nil
+		}
+
+		result.SolidEntryPoints = append(result.SolidEntryPoints, &snapshotInspectSolidEntryPoint{
+			BlockID:        blockID.ToHex(),
+			MilestoneIndex: msIndex,
+		})
+
+		return nil
+	}
+
+	fullMsDiffConsumer := msDiffConsumer
+	fullSEPConsumer := sepConsumer
+	if deltaPath != "" {
+		fullMsDiffConsumer = func(_ *snapshot.MilestoneDiff) error { return nil }
+		fullSEPConsumer = func(_ iotago.BlockID, _ iotago.MilestoneIndex) error { return nil }
+	}
+
+	fullFile, err := os.Open(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open full snapshot file: %w", err)
+	}
+	defer func() { _ = fullFile.Close() }()
+
+	if err := snapshot.StreamFullSnapshotDataFrom(
+		ctx,
+		fullFile,
+		func(header *snapshot.FullSnapshotHeader) error {
+			protoParams, err := header.ProtocolParameters()
+			if err != nil {
+				return err
+			}
+			hrp = protoParams.Bech32HRP
+			inspectOutputs.LedgerMilestoneIndex = header.LedgerMilestoneIndex
+
+			return nil
+		},
+		func(output *utxo.TreasuryOutput) error {
+			inspectOutputs.TreasuryAmount = output.Amount
+
+			return nil
+		},
+		func(output *utxo.Output) error {
+			inspectOutputs.TotalCount++
+			inspectOutputs.TotalAmount += output.Deposit()
+
+			if !showOutputs || !outputFilter.match(output) {
+				return nil
+			}
+
+			inspectOutputs.MatchingCount++
+			inspectOutputs.MatchingAmount += output.Deposit()
+
+			if limit > 0 && len(inspectOutputs.Outputs) >= limit {
+				return nil
+			}
+
+			snapOutput, err := newSnapshotOutput(output, hrp)
+			if err != nil {
+				return err
+			}
+			inspectOutputs.Outputs = append(inspectOutputs.Outputs, snapOutput)
+
+			return nil
+		},
+		fullMsDiffConsumer,
+		fullSEPConsumer,
+		protocolStorage.StoreProtocolParametersMilestoneOption,
+	); err != nil {
+		return nil, fmt.Errorf("unable to read full snapshot file: %w", err)
+	}
+
+	if showOutputs {
+		result.Outputs = inspectOutputs
+	}
+
+	if deltaPath == "" {
+		return result, nil
+	}
+
+	deltaFile, err := os.Open(deltaPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open delta snapshot file: %w", err)
+	}
+	defer func() { _ = deltaFile.Close() }()
+
+	if err := snapshot.StreamDeltaSnapshotDataFrom(
+		ctx,
+		deltaFile,
+		func() (*storage.ProtocolStorage, error) { return protocolStorage, nil },
+		func(_ *snapshot.DeltaSnapshotHeader) error { return nil },
+		msDiffConsumer,
+		sepConsumer,
+		func(_ *iotago.ProtocolParamsMilestoneOpt) error { return nil },
+	); err != nil {
+		return nil, fmt.Errorf("unable to read delta snapshot file: %w", err)
+	}
+
+	return result, nil
+}
+
+func printSnapshotInspectResult(result *snapshotInspectResult) {
+	fmt.Printf("inspecting snapshot %s\n", result.FilePath)
+
+	if result.Outputs != nil {
+		fmt.Printf("\noutputs at ledger milestone index %d:\n", result.Outputs.LedgerMilestoneIndex)
+		for _, output := range result.Outputs.Outputs {
+			printSnapshotOutput("    > ", output)
+		}
+		if uint64(len(result.Outputs.Outputs)) < result.Outputs.MatchingCount {
+			fmt.Printf("    ... %d more\n", result.Outputs.MatchingCount-uint64(len(result.Outputs.Outputs)))
+		}
+		fmt.Printf("matching outputs: %d, amount: %d\n", result.Outputs.MatchingCount, result.Outputs.MatchingAmount)
+		fmt.Printf("total outputs: %d, amount: %d, treasury: %d\n", result.Outputs.TotalCount, result.Outputs.TotalAmount, result.Outputs.TreasuryAmount)
+	}
+
+	if result.MilestoneDiffs != nil {
+		fmt.Printf("\nmilestone diffs:\n")
+		for _, msDiff := range result.MilestoneDiffs {
+			fmt.Printf("    > milestone %d (%s, %s): created %d, consumed %d\n", msDiff.MilestoneIndex, msDiff.MilestoneID, msDiff.MilestoneTimestamp.UTC().Format(time.RFC3339), msDiff.CreatedCount, msDiff.ConsumedCount)
+			for _, output := range msDiff.Created {
+				printSnapshotOutput("        + ", output)
+			}
+			for _, output := range msDiff.Consumed {
+				printSnapshotOutput("        - ", output)
+			}
+			if msDiff.SpentTreasuryOutput != nil {
+				fmt.Printf("        - treasury %s, amount %d\n", msDiff.SpentTreasuryOutput.MilestoneID.ToHex(), msDiff.SpentTreasuryOutput.Amount)
+			}
+		}
+	}
+
+	if result.SolidEntryPoints != nil {
+		fmt.Printf("\nsolid entry points:\n")
+		for _, sep := range result.SolidEntryPoints {
+			fmt.Printf("    > %s (milestone %d)\n", sep.BlockID, sep.MilestoneIndex)
+		}
+	}
+}
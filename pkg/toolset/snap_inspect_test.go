@@ -0,0 +1,89 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package toolset
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/filter"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestSnapshotInspect(t *testing.T) {
+	dir := t.TempDir()
+
+	address := tpkg.RandAddress(iotago.AddressEd25519)
+	aliasID := tpkg.RandAliasID()
+	fullPath := writeTestGenesisSnapshot(t, dir, "full_snapshot.bin", testTreasuryAllocation, address, aliasID)
+
+	showOutputs := map[string]struct{}{snapshotInspectShowOutputs: {}}
+
+	// without a filter all outputs match
+	result, err := inspectSnapshot(context.Background(), fullPath, "", showOutputs, &snapshotOutputFilter{filter: &filter.Filter{}}, 0)
+	require.NoError(t, err)
+	require.Equal(t, fullPath, result.FilePath)
+	require.Equal(t, uint64(5), result.Outputs.TotalCount)
+	require.Equal(t, uint64(testTokenSupply-testTreasuryAllocation), result.Outputs.TotalAmount)
+	require.Equal(t, uint64(testTreasuryAllocation), result.Outputs.TreasuryAmount)
+	require.Equal(t, result.Outputs.TotalCount, result.Outputs.MatchingCount)
+	require.Equal(t, result.Outputs.TotalAmount, result.Outputs.MatchingAmount)
+	require.Len(t, result.Outputs.Outputs, 5)
+	require.Nil(t, result.MilestoneDiffs)
+	require.Nil(t, result.SolidEntryPoints)
+
+	// the genesis output and the ledger output are basic outputs
+	basicFilter := &snapshotOutputFilter{filter: &filter.Filter{OutputTypes: []iotago.OutputType{iotago.OutputBasic}}}
+	result, err = inspectSnapshot(context.Background(), fullPath, "", showOutputs, basicFilter, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), result.Outputs.TotalCount)
+	require.Equal(t, uint64(2), result.Outputs.MatchingCount)
+	require.Len(t, result.Outputs.Outputs, 2)
+	for _, output := range result.Outputs.Outputs {
+		require.Equal(t, iotago.OutputBasic.String(), output.Type)
+		require.Equal(t, address.Bech32(iotago.PrefixTestnet), output.Address)
+	}
+
+	// the limit only applies to the listed outputs, not to the counters
+	result, err = inspectSnapshot(context.Background(), fullPath, "", showOutputs, basicFilter, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), result.Outputs.MatchingCount)
+	require.Len(t, result.Outputs.Outputs, 1)
+
+	// the amount bounds are inclusive
+	amountFilter := &snapshotOutputFilter{filter: &filter.Filter{}, minAmount: 1_000_000, maxAmount: 1_000_000}
+	result, err = inspectSnapshot(context.Background(), fullPath, "", showOutputs, amountFilter, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), result.Outputs.MatchingCount)
+	require.Equal(t, uint64(1_000_000), result.Outputs.MatchingAmount)
+
+	// the milestone diffs and solid entry points are taken from the delta snapshot
+	_, outputs := readTestSnapshot(t, fullPath)
+	deltaPath := filepath.Join(dir, "delta_snapshot.bin")
+	created := writeTestDeltaSnapshot(t, deltaPath, utxo.Outputs{outputs[3]}, 2_000_000, address)
+
+	showAll := map[string]struct{}{
+		snapshotInspectShowOutputs:          {},
+		snapshotInspectShowMilestoneDiffs:   {},
+		snapshotInspectShowSolidEntryPoints: {},
+	}
+	result, err = inspectSnapshot(context.Background(), fullPath, deltaPath, showAll, basicFilter, 0)
+	require.NoError(t, err)
+	require.Equal(t, deltaPath, result.FilePath)
+	require.Equal(t, uint64(2), result.Outputs.MatchingCount)
+	require.Empty(t, result.SolidEntryPoints)
+	require.Len(t, result.MilestoneDiffs, 1)
+
+	msDiff := result.MilestoneDiffs[0]
+	require.Equal(t, iotago.MilestoneIndex(1), msDiff.MilestoneIndex)
+	require.Equal(t, 1, msDiff.CreatedCount)
+	require.Equal(t, 1, msDiff.ConsumedCount)
+	require.Len(t, msDiff.Created, 1)
+	require.Equal(t, created.OutputID().ToHex(), msDiff.Created[0].OutputID)
+	// the consumed foundry output doesn't match the filter
+	require.Empty(t, msDiff.Consumed)
+}
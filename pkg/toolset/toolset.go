@@ -36,9 +36,13 @@ const (
 	FlagToolSnapshotPath       = "snapshotPath"
 	FlagToolSnapshotPathFull   = "fullSnapshotPath"
 	FlagToolSnapshotPathDelta  = "deltaSnapshotPath"
+	FlagToolSnapshotPathSource = "sourceSnapshotPath"
 	FlagToolSnapshotPathTarget = "targetSnapshotPath"
 	FlagToolSnapshotGlobal     = "global"
 
+	FlagToolSnapshotPathSourceDelta = "sourceDeltaSnapshotPath"
+	FlagToolSnapshotPathTargetDelta = "targetDeltaSnapshotPath"
+
 	FlagToolOutputPath = "outputPath"
 
 	FlagToolPrivateKey = "privateKey"
@@ -50,7 +54,10 @@ const (
 	FlagToolPassword  = "password"
 	FlagToolSalt      = "salt"
 
-	FlagToolNodeURL = "nodeURL"
+	FlagToolNodeURL        = "nodeURL"
+	FlagToolNodeURLs       = "nodeURLs"
+	FlagToolAuthToken      = "authToken"
+	FlagToolMilestoneIndex = "milestoneIndex"
 
 	FlagToolOutputJSON            = "json"
 	FlagToolDescriptionOutputJSON = "format output as JSON"
@@ -62,6 +69,14 @@ const (
 
 	FlagToolSnapGenMintAddress        = "mintAddress"
 	FlagToolSnapGenTreasuryAllocation = "treasuryAllocation"
+	FlagToolSnapGenLedgerPath         = "ledgerPath"
+
+	FlagToolSnapInspectShow = "show"
+	FlagToolOutputType      = "outputType"
+	FlagToolAddress         = "address"
+	FlagToolMinAmount       = "minAmount"
+	FlagToolMaxAmount       = "maxAmount"
+	FlagToolLimit           = "limit"
 
 	FlagToolDatabaseTargetIndex = "targetIndex"
 )
@@ -77,6 +92,8 @@ const (
 	ToolSnapMerge          = "snap-merge"
 	ToolSnapInfo           = "snap-info"
 	ToolSnapHash           = "snap-hash"
+	ToolSnapInspect        = "snap-inspect"
+	ToolSnapDiff           = "snap-diff"
 	ToolBenchmarkIO        = "bench-io"
 	ToolBenchmarkCPU       = "bench-cpu"
 	ToolDatabaseLedgerHash = "db-hash"
@@ -88,6 +105,7 @@ const (
 	//nolint:gosec
 	ToolBootstrapPrivateTangle = "bootstrap-private-tangle"
 	ToolNodeInfo               = "node-info"
+	ToolLedgerCompare          = "ledger-compare"
 )
 
 const (
@@ -137,6 +155,8 @@ func HandleTools() {
 		ToolSnapMerge:              snapshotMerge,
 		ToolSnapInfo:               snapshotInfo,
 		ToolSnapHash:               snapshotHash,
+		ToolSnapInspect:            snapshotInspect,
+		ToolSnapDiff:               snapshotDiff,
 		ToolBenchmarkIO:            benchmarkIO,
 		ToolBenchmarkCPU:           benchmarkCPU,
 		ToolDatabaseLedgerHash:     databaseLedgerHash,
@@ -147,6 +167,7 @@ func HandleTools() {
 		ToolDatabaseVerify:         databaseVerify,
 		ToolBootstrapPrivateTangle: networkBootstrap,
 		ToolNodeInfo:               nodeInfo,
+		ToolLedgerCompare:          ledgerCompare,
 	}
 
 	tool, exists := tools[strings.ToLower(args[1])]
@@ -180,6 +201,8 @@ func listTools() {
 	fmt.Printf("%-20s merges a full and delta snapshot into an updated full snapshot\n", fmt.Sprintf("%s:", ToolSnapMerge))
 	fmt.Printf("%-20s outputs information about a snapshot file\n", fmt.Sprintf("%s:", ToolSnapInfo))
 	fmt.Printf("%-20s calculates the sha256 hash of the ledger state inside a snapshot file\n", fmt.Sprintf("%s:", ToolSnapHash))
+	fmt.Printf("%-20s lists and filters the outputs, milestone diffs and solid entry points of a snapshot\n", fmt.Sprintf("%s:", ToolSnapInspect))
+	fmt.Printf("%-20s compares the ledger states of two snapshots\n", fmt.Sprintf("%s:", ToolSnapDiff))
 	fmt.Printf("%-20s benchmarks the IO throughput\n", fmt.Sprintf("%s:", ToolBenchmarkIO))
 	fmt.Printf("%-20s benchmarks the CPU performance\n", fmt.Sprintf("%s:", ToolBenchmarkCPU))
 	fmt.Printf("%-20s calculates the sha256 hash of the ledger state of a database\n", fmt.Sprintf("%s:", ToolDatabaseLedgerHash))
@@ -190,6 +213,7 @@ func listTools() {
 	fmt.Printf("%-20s verifies a valid ledger state and the existence of all blocks\n", fmt.Sprintf("%s:", ToolDatabaseVerify))
 	fmt.Printf("%-20s bootstraps a private tangle by creating a snapshot, database and coordinator state file\n", fmt.Sprintf("%s:", ToolBootstrapPrivateTangle))
 	fmt.Printf("%-20s queries the info endpoint of a node\n", fmt.Sprintf("%s:", ToolNodeInfo))
+	fmt.Printf("%-20s compares the ledger states of several nodes and searches the first diverging milestone\n", fmt.Sprintf("%s:", ToolLedgerCompare))
 }
 
 func yesOrNo(value bool) string {
@@ -0,0 +1,16 @@
+package txtracker
+
+import (
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+type Events struct {
+	// StateChanged is triggered when the state of a tracked transaction changed.
+	StateChanged *event.Event1[*StateChange]
+}
+
+func newEvents() *Events {
+	return &Events{
+		StateChanged: event.New1[*StateChange](),
+	}
+}
@@ -0,0 +1,460 @@
+package txtracker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/syncutils"
+	"github.com/iotaledger/hornet/v2/pkg/common"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrTransactionNotFound is returned if the transaction is not tracked.
+	ErrTransactionNotFound = errors.New("transaction not tracked")
+	// ErrTrackerFull is returned if the maximum amount of tracked transactions is reached.
+	ErrTrackerFull = errors.New("maximum amount of tracked transactions reached")
+	// ErrNoTransaction is returned if the given block does not contain the given transaction.
+	ErrNoTransaction = errors.New("block does not contain the transaction")
+)
+
+// State is the state of a tracked transaction.
+type State string
+
+const (
+	// StatePending means the transaction was not referenced by a milestone yet.
+	StatePending State = "pending"
+	// StatePromoted means the latest block of the transaction was promoted.
+	StatePromoted State = "promoted"
+	// StateReattached means the transaction was reattached in a new block.
+	StateReattached State = "reattached"
+	// StateIncluded means the transaction was included in the ledger.
+	StateIncluded State = "included"
+	// StateConflicting means the transaction was referenced by a milestone but conflicted.
+	StateConflicting State = "conflicting"
+	// StateFailed means the tracker gave up, because the maximum amount of reattachments was reached.
+	StateFailed State = "failed"
+)
+
+// IsFinal tells whether the tracker stops to take care of a transaction in this state.
+func (s State) IsFinal() bool {
+	return s == StateIncluded || s == StateConflicting || s == StateFailed
+}
+
+// StateChange is a state transition of a tracked transaction.
+type StateChange struct {
+	// The ID of the transaction.
+	TransactionID iotago.TransactionID
+	// The state before the transition.
+	PreviousState State
+	// The state after the transition.
+	State State
+	// The block that caused the transition, e.g. the promotion, the reattachment or the included block.
+	BlockID iotago.BlockID
+	// The time of the transition.
+	Timestamp time.Time
+}
+
+// TrackedTransaction is a transaction the tracker takes care of.
+type TrackedTransaction struct {
+	// The ID of the transaction.
+	TransactionID iotago.TransactionID
+	// The transaction itself, used for reattachments.
+	Transaction *iotago.Transaction
+	// The current state of the transaction.
+	State State
+	// The blocks that contain the transaction, the latest attachment is the last one.
+	Attachments iotago.BlockIDs
+	// The amount of promotions of the transaction.
+	Promotions int
+	// The block that was included in the ledger, or the conflicting block.
+	ReferencedBlockID iotago.BlockID
+	// The reason why the transaction conflicted.
+	Conflict storage.Conflict
+	// The state transitions of the transaction.
+	History []*StateChange
+	// The time the transaction was registered.
+	RegisteredAt time.Time
+	// The time of the last state transition.
+	UpdatedAt time.Time
+}
+
+// Reattachments returns the amount of reattachments of the transaction.
+func (t *TrackedTransaction) Reattachments() int {
+	return len(t.Attachments) - 1
+}
+
+// LatestAttachment returns the latest block that contains the transaction.
+func (t *TrackedTransaction) LatestAttachment() iotago.BlockID {
+	return t.Attachments[len(t.Attachments)-1]
+}
+
+func (t *TrackedTransaction) copy() *TrackedTransaction {
+	c := *t
+	c.Attachments = append(iotago.BlockIDs{}, t.Attachments...)
+	c.History = append([]*StateChange{}, t.History...)
+
+	return &c
+}
+
+// TipScoreFunc returns the tip score of the given block.
+type TipScoreFunc func(ctx context.Context, blockID iotago.BlockID) (tangle.TipScore, error)
+
+// AttachBlockFunc attaches the given block to the tangle.
+// If no parents are given, they are selected by the node.
+type AttachBlockFunc func(ctx context.Context, block *iotago.Block) (iotago.BlockID, error)
+
+// TipsFunc returns tips that can be used to promote a block.
+type TipsFunc func() (iotago.BlockIDs, error)
+
+// ProtocolVersionFunc returns the current protocol version.
+type ProtocolVersionFunc func() byte
+
+// Tracker monitors registered transactions until they are included in the ledger or conflict.
+// Blocks of the transactions that become lazy are promoted or reattached automatically.
+type Tracker struct {
+	// the logger used to log events.
+	*logger.WrappedLogger
+
+	storage             *storage.Storage
+	utxoManager         *utxo.Manager
+	tipScoreFunc        TipScoreFunc
+	attachBlockFunc     AttachBlockFunc
+	tipsFunc            TipsFunc
+	protocolVersionFunc ProtocolVersionFunc
+
+	maxTrackedTransactions int
+	maxReattachments       int
+	retentionTime          time.Duration
+
+	transactionsLock syncutils.RWMutex
+	transactions     map[iotago.TransactionID]*TrackedTransaction
+
+	Events *Events
+}
+
+// New creates a new Tracker.
+func New(
+	log *logger.Logger,
+	storage *storage.Storage,
+	utxoManager *utxo.Manager,
+	tipScoreFunc TipScoreFunc,
+	attachBlockFunc AttachBlockFunc,
+	tipsFunc TipsFunc,
+	protocolVersionFunc ProtocolVersionFunc,
+	maxTrackedTransactions int,
+	maxReattachments int,
+	retentionTime time.Duration) *Tracker {
+
+	return &Tracker{
+		WrappedLogger:          logger.NewWrappedLogger(log),
+		storage:                storage,
+		utxoManager:            utxoManager,
+		tipScoreFunc:           tipScoreFunc,
+		attachBlockFunc:        attachBlockFunc,
+		tipsFunc:               tipsFunc,
+		protocolVersionFunc:    protocolVersionFunc,
+		maxTrackedTransactions: maxTrackedTransactions,
+		maxReattachments:       maxReattachments,
+		retentionTime:          retentionTime,
+		transactions:           make(map[iotago.TransactionID]*TrackedTransaction),
+		Events:                 newEvents(),
+	}
+}
+
+// Register starts tracking the transaction contained in the given block.
+// If the transaction is already tracked, the existing entry is returned.
+func (t *Tracker) Register(transactionID iotago.TransactionID, blockID iotago.BlockID) (*TrackedTransaction, error) {
+	cachedBlock := t.storage.CachedBlockOrNil(blockID) // block +1
+	if cachedBlock == nil {
+		return nil, errors.WithMessagef(common.ErrBlockNotFound, "block %s", blockID.ToHex())
+	}
+	defer cachedBlock.Release(true) // block -1
+
+	transaction := cachedBlock.Block().Transaction()
+	if transaction == nil {
+		return nil, errors.WithMessagef(ErrNoTransaction, "block %s has no transaction payload", blockID.ToHex())
+	}
+
+	blockTransactionID, err := transaction.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	if blockTransactionID != transactionID {
+		return nil, errors.WithMessagef(ErrNoTransaction, "block %s contains transaction %s", blockID.ToHex(), blockTransactionID.ToHex())
+	}
+
+	t.transactionsLock.Lock()
+	defer t.transactionsLock.Unlock()
+
+	if tracked, exists := t.transactions[transactionID]; exists {
+		return tracked.copy(), nil
+	}
+
+	if len(t.transactions) >= t.maxTrackedTransactions {
+		return nil, ErrTrackerFull
+	}
+
+	now := time.Now()
+	tracked := &TrackedTransaction{
+		TransactionID: transactionID,
+		Transaction:   transaction,
+		State:         StatePending,
+		Attachments:   iotago.BlockIDs{blockID},
+		RegisteredAt:  now,
+		UpdatedAt:     now,
+		History: []*StateChange{{
+			TransactionID: transactionID,
+			PreviousState: "",
+			State:         StatePending,
+			BlockID:       blockID,
+			Timestamp:     now,
+		}},
+	}
+	t.transactions[transactionID] = tracked
+
+	return tracked.copy(), nil
+}
+
+// Unregister stops tracking the given transaction.
+func (t *Tracker) Unregister(transactionID iotago.TransactionID) error {
+	t.transactionsLock.Lock()
+	defer t.transactionsLock.Unlock()
+
+	if _, exists := t.transactions[transactionID]; !exists {
+		return ErrTransactionNotFound
+	}
+	delete(t.transactions, transactionID)
+
+	return nil
+}
+
+// Transaction returns the tracked transaction with the given ID.
+func (t *Tracker) Transaction(transactionID iotago.TransactionID) (*TrackedTransaction, error) {
+	t.transactionsLock.RLock()
+	defer t.transactionsLock.RUnlock()
+
+	tracked, exists := t.transactions[transactionID]
+	if !exists {
+		return nil, ErrTransactionNotFound
+	}
+
+	return tracked.copy(), nil
+}
+
+// Transactions returns all tracked transactions.
+func (t *Tracker) Transactions() []*TrackedTransaction {
+	t.transactionsLock.RLock()
+	defer t.transactionsLock.RUnlock()
+
+	transactions := make([]*TrackedTransaction, 0, len(t.transactions))
+	for _, tracked := range t.transactions {
+		transactions = append(transactions, tracked.copy())
+	}
+
+	return transactions
+}
+
+// Check updates the states of all tracked transactions and promotes or reattaches them if needed.
+// It should be called after every confirmed milestone.
+func (t *Tracker) Check(ctx context.Context) {
+	t.cleanup()
+
+	for _, tracked := range t.Transactions() {
+		if tracked.State.IsFinal() {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return
+		}
+
+		if err := t.checkTransaction(ctx, tracked); err != nil {
+			t.LogWarnf("checking transaction %s failed: %s", tracked.TransactionID.ToHex(), err)
+		}
+	}
+}
+
+// cleanup removes transactions in a final state after the retention time.
+func (t *Tracker) cleanup() {
+	t.transactionsLock.Lock()
+	defer t.transactionsLock.Unlock()
+
+	for transactionID, tracked := range t.transactions {
+		if tracked.State.IsFinal() && time.Since(tracked.UpdatedAt) > t.retentionTime {
+			delete(t.transactions, transactionID)
+		}
+	}
+}
+
+func (t *Tracker) checkTransaction(ctx context.Context, tracked *TrackedTransaction) error {
+
+	// check if the transaction was included in the ledger, regardless of which block contained it
+	includedBlockID, included, err := t.includedBlockID(tracked.TransactionID)
+	if err != nil {
+		return err
+	}
+	if included {
+		t.updateState(tracked.TransactionID, StateIncluded, includedBlockID, storage.ConflictNone)
+
+		return nil
+	}
+
+	latestAttachment := tracked.LatestAttachment()
+
+	referenced, conflict, err := t.referencedWithConflict(latestAttachment)
+	if err != nil {
+		return err
+	}
+	if referenced {
+		if conflict != storage.ConflictNone {
+			t.updateState(tracked.TransactionID, StateConflicting, latestAttachment, conflict)
+		}
+
+		// the block was referenced without a conflict, but the outputs are not in the ledger yet,
+		// the next check will mark it as included.
+		return nil
+	}
+
+	tipScore, err := t.tipScoreFunc(ctx, latestAttachment)
+	if err != nil {
+		return err
+	}
+
+	switch tipScore {
+	case tangle.TipScoreHealthy:
+		return nil
+
+	case tangle.TipScoreOCRIThresholdReached, tangle.TipScoreYCRIThresholdReached:
+		return t.promote(ctx, tracked)
+
+	default:
+		// the block is below max depth or unknown (e.g. pruned)
+		return t.reattach(ctx, tracked)
+	}
+}
+
+// includedBlockID returns the ID of the block that included the transaction in the ledger.
+func (t *Tracker) includedBlockID(transactionID iotago.TransactionID) (iotago.BlockID, bool, error) {
+	// every transaction has at least one output, so we check the first one
+	output, err := t.utxoManager.ReadOutputByOutputID(iotago.OutputIDFromTransactionIDAndIndex(transactionID, 0))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return iotago.EmptyBlockID(), false, nil
+		}
+
+		return iotago.EmptyBlockID(), false, err
+	}
+
+	return output.BlockID(), true, nil
+}
+
+// referencedWithConflict returns whether the block was referenced by a milestone and its conflict.
+func (t *Tracker) referencedWithConflict(blockID iotago.BlockID) (bool, storage.Conflict, error) {
+	cachedBlockMeta := t.storage.CachedBlockMetadataOrNil(blockID) // meta +1
+	if cachedBlockMeta == nil {
+		return false, storage.ConflictNone, nil
+	}
+	defer cachedBlockMeta.Release(true) // meta -1
+
+	metadata := cachedBlockMeta.Metadata()
+
+	return metadata.IsReferenced(), metadata.Conflict(), nil
+}
+
+func (t *Tracker) promote(ctx context.Context, tracked *TrackedTransaction) error {
+	tips, err := t.tipsFunc()
+	if err != nil {
+		return errors.Wrap(err, "tipselection failed")
+	}
+
+	// leave room for the block that gets promoted
+	if len(tips) >= iotago.BlockMaxParents {
+		tips = tips[:iotago.BlockMaxParents-1]
+	}
+
+	blockID, err := t.attachBlockFunc(ctx, &iotago.Block{
+		ProtocolVersion: t.protocolVersionFunc(),
+		Parents:         append(tips, tracked.LatestAttachment()).RemoveDupsAndSort(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "promotion failed")
+	}
+
+	t.transactionsLock.Lock()
+	if current, exists := t.transactions[tracked.TransactionID]; exists {
+		current.Promotions++
+	}
+	t.transactionsLock.Unlock()
+
+	t.updateState(tracked.TransactionID, StatePromoted, blockID, storage.ConflictNone)
+
+	return nil
+}
+
+func (t *Tracker) reattach(ctx context.Context, tracked *TrackedTransaction) error {
+	if tracked.Reattachments() >= t.maxReattachments {
+		t.updateState(tracked.TransactionID, StateFailed, tracked.LatestAttachment(), storage.ConflictNone)
+
+		return nil
+	}
+
+	blockID, err := t.attachBlockFunc(ctx, &iotago.Block{
+		ProtocolVersion: t.protocolVersionFunc(),
+		Payload:         tracked.Transaction,
+	})
+	if err != nil {
+		return errors.Wrap(err, "reattachment failed")
+	}
+
+	t.transactionsLock.Lock()
+	if current, exists := t.transactions[tracked.TransactionID]; exists {
+		current.Attachments = append(current.Attachments, blockID)
+	}
+	t.transactionsLock.Unlock()
+
+	t.updateState(tracked.TransactionID, StateReattached, blockID, storage.ConflictNone)
+
+	return nil
+}
+
+// updateState sets the new state of the transaction and triggers the StateChanged event.
+func (t *Tracker) updateState(transactionID iotago.TransactionID, state State, blockID iotago.BlockID, conflict storage.Conflict) {
+	t.transactionsLock.Lock()
+
+	tracked, exists := t.transactions[transactionID]
+	if !exists {
+		// the transaction was unregistered in the meantime
+		t.transactionsLock.Unlock()
+
+		return
+	}
+
+	stateChange := &StateChange{
+		TransactionID: transactionID,
+		PreviousState: tracked.State,
+		State:         state,
+		BlockID:       blockID,
+		Timestamp:     time.Now(),
+	}
+
+	tracked.State = state
+	tracked.UpdatedAt = stateChange.Timestamp
+	tracked.History = append(tracked.History, stateChange)
+	if state == StateIncluded || state == StateConflicting {
+		tracked.ReferencedBlockID = blockID
+		tracked.Conflict = conflict
+	}
+
+	t.transactionsLock.Unlock()
+
+	t.Events.StateChanged.Trigger(stateChange)
+}
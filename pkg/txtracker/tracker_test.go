@@ -0,0 +1,220 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package txtracker_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/tangle"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	"github.com/iotaledger/hornet/v2/pkg/txtracker"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+const (
+	ProtocolVersion = 2
+	MinPoWScore     = 1
+	BelowMaxDepth   = 15
+)
+
+var (
+	seed1, _ = hex.DecodeString("96d9ff7a79e4b0a5f3e5848ae7867064402da92a62eabb4ebbe463f12d1f3b1aace1775488f51cb1e3a80732a03ef60b111d6833ab605aa9f8faebeb33bbe3d9")
+	seed2, _ = hex.DecodeString("b15209ddc93cbdb600137ea6a8f88cdd7c5d480d5815c9352a0fb5c4e4b86f7151dcb44c2ba635657a2df5a8fd48cb9bab674a9eceea527dbbb254ef8c9f9cd7")
+	seed3, _ = hex.DecodeString("d5353ceeed380ab89a0f6abe4630c2091acc82617c0edd4ff10bd60bba89e2ed30805ef095b989c2bf208a474f8748d11d954aade374380422d4d812b6f1da90")
+)
+
+type testTracker struct {
+	*txtracker.Tracker
+	tipScore      tangle.TipScore
+	attached      []*iotago.Block
+	stateChanges  []*txtracker.StateChange
+	tips          iotago.BlockIDs
+	attachedBlock iotago.BlockID
+}
+
+func newTestTracker(te *testsuite.TestEnvironment, maxReattachments int) *testTracker {
+	tracker := &testTracker{
+		tipScore: tangle.TipScoreHealthy,
+		tips:     iotago.BlockIDs{tpkg.RandBlockID(), tpkg.RandBlockID()},
+	}
+
+	tracker.Tracker = txtracker.New(
+		nil,
+		te.Storage(),
+		te.UTXOManager(),
+		func(_ context.Context, _ iotago.BlockID) (tangle.TipScore, error) {
+			return tracker.tipScore, nil
+		},
+		func(_ context.Context, block *iotago.Block) (iotago.BlockID, error) {
+			tracker.attached = append(tracker.attached, block)
+			tracker.attachedBlock = tpkg.RandBlockID()
+
+			return tracker.attachedBlock, nil
+		},
+		func() (iotago.BlockIDs, error) {
+			return tracker.tips, nil
+		},
+		func() byte {
+			return ProtocolVersion
+		},
+		10,
+		maxReattachments,
+		time.Hour,
+	)
+
+	tracker.Events.StateChanged.Hook(func(stateChange *txtracker.StateChange) {
+		tracker.stateChanges = append(tracker.stateChanges, stateChange)
+	})
+
+	return tracker
+}
+
+func (t *testTracker) requireState(tb testing.TB, transactionID iotago.TransactionID, state txtracker.State) *txtracker.TrackedTransaction {
+	tracked, err := t.Transaction(transactionID)
+	require.NoError(tb, err)
+	require.Equal(tb, state, tracked.State)
+
+	return tracked
+}
+
+func setupTestEnvironment(t *testing.T) (*testsuite.TestEnvironment, *utils.HDWallet, *utils.HDWallet) {
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	te := testsuite.SetupTestEnvironment(t, seed1Wallet.Address(), 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, false)
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	return te, seed1Wallet, seed2Wallet
+}
+
+func TestTrackerIncluded(t *testing.T) {
+	te, seed1Wallet, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(true)
+
+	tracker := newTestTracker(te, 3)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(3_000_000).
+		BuildTransactionToWallet(seed2Wallet).
+		Store().
+		BookOnWallets()
+
+	transactionID, err := blockA.StoredBlock().Transaction().ID()
+	require.NoError(t, err)
+
+	// the block must contain the transaction
+	_, err = tracker.Register(iotago.TransactionID{}, blockA.StoredBlockID())
+	require.ErrorIs(t, err, txtracker.ErrNoTransaction)
+
+	tracked, err := tracker.Register(transactionID, blockA.StoredBlockID())
+	require.NoError(t, err)
+	require.Equal(t, txtracker.StatePending, tracked.State)
+	require.Equal(t, iotago.BlockIDs{blockA.StoredBlockID()}, tracked.Attachments)
+
+	// healthy blocks are left alone
+	tracker.Check(context.Background())
+	tracker.requireState(t, transactionID, txtracker.StatePending)
+	require.Empty(t, tracker.attached)
+
+	te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockA.StoredBlockID()}, false)
+
+	tracker.Check(context.Background())
+	tracked = tracker.requireState(t, transactionID, txtracker.StateIncluded)
+	require.Equal(t, blockA.StoredBlockID(), tracked.ReferencedBlockID)
+	require.Len(t, tracker.stateChanges, 1)
+	require.Equal(t, txtracker.StatePending, tracker.stateChanges[0].PreviousState)
+
+	// included transactions are not checked anymore
+	tracker.Check(context.Background())
+	require.Len(t, tracker.stateChanges, 1)
+
+	require.NoError(t, tracker.Unregister(transactionID))
+	_, err = tracker.Transaction(transactionID)
+	require.ErrorIs(t, err, txtracker.ErrTransactionNotFound)
+}
+
+func TestTrackerConflicting(t *testing.T) {
+	te, _, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(true)
+
+	seed3Wallet := utils.NewHDWallet("Seed3", seed3, 0)
+	tracker := newTestTracker(te, 3)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed3Wallet).
+		Amount(1_000_000).
+		FakeInputs().
+		BuildTransactionToWallet(seed2Wallet).
+		Store()
+
+	transactionID, err := blockA.StoredBlock().Transaction().ID()
+	require.NoError(t, err)
+
+	_, err = tracker.Register(transactionID, blockA.StoredBlockID())
+	require.NoError(t, err)
+
+	te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockA.StoredBlockID()}, false)
+
+	tracker.Check(context.Background())
+	tracked := tracker.requireState(t, transactionID, txtracker.StateConflicting)
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXONotFound), tracked.Conflict)
+	require.Equal(t, blockA.StoredBlockID(), tracked.ReferencedBlockID)
+}
+
+func TestTrackerPromoteAndReattach(t *testing.T) {
+	te, seed1Wallet, seed2Wallet := setupTestEnvironment(t)
+	defer te.CleanupTestEnvironment(true)
+
+	tracker := newTestTracker(te, 1)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(3_000_000).
+		BuildTransactionToWallet(seed2Wallet).
+		Store()
+
+	transaction := blockA.StoredBlock().Transaction()
+	transactionID, err := transaction.ID()
+	require.NoError(t, err)
+
+	_, err = tracker.Register(transactionID, blockA.StoredBlockID())
+	require.NoError(t, err)
+
+	// semi-lazy blocks are promoted
+	tracker.tipScore = tangle.TipScoreOCRIThresholdReached
+	tracker.Check(context.Background())
+	tracked := tracker.requireState(t, transactionID, txtracker.StatePromoted)
+	require.Equal(t, 1, tracked.Promotions)
+	require.Len(t, tracker.attached, 1)
+	require.Nil(t, tracker.attached[0].Payload)
+	require.Contains(t, tracker.attached[0].Parents, blockA.StoredBlockID())
+	require.Len(t, tracker.attached[0].Parents, len(tracker.tips)+1)
+
+	// lazy blocks are reattached
+	tracker.tipScore = tangle.TipScoreBelowMaxDepth
+	tracker.Check(context.Background())
+	tracked = tracker.requireState(t, transactionID, txtracker.StateReattached)
+	require.Len(t, tracker.attached, 2)
+	require.Equal(t, transaction, tracker.attached[1].Payload)
+	require.Empty(t, tracker.attached[1].Parents)
+	require.Equal(t, iotago.BlockIDs{blockA.StoredBlockID(), tracker.attachedBlock}, tracked.Attachments)
+	require.Equal(t, 1, tracked.Reattachments())
+
+	// the tracker gives up after the maximum amount of reattachments
+	tracker.Check(context.Background())
+	tracker.requireState(t, transactionID, txtracker.StateFailed)
+	require.Len(t, tracker.attached, 2)
+
+	require.Len(t, tracker.stateChanges, 3)
+}
@@ -233,7 +233,7 @@ func ConfirmMilestone(
 
 				if referencedBlock.IsTransaction {
 					if referencedBlock.Conflict != storage.ConflictNone {
-						meta.Metadata().SetConflictingTx(referencedBlock.Conflict)
+						meta.Metadata().SetConflictingTx(referencedBlock.Conflict, referencedBlock.ConflictDetails)
 					}
 				} else {
 					meta.Metadata().SetIsNoTransaction(true)
@@ -0,0 +1,194 @@
+// Package proof contains the proofs of inclusion of blocks in the merkle trees committed to by milestones.
+// It has no dependencies on the node, so light clients and bridges can verify proofs offline.
+package proof
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/merklehasher"
+
+	// import implementation.
+	_ "golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrBlockNotReferenced is returned if the block is not part of the blocks referenced by the milestone.
+	ErrBlockNotReferenced = errors.New("block not referenced by the milestone")
+	// ErrInvalidProof is returned if a proof does not match the merkle roots of the milestone.
+	ErrInvalidProof = errors.New("invalid inclusion proof")
+)
+
+// InclusionProof proves that a block was referenced by a milestone,
+// and if it contains a transaction that was applied to the ledger, that it was included.
+type InclusionProof struct {
+	// The milestone that referenced the block, including its signatures.
+	Milestone *iotago.Milestone
+	// The ID of the block.
+	BlockID iotago.BlockID
+	// The audit path of the block in the merkle tree of the inclusion merkle root.
+	// It is nil if the block was the only block referenced by the milestone.
+	InclusionPath *merklehasher.Proof
+	// Whether the block contains a transaction that was applied to the ledger.
+	Applied bool
+	// The audit path of the block in the merkle tree of the applied merkle root.
+	// It is nil if the block was not applied, or the only block applied by the milestone.
+	AppliedPath *merklehasher.Proof
+}
+
+type jsonInclusionProof struct {
+	Milestone     *iotago.Milestone   `json:"milestone"`
+	BlockID       string              `json:"blockId"`
+	InclusionPath *merklehasher.Proof `json:"inclusionPath,omitempty"`
+	Applied       bool                `json:"applied"`
+	AppliedPath   *merklehasher.Proof `json:"appliedPath,omitempty"`
+}
+
+func newHasher() *merklehasher.Hasher {
+	return merklehasher.NewHasher(crypto.BLAKE2b_256)
+}
+
+// computePath computes the audit path of the block in the merkle tree over the given block IDs.
+func computePath(blockIDs iotago.BlockIDs, blockID iotago.BlockID) (*merklehasher.Proof, error) {
+	if len(blockIDs) == 1 {
+		if blockIDs[0] != blockID {
+			return nil, ErrBlockNotReferenced
+		}
+
+		// a tree with a single leaf has no audit path
+		//nolint:nilnil // nil, nil is ok in this context, even if it is not go idiomatic
+		return nil, nil
+	}
+
+	for i := range blockIDs {
+		if blockIDs[i] == blockID {
+			return newHasher().ComputeProofForIndex(blockIDs, i)
+		}
+	}
+
+	return nil, ErrBlockNotReferenced
+}
+
+// verifyPath checks that the audit path of the block results in the given merkle root.
+func verifyPath(path *merklehasher.Proof, blockID iotago.BlockID, root []byte) bool {
+	hasher := newHasher()
+
+	if path == nil {
+		return bytes.Equal(hasher.HashBlockIDs(iotago.BlockIDs{blockID}), root)
+	}
+
+	if contains, err := path.ContainsValue(blockID); err != nil || !contains {
+		return false
+	}
+
+	return bytes.Equal(path.Hash(hasher), root)
+}
+
+// New creates a new InclusionProof for the given block.
+// The referenced and applied block IDs have to be in white-flag order, as they were used to compute the merkle roots of the milestone.
+func New(milestone *iotago.Milestone, referencedBlockIDs iotago.BlockIDs, appliedBlockIDs iotago.BlockIDs, blockID iotago.BlockID) (*InclusionProof, error) {
+	inclusionPath, err := computePath(referencedBlockIDs, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &InclusionProof{
+		Milestone:     milestone,
+		BlockID:       blockID,
+		InclusionPath: inclusionPath,
+	}
+
+	for i := range appliedBlockIDs {
+		if appliedBlockIDs[i] == blockID {
+			proof.Applied = true
+
+			break
+		}
+	}
+
+	if proof.Applied {
+		if proof.AppliedPath, err = computePath(appliedBlockIDs, blockID); err != nil {
+			return nil, err
+		}
+	}
+
+	// make sure the node does not hand out proofs that can't be verified
+	if err := proof.VerifyMerkleRoots(); err != nil {
+		return nil, err
+	}
+
+	return proof, nil
+}
+
+// VerifyMerkleRoots checks that the audit paths of the proof result in the merkle roots of the milestone.
+// It does not check the signatures of the milestone.
+func (p *InclusionProof) VerifyMerkleRoots() error {
+	if p.Milestone == nil {
+		return errors.WithMessage(ErrInvalidProof, "milestone missing")
+	}
+
+	if !verifyPath(p.InclusionPath, p.BlockID, p.Milestone.InclusionMerkleRoot[:]) {
+		return errors.WithMessage(ErrInvalidProof, "inclusion path does not match the inclusion merkle root of the milestone")
+	}
+
+	if !p.Applied {
+		return nil
+	}
+
+	if !verifyPath(p.AppliedPath, p.BlockID, p.Milestone.AppliedMerkleRoot[:]) {
+		return errors.WithMessage(ErrInvalidProof, "applied path does not match the applied merkle root of the milestone")
+	}
+
+	return nil
+}
+
+// Verify checks the signatures of the milestone against the given public keys
+// and that the audit paths of the proof result in the merkle roots of the milestone.
+func (p *InclusionProof) Verify(minSigThreshold int, publicKeys iotago.MilestonePublicKeySet) error {
+	if p.Milestone == nil {
+		return errors.WithMessage(ErrInvalidProof, "milestone missing")
+	}
+
+	if err := p.Milestone.VerifySignatures(minSigThreshold, publicKeys); err != nil {
+		return errors.WithMessagef(ErrInvalidProof, "invalid milestone signatures: %s", err)
+	}
+
+	return p.VerifyMerkleRoots()
+}
+
+// MarshalJSON returns the JSON encoding of the proof.
+func (p *InclusionProof) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonInclusionProof{
+		Milestone:     p.Milestone,
+		BlockID:       p.BlockID.ToHex(),
+		InclusionPath: p.InclusionPath,
+		Applied:       p.Applied,
+		AppliedPath:   p.AppliedPath,
+	})
+}
+
+// UnmarshalJSON parses the JSON encoding of the proof.
+func (p *InclusionProof) UnmarshalJSON(data []byte) error {
+	j := &jsonInclusionProof{}
+	if err := json.Unmarshal(data, j); err != nil {
+		return err
+	}
+
+	blockID, err := iotago.BlockIDFromHexString(j.BlockID)
+	if err != nil {
+		return fmt.Errorf("invalid blockId: %w", err)
+	}
+
+	p.Milestone = j.Milestone
+	p.BlockID = blockID
+	p.InclusionPath = j.InclusionPath
+	p.Applied = j.Applied
+	p.AppliedPath = j.AppliedPath
+
+	return nil
+}
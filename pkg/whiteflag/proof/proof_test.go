@@ -0,0 +1,98 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package proof_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/tpkg"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag/proof"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/iota.go/v3/merklehasher"
+)
+
+func randBlockIDs(count int) iotago.BlockIDs {
+	blockIDs := make(iotago.BlockIDs, count)
+	for i := range blockIDs {
+		copy(blockIDs[i][:], tpkg.RandBytes(iotago.BlockIDLength))
+	}
+
+	return blockIDs
+}
+
+func signedMilestone(t *testing.T, referencedBlockIDs iotago.BlockIDs, appliedBlockIDs iotago.BlockIDs) (*iotago.Milestone, iotago.MilestonePublicKeySet) {
+	hasher := merklehasher.NewHasher(crypto.BLAKE2b_256)
+
+	var inclusionMerkleRoot, appliedMerkleRoot [iotago.MilestoneMerkleProofLength]byte
+	copy(inclusionMerkleRoot[:], hasher.HashBlockIDs(referencedBlockIDs))
+	copy(appliedMerkleRoot[:], hasher.HashBlockIDs(appliedBlockIDs))
+
+	milestone := iotago.NewMilestone(10, 1000, 2, iotago.MilestoneID{}, randBlockIDs(1), inclusionMerkleRoot, appliedMerkleRoot)
+
+	pubKey, privKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	var milestonePubKey iotago.MilestonePublicKey
+	copy(milestonePubKey[:], pubKey)
+
+	require.NoError(t, milestone.Sign([]iotago.MilestonePublicKey{milestonePubKey}, iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{milestonePubKey: privKey})))
+
+	return milestone, iotago.MilestonePublicKeySet{milestonePubKey: {}}
+}
+
+func TestInclusionProof(t *testing.T) {
+	referencedBlockIDs := randBlockIDs(7)
+	appliedBlockIDs := iotago.BlockIDs{referencedBlockIDs[2], referencedBlockIDs[5]}
+
+	milestone, publicKeys := signedMilestone(t, referencedBlockIDs, appliedBlockIDs)
+
+	for _, blockID := range referencedBlockIDs {
+		inclusionProof, err := proof.New(milestone, referencedBlockIDs, appliedBlockIDs, blockID)
+		require.NoError(t, err)
+		require.NoError(t, inclusionProof.Verify(1, publicKeys))
+	}
+
+	appliedProof, err := proof.New(milestone, referencedBlockIDs, appliedBlockIDs, referencedBlockIDs[5])
+	require.NoError(t, err)
+	require.True(t, appliedProof.Applied)
+
+	// unknown signers are rejected
+	_, otherPublicKeys := signedMilestone(t, referencedBlockIDs, appliedBlockIDs)
+	require.ErrorIs(t, appliedProof.Verify(1, otherPublicKeys), proof.ErrInvalidProof)
+
+	// a proof for a different block is rejected
+	tamperedProof := *appliedProof
+	tamperedProof.BlockID = referencedBlockIDs[0]
+	require.ErrorIs(t, tamperedProof.Verify(1, publicKeys), proof.ErrInvalidProof)
+
+	// claiming that a referenced block was applied is rejected
+	notAppliedProof, err := proof.New(milestone, referencedBlockIDs, appliedBlockIDs, referencedBlockIDs[0])
+	require.NoError(t, err)
+	notAppliedProof.Applied = true
+	notAppliedProof.AppliedPath = appliedProof.AppliedPath
+	require.ErrorIs(t, notAppliedProof.Verify(1, publicKeys), proof.ErrInvalidProof)
+
+	// blocks that were not referenced can't be proven
+	_, err = proof.New(milestone, referencedBlockIDs, appliedBlockIDs, randBlockIDs(1)[0])
+	require.ErrorIs(t, err, proof.ErrBlockNotReferenced)
+
+	// the merkle roots of the milestone have to match
+	_, err = proof.New(milestone, referencedBlockIDs[1:], appliedBlockIDs, referencedBlockIDs[2])
+	require.ErrorIs(t, err, proof.ErrInvalidProof)
+}
+
+func TestInclusionProofSingleBlock(t *testing.T) {
+	referencedBlockIDs := randBlockIDs(1)
+
+	milestone, publicKeys := signedMilestone(t, referencedBlockIDs, referencedBlockIDs)
+
+	inclusionProof, err := proof.New(milestone, referencedBlockIDs, referencedBlockIDs, referencedBlockIDs[0])
+	require.NoError(t, err)
+	require.Nil(t, inclusionProof.InclusionPath)
+	require.Nil(t, inclusionProof.AppliedPath)
+	require.True(t, inclusionProof.Applied)
+	require.NoError(t, inclusionProof.Verify(1, publicKeys))
+}
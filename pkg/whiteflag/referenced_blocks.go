@@ -0,0 +1,81 @@
+package whiteflag
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/iotaledger/hornet/v2/pkg/dag"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// ReferencedBlocksOfMilestone reconstructs the blocks referenced by an already confirmed milestone
+// in the order they were applied under the "white-flag" approach, based on the stored block metadata.
+// The result can be used to recompute the merkle trees committed to by the milestone.
+func ReferencedBlocksOfMilestone(ctx context.Context, parentsTraverserStorage dag.ParentsTraverserStorage, msIndex iotago.MilestoneIndex, parents iotago.BlockIDs) (ReferencedBlocks, error) {
+
+	type indexedBlock struct {
+		wfIndex uint32
+		block   ReferencedBlock
+	}
+
+	var indexedBlocks []indexedBlock
+
+	if err := dag.TraverseParents(
+		ctx,
+		parentsTraverserStorage,
+		parents,
+		// traversal stops if no more blocks pass the given condition
+		// Caution: condition func is not in DFS order
+		func(cachedBlockMeta *storage.CachedMetadata) (bool, error) { // meta +1
+			defer cachedBlockMeta.Release(true) // meta -1
+
+			referenced, at := cachedBlockMeta.Metadata().ReferencedWithIndex()
+
+			// only blocks referenced by this milestone are part of the cone
+			return referenced && at == msIndex, nil
+		},
+		// consumer
+		func(cachedBlockMeta *storage.CachedMetadata) error { // meta +1
+			defer cachedBlockMeta.Release(true) // meta -1
+
+			metadata := cachedBlockMeta.Metadata()
+			_, _, wfIndex := metadata.ReferencedWithIndexAndWhiteFlagIndex()
+
+			indexedBlocks = append(indexedBlocks, indexedBlock{
+				wfIndex: wfIndex,
+				block: ReferencedBlock{
+					BlockID:         metadata.BlockID(),
+					IsTransaction:   !metadata.IsNoTransaction(),
+					Conflict:        metadata.Conflict(),
+					ConflictDetails: metadata.ConflictDetails(),
+				},
+			})
+
+			return nil
+		},
+		// called on missing parents
+		// return error on missing parents
+		nil,
+		// called on solid entry points
+		// Ignore solid entry points (snapshot milestone included)
+		nil,
+		false); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(indexedBlocks, func(i, j int) bool {
+		return indexedBlocks[i].wfIndex < indexedBlocks[j].wfIndex
+	})
+
+	referencedBlocks := make(ReferencedBlocks, len(indexedBlocks))
+	for i, indexedBlock := range indexedBlocks {
+		if indexedBlock.wfIndex != uint32(i) {
+			return nil, fmt.Errorf("white flag index of block %s is %d, expected %d", indexedBlock.block.BlockID.ToHex(), indexedBlock.wfIndex, i)
+		}
+		referencedBlocks[i] = indexedBlock.block
+	}
+
+	return referencedBlocks, nil
+}
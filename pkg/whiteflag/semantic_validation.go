@@ -0,0 +1,73 @@
+package whiteflag
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+// semanticallyValidate runs the semantic checks of iota.go on the transaction.
+// Errors of checks that fail because of a single input are returned as storage.InputConflictError.
+func semanticallyValidate(transaction *iotago.Transaction, semValCtx *iotago.SemanticValidationContext, inputOutputs utxo.Outputs) error {
+	// do not change the order of these functions as
+	// some of them might depend on certain mutations
+	// on the given SemanticValidationContext
+	return transaction.SemanticallyValidate(semValCtx, inputOutputs.ToOutputSet(),
+		txSemanticTimelock(),
+		txSemanticInputUnlocks(),
+		iotago.TxSemanticOutputsSender(),
+		iotago.TxSemanticDeposit(),
+		iotago.TxSemanticNativeTokens(),
+		iotago.TxSemanticSTVFOnChains(),
+	)
+}
+
+// txSemanticTimelock validates that the timelocks of the inputs are expired.
+// It replaces iotago.TxSemanticTimelock, which checks the inputs in random order and doesn't report the index of the input.
+func txSemanticTimelock() iotago.TxSemanticValidationFunc {
+	return func(svCtx *iotago.SemanticValidationContext) error {
+		for inputIndex, input := range svCtx.WorkingSet.Inputs {
+			if err := input.UnlockConditionSet().TimelocksExpired(svCtx.ExtParas); err != nil {
+				return storage.NewInputConflictError(inputIndex, fmt.Errorf("%w: input %d's timelocks are not expired", err, inputIndex))
+			}
+		}
+
+		return nil
+	}
+}
+
+// txSemanticInputUnlocks wraps iotago.TxSemanticInputUnlocks and attributes failed unlocks to the failing input.
+func txSemanticInputUnlocks() iotago.TxSemanticValidationFunc {
+	validateInputUnlocks := iotago.TxSemanticInputUnlocks()
+
+	return func(svCtx *iotago.SemanticValidationContext) error {
+		err := validateInputUnlocks(svCtx)
+		if err == nil || errors.Is(err, iotago.ErrInvalidInputsCommitment) {
+			return err
+		}
+
+		// the inputs are unlocked in order, so the failing input is the first one that was not unlocked.
+		for inputIndex := range svCtx.WorkingSet.Inputs {
+			if !inputUnlocked(svCtx.WorkingSet.UnlockedIdents, uint16(inputIndex)) {
+				return storage.NewInputConflictError(inputIndex, err)
+			}
+		}
+
+		return err
+	}
+}
+
+// inputUnlocked returns whether the input at the given index was unlocked by any identity.
+func inputUnlocked(unlockedIdents iotago.UnlockedIdentities, inputIndex uint16) bool {
+	for identKey := range unlockedIdents {
+		if unlockedIdents.UnlockedBy(inputIndex, identKey) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,207 @@
+package whiteflag
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+var (
+	// ErrInvalidTransaction is returned if a simulated transaction is syntactically invalid.
+	ErrInvalidTransaction = errors.New("invalid transaction")
+)
+
+// SimulatedOutput is an output that would be created by a simulated transaction.
+type SimulatedOutput struct {
+	// The ID of the output. It is only known if the signatures of the transaction were checked,
+	// because the unlocks are part of the transaction ID.
+	OutputID *iotago.OutputID
+	// The output itself.
+	Output iotago.Output
+	// The minimum storage deposit the output needs to cover.
+	MinStorageDeposit uint64
+}
+
+// TransactionSimulation is the result of the simulated white-flag validation of a transaction.
+type TransactionSimulation struct {
+	// The index of the milestone the ledger state was at during the simulation.
+	LedgerIndex iotago.MilestoneIndex
+	// The ID of the transaction. It is only known if the signatures of the transaction were checked.
+	TransactionID *iotago.TransactionID
+	// The reason why the transaction would be marked as conflicting.
+	Conflict storage.Conflict
+	// The details of the conflict, or nil if the transaction would not conflict.
+	ConflictDetails *storage.ConflictDetails
+	// The outputs the transaction would create. They are empty if the transaction would conflict.
+	Outputs []*SimulatedOutput
+}
+
+// SimulateTransaction validates the given transaction against the current ledger state
+// with the same rules that are used during white-flag confirmation, without applying it.
+// If skipSignatureChecks is set, the transaction doesn't need to be signed, and all checks that depend
+// on the unlocks are skipped (input unlocks, sender and issuer features).
+// Storage deposit return conditions always need to be fulfilled in that case.
+// The ledger state must be read locked while this function is getting called in order to ensure consistency.
+func SimulateTransaction(
+	utxoManager *utxo.Manager,
+	protoParams *iotago.ProtocolParameters,
+	transaction *iotago.Transaction,
+	msIndex iotago.MilestoneIndex,
+	msTimestamp uint32,
+	skipSignatureChecks bool) (*TransactionSimulation, error) {
+
+	if transaction == nil || transaction.Essence == nil {
+		return nil, errors.WithMessage(ErrInvalidTransaction, "transaction essence missing")
+	}
+
+	if err := syntacticallyValidateTransaction(transaction, protoParams, skipSignatureChecks); err != nil {
+		return nil, errors.WithMessage(ErrInvalidTransaction, err.Error())
+	}
+
+	simulation := &TransactionSimulation{
+		LedgerIndex: msIndex,
+		Conflict:    storage.ConflictNone,
+	}
+
+	transactionID, err := transaction.ID()
+	if err != nil {
+		return nil, err
+	}
+	if !skipSignatureChecks {
+		simulation.TransactionID = &transactionID
+	}
+
+	inputs := make(iotago.OutputIDs, len(transaction.Essence.Inputs))
+	for i, input := range transaction.Essence.Inputs {
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok {
+			return nil, errors.WithMessagef(ErrInvalidTransaction, "unsupported input type at index %d", i)
+		}
+		inputs[i] = utxoInput.ID()
+	}
+
+	// go through all the inputs and validate that they are unspent and in the ledger
+	inputOutputs := utxo.Outputs{}
+	for inputIndex, input := range inputs {
+		output, err := utxoManager.ReadOutputByOutputIDWithoutLocking(input)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				simulation.Conflict = storage.ConflictInputUTXONotFound
+				simulation.ConflictDetails = newInputConflictDetails(simulation.Conflict, inputIndex, inputs)
+
+				return simulation, nil
+			}
+
+			return nil, err
+		}
+
+		unspent, err := utxoManager.IsOutputUnspentWithoutLocking(output)
+		if err != nil {
+			return nil, err
+		}
+
+		if !unspent {
+			simulation.Conflict = storage.ConflictInputUTXOAlreadySpent
+			simulation.ConflictDetails = newInputConflictDetails(simulation.Conflict, inputIndex, inputs)
+
+			return simulation, nil
+		}
+
+		inputOutputs = append(inputOutputs, output)
+	}
+
+	semValCtx := &iotago.SemanticValidationContext{
+		ExtParas: &iotago.ExternalUnlockParameters{
+			ConfUnix: msTimestamp,
+		},
+	}
+
+	if err := semanticallyValidateTransaction(transaction, semValCtx, inputOutputs, skipSignatureChecks); err != nil {
+		simulation.Conflict = storage.ConflictFromSemanticValidationError(err)
+		simulation.ConflictDetails = newSemanticConflictDetails(err, inputs)
+
+		return simulation, nil
+	}
+
+	for i, output := range transaction.Essence.Outputs {
+		simulatedOutput := &SimulatedOutput{
+			Output:            output,
+			MinStorageDeposit: protoParams.RentStructure.MinRent(output),
+		}
+
+		if !skipSignatureChecks {
+			outputID := iotago.OutputIDFromTransactionIDAndIndex(transactionID, uint16(i))
+			simulatedOutput.OutputID = &outputID
+		}
+
+		simulation.Outputs = append(simulation.Outputs, simulatedOutput)
+	}
+
+	return simulation, nil
+}
+
+// syntacticallyValidateTransaction runs the syntactic checks of the transaction.
+// If skipSignatureChecks is set, the unlocks of the transaction are replaced by placeholders,
+// so that only the essence of the transaction is checked.
+func syntacticallyValidateTransaction(transaction *iotago.Transaction, protoParams *iotago.ProtocolParameters, skipSignatureChecks bool) error {
+	if skipSignatureChecks {
+		unlocks := make(iotago.Unlocks, len(transaction.Essence.Inputs))
+		for i := range unlocks {
+			if i == 0 {
+				unlocks[i] = &iotago.SignatureUnlock{Signature: &iotago.Ed25519Signature{}}
+
+				continue
+			}
+			unlocks[i] = &iotago.ReferenceUnlock{Reference: 0}
+		}
+
+		transaction = &iotago.Transaction{
+			Essence: transaction.Essence,
+			Unlocks: unlocks,
+		}
+	}
+
+	_, err := transaction.Serialize(serializer.DeSeriModePerformValidation, protoParams)
+
+	return err
+}
+
+// semanticallyValidateTransaction runs the semantic checks of the transaction.
+// If skipSignatureChecks is set, all checks that depend on the unlocks are skipped,
+// only the inputs commitment is verified.
+func semanticallyValidateTransaction(transaction *iotago.Transaction, semValCtx *iotago.SemanticValidationContext, inputOutputs utxo.Outputs, skipSignatureChecks bool) error {
+	if !skipSignatureChecks {
+		return semanticallyValidate(transaction, semValCtx, inputOutputs)
+	}
+
+	inputs := make(iotago.Outputs, len(inputOutputs))
+	for i, output := range inputOutputs {
+		inputs[i] = output.Output()
+	}
+
+	inputsCommitment, err := inputs.Commitment()
+	if err != nil {
+		return fmt.Errorf("unable to compute hash of inputs: %w", err)
+	}
+
+	if !bytes.Equal(transaction.Essence.InputsCommitment[:], inputsCommitment) {
+		return fmt.Errorf("%w: specified %v but got %v", iotago.ErrInvalidInputsCommitment, transaction.Essence.InputsCommitment[:], inputsCommitment)
+	}
+
+	// do not change the order of these functions as
+	// some of them might depend on certain mutations
+	// on the given SemanticValidationContext
+	return transaction.SemanticallyValidate(semValCtx, inputOutputs.ToOutputSet(),
+		txSemanticTimelock(),
+		iotago.TxSemanticDeposit(),
+		iotago.TxSemanticNativeTokens(),
+		iotago.TxSemanticSTVFOnChains(),
+	)
+}
@@ -0,0 +1,89 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/dag"
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag/proof"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func TestWhiteFlagInclusionProof(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+
+	genesisAddress := seed1Wallet.Address()
+
+	te := testsuite.SetupTestEnvironment(t, genesisAddress, 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, ShowConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!ShowConfirmationGraphs)
+
+	// Add token supply to our local HDWallet
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(te.ProtocolParameters().TokenSupply).
+		BuildTransactionToWallet(seed2Wallet).
+		Store().
+		BookOnWallets()
+
+	blockB := te.NewBlockBuilder("B").
+		Parents(iotago.BlockIDs{blockA.StoredBlockID(), te.LastMilestoneBlockID()}).
+		BuildTaggedData().
+		Store()
+
+	previousMilestoneBlockID := te.LastMilestoneBlockID()
+
+	conf, confStats := te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockB.StoredBlockID()}, false)
+	require.Equal(t, 2+1, confStats.BlocksReferenced) // 2 + previous milestone
+	require.Equal(t, 1, confStats.BlocksIncludedWithTransactions)
+
+	// the referenced blocks can be reconstructed from the stored metadata
+	memcachedTraverserStorage := dag.NewMemcachedTraverserStorage(te.Storage(), storage.NewMetadataMemcache(te.Storage().CachedBlockMetadata))
+	defer memcachedTraverserStorage.Cleanup(true)
+
+	referencedBlocks, err := whiteflag.ReferencedBlocksOfMilestone(context.Background(), memcachedTraverserStorage, conf.MilestoneIndex, conf.MilestoneParents)
+	require.NoError(t, err)
+	require.Equal(t, conf.Mutations.ReferencedBlocks, referencedBlocks)
+
+	milestonePayload := te.LastMilestonePayload()
+
+	// the transaction was applied
+	proofA, err := proof.New(milestonePayload, referencedBlocks.BlockIDs(), referencedBlocks.IncludedTransactionBlockIDs(), blockA.StoredBlockID())
+	require.NoError(t, err)
+	require.True(t, proofA.Applied)
+	require.NotNil(t, proofA.InclusionPath)
+
+	// blocks without transactions are only referenced
+	proofB, err := proof.New(milestonePayload, referencedBlocks.BlockIDs(), referencedBlocks.IncludedTransactionBlockIDs(), blockB.StoredBlockID())
+	require.NoError(t, err)
+	require.False(t, proofB.Applied)
+	require.Nil(t, proofB.AppliedPath)
+
+	_, err = proof.New(milestonePayload, referencedBlocks.BlockIDs(), referencedBlocks.IncludedTransactionBlockIDs(), previousMilestoneBlockID)
+	require.NoError(t, err)
+
+	// blocks of other milestones are not part of the proof
+	_, err = proof.New(milestonePayload, referencedBlocks.BlockIDs(), referencedBlocks.IncludedTransactionBlockIDs(), te.LastMilestoneBlockID())
+	require.ErrorIs(t, err, proof.ErrBlockNotReferenced)
+
+	// the proof survives a JSON round trip
+	proofJSON, err := json.Marshal(proofA)
+	require.NoError(t, err)
+
+	decodedProof := &proof.InclusionProof{}
+	require.NoError(t, json.Unmarshal(proofJSON, decodedProof))
+	require.Equal(t, proofA.BlockID, decodedProof.BlockID)
+	require.NoError(t, decodedProof.VerifyMerkleRoots())
+}
@@ -0,0 +1,168 @@
+//nolint:forcetypeassert,varnamelen,revive,exhaustruct // we don't care about these linters in test cases
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hornet/v2/pkg/model/storage"
+	"github.com/iotaledger/hornet/v2/pkg/model/utxo"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite"
+	"github.com/iotaledger/hornet/v2/pkg/testsuite/utils"
+	"github.com/iotaledger/hornet/v2/pkg/whiteflag"
+	iotago "github.com/iotaledger/iota.go/v3"
+)
+
+func simulateTransaction(t *testing.T, te *testsuite.TestEnvironment, transaction *iotago.Transaction, skipSignatureChecks bool) *whiteflag.TransactionSimulation {
+	te.UTXOManager().ReadLockLedger()
+	defer te.UTXOManager().ReadUnlockLedger()
+
+	simulation, err := whiteflag.SimulateTransaction(te.UTXOManager(), te.ProtocolParameters(), transaction, te.LastMilestoneIndex(), te.LastMilestonePayload().Timestamp, skipSignatureChecks)
+	require.NoError(t, err)
+
+	return simulation
+}
+
+func TestSimulateTransaction(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+	seed3Wallet := utils.NewHDWallet("Seed3", seed3, 0)
+
+	genesisAddress := seed1Wallet.Address()
+
+	te := testsuite.SetupTestEnvironment(t, genesisAddress, 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, ShowConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!ShowConfirmationGraphs)
+
+	// Add token supply to our local HDWallet
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(3_000_000).
+		BuildTransactionToWallet(seed2Wallet)
+
+	transactionA := blockA.IotaBlock().Payload.(*iotago.Transaction)
+	transactionIDA, err := transactionA.ID()
+	require.NoError(t, err)
+
+	// the signed transaction would be included
+	simulation := simulateTransaction(t, te, transactionA, false)
+	require.Equal(t, storage.Conflict(storage.ConflictNone), simulation.Conflict)
+	require.Nil(t, simulation.ConflictDetails)
+	require.Equal(t, te.LastMilestoneIndex(), simulation.LedgerIndex)
+	require.Equal(t, transactionIDA, *simulation.TransactionID)
+	require.Len(t, simulation.Outputs, len(transactionA.Essence.Outputs))
+	for i, output := range simulation.Outputs {
+		require.Equal(t, iotago.OutputIDFromTransactionIDAndIndex(transactionIDA, uint16(i)), *output.OutputID)
+		require.Equal(t, transactionA.Essence.Outputs[i], output.Output)
+		require.Equal(t, te.ProtocolParameters().RentStructure.MinRent(output.Output), output.MinStorageDeposit)
+	}
+
+	// the transaction would also be included without signatures
+	unsignedTransactionA := &iotago.Transaction{Essence: transactionA.Essence}
+	simulation = simulateTransaction(t, te, unsignedTransactionA, true)
+	require.Equal(t, storage.Conflict(storage.ConflictNone), simulation.Conflict)
+	require.Nil(t, simulation.TransactionID)
+	require.Len(t, simulation.Outputs, len(transactionA.Essence.Outputs))
+	require.Nil(t, simulation.Outputs[0].OutputID)
+
+	// unsigned transactions are invalid if the signatures are checked
+	te.UTXOManager().ReadLockLedger()
+	_, err = whiteflag.SimulateTransaction(te.UTXOManager(), te.ProtocolParameters(), unsignedTransactionA, te.LastMilestoneIndex(), te.LastMilestonePayload().Timestamp, false)
+	te.UTXOManager().ReadUnlockLedger()
+	require.ErrorIs(t, err, whiteflag.ErrInvalidTransaction)
+
+	// a transaction with unknown inputs would conflict
+	blockB := te.NewBlockBuilder("B").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed3Wallet).
+		Amount(1_000_000).
+		FakeInputs().
+		BuildTransactionToWallet(seed2Wallet)
+
+	transactionB := blockB.IotaBlock().Payload.(*iotago.Transaction)
+	simulation = simulateTransaction(t, te, transactionB, false)
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXONotFound), simulation.Conflict)
+	require.Equal(t, 0, simulation.ConflictDetails.InputIndex)
+	require.Equal(t, blockB.StoredBlock().TransactionEssenceUTXOInputs(), simulation.ConflictDetails.OutputIDs)
+	require.Empty(t, simulation.Outputs)
+
+	// nothing was applied to the ledger by the simulations
+	blockA.Store().BookOnWallets()
+	_, confStats := te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockA.StoredBlockID()}, false)
+	require.Equal(t, 1, confStats.BlocksIncludedWithTransactions)
+
+	// the transaction would conflict after it was confirmed
+	simulation = simulateTransaction(t, te, transactionA, false)
+	require.Equal(t, storage.Conflict(storage.ConflictInputUTXOAlreadySpent), simulation.Conflict)
+	require.Equal(t, 0, simulation.ConflictDetails.InputIndex)
+}
+
+func TestSimulateTransactionConflictingInputIndex(t *testing.T) {
+
+	seed1Wallet := utils.NewHDWallet("Seed1", seed1, 0)
+	seed2Wallet := utils.NewHDWallet("Seed2", seed2, 0)
+	seed3Wallet := utils.NewHDWallet("Seed3", seed3, 0)
+
+	genesisAddress := seed1Wallet.Address()
+
+	te := testsuite.SetupTestEnvironment(t, genesisAddress, 2, ProtocolVersion, BelowMaxDepth, MinPoWScore, ShowConfirmationGraphs)
+	defer te.CleanupTestEnvironment(!ShowConfirmationGraphs)
+
+	// Add token supply to our local HDWallet
+	seed1Wallet.BookOutput(te.GenesisOutput)
+
+	blockA := te.NewBlockBuilder("A").
+		Parents(te.LastMilestoneParents()).
+		FromWallet(seed1Wallet).
+		Amount(3_000_000).
+		BuildTransactionToWallet(seed2Wallet).
+		Store().
+		BookOnWallets()
+
+	blockB := te.NewBlockBuilder("B").
+		Parents(iotago.BlockIDs{blockA.StoredBlockID()}).
+		FromWallet(seed1Wallet).
+		Amount(2_000_000).
+		BuildTransactionToWallet(seed3Wallet).
+		Store().
+		BookOnWallets()
+
+	_, confStats := te.IssueAndConfirmMilestoneOnTips(iotago.BlockIDs{blockB.StoredBlockID()}, false)
+	require.Equal(t, 2, confStats.BlocksIncludedWithTransactions)
+
+	inputs := utxo.Outputs{seed2Wallet.Outputs()[0], seed3Wallet.Outputs()[0]}
+	signingWallets := []*utils.HDWallet{seed2Wallet, seed3Wallet}
+
+	blockC := te.NewBlockBuilder("C").
+		Parents(te.LastMilestoneParents()).
+		BuildTransactionWithInputsAndOutputs(inputs, iotago.Outputs{
+			&iotago.BasicOutput{Conditions: iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: seed1Wallet.Address()}}, Amount: 5_000_000},
+		}, signingWallets)
+
+	blockD := te.NewBlockBuilder("D").
+		Parents(te.LastMilestoneParents()).
+		BuildTransactionWithInputsAndOutputs(inputs, iotago.Outputs{
+			&iotago.BasicOutput{Conditions: iotago.UnlockConditions{&iotago.AddressUnlockCondition{Address: seed2Wallet.Address()}}, Amount: 5_000_000},
+		}, signingWallets)
+
+	transactionC := blockC.IotaBlock().Payload.(*iotago.Transaction)
+	transactionD := blockD.IotaBlock().Payload.(*iotago.Transaction)
+
+	simulation := simulateTransaction(t, te, transactionC, false)
+	require.Equal(t, storage.Conflict(storage.ConflictNone), simulation.Conflict)
+
+	// the second input is unlocked with a signature of another transaction
+	invalidTransaction := &iotago.Transaction{
+		Essence: transactionC.Essence,
+		Unlocks: iotago.Unlocks{transactionC.Unlocks[0], transactionD.Unlocks[1]},
+	}
+
+	simulation = simulateTransaction(t, te, invalidTransaction, false)
+	require.Equal(t, storage.Conflict(storage.ConflictInvalidSignature), simulation.Conflict)
+	require.Equal(t, 1, simulation.ConflictDetails.InputIndex)
+	require.Equal(t, iotago.OutputIDs{inputs[0].OutputID(), inputs[1].OutputID()}, simulation.ConflictDetails.OutputIDs)
+}
@@ -153,6 +153,7 @@ func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 
 	// Verify the blocks have the expected conflict reason
 	te.AssertBlockConflictReason(blockC.StoredBlockID(), storage.ConflictInputUTXONotFound)
+	te.AssertBlockConflictDetails(blockC.StoredBlockID(), 0, blockC.StoredBlock().TransactionEssenceUTXOInputs())
 
 	// Verify balances
 	te.AssertWalletBalance(seed1Wallet, 2_779_530_280_277_761)
@@ -217,6 +218,7 @@ func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 
 	// Verify the blocks have the expected conflict reason
 	te.AssertBlockConflictReason(blockF.StoredBlockID(), storage.ConflictInputUTXOAlreadySpent)
+	te.AssertBlockConflictDetails(blockF.StoredBlockID(), 0, blockF.StoredBlock().TransactionEssenceUTXOInputs())
 
 	// Verify balances
 	te.AssertWalletBalance(seed1Wallet, 2_779_530_280_277_761)
@@ -252,6 +254,7 @@ func TestWhiteFlagWithMultipleConflicting(t *testing.T) {
 
 	// Verify the blocks have the expected conflict reason
 	te.AssertBlockConflictReason(blockH.StoredBlockID(), storage.ConflictInputUTXOAlreadySpentInThisMilestone)
+	te.AssertBlockConflictDetails(blockH.StoredBlockID(), 0, blockH.StoredBlock().TransactionEssenceUTXOInputs())
 
 	// Verify balances
 	te.AssertWalletBalance(seed1Wallet, 2_779_530_280_277_761)
@@ -52,6 +52,8 @@ type ReferencedBlock struct {
 	BlockID       iotago.BlockID
 	IsTransaction bool
 	Conflict      storage.Conflict
+	// ConflictDetails explains the conflict in detail, it is nil if the transaction is not conflicting.
+	ConflictDetails *storage.ConflictDetails
 }
 
 type ReferencedBlocks []ReferencedBlock
@@ -223,6 +225,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 		}
 
 		var conflict = storage.ConflictNone
+		var conflictDetails *storage.ConflictDetails
 
 		transaction := block.Transaction()
 		transactionID, err := transaction.ID()
@@ -234,13 +237,14 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 		inputOutputs := utxo.Outputs{}
 		if conflict == storage.ConflictNone {
 			inputs := block.TransactionEssenceUTXOInputs()
-			for _, input := range inputs {
+			for inputIndex, input := range inputs {
 
 				// check if this input was already spent during the confirmation
 				_, hasSpent := wfConf.NewSpents[input]
 				if hasSpent {
 					// UTXO already spent, so mark as conflict
 					conflict = storage.ConflictInputUTXOAlreadySpentInThisMilestone
+					conflictDetails = newInputConflictDetails(conflict, inputIndex, inputs)
 
 					break
 				}
@@ -260,6 +264,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 					if errors.Is(err, kvstore.ErrKeyNotFound) {
 						// input not found, so mark as invalid tx
 						conflict = storage.ConflictInputUTXONotFound
+						conflictDetails = newInputConflictDetails(conflict, inputIndex, inputs)
 
 						break
 					}
@@ -276,6 +281,7 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 				if !unspent {
 					// output is already spent, so mark as conflict
 					conflict = storage.ConflictInputUTXOAlreadySpent
+					conflictDetails = newInputConflictDetails(conflict, inputIndex, inputs)
 
 					break
 				}
@@ -285,8 +291,9 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 
 			if conflict == storage.ConflictNone {
 				// Verify that all outputs consume all inputs and have valid signatures. Also verify that the amounts match.
-				if err := transaction.SemanticallyValidate(semValCtx, inputOutputs.ToOutputSet()); err != nil {
+				if err := semanticallyValidate(transaction, semValCtx, inputOutputs); err != nil {
 					conflict = storage.ConflictFromSemanticValidationError(err)
+					conflictDetails = newSemanticConflictDetails(err, inputs)
 				}
 			}
 		}
@@ -310,9 +317,10 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 		}
 
 		wfConf.ReferencedBlocks = append(wfConf.ReferencedBlocks, ReferencedBlock{
-			BlockID:       blockID,
-			IsTransaction: true,
-			Conflict:      conflict,
+			BlockID:         blockID,
+			IsTransaction:   true,
+			Conflict:        conflict,
+			ConflictDetails: conflictDetails,
 		})
 
 		if conflict != storage.ConflictNone {
@@ -373,3 +381,23 @@ func ComputeWhiteFlagMutations(ctx context.Context,
 
 	return wfConf, nil
 }
+
+// newInputConflictDetails explains a conflict caused by an input which references an output that can't be consumed.
+func newInputConflictDetails(conflict storage.Conflict, inputIndex int, inputs iotago.OutputIDs) *storage.ConflictDetails {
+	var reason string
+	switch conflict {
+	case storage.ConflictInputUTXOAlreadySpentInThisMilestone:
+		reason = "was already spent in this milestone"
+	case storage.ConflictInputUTXONotFound:
+		reason = "was not found"
+	default:
+		reason = "was already spent"
+	}
+
+	return storage.NewConflictDetails(inputIndex, fmt.Sprintf("input %d references output %s which %s", inputIndex, inputs[inputIndex].ToHex(), reason), inputs)
+}
+
+// newSemanticConflictDetails explains a conflict caused by a failed semantic validation.
+func newSemanticConflictDetails(err error, inputs iotago.OutputIDs) *storage.ConflictDetails {
+	return storage.NewConflictDetails(storage.ConflictInputIndexFromError(err), err.Error(), inputs)
+}